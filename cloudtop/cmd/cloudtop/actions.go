@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+const (
+	// defaultActionTimeout is the default for --action-timeout
+	defaultActionTimeout = 5 * time.Minute
+
+	// actionPollInterval is how often an instance's state is checked while
+	// waiting for an action to finish
+	actionPollInterval = 5 * time.Second
+)
+
+// settledStates are the lifecycle states an instance rests in; any other
+// state is a transition
+var settledStates = map[string]bool{"running": true, "stopped": true, "terminated": true}
+
+// instanceAction returns the action and instance ID requested with
+// --start, --stop or --reboot, or an empty action
+func instanceAction() (string, string) {
+	switch {
+	case flagStart != "":
+		return "start", flagStart
+	case flagStop != "":
+		return "stop", flagStop
+	case flagReboot != "":
+		return "reboot", flagReboot
+	}
+	return "", ""
+}
+
+// validateInstanceAction checks an action's flags before any provider is
+// contacted: it needs --yes, exactly one provider and a positive timeout
+func validateInstanceAction(action, id string, providerNames []string) error {
+	if !flagYes {
+		return fmt.Errorf("--%s %s changes the instance; add --yes to confirm", action, id)
+	}
+	if len(providerNames) != 1 {
+		return fmt.Errorf("--%s needs exactly one provider (e.g. --oracle), got %d", action, len(providerNames))
+	}
+	if flagActionTimeout <= 0 {
+		return fmt.Errorf("--action-timeout must be positive, got %s", flagActionTimeout)
+	}
+	return nil
+}
+
+// runInstanceAction starts, stops or reboots an instance, then waits for
+// it to reach a settled state and reports that state
+func runInstanceAction(ctx context.Context, providers map[string]provider.Provider, action, id string) error {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) != 1 {
+		return fmt.Errorf("--%s needs exactly one available provider, got %d", action, len(names))
+	}
+	name := names[0]
+
+	actions, ok := provider.As[provider.ComputeActions](providers[name])
+	if !ok {
+		return fmt.Errorf("provider %s does not support instance actions", name)
+	}
+
+	initial, err := actions.GetInstanceState(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	run := map[string]func(context.Context, string) error{
+		"start":  actions.StartInstance,
+		"stop":   actions.StopInstance,
+		"reboot": actions.RebootInstance,
+	}[action]
+	fmt.Printf("Requesting %s of %s instance %s (state: %s)\n", action, name, id, initial)
+	if err := run(ctx, id); err != nil {
+		return err
+	}
+
+	state, err := waitForStateChange(ctx, actions, id, initial, flagActionTimeout)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Instance %s is %s\n", id, state)
+	return nil
+}
+
+// waitForStateChange polls an instance until its state has changed from
+// initial and settled, or timeout passes. A reboot settles back in its
+// initial state, so any transitional state seen counts as the change.
+func waitForStateChange(ctx context.Context, actions provider.ComputeActions, id, initial string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(actionPollInterval)
+	defer ticker.Stop()
+
+	state, changed := initial, false
+	for {
+		select {
+		case <-ctx.Done():
+			return state, ctx.Err()
+		case <-ticker.C:
+		}
+
+		next, err := actions.GetInstanceState(ctx, id)
+		if err != nil {
+			return state, err
+		}
+		if next != state {
+			fmt.Printf("  %s\n", next)
+		}
+		state = next
+		if state != initial || !settledStates[state] {
+			changed = true
+		}
+		if changed && settledStates[state] {
+			return state, nil
+		}
+
+		if time.Now().After(deadline) {
+			return state, fmt.Errorf("timed out after %s waiting for instance %s (last state: %s)", timeout, id, state)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// validateAliases rejects configured aliases that could never be reached or
+// would be confused with real options: names that match a subcommand or a
+// flag, and expansions that are empty
+func validateAliases(cmd *cobra.Command, aliases map[string][]string) error {
+	reserved := make(map[string]bool)
+	for _, sub := range cmd.Root().Commands() {
+		reserved[sub.Name()] = true
+		for _, a := range sub.Aliases {
+			reserved[a] = true
+		}
+	}
+	reserved["help"] = true
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch {
+		case name == "" || strings.HasPrefix(name, "-"):
+			return fmt.Errorf("alias %q: names must not be empty or start with '-'", name)
+		case reserved[name]:
+			return fmt.Errorf("alias %q shadows the %s command", name, name)
+		case cmd.Flags().Lookup(name) != nil:
+			return fmt.Errorf("alias %q shadows the --%s flag", name, name)
+		case len(aliases[name]) == 0:
+			return fmt.Errorf("alias %q has an empty expansion", name)
+		}
+	}
+	return nil
+}
+
+// expandAlias applies the flags configured for alias name. Flags given on
+// the command line take precedence: any flag the user set explicitly is left
+// as is, so `cloudtop my-gpus --json` overrides an alias that sets --wide.
+// Expansions may only contain flags, not other aliases or commands.
+func expandAlias(cmd *cobra.Command, aliases map[string][]string, name string) error {
+	expansion, ok := aliases[name]
+	if !ok {
+		return fmt.Errorf("unknown command or alias %q", name)
+	}
+
+	// Flags set by this expansion, so repeated flags (e.g. several --tag)
+	// accumulate instead of being skipped as user-provided
+	set := make(map[string]bool)
+
+	flags := cmd.Flags()
+	for i := 0; i < len(expansion); i++ {
+		arg := expansion[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" || arg == "--" {
+			return fmt.Errorf("alias %q: expected a flag, got %q", name, arg)
+		}
+
+		key, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		flag := flags.Lookup(key)
+		if flag == nil && !strings.HasPrefix(arg, "--") {
+			flag = flags.ShorthandLookup(key)
+		}
+		if flag == nil {
+			return fmt.Errorf("alias %q: unknown flag %s", name, arg)
+		}
+
+		if !hasValue {
+			if flag.NoOptDefVal != "" {
+				value = flag.NoOptDefVal
+			} else if i+1 < len(expansion) {
+				i++
+				value = expansion[i]
+			} else {
+				return fmt.Errorf("alias %q: flag %s needs a value", name, arg)
+			}
+		}
+
+		if flag.Changed && !set[flag.Name] {
+			continue
+		}
+		if err := flags.Set(flag.Name, value); err != nil {
+			return fmt.Errorf("alias %q: invalid value for --%s: %w", name, flag.Name, err)
+		}
+		set[flag.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+	"github.com/afterdarksys/cloudtop/internal/provider/external"
+	"github.com/spf13/cobra"
+)
+
+// registerCompletions adds shell completion for flag values and arguments
+// that name providers, services and aliases
+func registerCompletions() {
+	rootCmd.ValidArgsFunction = completeAliases
+	_ = rootCmd.RegisterFlagCompletionFunc("provider", completeProviders)
+	_ = rootCmd.RegisterFlagCompletionFunc("service", completeServices)
+
+	getCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeProviders(cmd, args, toComplete)
+	}
+}
+
+// completeProviders suggests registered providers and any configured under
+// other names. The external provider type is only usable through a
+// configured name, so it is not suggested itself.
+func completeProviders(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, name := range provider.ListRegistered() {
+		if name != external.ProviderType {
+			names = append(names, name)
+		}
+	}
+	if cfg != nil {
+		for name := range cfg.Providers {
+			names = append(names, name)
+		}
+	}
+	return matchingCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeServices suggests the services of the providers selected by the
+// flags typed so far, falling back to the enabled providers and then to
+// every registered one. Providers are not initialized; external providers,
+// whose services come from running their command, are skipped.
+func completeServices(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := getProvidersFromFlags()
+	if len(names) == 0 && cfg != nil {
+		names = cfg.GetEnabledProviders()
+	}
+	if len(names) == 0 {
+		names = provider.ListRegistered()
+	}
+
+	descriptions := make(map[string]string)
+	for _, name := range names {
+		kind := providerKind(name)
+		if kind == external.ProviderType {
+			continue
+		}
+		p, err := provider.Create(kind)
+		if err != nil {
+			continue
+		}
+		services, err := p.ListServices(context.Background())
+		if err != nil {
+			continue
+		}
+		for _, svc := range services {
+			if _, ok := descriptions[svc.ID]; !ok {
+				descriptions[svc.ID] = svc.Name
+			}
+		}
+	}
+
+	var completions []string
+	for id, desc := range descriptions {
+		if strings.HasPrefix(id, toComplete) {
+			completions = append(completions, id+"\t"+desc)
+		}
+	}
+	sort.Strings(completions)
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAliases suggests the query aliases defined in the config
+func completeAliases(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(cfg.Aliases))
+	for name := range cfg.Aliases {
+		names = append(names, name)
+	}
+	return matchingCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// matchingCompletions returns the unique names starting with prefix, sorted
+func matchingCompletions(names []string, prefix string) []string {
+	seen := make(map[string]bool, len(names))
+	var matches []string
+	for _, name := range names {
+		if !seen[name] && strings.HasPrefix(name, prefix) {
+			seen[name] = true
+			matches = append(matches, name)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
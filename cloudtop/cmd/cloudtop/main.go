@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -14,12 +18,18 @@ import (
 
 	"github.com/afterdarksys/cloudtop/internal/collector"
 	"github.com/afterdarksys/cloudtop/internal/config"
+	"github.com/afterdarksys/cloudtop/internal/inventory"
+	"github.com/afterdarksys/cloudtop/internal/logging"
+	"github.com/afterdarksys/cloudtop/internal/metrics"
 	"github.com/afterdarksys/cloudtop/internal/output"
 	"github.com/afterdarksys/cloudtop/internal/provider"
+	"github.com/afterdarksys/cloudtop/internal/tui"
+	"github.com/afterdarksys/cloudtop/pkg/httpclient"
 
 	// Import all providers to register them
 	_ "github.com/afterdarksys/cloudtop/internal/provider/azure"
 	_ "github.com/afterdarksys/cloudtop/internal/provider/cloudflare"
+	"github.com/afterdarksys/cloudtop/internal/provider/external"
 	_ "github.com/afterdarksys/cloudtop/internal/provider/gcp"
 	_ "github.com/afterdarksys/cloudtop/internal/provider/neon"
 	_ "github.com/afterdarksys/cloudtop/internal/provider/oracle"
@@ -27,6 +37,13 @@ import (
 	_ "github.com/afterdarksys/cloudtop/internal/provider/vastai"
 )
 
+// Build information, set by the Makefile via -ldflags
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = "unknown"
+)
+
 var (
 	cfgFile string
 	cfg     *config.Config
@@ -41,10 +58,21 @@ var (
 
 	// Service flags
 	flagService string
+	flagMetrics bool
+
+	// Metrics request shape
+	flagMetricWindow      time.Duration
+	flagMetricGranularity time.Duration
 
 	// AI/GPU flags
 	flagAI  string
 	flagGPU bool
+	flagTop int
+
+	// GPU price watch flags
+	flagWatchPrice     bool
+	flagPriceThreshold float64
+	flagDropPct        float64
 
 	// List flags
 	flagList     bool
@@ -56,9 +84,56 @@ var (
 	flagTable bool
 	flagWide  bool
 	flagJSON  bool
+	flagJSONL bool
+
+	flagProviderOrder []string
 
 	// Other flags
-	flagRefresh time.Duration
+	flagRefresh     time.Duration
+	flagTUI         bool
+	flagTrendCycles int
+	flagSince       time.Duration
+	flagTags        []string
+	flagExplain     bool
+	flagMaxResults  int
+	flagNoDedup     bool
+
+	// Notification flags
+	flagNotifySlack string
+	flagNotifyOn    string
+
+	// Compute metrics flags
+	flagSortBy    string
+	flagThreshold float64
+
+	flagRedact []string
+
+	// Instance action flags
+	flagStart         string
+	flagStop          string
+	flagReboot        string
+	flagYes           bool
+	flagActionTimeout time.Duration
+
+	// Config reload
+	flagWatchConfig bool
+	configWatcher   *config.Watcher
+
+	// Resource event log
+	flagEventLog string
+	eventLog     *collector.EventLog
+
+	// Provider response recording and replay
+	flagRecord string
+	flagReplay string
+
+	// Verbosity
+	flagQuiet   bool
+	flagVerbose bool
+
+	// flagMaxResultsSet records whether --max-results was given, so a
+	// config reload does not override it
+	flagMaxResultsSet bool
 )
 
 func main() {
@@ -79,23 +154,90 @@ Examples:
   # Show all resources from all configured providers
   cloudtop --all
 
+  # Browse them in the interactive dashboard, refreshing every minute
+  cloudtop --all --tui --refresh 1m
+
   # Show only Cloudflare resources
   cloudtop --cloudflare
 
   # Show Oracle Cloud compute instances
   cloudtop --oracle --service compute
 
+  # Stop an Oracle instance and wait until it has stopped
+  cloudtop --oracle --stop ocid1.instance.oc1..example --yes
+
   # Show GPU instances from AI providers
   cloudtop --ai vast --gpu
 
   # List available GPU compute
   cloudtop --gpu --list
 
+  # The 5 cheapest GPU offerings, or the 5 busiest GPU instances
+  cloudtop --gpu --list --top 5
+  cloudtop --gpu --sort-by util --top 5
+
+  # Watch GPU prices and alert on drops below $1/hr or by 20%
+  cloudtop --gpu --list --watch-price --price-threshold 1.00 --drop-pct 20 --refresh 5m
+
+  # Show Workers AI inferences, tokens and latency per model for the last hour
+  cloudtop --ai cf
+
+  # Show R2 bucket object counts, sizes and 24h growth (--wide adds requests)
+  cloudtop --cloudflare --service r2 --metrics
+
+  # Show the busiest instances first, highlighting any above 80% CPU or memory
+  cloudtop --all --service compute --metrics --sort-by cpu --threshold 80
+
+  # Watch per-instance CPU and GPU utilization trends as sparklines
+  cloudtop --all --service compute --metrics --wide --refresh 30s
+  cloudtop --gpu --metrics --wide --refresh 30s
+
   # Output in JSON format
   cloudtop --all --json
 
+  # Scripted use: only the JSON, no warnings on stderr
+  cloudtop --all --json --quiet
+
+  # Debug slow providers: per-provider timing and each HTTP request
+  cloudtop --all --verbose
+
+  # Debug credentials: where each one resolved from, plus a health check
+  cloudtop providers test-auth
+
+  # Stream one JSON object per resource per line
+  cloudtop --all --jsonl
+
   # Auto-refresh every 30 seconds
-  cloudtop --all --refresh 30s`,
+  cloudtop --all --refresh 30s
+
+  # Reload the config file whenever it changes
+  cloudtop --all --refresh 30s --watch-config
+
+  # Keep an audit trail of resources appearing, disappearing and changing status
+  cloudtop --all --refresh 1m --event-log events.jsonl
+
+  # Record provider responses, then replay them without credentials
+  cloudtop --all --record ./fixtures
+  cloudtop --all --replay ./fixtures
+
+  # Show resources created in the last day
+  cloudtop --all --since 24h
+
+  # Show at most 200 resources per provider
+  cloudtop --all --max-results 200
+
+  # Post a summary to Slack whenever a provider fails
+  cloudtop --all --refresh 5m --notify-slack https://hooks.slack.com/services/... --notify-on errors
+
+  # Project monthly spend for running GPU and priced instances
+  cloudtop cost --monthly
+
+  # Mask resource names and Neon endpoint hosts in shared output
+  cloudtop --all --json --redact name,endpoint
+
+  # Run a query saved under "aliases" in the config
+  cloudtop my-gpus`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runMonitor,
 }
 
@@ -122,10 +264,157 @@ var initConfigCmd = &cobra.Command{
 	},
 }
 
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the configuration file",
+}
+
+var flagMigrateDryRun bool
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade the configuration file to the current version",
+	Long: `Applies versioned migrations to the configuration file and writes it back.
+The original file is kept alongside it with a .bak suffix.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := cfgFile
+		if path == "" {
+			path = viper.ConfigFileUsed()
+		}
+		if path == "" {
+			return fmt.Errorf("no config file found; use --config to specify one")
+		}
+
+		applied, migrated, err := config.MigrateFile(path, flagMigrateDryRun)
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			fmt.Printf("%s is already at version %s\n", path, config.CurrentVersion)
+			return nil
+		}
+
+		for _, m := range applied {
+			fmt.Printf("  %s -> %s: %s\n", m.From, m.To, m.Description)
+		}
+
+		if flagMigrateDryRun {
+			fmt.Println(string(migrated))
+			return nil
+		}
+
+		fmt.Printf("Migrated %s to version %s (backup: %s.bak)\n", path, config.CurrentVersion, path)
+		return nil
+	},
+}
+
+var flagReveal bool
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the loaded configuration with secrets masked",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shown := cfg
+		if !flagReveal {
+			shown = cfg.Redacted()
+		}
+
+		data, err := json.MarshalIndent(shown, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		fmt.Println(string(data))
+
+		// Show what env-based credentials resolve to at runtime
+		names := make([]string, 0, len(cfg.Providers))
+		for name := range cfg.Providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var lines []string
+		for _, name := range names {
+			auth := cfg.Providers[name].Auth
+			if auth.EnvAPIKey == "" && auth.EnvSecret == "" && auth.Method != "secret_ref" {
+				continue
+			}
+			creds := auth.ToCredentials()
+			keys := make([]string, 0, len(creds))
+			for k := range creds {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				value := creds[k]
+				if value == "" {
+					value = "(unset)"
+				} else if !flagReveal {
+					value = config.MaskSecret(value)
+				}
+				lines = append(lines, fmt.Sprintf("  %s.%s = %s", name, k, value))
+			}
+		}
+		if len(lines) > 0 {
+			fmt.Println("\nResolved credentials:")
+			fmt.Println(strings.Join(lines, "\n"))
+		}
+		return nil
+	},
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print or check published output schemas",
+}
+
+var schemaOutputCmd = &cobra.Command{
+	Use:   "output",
+	Short: "Print the JSON Schema for --json output",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := json.MarshalIndent(output.ResultSchema(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal schema: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+var schemaValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Validate --json output against the schema (reads stdin when no file is given)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var data []byte
+		var err error
+		if len(args) == 1 {
+			data, err = os.ReadFile(args[0])
+		} else {
+			data, err = io.ReadAll(os.Stdin)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		if err := output.ValidateResult(data); err != nil {
+			return err
+		}
+		fmt.Println("output matches schema")
+		return nil
+	},
+}
+
+var flagProvidersJSON bool
+
 var providersCmd = &cobra.Command{
 	Use:   "providers",
 	Short: "List registered providers",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagProvidersJSON {
+			return printProvidersJSON()
+		}
+
 		fmt.Println("Registered providers:")
 		for _, name := range provider.ListRegistered() {
 			status := "available"
@@ -136,14 +425,344 @@ var providersCmd = &cobra.Command{
 			}
 			fmt.Printf("  - %s (%s)\n", name, status)
 		}
+		return nil
 	},
 }
 
+var (
+	flagFindAll  bool
+	flagFindJSON bool
+	flagFindWide bool
+)
+
+var findCmd = &cobra.Command{
+	Use:   "find <substring>",
+	Short: "Find resources by name or ID across providers",
+	Long: `Search every enabled provider for resources whose name or ID contains
+the substring (case-insensitive) and print the matches in one table.
+
+Examples:
+  # Find resources named like "api" in all enabled providers
+  cloudtop find api
+
+  # Also search registered providers that are not in the config,
+  # using credentials from the environment
+  cloudtop find api --all`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFind,
+}
+
+// runFind collects from the selected providers with a name/ID query filter
+// and prints the matches as one flat table
+func runFind(cmd *cobra.Command, args []string) error {
+	query := strings.TrimSpace(args[0])
+	if query == "" {
+		return fmt.Errorf("search substring must not be empty")
+	}
+
+	ctx := context.Background()
+
+	names := cfg.GetEnabledProviders()
+	if flagFindAll {
+		names = provider.ListRegistered()
+	}
+	if len(names) == 0 {
+		fmt.Println("No providers configured. Run 'cloudtop init' to generate a config file.")
+		return nil
+	}
+
+	providers, initReport, err := initializeProviders(ctx, names)
+	if err != nil {
+		return fmt.Errorf("failed to initialize providers: %w", err)
+	}
+	defer closeProviders(providers)
+	warnInitFailures(initReport)
+
+	col := newCollector(providers)
+	resp, err := col.Collect(ctx, &collector.CollectRequest{
+		Timeout: 30 * time.Second,
+		Filters: &provider.ResourceFilter{Query: query},
+	})
+	if err != nil {
+		return fmt.Errorf("collection failed: %w", err)
+	}
+	for name, err := range resp.Errors {
+		logging.Warnf("%s: %v", name, err)
+	}
+
+	format := "table"
+	if flagFindJSON {
+		format = "json"
+	} else if flagFindWide {
+		format = "wide"
+	}
+	return output.NewFindFormatter(format, os.Stdout).Format(redactor().Result(resp))
+}
+
+var flagGetJSON bool
+
+var getCmd = &cobra.Command{
+	Use:   "get <provider> <id>",
+	Short: "Show details for a single resource",
+	Long: `Fetch one resource by ID. Providers whose API supports direct lookup
+(neon projects, cloudflare workers, runpod pods) are queried directly; for
+others every resource is listed and the matching ID returned.
+
+Examples:
+  cloudtop get neon aged-river-123456
+  cloudtop get runpod abc123xyz --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runGet,
+}
+
+// runGet fetches and prints a single resource
+func runGet(cmd *cobra.Command, args []string) error {
+	name, id := args[0], args[1]
+	ctx := context.Background()
+
+	providers, initReport, err := initializeProviders(ctx, []string{name})
+	if err != nil {
+		return fmt.Errorf("failed to initialize providers: %w", err)
+	}
+	defer closeProviders(providers)
+	if _, ok := providers[name]; !ok {
+		return fmt.Errorf("provider %s is not available: %s", name, initReport[name].Error)
+	}
+
+	col := newCollector(providers)
+	resource, err := col.GetResource(ctx, name, id)
+	if err != nil {
+		return err
+	}
+	redacted := redactor().Resource(*resource)
+	return output.FormatResource(os.Stdout, &redacted, flagGetJSON)
+}
+
+var (
+	flagExportOut string
+	flagDiffJSON  bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write a point-in-time inventory of all resources",
+	Long: `Collect from every enabled provider and write a normalized inventory
+(provider, id, name, type, region, status, created, tags) with a capture
+timestamp and the cloudtop version. Compare two inventories with
+'cloudtop diff'.
+
+Providers that fail are recorded in the inventory's "errors" so a later
+diff can tell missing resources from an outage.
+
+Examples:
+  cloudtop export --out inventory.json
+  cloudtop export --out "inventory-$(date +%F).json"`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old-inventory> <new-inventory>",
+	Short: "Compare two inventories written by export",
+	Long: `Report resources added, removed and changed between two inventories
+written by 'cloudtop export'. Resources are matched by provider, type and
+ID; name, region, status, creation time and tags are compared.
+
+Exits with status 1 when the inventories differ, like diff(1).
+
+Examples:
+  cloudtop diff inventory-old.json inventory-new.json
+  cloudtop diff inventory-old.json inventory-new.json --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+// runExport collects from all enabled providers and saves an inventory
+func runExport(cmd *cobra.Command, args []string) error {
+	if flagExportOut == "" {
+		return fmt.Errorf("--out is required")
+	}
+
+	ctx := context.Background()
+	names := cfg.GetEnabledProviders()
+	if len(names) == 0 {
+		fmt.Println("No providers configured. Run 'cloudtop init' to generate a config file.")
+		return nil
+	}
+
+	providers, initReport, err := initializeProviders(ctx, names)
+	if err != nil {
+		return fmt.Errorf("failed to initialize providers: %w", err)
+	}
+	defer closeProviders(providers)
+	warnInitFailures(initReport)
+
+	// Inventories must be complete, so bypass the cache and any result cap
+	col := collector.NewCollector(providers, collector.NewNoopCache())
+	resp, err := col.Collect(ctx, &collector.CollectRequest{Timeout: 60 * time.Second})
+	if err != nil {
+		return fmt.Errorf("collection failed: %w", err)
+	}
+	for name, err := range resp.Errors {
+		logging.Warnf("%s: %v", name, err)
+	}
+
+	queried := make([]string, 0, len(providers))
+	for name := range providers {
+		queried = append(queried, name)
+	}
+	inv := inventory.New(output.FlattenResources(resp), queried, resp.Errors, Version, resp.Timestamp)
+	if err := inv.Save(flagExportOut); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d resources from %d providers to %s\n", len(inv.Items), len(inv.Providers), flagExportOut)
+	return nil
+}
+
+// runDiff compares two inventory files
+func runDiff(cmd *cobra.Command, args []string) error {
+	before, err := inventory.Load(args[0])
+	if err != nil {
+		return err
+	}
+	after, err := inventory.Load(args[1])
+	if err != nil {
+		return err
+	}
+
+	d := inventory.Compare(before, after)
+	if err := output.FormatInventoryDiff(os.Stdout, before, after, d, flagDiffJSON); err != nil {
+		return err
+	}
+	if !d.Empty() {
+		os.Exit(1)
+	}
+	return nil
+}
+
+var (
+	flagCostAll            bool
+	flagCostMonthly        bool
+	flagCostIncludeStopped bool
+	flagCostJSON           bool
+)
+
+var costCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "Project spend from current resource prices",
+	Long: `Collect GPU instances and priced resources (such as Oracle compute
+instances) and project their cost over 24 hours, and over 30 days with
+--monthly, from the hourly prices the providers report. Costs are grouped
+by provider and resource type with a grand total.
+
+This is a dry run: nothing is billed or changed. Estimates are approximate
+and assume continuous uptime at list price. Stopped resources are excluded
+unless --include-stopped is set.
+
+Examples:
+  cloudtop cost
+  cloudtop cost --all --monthly
+  cloudtop cost --monthly --json`,
+	Args: cobra.NoArgs,
+	RunE: runCost,
+}
+
+// runCost collects priced resources and prints a cost projection
+func runCost(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	names := cfg.GetEnabledProviders()
+	if flagCostAll {
+		names = provider.ListRegistered()
+	}
+	if len(names) == 0 {
+		fmt.Println("No providers configured. Run 'cloudtop init' to generate a config file.")
+		return nil
+	}
+
+	providers, initReport, err := initializeProviders(ctx, names)
+	if err != nil {
+		return fmt.Errorf("failed to initialize providers: %w", err)
+	}
+	defer closeProviders(providers)
+	warnInitFailures(initReport)
+
+	col := newCollector(providers)
+	gpus, gpuErrors := col.CollectGPU(ctx, &provider.GPUFilter{})
+	for name, err := range gpuErrors {
+		logging.Warnf("%s: %v", name, err)
+	}
+	resp, err := col.Collect(ctx, &collector.CollectRequest{Timeout: 60 * time.Second})
+	if err != nil {
+		return fmt.Errorf("collection failed: %w", err)
+	}
+	for name, err := range resp.Errors {
+		logging.Warnf("%s: %v", name, err)
+	}
+
+	report := output.ProjectCost(output.FlattenResources(resp), gpus, flagCostIncludeStopped)
+	return output.FormatCostReport(os.Stdout, report, flagCostMonthly, flagCostJSON)
+}
+
+// providerInfo is one entry of `providers --json`
+type providerInfo struct {
+	Name         string   `json:"name"`
+	Registered   bool     `json:"registered"`
+	Enabled      bool     `json:"enabled"`
+	Configured   bool     `json:"configured"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// printProvidersJSON lists registered providers, plus any configured ones
+// that are not registered, as a JSON array sorted by name
+func printProvidersJSON() error {
+	names := provider.ListRegistered()
+	if cfg != nil {
+		for name := range cfg.Providers {
+			if !provider.GetRegistry().Exists(name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	infos := make([]providerInfo, 0, len(names))
+	for _, name := range names {
+		info := providerInfo{Name: name, Capabilities: []string{}}
+		if cfg != nil {
+			if pc, ok := cfg.Providers[name]; ok {
+				info.Configured = true
+				info.Enabled = pc.Enabled
+			}
+		}
+		// A fresh instance is enough for interface assertions
+		if p, err := provider.Create(providerKind(name)); err == nil {
+			info.Registered = true
+			if caps := provider.Capabilities(p); caps != nil {
+				info.Capabilities = caps
+			}
+		}
+		infos = append(infos, info)
+	}
+
+	data, err := json.MarshalIndent(infos, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal providers: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
 	// Config file
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: ./cloudtop.json or ~/.cloudtop.json)")
+	rootCmd.PersistentFlags().BoolVarP(&flagQuiet, "quiet", "q", false, "Print only results: no warnings or summary footer (errors still print)")
+	rootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false, "Also print per-provider timings and HTTP requests to stderr")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.PersistentFlags().StringSliceVar(&flagRedact, "redact", nil, "Mask these resource fields or tag keys with **** in output (e.g. name,endpoint); adds to output.redact_fields")
 
 	// Provider flags
 	rootCmd.Flags().BoolVarP(&flagCloudflare, "cloudflare", "c", false, "Show Cloudflare resources")
@@ -155,10 +774,18 @@ func init() {
 
 	// Service flags
 	rootCmd.Flags().StringVarP(&flagService, "service", "s", "", "Filter by specific service (e.g., compute, storage, workers)")
+	rootCmd.Flags().BoolVar(&flagMetrics, "metrics", false, "Show usage metrics for the selected service (compute: CPU and memory per instance; storage: per-bucket objects and size); with --refresh --wide, adds CPU or GPU utilization sparklines")
+
+	rootCmd.Flags().DurationVar(&flagMetricWindow, "metric-window", 0, "How far back metrics requests look (default: defaults.metric_window or 5m)")
+	rootCmd.Flags().DurationVar(&flagMetricGranularity, "metric-granularity", 0, "Step between metric data points; must divide the window (default: defaults.metric_granularity or 1m)")
 
 	// AI/GPU flags
-	rootCmd.Flags().StringVar(&flagAI, "ai", "", "Show AI workloads (vast|io|cf|oracle)")
+	rootCmd.Flags().StringVar(&flagAI, "ai", "", "Show AI workloads (vast|io|cf|oracle); cf shows Workers AI usage per model")
 	rootCmd.Flags().BoolVar(&flagGPU, "gpu", false, "Show GPU information")
+	rootCmd.Flags().IntVar(&flagTop, "top", 0, "With --gpu, show only the first N rows: the cheapest offerings with --list, or the busiest instances with --sort-by util")
+	rootCmd.Flags().BoolVar(&flagWatchPrice, "watch-price", false, "With --gpu --list, track offering prices and report drops on each refresh")
+	rootCmd.Flags().Float64Var(&flagPriceThreshold, "price-threshold", 0, "Alert when an offering's $/hr falls below this price")
+	rootCmd.Flags().Float64Var(&flagDropPct, "drop-pct", 0, "Alert when an offering's price falls by at least this percent")
 
 	// List flags
 	rootCmd.Flags().BoolVar(&flagList, "list", false, "List available compute resources")
@@ -170,16 +797,80 @@ func init() {
 	rootCmd.Flags().BoolVar(&flagTable, "table", false, "Output in table format (default)")
 	rootCmd.Flags().BoolVar(&flagWide, "wide", false, "Output in wide table format")
 	rootCmd.Flags().BoolVar(&flagJSON, "json", false, "Output in JSON format")
+	rootCmd.Flags().BoolVar(&flagJSONL, "jsonl", false, "Stream output as JSON lines, one resource per line")
+	rootCmd.Flags().StringSliceVar(&flagProviderOrder, "provider-order", nil, "Render these providers first, in order (e.g., oracle,cloudflare)")
 
 	// Other flags
 	rootCmd.Flags().DurationVar(&flagRefresh, "refresh", 0, "Auto-refresh interval (e.g., 30s, 1m)")
+	rootCmd.Flags().StringVar(&flagEventLog, "event-log", "", "With --refresh, append a JSON line to this file whenever a resource appears, disappears or changes status")
+	rootCmd.Flags().StringVar(&flagRecord, "record", "", "Save each provider's responses to <dir>/<provider>.json for --replay")
+	rootCmd.Flags().StringVar(&flagReplay, "replay", "", "Serve provider responses recorded with --record from this directory instead of calling the providers")
+	rootCmd.Flags().BoolVar(&flagTUI, "tui", false, "Interactive dashboard: collapsible provider panels, filter as you type and a GPU cost bar, refreshed every --refresh (default: defaults.refresh_interval)")
+	rootCmd.Flags().BoolVar(&flagWatchConfig, "watch-config", false, "With --refresh, reload the config file when it changes and re-initialize providers")
+	rootCmd.Flags().IntVar(&flagTrendCycles, "trend-cycles", collector.DefaultTrendCycles, "Refresh cycles retained for trend deltas (0 to disable)")
+	rootCmd.Flags().BoolVar(&flagExplain, "explain", false, "Print per-provider counts of resources fetched and dropped by each filter")
+	rootCmd.Flags().BoolVar(&flagNoDedup, "no-dedup", false, "Keep resources a provider returns more than once instead of merging them")
+	rootCmd.Flags().DurationVar(&flagSince, "since", 0, "Show only resources created within this duration (e.g., 24h)")
+	rootCmd.Flags().IntVar(&flagMaxResults, "max-results", 0, "Show at most this many resources per provider (default: defaults.max_resources_per_provider, 0 for no limit)")
+	rootCmd.Flags().StringVar(&flagSortBy, "sort-by", "", "With --service compute --metrics, order instances by usage: cpu or mem; with --gpu, util orders GPU instances busiest first")
+	rootCmd.Flags().Float64Var(&flagThreshold, "threshold", 0, "With --service compute --metrics, highlight instances at or above this CPU or memory percentage")
+	rootCmd.Flags().StringVar(&flagNotifySlack, "notify-slack", "", "Post a summary to this Slack incoming webhook URL after each collection")
+	rootCmd.Flags().StringVar(&flagNotifyOn, "notify-on", output.NotifyOnAlways, "When to send notifications: always or errors")
+	rootCmd.Flags().StringArrayVar(&flagTags, "tag", nil, "Show only resources with this tag, as key=value or key (repeatable)")
+
+	// Instance action flags
+	rootCmd.Flags().StringVar(&flagStart, "start", "", "Start this instance on the selected provider (needs --yes)")
+	rootCmd.Flags().StringVar(&flagStop, "stop", "", "Stop this instance on the selected provider (needs --yes)")
+	rootCmd.Flags().StringVar(&flagReboot, "reboot", "", "Reboot this instance on the selected provider (needs --yes)")
+	rootCmd.MarkFlagsMutuallyExclusive("start", "stop", "reboot")
+	rootCmd.Flags().BoolVar(&flagYes, "yes", false, "Confirm an instance action")
+	rootCmd.Flags().DurationVar(&flagActionTimeout, "action-timeout", defaultActionTimeout, "How long to wait for an instance action to finish")
 
 	// Add subcommands
 	rootCmd.AddCommand(initConfigCmd)
+	providersCmd.Flags().BoolVar(&flagProvidersJSON, "json", false, "Output providers as JSON")
+
+	rootCmd.AddCommand(getCmd)
+	getCmd.Flags().BoolVar(&flagGetJSON, "json", false, "Output the resource as JSON")
+
+	rootCmd.AddCommand(findCmd)
+	findCmd.Flags().BoolVar(&flagFindAll, "all", false, "Search all registered providers, not only those enabled in the config")
+	findCmd.Flags().BoolVar(&flagFindJSON, "json", false, "Output matches as JSON")
+	findCmd.Flags().BoolVar(&flagFindWide, "wide", false, "Include resource IDs in the table")
 	rootCmd.AddCommand(providersCmd)
+
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVar(&flagExportOut, "out", "", "Inventory file to write (required)")
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().BoolVar(&flagDiffJSON, "json", false, "Output the diff as JSON")
+	rootCmd.AddCommand(costCmd)
+	costCmd.Flags().BoolVar(&flagCostAll, "all", false, "Query all registered providers, not only those enabled in the config")
+	costCmd.Flags().BoolVar(&flagCostMonthly, "monthly", false, "Also project cost over 30 days")
+	costCmd.Flags().BoolVar(&flagCostIncludeStopped, "include-stopped", false, "Count stopped resources as if they were running")
+	costCmd.Flags().BoolVar(&flagCostJSON, "json", false, "Output the projection as JSON")
+
+	configMigrateCmd.Flags().BoolVar(&flagMigrateDryRun, "dry-run", false, "Print the migrated config without writing it")
+	configCmd.AddCommand(configMigrateCmd)
+	configShowCmd.Flags().BoolVar(&flagReveal, "reveal", false, "Show secrets unmasked")
+	configCmd.AddCommand(configShowCmd)
+
+	schemaCmd.AddCommand(schemaOutputCmd)
+	schemaCmd.AddCommand(schemaValidateCmd)
+	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(configCmd)
+
+	registerCompletions()
 }
 
 func initConfig() {
+	switch {
+	case flagQuiet:
+		logging.SetLevel(logging.LevelQuiet)
+	case flagVerbose:
+		logging.SetLevel(logging.LevelVerbose)
+		httpclient.DebugLog = logging.Debugf
+	}
+
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
@@ -204,7 +895,7 @@ func initConfig() {
 	var err error
 	cfg, err = config.Load(viper.ConfigFileUsed())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+		logging.Warnf("could not load config: %v", err)
 		cfg = config.DefaultConfig()
 	}
 }
@@ -221,12 +912,104 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
+	// Expand a configured alias before anything reads the flags it sets
+	if len(args) > 0 {
+		if err := validateAliases(cmd, cfg.Aliases); err != nil {
+			return err
+		}
+		if err := expandAlias(cmd, cfg.Aliases, args[0]); err != nil {
+			return err
+		}
+	}
+
+	if flagMaxResults < 0 {
+		return fmt.Errorf("--max-results must not be negative")
+	}
+	flagMaxResultsSet = cmd.Flags().Changed("max-results")
+	if flagNotifyOn != output.NotifyOnAlways && flagNotifyOn != output.NotifyOnErrors {
+		return fmt.Errorf("--notify-on must be %q or %q", output.NotifyOnAlways, output.NotifyOnErrors)
+	}
+	if flagSortBy == output.SortByUtil && (!flagGPU || flagList) {
+		return fmt.Errorf("--sort-by util applies to GPU instances (--gpu without --list)")
+	}
+	if flagTop < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+	if flagTop > 0 && !flagGPU {
+		return fmt.Errorf("--top applies to GPU output (--gpu)")
+	}
+	if (flagSortBy != "" && flagSortBy != output.SortByUtil) || flagThreshold != 0 {
+		if !flagMetrics || !isComputeService(flagService) {
+			return fmt.Errorf("--sort-by and --threshold apply to compute metrics (--service compute --metrics)")
+		}
+		if flagSortBy != "" && flagSortBy != output.SortByCPU && flagSortBy != output.SortByMem {
+			return fmt.Errorf("--sort-by must be %q or %q", output.SortByCPU, output.SortByMem)
+		}
+		if flagThreshold < 0 || flagThreshold > 100 {
+			return fmt.Errorf("--threshold must be between 0 and 100")
+		}
+	}
+
+	if len(flagTags) > 0 {
+		if _, err := provider.ParseTagFilter(flagTags); err != nil {
+			return err
+		}
+	}
+	if flagRefresh < 0 {
+		return fmt.Errorf("--refresh must be positive, got %s", flagRefresh)
+	}
+	if flagMetricWindow < 0 || flagMetricGranularity < 0 {
+		return fmt.Errorf("--metric-window and --metric-granularity must not be negative")
+	}
+	if flagWatchConfig && flagRefresh <= 0 {
+		return fmt.Errorf("--watch-config requires --refresh")
+	}
+	if flagEventLog != "" {
+		if flagRefresh <= 0 {
+			return fmt.Errorf("--event-log requires --refresh")
+		}
+		if flagGPU || flagMetrics || flagTUI {
+			return fmt.Errorf("--event-log records resource listings and cannot be combined with --gpu, --metrics or --tui")
+		}
+	}
+	if flagRecord != "" || flagReplay != "" {
+		if flagRecord != "" && flagReplay != "" {
+			return fmt.Errorf("--record and --replay cannot be combined")
+		}
+		if flagMetrics || flagWatchConfig {
+			return fmt.Errorf("--record and --replay cannot be combined with --metrics or --watch-config")
+		}
+		if action, _ := instanceAction(); action != "" {
+			return fmt.Errorf("--record and --replay cannot be combined with --start, --stop or --reboot")
+		}
+	}
+	if flagTUI {
+		if flagGPU || flagMetrics {
+			return fmt.Errorf("--tui shows resources and cannot be combined with --gpu or --metrics")
+		}
+		if flagJSON || flagJSONL || flagWatchConfig {
+			return fmt.Errorf("--tui cannot be combined with --json, --jsonl or --watch-config")
+		}
+		if action, _ := instanceAction(); action != "" {
+			return fmt.Errorf("--tui cannot be combined with --start, --stop or --reboot")
+		}
+	}
+	applyFlagOverrides()
+	if _, _, err := cfg.Defaults.MetricSettings(); err != nil {
+		return err
+	}
+
 	// Determine which providers to query
-	providersToQuery := getProvidersFromFlags()
-	if len(providersToQuery) == 0 {
-		providersToQuery = cfg.GetEnabledProviders()
+	providersToQuery := selectProviders()
+	if action, id := instanceAction(); action != "" {
+		if err := validateInstanceAction(action, id, getProvidersFromFlags()); err != nil {
+			return err
+		}
 	}
 
+	if len(providersToQuery) == 0 && flagReplay != "" {
+		return fmt.Errorf("--replay: no recordings in %s", flagReplay)
+	}
 	if len(providersToQuery) == 0 {
 		fmt.Println("No providers configured. Run 'cloudtop init' to generate a config file.")
 		fmt.Println("Or specify providers with --cloudflare, --oracle, --neon, etc.")
@@ -234,36 +1017,261 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize providers
-	providers, err := initializeProviders(ctx, providersToQuery)
+	providers, initReport, err := initializeProviders(ctx, providersToQuery)
 	if err != nil {
 		return fmt.Errorf("failed to initialize providers: %w", err)
 	}
-	defer closeProviders(providers)
 
-	// Create collector
-	var cache collector.Cache
-	if cfg.Cache.Enabled {
-		cache = collector.NewMemoryCache(cfg.Cache.TTL.Duration(), cfg.Cache.MaxSize)
-	} else {
-		cache = collector.NewNoopCache()
+	col := newCollector(providers)
+	col.SetInitReport(initReport)
+	defer func() { closeProviders(col.GetProviders()) }()
+	if !reportsInitFailures(col) {
+		warnInitFailures(initReport)
+	}
+	warnMetricWindowClamps(providers)
+
+	if action, id := instanceAction(); action != "" {
+		return runInstanceAction(ctx, providers, action, id)
+	}
+
+	if flagRefresh > 0 {
+		col.EnableTrends(flagTrendCycles)
+
+		// Per-resource utilization history feeds the wide-output sparklines
+		if flagMetrics {
+			col.EnableMetricHistory(metrics.DefaultHistorySamples)
+		}
+	}
+
+	// Sorting by utilization needs a GPU metrics sample per instance
+	if flagSortBy == output.SortByUtil && col.GPUHistory() == nil {
+		col.EnableMetricHistory(metrics.DefaultHistorySamples)
+	}
+
+	if flagWatchConfig {
+		path := viper.ConfigFileUsed()
+		if path == "" {
+			return fmt.Errorf("--watch-config: no config file found; pass --config or run 'cloudtop init'")
+		}
+		configWatcher, err = config.NewWatcher(path)
+		if err != nil {
+			return err
+		}
+		defer configWatcher.Close()
+	}
+
+	if flagTUI {
+		interval := flagRefresh
+		if interval <= 0 {
+			interval = cfg.Defaults.RefreshInterval.Duration()
+		}
+		return tui.New(col, tui.Options{
+			Interval: interval,
+			Request:  buildCollectRequest(),
+			Redactor: redactor(),
+			Order:    cfg.Output.ProviderOrder,
+		}).Run(ctx)
 	}
-	col := collector.NewCollector(providers, cache)
 
 	// Handle GPU-specific commands
+	if flagGPU && flagList && flagWatchPrice {
+		return runGPUPriceWatch(ctx, col)
+	}
 	if flagGPU && flagList {
 		return runGPUList(ctx, col)
 	}
+	if flagGPU && flagRefresh > 0 {
+		return runContinuous(ctx, col, runGPUInstances)
+	}
 	if flagGPU {
 		return runGPUInstances(ctx, col)
 	}
 
+	// Handle AI inference usage; providers without AI metrics fall
+	// through to the resource listing
+	if flagAI != "" && col.HasAIProviders() {
+		if flagEventLog != "" {
+			return fmt.Errorf("--event-log records resource listings, not AI usage")
+		}
+		if flagRefresh > 0 {
+			return runContinuous(ctx, col, runAIMetrics)
+		}
+		return runAIMetrics(ctx, col)
+	}
+
+	// Handle usage metrics
+	if flagMetrics && isComputeService(flagService) {
+		if flagRefresh > 0 {
+			return runContinuous(ctx, col, runComputeMetrics)
+		}
+		return runComputeMetrics(ctx, col)
+	}
+	if flagMetrics {
+		if !isStorageService(flagService) {
+			return fmt.Errorf("--metrics supports compute and storage services (e.g. --service compute, --service r2)")
+		}
+		if flagRefresh > 0 {
+			return runContinuous(ctx, col, runStorageMetrics)
+		}
+		return runStorageMetrics(ctx, col)
+	}
+
 	// Run collection loop
+	if flagEventLog != "" {
+		eventLog, err = collector.OpenEventLog(flagEventLog, redactor())
+		if err != nil {
+			return err
+		}
+		defer eventLog.Close()
+	}
 	if flagRefresh > 0 {
-		return runContinuous(ctx, col)
+		return runContinuous(ctx, col, runOnce)
 	}
 	return runOnce(ctx, col)
 }
 
+// applyFlagOverrides applies flags that adjust the loaded config. It runs
+// again after a config reload.
+func applyFlagOverrides() {
+	if len(flagProviderOrder) > 0 {
+		cfg.Output.ProviderOrder = flagProviderOrder
+	}
+	if !flagMaxResultsSet {
+		flagMaxResults = cfg.Defaults.MaxResourcesPerProvider
+	}
+	if flagMetricWindow > 0 {
+		cfg.Defaults.MetricWindow = config.Duration(flagMetricWindow)
+	}
+	if flagMetricGranularity > 0 {
+		cfg.Defaults.MetricGranularity = config.Duration(flagMetricGranularity)
+	}
+
+	// Filtered tag keys are shown as wide-output columns
+	seen := make(map[string]bool)
+	for _, key := range cfg.Output.TagColumns {
+		seen[key] = true
+	}
+	for _, arg := range flagTags {
+		key, _, _ := strings.Cut(arg, "=")
+		if key = strings.TrimSpace(key); !seen[key] {
+			seen[key] = true
+			cfg.Output.TagColumns = append(cfg.Output.TagColumns, key)
+		}
+	}
+}
+
+// selectProviders returns the providers named by flags, or every provider
+// enabled in the config when no provider flag is set
+func selectProviders() []string {
+	// Replaying --all (or no provider flags) covers every recorded provider,
+	// whether or not it is configured here
+	if flagReplay != "" && (flagAll || len(getProvidersFromFlags()) == 0) {
+		names, err := provider.RecordedProviders(flagReplay)
+		if err != nil {
+			logging.Warnf("%v", err)
+		}
+		return names
+	}
+	if names := getProvidersFromFlags(); len(names) > 0 {
+		return names
+	}
+	return cfg.GetEnabledProviders()
+}
+
+// reloadConfig re-reads the config file after a change and swaps the
+// collector onto providers initialized from it. If the file cannot be
+// loaded the previous config stays in effect. Cache settings apply only
+// at startup.
+func reloadConfig(ctx context.Context, col *collector.Collector) error {
+	path := viper.ConfigFileUsed()
+
+	// Load falls back to defaults for a missing file, which would drop
+	// every provider mid-session
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	reloaded, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	cfg = reloaded
+	applyFlagOverrides()
+
+	providers, initReport, err := initializeProviders(ctx, selectProviders())
+	if err != nil {
+		return err
+	}
+	closeProviders(col.SetProviders(providers))
+	col.SetInitReport(initReport)
+	if !reportsInitFailures(col) {
+		warnInitFailures(initReport)
+	}
+	return nil
+}
+
+// isStorageService reports whether --service selects object storage.
+// "storage" selects every storage provider's buckets.
+func isStorageService(service string) bool {
+	switch strings.ToLower(service) {
+	case "storage", "r2", "gcs", "blob", "object_storage":
+		return true
+	}
+	return false
+}
+
+// isComputeService reports whether --service selects compute instances
+func isComputeService(service string) bool {
+	switch strings.ToLower(service) {
+	case "compute", "instances", "vm":
+		return true
+	}
+	return false
+}
+
+// runComputeMetrics lists compute instances with their CPU and memory usage
+func runComputeMetrics(ctx context.Context, col *collector.Collector) error {
+	instances, errors := col.CollectCompute(ctx, &provider.InstanceFilter{})
+	for p, err := range errors {
+		logging.Warnf("%s: %v", p, err)
+	}
+
+	formatter := output.NewComputeFormatter(getOutputFormat(), os.Stdout, output.ComputeOptions{
+		SortBy:    flagSortBy,
+		Threshold: flagThreshold,
+		Color:     cfg.Output.ColorEnabled,
+		History:   col.CPUHistory(),
+	})
+	return formatter.FormatInstances(redactor().Instances(instances))
+}
+
+// runStorageMetrics lists buckets with their object counts and sizes
+func runStorageMetrics(ctx context.Context, col *collector.Collector) error {
+	var types []string
+	if service := strings.ToLower(flagService); service != "storage" {
+		types = []string{service}
+	}
+
+	buckets, errors := col.CollectStorage(ctx, types)
+	for p, err := range errors {
+		logging.Warnf("%s: %v", p, err)
+	}
+
+	formatter := output.NewStorageFormatter(getOutputFormat(), os.Stdout)
+	return formatter.FormatBuckets(buckets)
+}
+
+// runAIMetrics lists AI inference usage per model
+func runAIMetrics(ctx context.Context, col *collector.Collector) error {
+	usage, errors := col.CollectAI(ctx)
+	for p, err := range errors {
+		logging.Warnf("%s: %v", p, err)
+	}
+
+	formatter := output.NewAIFormatter(getOutputFormat(), os.Stdout)
+	return formatter.FormatAIMetrics(usage)
+}
+
 func getProvidersFromFlags() []string {
 	var providers []string
 
@@ -308,6 +1316,9 @@ func getProvidersFromFlags() []string {
 }
 
 func getOutputFormat() string {
+	if flagJSONL {
+		return "jsonl"
+	}
 	if flagJSON {
 		return "json"
 	}
@@ -320,10 +1331,27 @@ func getOutputFormat() string {
 	return cfg.Defaults.OutputFormat
 }
 
-func initializeProviders(ctx context.Context, providerNames []string) (map[string]provider.Provider, error) {
+// initializeProviders creates and initializes the named providers. The
+// report records each provider's outcome, including those that were
+// disabled, unavailable or failed to initialize and so are not returned.
+// With --replay each provider serves its recording instead, and with
+// --record its responses are saved.
+func initializeProviders(ctx context.Context, providerNames []string) (map[string]provider.Provider, output.InitReport, error) {
 	providers := make(map[string]provider.Provider)
+	report := make(output.InitReport, len(providerNames))
 
 	for _, name := range providerNames {
+		if flagReplay != "" {
+			p := provider.NewReplayProvider(name, flagReplay)
+			if err := p.Initialize(ctx, nil); err != nil {
+				report[name] = output.ProviderInit{Error: fmt.Sprintf("replay failed: %v", err)}
+				continue
+			}
+			providers[name] = p
+			report[name] = output.ProviderInit{Initialized: true}
+			continue
+		}
+
 		// Get provider config
 		providerCfg, ok := cfg.Providers[name]
 		if !ok {
@@ -337,49 +1365,126 @@ func initializeProviders(ctx context.Context, providerNames []string) (map[strin
 		}
 
 		if !providerCfg.Enabled {
-			fmt.Fprintf(os.Stderr, "Warning: provider %s is disabled\n", name)
+			report[name] = output.ProviderInit{Error: "provider is disabled"}
 			continue
 		}
 
 		// Create provider instance
-		p, err := provider.Create(name)
+		p, err := provider.Create(providerKind(name))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: provider %s not available: %v\n", name, err)
+			report[name] = output.ProviderInit{Error: fmt.Sprintf("provider not available: %v", err)}
 			continue
 		}
 
-		// Convert config to provider config
-		pCfg := &provider.ProviderConfig{
-			Name:        name,
-			Enabled:     providerCfg.Enabled,
-			Credentials: providerCfg.Auth.ToCredentials(),
-			Options:     providerCfg.Options,
-		}
-
-		if providerCfg.RateLimit != nil {
-			pCfg.RateLimit = &provider.RateLimitConfig{
-				RequestsPerSecond: providerCfg.RateLimit.RequestsPerSecond,
-				Burst:             providerCfg.RateLimit.Burst,
-				Timeout:           providerCfg.RateLimit.Timeout.Duration(),
-			}
-		}
+		credentials, credErr := providerCfg.Auth.ResolveCredentials()
 
 		// Initialize provider
-		if err := p.Initialize(ctx, pCfg); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to initialize %s: %v\n", name, err)
+		if err := p.Initialize(ctx, providerConfig(name, providerCfg, credentials)); err != nil {
+			msg := fmt.Sprintf("initialize failed: %v", err)
+			if credErr != nil {
+				msg += fmt.Sprintf(" (failed to resolve credentials: %v)", credErr)
+			}
+			report[name] = output.ProviderInit{Error: msg}
 			continue
 		}
+		if credErr != nil {
+			logging.Warnf("%s: failed to resolve credentials: %v", name, credErr)
+		}
 
+		if flagRecord != "" {
+			p = provider.NewRecordingProvider(name, p, flagRecord)
+		}
 		providers[name] = p
+		report[name] = output.ProviderInit{Initialized: true}
+	}
+
+	return providers, report, nil
+}
+
+// warnInitFailures warns about the providers in report that did not
+// initialize, for output that does not carry the report itself
+func warnInitFailures(report output.InitReport) {
+	for _, name := range report.Failed() {
+		logging.Warnf("%s: %s", name, report[name].Error)
+	}
+}
+
+// reportsInitFailures reports whether this run's output carries the
+// provider init report. The dashboard and the table and JSON resource
+// listings do; the GPU, AI and metrics views, instance actions and JSON
+// lines do not.
+func reportsInitFailures(col *collector.Collector) bool {
+	if flagTUI {
+		return true
+	}
+	if action, _ := instanceAction(); action != "" {
+		return false
+	}
+	if flagGPU || flagMetrics || (flagAI != "" && col.HasAIProviders()) {
+		return false
+	}
+	return getOutputFormat() != "jsonl"
+}
+
+// providerConfig converts a provider's config entry and resolved
+// credentials to the config it is initialized with
+func providerConfig(name string, providerCfg config.Provider, credentials map[string]string) *provider.ProviderConfig {
+	pCfg := &provider.ProviderConfig{
+		Name:        name,
+		Enabled:     providerCfg.Enabled,
+		Credentials: credentials,
+		Options:     providerCfg.Options,
+		HTTP:        providerCfg.HTTP.ToClientConfig(),
+	}
+
+	if providerCfg.RateLimit != nil {
+		pCfg.RateLimit = &provider.RateLimitConfig{
+			RequestsPerSecond: providerCfg.RateLimit.RequestsPerSecond,
+			Burst:             providerCfg.RateLimit.Burst,
+			Timeout:           providerCfg.RateLimit.Timeout.Duration(),
+		}
+	}
+	return pCfg
+}
+
+// redactor masks the fields named by output.redact_fields and --redact
+func redactor() *output.Redactor {
+	var fields []string
+	if cfg != nil {
+		fields = append(fields, cfg.Output.RedactFields...)
 	}
+	return output.NewRedactor(append(fields, flagRedact...))
+}
 
-	return providers, nil
+// newCollector creates a collector over providers using the configured cache
+func newCollector(providers map[string]provider.Provider) *collector.Collector {
+	var cache collector.Cache
+	if cfg.Cache.Enabled {
+		cache = collector.NewMemoryCache(cfg.Cache.TTL.Duration(), cfg.Cache.MaxSize)
+	} else {
+		cache = collector.NewNoopCache()
+	}
+	return collector.NewCollector(providers, cache)
+}
+
+// providerKind returns the registry name used to create provider name.
+// Providers configured with options.command run an external program under
+// their configured name.
+func providerKind(name string) string {
+	if cfg != nil {
+		if pc, ok := cfg.Providers[name]; ok {
+			if _, ok := pc.Options["command"]; ok {
+				return external.ProviderType
+			}
+		}
+	}
+	return name
 }
 
 func closeProviders(providers map[string]provider.Provider) {
 	for name, p := range providers {
 		if err := p.Close(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error closing provider %s: %v\n", name, err)
+			logging.Errorf("closing provider %s: %v", name, err)
 		}
 	}
 }
@@ -388,33 +1493,139 @@ func runOnce(ctx context.Context, col *collector.Collector) error {
 	// Build collection request
 	req := buildCollectRequest()
 
+	if getOutputFormat() == "jsonl" {
+		return runStream(ctx, col, req)
+	}
+
 	// Collect data
 	resp, err := col.Collect(ctx, req)
 	if err != nil {
 		return fmt.Errorf("collection failed: %w", err)
 	}
+	for _, name := range output.OrderProviders(resp.Results, nil) {
+		debugTiming(resp.Results[name])
+		warnMissingCreatedAt(resp.Results[name])
+		explainResult(resp.Results[name])
+	}
+	notify(ctx, output.SummarizeResult(resp))
+	logEvents(resp)
 
 	// Format and output results
 	formatter := output.NewFormatter(getOutputFormat(), &cfg.Output, os.Stdout)
-	return formatter.Format(resp)
+	return formatter.Format(redactor().Result(resp))
+}
+
+// runStream writes each provider's resources as JSON lines as soon as the
+// provider returns, rather than waiting for the full collection
+func runStream(ctx context.Context, col *collector.Collector, req *collector.CollectRequest) error {
+	formatter := output.NewJSONLFormatter(os.Stdout)
+	rd := redactor()
+
+	// Only counts are kept for the notification summary
+	summary := &output.NotifySummary{
+		Timestamp: time.Now(),
+		Counts:    make(map[string]int),
+		Errors:    make(map[string]string),
+	}
+
+	// Results are kept for the event log only when there is one
+	collected := &output.CollectResult{
+		Results: make(map[string]*output.ProviderResult),
+		Errors:  make(map[string]error),
+	}
+
+	var writeErr error
+	col.CollectStream(ctx, req, func(name string, result *output.ProviderResult, err error) {
+		if err != nil {
+			logging.Warnf("%s: %v", name, err)
+			summary.Errors[name] = err.Error()
+			collected.Errors[name] = err
+		}
+		if result == nil {
+			return
+		}
+		if eventLog != nil {
+			collected.Results[name] = result
+		}
+		debugTiming(result)
+		if result.Stale {
+			logging.Warnf("%s: showing stale results as of %s", name, result.LastSuccess.Local().Format("15:04"))
+		}
+		summary.Counts[name] = len(result.Resources)
+		warnMissingCreatedAt(result)
+		explainResult(result)
+		if result.Truncated() {
+			logging.Warnf("%s: showing %s resources", result.Provider, result.ShownCount())
+		}
+		if writeErr == nil {
+			writeErr = formatter.WriteProviderResult(rd.ProviderResult(result))
+		}
+	})
+	notify(ctx, summary)
+	collected.Timestamp = time.Now()
+	logEvents(collected)
+
+	return writeErr
+}
+
+// logEvents appends the resource changes since the previous cycle to the
+// --event-log file
+func logEvents(result *output.CollectResult) {
+	if eventLog == nil {
+		return
+	}
+	if _, err := eventLog.Record(result); err != nil {
+		logging.Warnf("%v", err)
+	}
 }
 
-func runContinuous(ctx context.Context, col *collector.Collector) error {
+func runContinuous(ctx context.Context, col *collector.Collector, run func(context.Context, *collector.Collector) error) error {
 	ticker := time.NewTicker(flagRefresh)
 	defer ticker.Stop()
 
+	var changes <-chan struct{}
+	if configWatcher != nil {
+		changes = configWatcher.Changes()
+	}
+
+	// reloadNotice reports the last config reload on the next redraw,
+	// since warnings printed before it would be cleared
+	var reloadNotice string
+
 	for {
-		// Clear screen
-		fmt.Print("\033[H\033[2J")
-		fmt.Printf("cloudtop - refreshing every %v (Ctrl+C to quit)\n", flagRefresh)
+		// Streams are appended to, not redrawn
+		if getOutputFormat() != "jsonl" {
+			// Clear screen
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("cloudtop - refreshing every %v (Ctrl+C to quit)\n", flagRefresh)
+		}
+		if reloadNotice != "" {
+			if !logging.Quiet() {
+				fmt.Fprintln(os.Stderr, reloadNotice)
+			}
+			reloadNotice = ""
+		}
 
-		if err := runOnce(ctx, col); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if err := run(ctx, col); err != nil {
+			logging.Errorf("%v", err)
+		} else if summary := col.Trends().Summary(); summary != "" && !logging.Quiet() {
+			// Trend lines would corrupt machine-readable output
+			if format := getOutputFormat(); format != "json" && format != "jsonl" {
+				fmt.Printf("\nTrend: %s\n", summary)
+			}
 		}
 
 		select {
 		case <-ticker.C:
 			continue
+		case <-changes:
+			// Reload between cycles and refresh straight away
+			if err := reloadConfig(ctx, col); err != nil {
+				reloadNotice = fmt.Sprintf("Warning: config reload failed, keeping previous config: %v", err)
+			} else {
+				reloadNotice = "Config reloaded from " + viper.ConfigFileUsed()
+			}
+			ticker.Reset(flagRefresh)
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -431,12 +1642,16 @@ func runGPUInstances(ctx context.Context, col *collector.Collector) error {
 
 	// Show errors
 	for p, err := range errors {
-		fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", p, err)
+		logging.Warnf("%s: %v", p, err)
 	}
+	notify(ctx, output.SummarizeGPU(instances, errors))
 
 	// Format output
-	formatter := output.NewGPUFormatter(flagWide, os.Stdout)
-	return formatter.FormatGPUInstances(instances)
+	formatter := output.NewGPUFormatter(flagWide, os.Stdout).
+		WithHistory(col.GPUHistory()).
+		WithSort(flagSortBy).
+		WithTop(flagTop)
+	return formatter.FormatGPUInstances(redactor().GPUInstances(instances))
 }
 
 func runGPUList(ctx context.Context, col *collector.Collector) error {
@@ -444,19 +1659,84 @@ func runGPUList(ctx context.Context, col *collector.Collector) error {
 
 	// Show errors
 	for p, err := range errors {
-		fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", p, err)
+		logging.Warnf("%s: %v", p, err)
 	}
 
 	// Format output
-	formatter := output.NewGPUFormatter(flagWide, os.Stdout)
-	return formatter.FormatGPUOfferings(offerings)
+	formatter := output.NewGPUFormatter(flagWide, os.Stdout).WithTop(flagTop)
+	return formatter.FormatGPUOfferings(offerings, errors)
+}
+
+// runGPUPriceWatch lists GPU offerings on every refresh, comparing prices
+// against the history persisted in the cache directory
+func runGPUPriceWatch(ctx context.Context, col *collector.Collector) error {
+	history, err := collector.LoadPriceHistory(priceHistoryPath())
+	if err != nil {
+		return err
+	}
+
+	interval := flagRefresh
+	if interval <= 0 {
+		interval = cfg.Defaults.RefreshInterval.Duration()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	formatter := output.NewGPUFormatter(flagWide, os.Stdout).WithTop(flagTop)
+
+	for {
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("cloudtop - watching GPU prices every %v (Ctrl+C to quit)\n", interval)
+
+		offerings, errors := col.CollectGPUAvailability(ctx)
+		for p, err := range errors {
+			logging.Warnf("%s: %v", p, err)
+		}
+
+		alerts := history.Compare(offerings, flagPriceThreshold, flagDropPct)
+		history.Record(offerings, time.Now())
+		if err := history.Save(); err != nil {
+			logging.Warnf("%v", err)
+		}
+
+		if err := formatter.FormatGPUOfferings(offerings, errors); err != nil {
+			return err
+		}
+		if err := formatter.FormatPriceAlerts(alerts); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// priceHistoryPath returns the GPU price history file in the cache directory
+func priceHistoryPath() string {
+	dir := cfg.Cache.CacheDir
+	if dir == "" {
+		if userCache, err := os.UserCacheDir(); err == nil {
+			dir = filepath.Join(userCache, "cloudtop")
+		} else {
+			dir = ".cloudtop-cache"
+		}
+	}
+	return filepath.Join(dir, "gpu-prices.json")
 }
 
 func buildCollectRequest() *collector.CollectRequest {
 	req := &collector.CollectRequest{
-		Timeout: 30 * time.Second,
-		Filters: &provider.ResourceFilter{},
+		Timeout:        30 * time.Second,
+		Filters:        &provider.ResourceFilter{},
+		Explain:        flagExplain,
+		MaxResults:     flagMaxResults,
+		KeepDuplicates: flagNoDedup,
 	}
+	req.MetricWindow, req.MetricGranularity, _ = cfg.Defaults.MetricSettings()
 
 	// Apply service filter
 	if flagService != "" {
@@ -469,5 +1749,84 @@ func buildCollectRequest() *collector.CollectRequest {
 		req.Filters.Status = []string{"running", "active"}
 	}
 
+	// Apply tag filter; tags were validated in run
+	if len(flagTags) > 0 {
+		req.Filters.Tags, _ = provider.ParseTagFilter(flagTags)
+	}
+
+	// Apply creation time filter
+	if flagSince > 0 {
+		createdAfter := time.Now().Add(-flagSince)
+		req.Filters.CreatedAfter = &createdAfter
+	}
+
 	return req
 }
+
+// warnMetricWindowClamps warns about each provider whose metrics API
+// cannot serve the configured window, which is cut to its limit
+func warnMetricWindowClamps(providers map[string]provider.Provider) {
+	window, granularity, err := cfg.Defaults.MetricSettings()
+	if err != nil {
+		return
+	}
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if w, g, clamped := collector.MetricWindowFor(providers[name], window, granularity); clamped {
+			logging.Warnf("%s: metric window %s exceeds the provider limit, using %s at %s granularity", name, window, w, g)
+		}
+	}
+}
+
+// notify posts the collection summary to Slack when --notify-slack is set
+// and the --notify-on gate passes. Failures are reported but not fatal.
+func notify(ctx context.Context, summary *output.NotifySummary) {
+	if flagNotifySlack == "" || !summary.ShouldNotify(flagNotifyOn) {
+		return
+	}
+	client, _ := httpclient.New(nil)
+	if err := output.SendSlack(ctx, client, flagNotifySlack, summary); err != nil {
+		logging.Warnf("slack notification failed: %v", err)
+	}
+}
+
+// debugTiming logs how long a provider's collection took, in verbose mode
+func debugTiming(result *output.ProviderResult) {
+	source := ""
+	switch {
+	case result.Stale:
+		source = ", stale"
+	case result.Cached:
+		source = ", cached"
+	}
+	logging.Debugf("%s: %d resources in %v%s", result.Provider, len(result.Resources), result.Duration.Round(time.Millisecond), source)
+}
+
+// explainResult prints the filter stage counts for --explain
+func explainResult(result *output.ProviderResult) {
+	if !flagExplain || result.Filter == nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "explain: %s: %s\n", result.Provider, result.Filter)
+}
+
+// warnMissingCreatedAt notes when --since could not be applied because a
+// provider does not report creation times
+func warnMissingCreatedAt(result *output.ProviderResult) {
+	if flagSince <= 0 {
+		return
+	}
+	missing := 0
+	for _, r := range result.Resources {
+		if r.CreatedAt.IsZero() {
+			missing++
+		}
+	}
+	if missing > 0 {
+		logging.Warnf("%s: %d resources have no creation time; --since not applied to them", result.Provider, missing)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/config"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+// defaultTestAuthTimeout bounds each provider's initialization and health
+// check in providers test-auth
+const defaultTestAuthTimeout = 30 * time.Second
+
+var (
+	flagTestAuthJSON    bool
+	flagTestAuthTimeout time.Duration
+)
+
+var providersTestAuthCmd = &cobra.Command{
+	Use:   "test-auth [provider...]",
+	Short: "Show which credentials each provider resolves and check they work",
+	Long: `For each enabled provider (or the providers named), show the auth method,
+where each credential resolved from (env var name, key file path, config
+field or secret reference) and whether it is set, then initialize the
+provider and run its health check. Secret values are never printed.
+
+Credentials resolve in this order: an env var named by env_api_key or
+env_secret wins over api_key or api_secret in the config; key_file is used
+for service_account; secret_ref and api_secret_ref are read from the
+secrets manager.
+
+Exits non-zero when any provider fails its health check.
+
+Examples:
+  cloudtop providers test-auth
+  cloudtop providers test-auth cloudflare oracle
+  cloudtop providers test-auth --json`,
+	// A failed check is a result, not a usage mistake
+	SilenceUsage: true,
+	RunE:         runTestAuth,
+}
+
+// authReport is the test-auth result for one provider
+type authReport struct {
+	Provider    string                    `json:"provider"`
+	Method      string                    `json:"method"`
+	Configured  bool                      `json:"configured"`
+	Enabled     bool                      `json:"enabled"`
+	Credentials []config.CredentialSource `json:"credentials"`
+	Healthy     bool                      `json:"healthy"`
+	Error       string                    `json:"error,omitempty"`
+	DurationMs  int64                     `json:"duration_ms"`
+}
+
+// testProviderAuth resolves a provider's credentials, initializes it and
+// runs its health check within timeout
+func testProviderAuth(ctx context.Context, name string, timeout time.Duration) authReport {
+	providerCfg, configured := cfg.Providers[name]
+	if !configured {
+		// Same fallback as initializeProviders
+		providerCfg = config.Provider{Enabled: true, Auth: config.AuthConfig{Method: "env"}}
+	}
+
+	report := authReport{
+		Provider:    name,
+		Method:      providerCfg.Auth.Method,
+		Configured:  configured,
+		Enabled:     providerCfg.Enabled,
+		Credentials: providerCfg.Auth.CredentialSources(),
+	}
+
+	p, err := provider.Create(providerKind(name))
+	if err != nil {
+		report.Error = fmt.Sprintf("provider not available: %v", err)
+		return report
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Resolution errors are already reported per credential
+	credentials, _ := providerCfg.Auth.ResolveCredentials()
+	start := time.Now()
+	err = p.Initialize(ctx, providerConfig(name, providerCfg, credentials))
+	if err != nil {
+		report.Error = fmt.Sprintf("initialize failed: %v", err)
+	} else {
+		if err = p.HealthCheck(ctx); err != nil {
+			report.Error = fmt.Sprintf("health check failed: %v", err)
+		}
+		p.Close()
+	}
+	report.DurationMs = time.Since(start).Milliseconds()
+	report.Healthy = err == nil
+	return report
+}
+
+// describeSource renders where a credential came from
+func describeSource(src config.CredentialSource) string {
+	switch src.Source {
+	case "env":
+		return "env $" + src.Ref
+	case "file":
+		return "file " + src.Ref
+	case "literal":
+		return "config auth." + src.Ref
+	case "secret_ref":
+		return "secret " + src.Ref
+	}
+	return src.Source
+}
+
+func runTestAuth(cmd *cobra.Command, args []string) error {
+	if flagTestAuthTimeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+
+	names := args
+	if len(names) == 0 {
+		names = cfg.GetEnabledProviders()
+		sort.Strings(names)
+	}
+	if len(names) == 0 {
+		fmt.Println("No providers configured. Run 'cloudtop init' to generate a config file.")
+		return nil
+	}
+
+	ctx := context.Background()
+	reports := make([]authReport, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			reports[i] = testProviderAuth(ctx, name, flagTestAuthTimeout)
+		}(i, name)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, r := range reports {
+		if !r.Healthy {
+			failed++
+		}
+	}
+
+	if flagTestAuthJSON {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		for i, r := range reports {
+			if i > 0 {
+				fmt.Println()
+			}
+			printAuthReport(r)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d provider(s) failed the health check", failed, len(reports))
+	}
+	return nil
+}
+
+// printAuthReport prints one provider's test-auth result
+func printAuthReport(r authReport) {
+	heading := fmt.Sprintf("%s (method: %s)", r.Provider, r.Method)
+	switch {
+	case !r.Configured:
+		heading += " - not in config"
+	case !r.Enabled:
+		heading += " - disabled"
+	}
+	fmt.Println(heading)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if len(r.Credentials) == 0 {
+		fmt.Fprintf(w, "  (no credentials configured for method %q)\n", r.Method)
+	}
+	for _, c := range r.Credentials {
+		status := "set"
+		switch {
+		case c.Error != "":
+			status = "error: " + c.Error
+		case !c.Set:
+			status = "EMPTY"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", c.Key, describeSource(c), status)
+	}
+
+	health := fmt.Sprintf("ok (%dms)", r.DurationMs)
+	if !r.Healthy {
+		health = r.Error
+	}
+	fmt.Fprintf(w, "  health\t%s\n", health)
+	w.Flush()
+}
+
+func init() {
+	providersTestAuthCmd.Flags().BoolVar(&flagTestAuthJSON, "json", false, "Output results as JSON")
+	providersTestAuthCmd.Flags().DurationVar(&flagTestAuthTimeout, "timeout", defaultTestAuthTimeout, "Time allowed for each provider's health check")
+	providersCmd.AddCommand(providersTestAuthCmd)
+}
@@ -3,6 +3,8 @@ package collector
 import (
 	"sync"
 	"time"
+
+	"github.com/afterdarksys/cloudtop/pkg/clock"
 )
 
 // Cache interface for flexible caching backends
@@ -19,6 +21,7 @@ type MemoryCache struct {
 	items   map[string]*cacheItem
 	ttl     time.Duration
 	maxSize int
+	clock   clock.Clock
 }
 
 type cacheItem struct {
@@ -28,10 +31,17 @@ type cacheItem struct {
 
 // NewMemoryCache creates a new in-memory cache
 func NewMemoryCache(ttl time.Duration, maxSize int) *MemoryCache {
+	return NewMemoryCacheWithClock(ttl, maxSize, clock.System())
+}
+
+// NewMemoryCacheWithClock creates a new in-memory cache whose TTL checks use
+// clk instead of the system clock
+func NewMemoryCacheWithClock(ttl time.Duration, maxSize int, clk clock.Clock) *MemoryCache {
 	cache := &MemoryCache{
 		items:   make(map[string]*cacheItem),
 		ttl:     ttl,
 		maxSize: maxSize,
+		clock:   clk,
 	}
 
 	// Start cleanup goroutine
@@ -50,7 +60,7 @@ func (c *MemoryCache) Get(key string) (interface{}, bool) {
 		return nil, false
 	}
 
-	if time.Now().After(item.expiration) {
+	if c.clock.Now().After(item.expiration) {
 		return nil, false
 	}
 
@@ -69,7 +79,7 @@ func (c *MemoryCache) Set(key string, value interface{}) {
 
 	c.items[key] = &cacheItem{
 		value:      value,
-		expiration: time.Now().Add(c.ttl),
+		expiration: c.clock.Now().Add(c.ttl),
 	}
 }
 
@@ -89,7 +99,7 @@ func (c *MemoryCache) Clear() {
 
 // evictOldest removes items that have expired or the oldest items
 func (c *MemoryCache) evictOldest() {
-	now := time.Now()
+	now := c.clock.Now()
 	var oldestKey string
 	var oldestTime time.Time
 
@@ -116,7 +126,7 @@ func (c *MemoryCache) cleanup() {
 
 	for range ticker.C {
 		c.mu.Lock()
-		now := time.Now()
+		now := c.clock.Now()
 		for key, item := range c.items {
 			if now.After(item.expiration) {
 				delete(c.items, key)
@@ -6,14 +6,51 @@ import (
 	"sync"
 	"time"
 
+	"github.com/afterdarksys/cloudtop/internal/config"
+	cterrors "github.com/afterdarksys/cloudtop/internal/errors"
+	"github.com/afterdarksys/cloudtop/internal/metrics"
 	"github.com/afterdarksys/cloudtop/internal/output"
 	"github.com/afterdarksys/cloudtop/internal/provider"
+	"github.com/afterdarksys/cloudtop/pkg/circuit"
+	"github.com/afterdarksys/cloudtop/pkg/retry"
 )
 
+// Default circuit breaker settings for providers
+const (
+	DefaultBreakerThreshold = 3
+	DefaultBreakerCooldown  = 2 * time.Minute
+)
+
+// gpuAvailabilityRetry bounds the retries of a GPU availability request
+// that failed with a retryable error
+var gpuAvailabilityRetry = retry.Config{
+	MaxRetries:      2,
+	InitialInterval: 500 * time.Millisecond,
+	MaxInterval:     5 * time.Second,
+	Multiplier:      2.0,
+	Jitter:          0.1,
+}
+
 // Collector orchestrates data collection from multiple providers
 type Collector struct {
 	providers map[string]provider.Provider
 	cache     Cache
+	breakers  *circuit.Group
+	trends    *trendRing
+
+	// cpuHistory and gpuHistory hold per-resource utilization samples
+	// when metric history is enabled
+	cpuHistory *metrics.History
+	gpuHistory *metrics.History
+
+	// lastGood holds each cache key's last successful result, served as
+	// stale when the provider fails and caching is enabled
+	lastGoodMu sync.Mutex
+	lastGood   map[string]*output.ProviderResult
+
+	// init is how the providers' initialization went, reported with each
+	// collection
+	init output.InitReport
 }
 
 // CollectRequest specifies what to collect
@@ -23,6 +60,49 @@ type CollectRequest struct {
 	MetricTypes []string
 	Filters     *provider.ResourceFilter
 	Timeout     time.Duration
+
+	// Explain records per-stage filter counts on each ProviderResult
+	Explain bool
+
+	// MaxResults caps the resources kept per provider; 0 means no limit.
+	// Truncated results record the uncapped count in ProviderResult.Total,
+	// and listings the provider may have cut short set Partial.
+	MaxResults int
+
+	// KeepDuplicates disables merging resources a provider returned more
+	// than once (see MergeDuplicates)
+	KeepDuplicates bool
+
+	// MetricWindow and MetricGranularity shape the metrics requested for
+	// MetricTypes; zero means config.DefaultMetricWindow and
+	// config.DefaultMetricGranularity. Windows beyond a provider's limit are
+	// clamped (see MetricWindowFor).
+	MetricWindow      time.Duration
+	MetricGranularity time.Duration
+}
+
+// MetricWindowFor fits a metrics window and granularity to p's limit. When
+// the window is longer than p allows it is cut to the limit, rounded down
+// to a whole number of granularity steps, and clamped is true.
+func MetricWindowFor(p provider.Provider, window, granularity time.Duration) (time.Duration, time.Duration, bool) {
+	if window <= 0 {
+		window = config.DefaultMetricWindow
+	}
+	if granularity <= 0 {
+		granularity = config.DefaultMetricGranularity
+	}
+
+	limits, ok := p.(provider.MetricLimits)
+	if !ok || limits.MaxMetricWindow() <= 0 || window <= limits.MaxMetricWindow() {
+		return window, granularity, false
+	}
+
+	window = limits.MaxMetricWindow()
+	if granularity > window {
+		granularity = window
+	}
+	window -= window % granularity
+	return window, granularity, true
 }
 
 // NewCollector creates a new collector instance
@@ -30,13 +110,57 @@ func NewCollector(providers map[string]provider.Provider, cache Cache) *Collecto
 	return &Collector{
 		providers: providers,
 		cache:     cache,
+		breakers:  circuit.NewGroup(DefaultBreakerThreshold, DefaultBreakerCooldown),
+		lastGood:  make(map[string]*output.ProviderResult),
 	}
 }
 
+// SetCircuitBreakers replaces the per-provider circuit breakers
+func (c *Collector) SetCircuitBreakers(breakers *circuit.Group) {
+	c.breakers = breakers
+}
+
 // Collect gathers data from all specified providers concurrently
 func (c *Collector) Collect(ctx context.Context, req *CollectRequest) (*output.CollectResult, error) {
 	start := time.Now()
 
+	results := make(map[string]*output.ProviderResult)
+	errors := make(map[string]error)
+
+	c.CollectStream(ctx, req, func(name string, result *output.ProviderResult, err error) {
+		if err != nil {
+			errors[name] = err
+		}
+		if result != nil {
+			results[name] = result
+		}
+	})
+
+	result := &output.CollectResult{
+		Results:   results,
+		Errors:    errors,
+		Timestamp: time.Now(),
+		Duration:  time.Since(start),
+		Init:      c.init,
+	}
+	c.recordResults(result)
+
+	return result, nil
+}
+
+// CollectStream gathers data from all specified providers concurrently and
+// hands each provider's result to emit as soon as it is available, so callers
+// can stream output without holding every result in memory. Calls to emit are
+// serialized.
+//
+// When caching is enabled, a provider that fails is emitted with both its
+// error and its last successful result, marked Stale.
+//
+// When req.Timeout passes, providers that have not finished are emitted as
+// timeout errors and CollectStream returns without waiting for them; results
+// they produce later are discarded. Providers that already returned keep
+// their results.
+func (c *Collector) CollectStream(ctx context.Context, req *CollectRequest, emit func(name string, result *output.ProviderResult, err error)) {
 	// Set default timeout if not specified
 	if req.Timeout == 0 {
 		req.Timeout = 60 * time.Second
@@ -49,40 +173,52 @@ func (c *Collector) Collect(ctx context.Context, req *CollectRequest) (*output.C
 	// Determine which providers to query
 	providersToQuery := c.getProvidersToQuery(req.Providers)
 
-	// Collect from providers concurrently
-	results := make(map[string]*output.ProviderResult)
-	errors := make(map[string]error)
-
-	var wg sync.WaitGroup
 	var mu sync.Mutex
+	pending := make(map[string]bool, len(providersToQuery))
+	done := make(chan struct{})
 
 	for _, providerName := range providersToQuery {
-		wg.Add(1)
+		pending[providerName] = true
+	}
+	if len(pending) == 0 {
+		return
+	}
 
+	for _, providerName := range providersToQuery {
 		go func(name string) {
-			defer wg.Done()
-
 			result, err := c.collectFromProvider(ctx, name, req)
 
 			mu.Lock()
 			defer mu.Unlock()
 
-			if err != nil {
-				errors[name] = err
-			} else {
-				results[name] = result
+			// Already reported as timed out
+			if !pending[name] {
+				return
+			}
+			delete(pending, name)
+			emit(name, result, err)
+			if len(pending) == 0 {
+				close(done)
 			}
 		}(providerName)
 	}
 
-	wg.Wait()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		mu.Lock()
+		defer mu.Unlock()
 
-	return &output.CollectResult{
-		Results:   results,
-		Errors:    errors,
-		Timestamp: time.Now(),
-		Duration:  time.Since(start),
-	}, nil
+		cause := ctx.Err()
+		if cause == context.DeadlineExceeded {
+			cause = fmt.Errorf("no response within %s", req.Timeout)
+		}
+		for name := range pending {
+			emit(name, c.staleResult(c.buildCacheKey(name, req)), cterrors.NewTimeoutError(name, cause))
+		}
+		// Emptying pending stops late results from being emitted
+		pending = map[string]bool{}
+	}
 }
 
 // CollectGPU collects GPU instances from all GPU providers
@@ -93,7 +229,7 @@ func (c *Collector) CollectGPU(ctx context.Context, filter *provider.GPUFilter)
 	var wg sync.WaitGroup
 
 	for name, p := range c.providers {
-		gpuProvider, ok := p.(provider.GPUProvider)
+		gpuProvider, ok := provider.As[provider.GPUProvider](p)
 		if !ok {
 			continue
 		}
@@ -103,6 +239,9 @@ func (c *Collector) CollectGPU(ctx context.Context, filter *provider.GPUFilter)
 			defer wg.Done()
 
 			instances, err := gp.ListGPUInstances(ctx, filter)
+			if err == nil && c.gpuHistory != nil {
+				c.recordGPUUtilization(ctx, gp, instances)
+			}
 
 			mu.Lock()
 			defer mu.Unlock()
@@ -116,10 +255,24 @@ func (c *Collector) CollectGPU(ctx context.Context, filter *provider.GPUFilter)
 	}
 
 	wg.Wait()
+	c.recordGPU(allInstances)
+	if c.gpuHistory != nil {
+		c.gpuHistory.EndCycle()
+	}
 	return allInstances, errors
 }
 
-// CollectGPUAvailability collects GPU offerings from all GPU providers
+// permanentError stops retry.Do from retrying an error that is not a
+// retryable CloudtopError
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string     { return e.err.Error() }
+func (e permanentError) IsRetryable() bool { return false }
+
+// CollectGPUAvailability collects GPU offerings from all GPU providers.
+// Requests failing with a retryable error are retried with backoff; the
+// returned errors map holds the providers that still failed, whose
+// offerings are missing from the result.
 func (c *Collector) CollectGPUAvailability(ctx context.Context) ([]provider.GPUOffering, map[string]error) {
 	var allOfferings []provider.GPUOffering
 	errors := make(map[string]error)
@@ -127,7 +280,7 @@ func (c *Collector) CollectGPUAvailability(ctx context.Context) ([]provider.GPUO
 	var wg sync.WaitGroup
 
 	for name, p := range c.providers {
-		gpuProvider, ok := p.(provider.GPUProvider)
+		gpuProvider, ok := provider.As[provider.GPUProvider](p)
 		if !ok {
 			continue
 		}
@@ -136,7 +289,16 @@ func (c *Collector) CollectGPUAvailability(ctx context.Context) ([]provider.GPUO
 		go func(name string, gp provider.GPUProvider) {
 			defer wg.Done()
 
-			offerings, err := gp.GetGPUAvailability(ctx)
+			offerings, err := retry.DoWithResult(ctx, gpuAvailabilityRetry, func() ([]provider.GPUOffering, error) {
+				offerings, err := gp.GetGPUAvailability(ctx)
+				if err != nil && !cterrors.IsRetryableError(err) {
+					return nil, permanentError{err}
+				}
+				return offerings, err
+			})
+			if perr, ok := err.(permanentError); ok {
+				err = perr.err
+			}
 
 			mu.Lock()
 			defer mu.Unlock()
@@ -153,17 +315,252 @@ func (c *Collector) CollectGPUAvailability(ctx context.Context) ([]provider.GPUO
 	return allOfferings, errors
 }
 
-// collectFromProvider collects data from a single provider
+// CollectCompute lists instances from all compute providers along with
+// their usage metrics. An instance whose metrics cannot be fetched is still
+// returned, without metrics.
+func (c *Collector) CollectCompute(ctx context.Context, filter *provider.InstanceFilter) ([]provider.InstanceMetrics, map[string]error) {
+	var allInstances []provider.InstanceMetrics
+	errors := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, p := range c.providers {
+		computeProvider, ok := provider.As[provider.ComputeProvider](p)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, cp provider.ComputeProvider) {
+			defer wg.Done()
+
+			instances, err := cp.ListInstances(ctx, filter)
+			if err != nil {
+				mu.Lock()
+				errors[name] = err
+				mu.Unlock()
+				return
+			}
+
+			results := make([]provider.InstanceMetrics, len(instances))
+			for i, inst := range instances {
+				results[i].Instance = inst
+				if m, err := cp.GetInstanceMetrics(ctx, inst.ID); err == nil {
+					results[i].Metrics = m
+					if c.cpuHistory != nil {
+						c.cpuHistory.Record(metrics.HistoryKey(inst.Provider, inst.ID), m.CPUUsagePercent)
+					}
+				}
+			}
+
+			mu.Lock()
+			allInstances = append(allInstances, results...)
+			mu.Unlock()
+		}(name, computeProvider)
+	}
+
+	wg.Wait()
+	if c.cpuHistory != nil {
+		c.cpuHistory.EndCycle()
+	}
+	return allInstances, errors
+}
+
+// EnableMetricHistory keeps the last samples CPU and GPU utilization
+// readings per resource across collections. Once enabled, CollectGPU also
+// fetches GPU metrics for every instance it lists.
+func (c *Collector) EnableMetricHistory(samples int) {
+	c.cpuHistory = metrics.NewHistory(samples)
+	c.gpuHistory = metrics.NewHistory(samples)
+}
+
+// CPUHistory returns the per-instance CPU utilization history, or nil if
+// metric history is not enabled
+func (c *Collector) CPUHistory() *metrics.History {
+	return c.cpuHistory
+}
+
+// GPUHistory returns the per-instance GPU utilization history, averaged
+// across each instance's GPUs, or nil if metric history is not enabled
+func (c *Collector) GPUHistory() *metrics.History {
+	return c.gpuHistory
+}
+
+// recordGPUUtilization samples the mean GPU utilization of each instance.
+// Instances whose provider reports no device metrics are skipped.
+func (c *Collector) recordGPUUtilization(ctx context.Context, gp provider.GPUProvider, instances []provider.GPUInstance) {
+	for _, inst := range instances {
+		m, err := gp.GetGPUMetrics(ctx, inst.ID)
+		if err != nil || len(m.GPUs) == 0 {
+			continue
+		}
+		var total float64
+		for _, gpu := range m.GPUs {
+			total += gpu.GPUUtilization
+		}
+		c.gpuHistory.Record(metrics.HistoryKey(inst.Provider, inst.ID), total/float64(len(m.GPUs)))
+	}
+}
+
+// CollectStorage collects buckets with usage from all storage providers.
+// When types is non-empty only buckets of those resource types (e.g. "r2")
+// are returned.
+func (c *Collector) CollectStorage(ctx context.Context, types []string) ([]provider.Bucket, map[string]error) {
+	var allBuckets []provider.Bucket
+	errors := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	filter := &provider.ResourceFilter{Types: types}
+	for name, p := range c.providers {
+		storageProvider, ok := provider.As[provider.StorageProvider](p)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, sp provider.StorageProvider) {
+			defer wg.Done()
+
+			buckets, err := sp.ListBuckets(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errors[name] = err
+				return
+			}
+			for _, b := range buckets {
+				if filter.MatchesType(b.Resource) {
+					allBuckets = append(allBuckets, b)
+				}
+			}
+		}(name, storageProvider)
+	}
+
+	wg.Wait()
+	return allBuckets, errors
+}
+
+// HasAIProviders reports whether any configured provider reports AI
+// workload metrics
+func (c *Collector) HasAIProviders() bool {
+	for _, p := range c.providers {
+		if _, ok := provider.As[provider.AIProvider](p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// CollectAI collects per-model AI workload metrics from all AI providers
+func (c *Collector) CollectAI(ctx context.Context) ([]metrics.AIMetrics, map[string]error) {
+	var allMetrics []metrics.AIMetrics
+	errors := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, p := range c.providers {
+		aiProvider, ok := provider.As[provider.AIProvider](p)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, ap provider.AIProvider) {
+			defer wg.Done()
+
+			usage, err := ap.ListAIMetrics(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errors[name] = err
+			} else {
+				allMetrics = append(allMetrics, usage...)
+			}
+		}(name, aiProvider)
+	}
+
+	wg.Wait()
+	return allMetrics, errors
+}
+
+// GetResource fetches one resource from a provider by ID. Providers that
+// implement provider.ResourceGetter are asked directly; for the rest every
+// resource is listed and the one with a matching ID returned.
+func (c *Collector) GetResource(ctx context.Context, providerName, id string) (*provider.Resource, error) {
+	p, ok := c.providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("provider %s not found", providerName)
+	}
+
+	if getter, ok := provider.As[provider.ResourceGetter](p); ok {
+		return getter.GetResource(ctx, id)
+	}
+
+	resources, err := p.ListResources(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+	for i := range resources {
+		if resources[i].ID == id {
+			if resources[i].Provider == "" {
+				resources[i].Provider = providerName
+			}
+			return &resources[i], nil
+		}
+	}
+	return nil, cterrors.NewNotFoundError(providerName, id)
+}
+
+// collectFromProvider collects data from a single provider. On failure it
+// also returns the last successful result as stale, when there is one.
 func (c *Collector) collectFromProvider(ctx context.Context, providerName string, req *CollectRequest) (*output.ProviderResult, error) {
+	cacheKey := c.buildCacheKey(providerName, req)
+	result, err := c.fetchFromProvider(ctx, providerName, req, cacheKey)
+	if err != nil {
+		return c.staleResult(cacheKey), err
+	}
+	return result, nil
+}
+
+// staleResult returns a copy of the last successful result for cacheKey
+// marked Stale, or nil when there is none
+func (c *Collector) staleResult(cacheKey string) *output.ProviderResult {
+	c.lastGoodMu.Lock()
+	defer c.lastGoodMu.Unlock()
+
+	last, ok := c.lastGood[cacheKey]
+	if !ok {
+		return nil
+	}
+	stale := *last
+	stale.Cached = true
+	stale.Stale = true
+	return &stale
+}
+
+// cachingEnabled reports whether results are kept between collections
+func (c *Collector) cachingEnabled() bool {
+	if c.cache == nil {
+		return false
+	}
+	_, noop := c.cache.(*NoopCache)
+	return !noop
+}
+
+func (c *Collector) fetchFromProvider(ctx context.Context, providerName string, req *CollectRequest, cacheKey string) (*output.ProviderResult, error) {
 	start := time.Now()
 
-	// Check cache first
-	cacheKey := c.buildCacheKey(providerName, req)
+	// Check cache first. The cached entry is shared, so mark a copy.
 	if c.cache != nil {
 		if cached, ok := c.cache.Get(cacheKey); ok {
-			result := cached.(*output.ProviderResult)
+			result := *cached.(*output.ProviderResult)
 			result.Cached = true
-			return result, nil
+			return &result, nil
 		}
 	}
 
@@ -173,16 +570,67 @@ func (c *Collector) collectFromProvider(ctx context.Context, providerName string
 		return nil, fmt.Errorf("provider %s not found", providerName)
 	}
 
+	// Skip providers that keep failing until their cooldown expires
+	breaker := c.breakers.Get(providerName)
+	if err := breaker.Allow(); err != nil {
+		return nil, err
+	}
+
 	// Check provider health
 	if err := p.HealthCheck(ctx); err != nil {
+		breaker.Failure(err)
 		return nil, fmt.Errorf("health check failed: %w", err)
 	}
 
+	// When explaining, leave status, time and tag filtering to the collector
+	// so every drop is counted
+	providerFilter := req.Filters
+	if req.Explain && req.Filters != nil {
+		scoped := *req.Filters
+		scoped.Status = nil
+		scoped.CreatedAfter = nil
+		scoped.Tags = nil
+		providerFilter = &scoped
+	}
+	// The cap is only a safe paging hint when nothing listed will be
+	// filtered out afterwards
+	hinted := req.MaxResults > 0 && !req.Filters.Selective()
+	if hinted {
+		scoped := provider.ResourceFilter{}
+		if providerFilter != nil {
+			scoped = *providerFilter
+		}
+		scoped.MaxResults = req.MaxResults
+		providerFilter = &scoped
+	}
+
 	// List resources
-	resources, err := p.ListResources(ctx, req.Filters)
+	resources, err := p.ListResources(ctx, providerFilter)
 	if err != nil {
+		breaker.Failure(err)
 		return nil, fmt.Errorf("failed to list resources: %w", err)
 	}
+	breaker.Success()
+
+	// A provider holding the hinted number of resources may have stopped
+	// listing there, so more could exist than were fetched
+	partial := hinted && len(resources) >= req.MaxResults
+
+	merged := 0
+	if !req.KeepDuplicates {
+		resources, merged = MergeDuplicates(resources)
+	}
+
+	// Apply filters uniformly, since not every provider honors them
+	resources, stats := applyFilters(resources, req.Filters)
+	stats.Fetched += merged
+	stats.Merged = merged
+
+	total := 0
+	if req.MaxResults > 0 && len(resources) > req.MaxResults {
+		total = len(resources)
+		resources = resources[:req.MaxResults]
+	}
 
 	// Collect metrics for resources
 	metricsData := make(map[string]interface{})
@@ -193,12 +641,14 @@ func (c *Collector) collectFromProvider(ctx context.Context, providerName string
 			resourceIDs[i] = r.ID
 		}
 
+		window, granularity, _ := MetricWindowFor(p, req.MetricWindow, req.MetricGranularity)
+		end := time.Now()
 		metricsReq := &provider.MetricsRequest{
 			ResourceIDs: resourceIDs,
 			MetricNames: req.MetricTypes,
-			StartTime:   time.Now().Add(-5 * time.Minute),
-			EndTime:     time.Now(),
-			Granularity: 1 * time.Minute,
+			StartTime:   end.Add(-window),
+			EndTime:     end,
+			Granularity: granularity,
 		}
 
 		metricsResp, err := p.GetMetrics(ctx, metricsReq)
@@ -208,21 +658,59 @@ func (c *Collector) collectFromProvider(ctx context.Context, providerName string
 	}
 
 	result := &output.ProviderResult{
-		Provider:  providerName,
-		Resources: resources,
-		Metrics:   metricsData,
-		Cached:    false,
-		Duration:  time.Since(start),
+		Provider:    providerName,
+		Resources:   resources,
+		Metrics:     metricsData,
+		Cached:      false,
+		Duration:    time.Since(start),
+		Total:       total,
+		Partial:     partial,
+		LastSuccess: time.Now(),
+	}
+	if req.Explain {
+		result.Filter = stats
 	}
 
 	// Cache the result
 	if c.cache != nil {
 		c.cache.Set(cacheKey, result)
 	}
+	if c.cachingEnabled() {
+		c.lastGoodMu.Lock()
+		c.lastGood[cacheKey] = result
+		c.lastGoodMu.Unlock()
+	}
 
 	return result, nil
 }
 
+// applyFilters runs the type, status, time, tag and query filter stages in order,
+// counting how many resources each stage drops
+func applyFilters(resources []provider.Resource, filter *provider.ResourceFilter) ([]provider.Resource, *output.FilterStats) {
+	stats := &output.FilterStats{Fetched: len(resources)}
+
+	kept := resources[:0]
+	for _, r := range resources {
+		switch {
+		case !filter.MatchesType(r):
+			stats.DroppedByType++
+		case !filter.MatchesStatus(r):
+			stats.DroppedByStatus++
+		case !filter.MatchesCreatedAfter(r):
+			stats.DroppedByTime++
+		case !filter.MatchesTags(r):
+			stats.DroppedByTag++
+		case !filter.MatchesQuery(r):
+			stats.DroppedByQuery++
+		default:
+			kept = append(kept, r)
+		}
+	}
+
+	stats.Kept = len(kept)
+	return kept, stats
+}
+
 // getProvidersToQuery determines which providers to query
 func (c *Collector) getProvidersToQuery(requested []string) []string {
 	if len(requested) == 0 {
@@ -238,7 +726,13 @@ func (c *Collector) getProvidersToQuery(requested []string) []string {
 
 // buildCacheKey creates a cache key from request parameters
 func (c *Collector) buildCacheKey(provider string, req *CollectRequest) string {
-	return fmt.Sprintf("%s:%v:%v", provider, req.Services, req.MetricTypes)
+	var tags map[string]string
+	var query string
+	if req.Filters != nil {
+		tags = req.Filters.Tags
+		query = req.Filters.Query
+	}
+	return fmt.Sprintf("%s:%v:%v:%s:%s:%t:%v:%d:%q:%t", provider, req.Services, req.MetricTypes, req.MetricWindow, req.MetricGranularity, req.Explain, tags, req.MaxResults, query, req.KeepDuplicates)
 }
 
 // GetProvider returns a specific provider by name
@@ -247,6 +741,33 @@ func (c *Collector) GetProvider(name string) (provider.Provider, bool) {
 	return p, ok
 }
 
+// SetProviders replaces the providers queried by later collections and
+// returns the previous set for the caller to close. Cached results and
+// circuit breaker state belong to the old providers and are discarded.
+// It must not be called while a collection is running.
+func (c *Collector) SetProviders(providers map[string]provider.Provider) map[string]provider.Provider {
+	previous := c.providers
+	c.providers = providers
+	c.cache.Clear()
+	c.breakers.Reset()
+	c.lastGoodMu.Lock()
+	c.lastGood = make(map[string]*output.ProviderResult)
+	c.lastGoodMu.Unlock()
+	return previous
+}
+
+// SetInitReport records how the providers' initialization went, including
+// providers that failed and are not in the collector. Collect reports it in
+// CollectResult.Init.
+func (c *Collector) SetInitReport(report output.InitReport) {
+	c.init = report
+}
+
+// InitReport returns the report set by SetInitReport
+func (c *Collector) InitReport() output.InitReport {
+	return c.init
+}
+
 // GetProviders returns all providers
 func (c *Collector) GetProviders() map[string]provider.Provider {
 	return c.providers
@@ -0,0 +1,130 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// pagingProvider lists count resources, stopping early once it holds
+// filter.MaxResults of them like providers that honor the paging hint
+type pagingProvider struct {
+	count int
+
+	// filter is the filter of the last ListResources call
+	filter *provider.ResourceFilter
+}
+
+func (p *pagingProvider) Name() string { return "paging" }
+
+func (p *pagingProvider) Initialize(ctx context.Context, config *provider.ProviderConfig) error {
+	return nil
+}
+
+func (p *pagingProvider) HealthCheck(ctx context.Context) error { return nil }
+
+func (p *pagingProvider) ListServices(ctx context.Context) ([]provider.Service, error) {
+	return nil, nil
+}
+
+func (p *pagingProvider) GetMetrics(ctx context.Context, req *provider.MetricsRequest) (*provider.MetricsResponse, error) {
+	return &provider.MetricsResponse{}, nil
+}
+
+func (p *pagingProvider) ListResources(ctx context.Context, filter *provider.ResourceFilter) ([]provider.Resource, error) {
+	p.filter = filter
+	var resources []provider.Resource
+	for i := 0; i < p.count && !filter.Full(len(resources)); i++ {
+		status := "running"
+		if i%2 == 1 {
+			status = "stopped"
+		}
+		resources = append(resources, provider.Resource{
+			ID:       fmt.Sprintf("vm-%d", i),
+			Name:     fmt.Sprintf("vm-%d", i),
+			Type:     "compute",
+			Provider: "paging",
+			Status:   status,
+		})
+	}
+	return resources, nil
+}
+
+func (p *pagingProvider) Close() error { return nil }
+
+func TestCollectMaxResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		filters   *provider.ResourceFilter
+		wantHint  int
+		wantShown int
+		wantTotal int
+		wantNote  string
+	}{
+		{
+			name:      "provider stops at the cap",
+			count:     100,
+			wantHint:  10,
+			wantShown: 10,
+			wantNote:  "the first 10",
+		},
+		{
+			name:      "fewer than the cap",
+			count:     5,
+			wantHint:  10,
+			wantShown: 5,
+		},
+		{
+			name:      "status filter keeps the full listing",
+			count:     100,
+			filters:   &provider.ResourceFilter{Status: []string{"running"}},
+			wantShown: 10,
+			wantTotal: 50,
+			wantNote:  "10 of 50",
+		},
+		{
+			name:      "query filter keeps the full listing",
+			count:     30,
+			filters:   &provider.ResourceFilter{Query: "vm-2"},
+			wantShown: 10,
+			wantTotal: 11,
+			wantNote:  "10 of 11",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &pagingProvider{count: tt.count}
+			c := NewCollector(map[string]provider.Provider{"paging": p}, nil)
+
+			res, err := c.Collect(context.Background(), &CollectRequest{Filters: tt.filters, MaxResults: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := res.Errors["paging"]; err != nil {
+				t.Fatal(err)
+			}
+			result := res.Results["paging"]
+
+			hint := 0
+			if p.filter != nil {
+				hint = p.filter.MaxResults
+			}
+			if hint != tt.wantHint {
+				t.Errorf("provider was passed MaxResults %d, want %d", hint, tt.wantHint)
+			}
+			if len(result.Resources) != tt.wantShown || result.Total != tt.wantTotal {
+				t.Errorf("got %d resources with Total %d, want %d with Total %d", len(result.Resources), result.Total, tt.wantShown, tt.wantTotal)
+			}
+			if result.Truncated() != (tt.wantNote != "") {
+				t.Fatalf("Truncated() = %t, want %t", result.Truncated(), tt.wantNote != "")
+			}
+			if tt.wantNote != "" && result.ShownCount() != tt.wantNote {
+				t.Errorf("ShownCount() = %q, want %q", result.ShownCount(), tt.wantNote)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package collector
+
+import "github.com/afterdarksys/cloudtop/internal/provider"
+
+// MergeDuplicates merges resources a provider returned more than once, such
+// as an instance listed under both its compute and GPU services. Entries
+// are duplicates when provider, type and ID match. The first entry is kept
+// in place; fields it leaves empty are filled from later entries, tags are
+// unioned with the first value winning, and UpdatedAt takes the latest
+// time. It returns the merged list and the number of entries merged away.
+func MergeDuplicates(resources []provider.Resource) ([]provider.Resource, int) {
+	index := make(map[string]int, len(resources))
+	merged := make([]provider.Resource, 0, len(resources))
+
+	for _, r := range resources {
+		key := r.Provider + "/" + r.Type + "/" + r.ID
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, r)
+			continue
+		}
+		merged[i] = mergeResource(merged[i], r)
+	}
+
+	return merged, len(resources) - len(merged)
+}
+
+// mergeResource fills the gaps in a from its duplicate b
+func mergeResource(a, b provider.Resource) provider.Resource {
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&a.Name, b.Name)
+	fill(&a.Region, b.Region)
+	fill(&a.Status, b.Status)
+
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = b.CreatedAt
+	}
+	if b.UpdatedAt.After(a.UpdatedAt) {
+		a.UpdatedAt = b.UpdatedAt
+	}
+	if a.HourlyRate == 0 {
+		a.HourlyRate = b.HourlyRate
+	}
+
+	if len(b.Tags) > 0 {
+		tags := make(map[string]string, len(a.Tags)+len(b.Tags))
+		for k, v := range b.Tags {
+			tags[k] = v
+		}
+		for k, v := range a.Tags {
+			tags[k] = v
+		}
+		a.Tags = tags
+	}
+
+	return a
+}
@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+func TestMergeDuplicates(t *testing.T) {
+	t1 := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		in         []provider.Resource
+		want       []provider.Resource
+		wantMerged int
+	}{
+		{
+			name: "no duplicates",
+			in: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle"},
+				{ID: "b", Type: "compute", Provider: "oracle"},
+			},
+			want: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle"},
+				{ID: "b", Type: "compute", Provider: "oracle"},
+			},
+		},
+		{
+			name: "same ID with a different type or provider is kept",
+			in: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle"},
+				{ID: "a", Type: "gpu", Provider: "oracle"},
+				{ID: "a", Type: "compute", Provider: "gcp"},
+			},
+			want: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle"},
+				{ID: "a", Type: "gpu", Provider: "oracle"},
+				{ID: "a", Type: "compute", Provider: "gcp"},
+			},
+		},
+		{
+			name: "empty fields are filled from later entries",
+			in: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle", Name: "web-1"},
+				{ID: "a", Type: "compute", Provider: "oracle", Name: "ignored", Region: "us-ashburn-1", CreatedAt: t1, HourlyRate: 0.5},
+				{ID: "a", Type: "compute", Provider: "oracle", Status: "running", HourlyRate: 0.75},
+			},
+			want: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle", Name: "web-1", Region: "us-ashburn-1", Status: "running", CreatedAt: t1, HourlyRate: 0.5},
+			},
+			wantMerged: 2,
+		},
+		{
+			name: "tags are unioned and the first value wins",
+			in: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle", Tags: map[string]string{"env": "prod"}},
+				{ID: "a", Type: "compute", Provider: "oracle", Tags: map[string]string{"env": "dev", "team": "infra"}},
+				{ID: "a", Type: "compute", Provider: "oracle", Tags: map[string]string{"team": "web", "gpu": "a100"}},
+			},
+			want: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle", Tags: map[string]string{"env": "prod", "team": "infra", "gpu": "a100"}},
+			},
+			wantMerged: 2,
+		},
+		{
+			name: "UpdatedAt takes the latest time",
+			in: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle", UpdatedAt: t1},
+				{ID: "a", Type: "compute", Provider: "oracle", UpdatedAt: t2},
+				{ID: "a", Type: "compute", Provider: "oracle"},
+			},
+			want: []provider.Resource{
+				{ID: "a", Type: "compute", Provider: "oracle", UpdatedAt: t2},
+			},
+			wantMerged: 2,
+		},
+		{
+			name: "first entry keeps its position",
+			in: []provider.Resource{
+				{ID: "b", Type: "compute", Provider: "oracle"},
+				{ID: "a", Type: "compute", Provider: "oracle"},
+				{ID: "b", Type: "compute", Provider: "oracle", Name: "db-1"},
+				{ID: "c", Type: "compute", Provider: "oracle"},
+			},
+			want: []provider.Resource{
+				{ID: "b", Type: "compute", Provider: "oracle", Name: "db-1"},
+				{ID: "a", Type: "compute", Provider: "oracle"},
+				{ID: "c", Type: "compute", Provider: "oracle"},
+			},
+			wantMerged: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, merged := MergeDuplicates(tt.in)
+			if merged != tt.wantMerged {
+				t.Errorf("merged = %d, want %d", merged, tt.wantMerged)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeDuplicates =\n  %+v\nwant\n  %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeDuplicatesLeavesInputTags(t *testing.T) {
+	first := map[string]string{"env": "prod"}
+	in := []provider.Resource{
+		{ID: "a", Type: "compute", Provider: "oracle", Tags: first},
+		{ID: "a", Type: "compute", Provider: "oracle", Tags: map[string]string{"team": "infra"}},
+	}
+	MergeDuplicates(in)
+	if len(first) != 1 {
+		t.Errorf("first entry's tag map was modified: %v", first)
+	}
+}
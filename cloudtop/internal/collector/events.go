@@ -0,0 +1,177 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/output"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// Resource event types
+const (
+	EventAppeared      = "appeared"
+	EventDisappeared   = "disappeared"
+	EventStatusChanged = "status_changed"
+)
+
+// ResourceEvent is a change to a resource observed between two collections
+type ResourceEvent struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Event        string    `json:"event"`
+	Provider     string    `json:"provider"`
+	ID           string    `json:"id"`
+	Name         string    `json:"name,omitempty"`
+	ResourceType string    `json:"type,omitempty"`
+	OldStatus    string    `json:"old_status,omitempty"`
+	NewStatus    string    `json:"new_status,omitempty"`
+}
+
+// resourceKey identifies a resource within its provider: its ID, or its
+// type and name when the provider reports no ID
+func resourceKey(r provider.Resource) string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return r.Type + "/" + r.Name
+}
+
+// comparableResults reports whether a provider's resources in two results
+// can be diffed. Failed, stale and truncated results do not hold the
+// provider's full current resources, so diffing them would report
+// resources appearing and disappearing that did not.
+func comparableResults(prev, next *output.ProviderResult) bool {
+	for _, r := range []*output.ProviderResult{prev, next} {
+		if r == nil || r.Stale || r.Truncated() {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffResults returns the resources that appeared, disappeared or changed
+// status between two consecutive collections, matched on provider and ID
+// and stamped with next's timestamp. Providers missing from either result
+// or whose results are stale or truncated are skipped. Events are sorted by
+// provider and ID.
+func DiffResults(prev, next *output.CollectResult) []ResourceEvent {
+	if prev == nil || next == nil {
+		return nil
+	}
+
+	var events []ResourceEvent
+	for name, nextResult := range next.Results {
+		prevResult := prev.Results[name]
+		if !comparableResults(prevResult, nextResult) {
+			continue
+		}
+
+		event := func(kind string, r provider.Resource) ResourceEvent {
+			return ResourceEvent{
+				Timestamp:    next.Timestamp,
+				Event:        kind,
+				Provider:     name,
+				ID:           r.ID,
+				Name:         r.Name,
+				ResourceType: r.Type,
+			}
+		}
+
+		before := make(map[string]provider.Resource, len(prevResult.Resources))
+		for _, r := range prevResult.Resources {
+			before[resourceKey(r)] = r
+		}
+		seen := make(map[string]bool, len(nextResult.Resources))
+		for _, r := range nextResult.Resources {
+			key := resourceKey(r)
+			seen[key] = true
+			old, ok := before[key]
+			switch {
+			case !ok:
+				e := event(EventAppeared, r)
+				e.NewStatus = r.Status
+				events = append(events, e)
+			case old.Status != r.Status:
+				e := event(EventStatusChanged, r)
+				e.OldStatus, e.NewStatus = old.Status, r.Status
+				events = append(events, e)
+			}
+		}
+		for _, r := range prevResult.Resources {
+			if !seen[resourceKey(r)] {
+				e := event(EventDisappeared, r)
+				e.OldStatus = r.Status
+				events = append(events, e)
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Provider != events[j].Provider {
+			return events[i].Provider < events[j].Provider
+		}
+		return events[i].ID < events[j].ID
+	})
+	return events
+}
+
+// EventLog appends the resource events between consecutive collections to
+// a JSON lines file, one event per line
+type EventLog struct {
+	mu       sync.Mutex
+	file     *os.File
+	redactor *output.Redactor
+	prev     *output.CollectResult
+}
+
+// OpenEventLog opens the event log at path for appending, creating it if
+// needed. Events are masked by redactor; nil logs them as collected.
+func OpenEventLog(path string, redactor *output.Redactor) (*EventLog, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+	return &EventLog{file: f, redactor: redactor}, nil
+}
+
+// Record appends the events since the previously recorded collection and
+// returns how many it wrote. The first collection recorded is the baseline
+// and writes nothing.
+func (l *EventLog) Record(result *output.CollectResult) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	events := DiffResults(l.prev, result)
+	l.prev = result
+
+	enc := json.NewEncoder(l.file)
+	for i, e := range events {
+		if err := enc.Encode(l.redact(e)); err != nil {
+			return i, fmt.Errorf("failed to write event log: %w", err)
+		}
+	}
+	return len(events), nil
+}
+
+// redact masks an event's fields the way the resource's would be
+func (l *EventLog) redact(e ResourceEvent) ResourceEvent {
+	if l.redactor == nil {
+		return e
+	}
+	masked := func(status string) string {
+		return l.redactor.Resource(provider.Resource{Status: status}).Status
+	}
+	r := l.redactor.Resource(provider.Resource{ID: e.ID, Name: e.Name, Type: e.ResourceType, Provider: e.Provider})
+	e.ID, e.Name, e.ResourceType, e.Provider = r.ID, r.Name, r.Type, r.Provider
+	e.OldStatus, e.NewStatus = masked(e.OldStatus), masked(e.NewStatus)
+	return e
+}
+
+// Close closes the log file
+func (l *EventLog) Close() error {
+	return l.file.Close()
+}
@@ -0,0 +1,153 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/output"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// maxPricePoints caps the samples retained per offering key
+const maxPricePoints = 100
+
+// PricePoint is a single observed GPU offering price
+type PricePoint struct {
+	Time  time.Time `json:"time"`
+	Price float64   `json:"price"`
+}
+
+// PriceHistory persists GPU offering prices keyed on provider, GPU type and
+// region so successive runs can detect price drops
+type PriceHistory struct {
+	mu     sync.Mutex
+	path   string
+	Prices map[string][]PricePoint `json:"prices"`
+}
+
+// LoadPriceHistory reads the history file at path, starting empty if it
+// does not exist yet
+func LoadPriceHistory(path string) (*PriceHistory, error) {
+	h := &PriceHistory{path: path, Prices: make(map[string][]PricePoint)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return nil, fmt.Errorf("failed to read price history: %w", err)
+	}
+
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("failed to parse price history: %w", err)
+	}
+	if h.Prices == nil {
+		h.Prices = make(map[string][]PricePoint)
+	}
+	return h, nil
+}
+
+// Compare checks offerings against the last recorded prices. An alert is
+// raised when the cheapest price for a key falls below threshold (on the
+// crossing only) or drops by at least dropPct percent. Zero disables a check.
+func (h *PriceHistory) Compare(offerings []provider.GPUOffering, threshold, dropPct float64) []output.PriceAlert {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var alerts []output.PriceAlert
+	for key, offer := range cheapestOfferings(offerings) {
+		current := offer.PricePerHour
+		var previous float64
+		if points := h.Prices[key]; len(points) > 0 {
+			previous = points[len(points)-1].Price
+		}
+
+		alert := output.PriceAlert{
+			Provider:      offer.Provider,
+			GPUType:       offer.GPUType,
+			Region:        offer.Region,
+			PreviousPrice: previous,
+			CurrentPrice:  current,
+		}
+
+		if threshold > 0 && current < threshold && (previous == 0 || previous >= threshold) {
+			alert.Reason = fmt.Sprintf("below $%.2f/hr", threshold)
+			alerts = append(alerts, alert)
+			continue
+		}
+
+		if dropPct > 0 && previous > 0 && current < previous {
+			drop := (previous - current) / previous * 100
+			if drop >= dropPct {
+				alert.DropPct = drop
+				alert.Reason = fmt.Sprintf("dropped %.1f%%", drop)
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].CurrentPrice < alerts[j].CurrentPrice
+	})
+	return alerts
+}
+
+// Record appends the cheapest current price for each offering key
+func (h *PriceHistory) Record(offerings []provider.GPUOffering, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for key, offer := range cheapestOfferings(offerings) {
+		points := append(h.Prices[key], PricePoint{Time: at, Price: offer.PricePerHour})
+		if len(points) > maxPricePoints {
+			points = points[len(points)-maxPricePoints:]
+		}
+		h.Prices[key] = points
+	}
+}
+
+// Save writes the history back to its file
+func (h *PriceHistory) Save() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("failed to marshal price history: %w", err)
+	}
+
+	if err := os.WriteFile(h.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write price history: %w", err)
+	}
+	return nil
+}
+
+// priceKey identifies an offering across runs
+func priceKey(offer provider.GPUOffering) string {
+	return strings.Join([]string{offer.Provider, offer.GPUType, offer.Region}, "|")
+}
+
+// cheapestOfferings reduces offerings to the lowest-priced one per key
+func cheapestOfferings(offerings []provider.GPUOffering) map[string]provider.GPUOffering {
+	cheapest := make(map[string]provider.GPUOffering)
+	for _, offer := range offerings {
+		if offer.PricePerHour <= 0 {
+			continue
+		}
+		key := priceKey(offer)
+		if existing, ok := cheapest[key]; !ok || offer.PricePerHour < existing.PricePerHour {
+			cheapest[key] = offer
+		}
+	}
+	return cheapest
+}
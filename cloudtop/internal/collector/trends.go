@@ -0,0 +1,173 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/output"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// DefaultTrendCycles is the number of refresh cycles retained for trends
+const DefaultTrendCycles = 10
+
+// CycleSnapshot summarizes one collection cycle
+type CycleSnapshot struct {
+	Timestamp  time.Time
+	Resources  int
+	ByProvider map[string]int
+	// HourlyCost sums resource hourly rates, or GPU instance prices for
+	// GPU collections
+	HourlyCost float64
+}
+
+// Trend holds the retained snapshots, oldest first
+type Trend struct {
+	Snapshots []CycleSnapshot
+}
+
+// trendRing is a fixed-size ring buffer of cycle snapshots
+type trendRing struct {
+	mu    sync.Mutex
+	buf   []CycleSnapshot
+	next  int
+	count int
+}
+
+func newTrendRing(size int) *trendRing {
+	return &trendRing{buf: make([]CycleSnapshot, size)}
+}
+
+func (r *trendRing) add(s CycleSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf[r.next] = s
+	r.next = (r.next + 1) % len(r.buf)
+	if r.count < len(r.buf) {
+		r.count++
+	}
+}
+
+func (r *trendRing) snapshots() []CycleSnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]CycleSnapshot, 0, r.count)
+	start := (r.next - r.count + len(r.buf)) % len(r.buf)
+	for i := 0; i < r.count; i++ {
+		out = append(out, r.buf[(start+i)%len(r.buf)])
+	}
+	return out
+}
+
+// EnableTrends retains the last cycles collection snapshots for Trends. A
+// non-positive value disables trend tracking.
+func (c *Collector) EnableTrends(cycles int) {
+	if cycles <= 0 {
+		c.trends = nil
+		return
+	}
+	c.trends = newTrendRing(cycles)
+}
+
+// Trends returns the retained cycle snapshots, or nil when trend tracking
+// is disabled
+func (c *Collector) Trends() *Trend {
+	if c.trends == nil {
+		return nil
+	}
+	return &Trend{Snapshots: c.trends.snapshots()}
+}
+
+// recordResults adds a snapshot of a resource collection
+func (c *Collector) recordResults(result *output.CollectResult) {
+	if c.trends == nil {
+		return
+	}
+
+	snapshot := CycleSnapshot{Timestamp: result.Timestamp, ByProvider: make(map[string]int)}
+	for name, pr := range result.Results {
+		snapshot.ByProvider[name] = len(pr.Resources)
+		snapshot.Resources += len(pr.Resources)
+		for _, r := range pr.Resources {
+			snapshot.HourlyCost += r.HourlyRate
+		}
+	}
+	c.trends.add(snapshot)
+}
+
+// recordGPU adds a snapshot of a GPU instance collection
+func (c *Collector) recordGPU(instances []provider.GPUInstance) {
+	if c.trends == nil {
+		return
+	}
+
+	snapshot := CycleSnapshot{Timestamp: time.Now(), ByProvider: make(map[string]int)}
+	for _, inst := range instances {
+		snapshot.ByProvider[inst.Provider]++
+		snapshot.Resources++
+		snapshot.HourlyCost += inst.PricePerHour
+	}
+	c.trends.add(snapshot)
+}
+
+// Len returns the number of retained snapshots
+func (t *Trend) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.Snapshots)
+}
+
+// ResourceDelta returns the change in resource count since the previous cycle
+func (t *Trend) ResourceDelta() int {
+	if t.Len() < 2 {
+		return 0
+	}
+	n := len(t.Snapshots)
+	return t.Snapshots[n-1].Resources - t.Snapshots[n-2].Resources
+}
+
+// CostDelta returns the change in hourly cost since the previous cycle
+func (t *Trend) CostDelta() float64 {
+	if t.Len() < 2 {
+		return 0
+	}
+	n := len(t.Snapshots)
+	return t.Snapshots[n-1].HourlyCost - t.Snapshots[n-2].HourlyCost
+}
+
+// Summary describes the change since the previous cycle, for example
+// "+2 resources since last cycle, cost up $1.20/hr". It is empty until two
+// cycles have been recorded.
+func (t *Trend) Summary() string {
+	if t.Len() < 2 {
+		return ""
+	}
+
+	var parts []string
+	switch delta := t.ResourceDelta(); {
+	case delta == 0:
+		parts = append(parts, "no change in resources since last cycle")
+	default:
+		parts = append(parts, fmt.Sprintf("%+d resources since last cycle", delta))
+	}
+
+	// Skip cost when no provider reports pricing
+	n := len(t.Snapshots)
+	if t.Snapshots[n-1].HourlyCost != 0 || t.Snapshots[n-2].HourlyCost != 0 {
+		switch delta := t.CostDelta(); {
+		case delta > 0.005:
+			parts = append(parts, fmt.Sprintf("cost up $%.2f/hr", delta))
+		case delta < -0.005:
+			parts = append(parts, fmt.Sprintf("cost down $%.2f/hr", -delta))
+		default:
+			parts = append(parts, "cost unchanged")
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
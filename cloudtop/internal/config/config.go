@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/afterdarksys/cloudtop/pkg/httpclient"
 )
 
 // Config represents the root cloudtop configuration
@@ -15,6 +17,10 @@ type Config struct {
 	Defaults  Defaults            `json:"defaults"`
 	Output    OutputConfig        `json:"output"`
 	Cache     CacheConfig         `json:"cache"`
+
+	// Aliases maps a shorthand to the flags it stands for, so
+	// `cloudtop my-gpus` runs e.g. ["--ai", "vast", "--gpu"]
+	Aliases map[string][]string `json:"aliases,omitempty"`
 }
 
 // Provider represents a single provider configuration
@@ -25,12 +31,41 @@ type Provider struct {
 	Services  []string               `json:"services,omitempty"`
 	RateLimit *RateLimitConfig       `json:"rate_limit,omitempty"`
 	Timeout   Duration               `json:"timeout,omitempty"`
+	HTTP      *HTTPConfig            `json:"http,omitempty"`
 	Options   map[string]interface{} `json:"options,omitempty"`
 }
 
+// HTTPConfig customizes the provider's HTTP client for proxies and private CAs
+type HTTPConfig struct {
+	ProxyURL           string   `json:"proxy_url,omitempty"`
+	CABundle           string   `json:"ca_bundle,omitempty"`
+	InsecureSkipVerify bool     `json:"insecure_skip_verify,omitempty"`
+	Timeout            Duration `json:"timeout,omitempty"`
+}
+
+// ToClientConfig converts HTTPConfig to an httpclient config
+func (h *HTTPConfig) ToClientConfig() *httpclient.Config {
+	if h == nil {
+		return nil
+	}
+
+	caBundle := h.CABundle
+	if caBundle != "" && caBundle[0] == '~' {
+		home, _ := os.UserHomeDir()
+		caBundle = filepath.Join(home, caBundle[1:])
+	}
+
+	return &httpclient.Config{
+		ProxyURL:           h.ProxyURL,
+		CABundle:           caBundle,
+		InsecureSkipVerify: h.InsecureSkipVerify,
+		Timeout:            h.Timeout.Duration(),
+	}
+}
+
 // AuthConfig handles multiple authentication methods
 type AuthConfig struct {
-	Method       string `json:"method"` // "api_key", "oauth", "service_account", "env"
+	Method       string `json:"method"` // "api_key", "oauth", "service_account", "env", "secret_ref"
 	APIKey       string `json:"api_key,omitempty"`
 	APISecret    string `json:"api_secret,omitempty"`
 	ClientID     string `json:"client_id,omitempty"`
@@ -39,48 +74,139 @@ type AuthConfig struct {
 	KeyFile      string `json:"key_file,omitempty"`
 	EnvAPIKey    string `json:"env_api_key,omitempty"`
 	EnvSecret    string `json:"env_secret,omitempty"`
+
+	// SecretRef and APISecretRef point at a secrets manager entry, e.g.
+	// vault://secret/data/cloudtop#api_token (used with method "secret_ref")
+	SecretRef    string `json:"secret_ref,omitempty"`
+	APISecretRef string `json:"api_secret_ref,omitempty"`
 }
 
-// ToCredentials converts AuthConfig to a credentials map
+// ToCredentials converts AuthConfig to a credentials map. Secret references
+// that fail to resolve are left out; use ResolveCredentials to see why.
 func (a *AuthConfig) ToCredentials() map[string]string {
+	creds, _ := a.ResolveCredentials()
+	return creds
+}
+
+// ResolveCredentials converts AuthConfig to a credentials map, returning the
+// first secret resolution error encountered
+func (a *AuthConfig) ResolveCredentials() (map[string]string, error) {
 	creds := make(map[string]string)
+	var resolveErr error
+	for _, c := range a.resolve() {
+		if c.err != nil {
+			if resolveErr == nil {
+				resolveErr = c.err
+			}
+			continue
+		}
+		creds[c.Key] = c.value
+	}
+	return creds, resolveErr
+}
+
+// CredentialSource describes where one credential resolved from, without
+// its value
+type CredentialSource struct {
+	Key    string `json:"key"`    // credentials key, e.g. "api_token"
+	Source string `json:"source"` // "env", "file", "literal" or "secret_ref"
+	Ref    string `json:"ref"`    // env var name, file path, config field or secret reference
+	Set    bool   `json:"set"`    // resolved to a non-empty value (for files, a non-empty file)
+	Error  string `json:"error,omitempty"`
+}
+
+// CredentialSources reports, in resolution order, where each credential of
+// ResolveCredentials comes from and whether it is set. Key files are checked
+// for existence; secret references are resolved.
+func (a *AuthConfig) CredentialSources() []CredentialSource {
+	resolved := a.resolve()
+	sources := make([]CredentialSource, 0, len(resolved))
+	for _, c := range resolved {
+		src := c.CredentialSource
+		switch {
+		case c.err != nil:
+			src.Error = c.err.Error()
+		case src.Source == "file" && c.value != "":
+			info, err := os.Stat(c.value)
+			if err != nil {
+				src.Error = err.Error()
+			} else {
+				src.Set = info.Size() > 0
+			}
+		default:
+			src.Set = c.value != ""
+		}
+		sources = append(sources, src)
+	}
+	return sources
+}
+
+// resolvedCredential is a credential value with its source
+type resolvedCredential struct {
+	CredentialSource
+	value string
+	err   error
+}
+
+// resolve looks up each credential the auth method uses. An env var named
+// in the config takes precedence over a literal value.
+func (a *AuthConfig) resolve() []resolvedCredential {
+	var out []resolvedCredential
+	add := func(key, source, ref, value string, err error) {
+		out = append(out, resolvedCredential{
+			CredentialSource: CredentialSource{Key: key, Source: source, Ref: ref},
+			value:            value,
+			err:              err,
+		})
+	}
+	env := func(key, name string) {
+		add(key, "env", name, os.Getenv(name), nil)
+	}
 
 	switch a.Method {
 	case "api_key":
 		if a.EnvAPIKey != "" {
-			creds["api_token"] = os.Getenv(a.EnvAPIKey)
+			env("api_token", a.EnvAPIKey)
 		} else if a.APIKey != "" {
-			creds["api_token"] = a.APIKey
+			add("api_token", "literal", "api_key", a.APIKey, nil)
 		}
 		if a.EnvSecret != "" {
-			creds["api_secret"] = os.Getenv(a.EnvSecret)
+			env("api_secret", a.EnvSecret)
 		} else if a.APISecret != "" {
-			creds["api_secret"] = a.APISecret
+			add("api_secret", "literal", "api_secret", a.APISecret, nil)
 		}
 	case "service_account":
 		if a.KeyFile != "" {
+			path := a.KeyFile
 			// Expand ~ to home directory
-			if a.KeyFile[0] == '~' {
+			if path[0] == '~' {
 				home, _ := os.UserHomeDir()
-				creds["key_file"] = filepath.Join(home, a.KeyFile[1:])
-			} else {
-				creds["key_file"] = a.KeyFile
+				path = filepath.Join(home, path[1:])
 			}
+			add("key_file", "file", path, path, nil)
 		}
 	case "oauth":
-		creds["client_id"] = a.ClientID
-		creds["client_secret"] = a.ClientSecret
-		creds["token_url"] = a.TokenURL
+		add("client_id", "literal", "client_id", a.ClientID, nil)
+		add("client_secret", "literal", "client_secret", a.ClientSecret, nil)
+		add("token_url", "literal", "token_url", a.TokenURL, nil)
 	case "env":
 		if a.EnvAPIKey != "" {
-			creds["api_token"] = os.Getenv(a.EnvAPIKey)
+			env("api_token", a.EnvAPIKey)
 		}
 		if a.EnvSecret != "" {
-			creds["api_secret"] = os.Getenv(a.EnvSecret)
+			env("api_secret", a.EnvSecret)
+		}
+	case "secret_ref":
+		if a.SecretRef != "" {
+			value, err := ResolveSecret(a.SecretRef)
+			add("api_token", "secret_ref", a.SecretRef, value, err)
+		}
+		if a.APISecretRef != "" {
+			value, err := ResolveSecret(a.APISecretRef)
+			add("api_secret", "secret_ref", a.APISecretRef, value, err)
 		}
 	}
-
-	return creds
+	return out
 }
 
 // RateLimitConfig defines rate limiting parameters
@@ -95,6 +221,38 @@ type Defaults struct {
 	RefreshInterval Duration `json:"refresh_interval"`
 	OutputFormat    string   `json:"output_format"` // "table", "wide", "json"
 	ShowCached      bool     `json:"show_cached"`
+
+	// MaxResourcesPerProvider caps how many resources each provider
+	// contributes to a listing; 0 means no limit
+	MaxResourcesPerProvider int `json:"max_resources_per_provider,omitempty"`
+
+	// MetricWindow is how far back metrics requests look, and
+	// MetricGranularity the step between data points; unset means
+	// DefaultMetricWindow and DefaultMetricGranularity
+	MetricWindow      Duration `json:"metric_window,omitempty"`
+	MetricGranularity Duration `json:"metric_granularity,omitempty"`
+}
+
+// Default metrics request window and granularity
+const (
+	DefaultMetricWindow      = 5 * time.Minute
+	DefaultMetricGranularity = time.Minute
+)
+
+// MetricSettings returns the metrics window and granularity, falling back
+// to the defaults for unset values. The granularity must divide the window.
+func (d Defaults) MetricSettings() (time.Duration, time.Duration, error) {
+	window, granularity := d.MetricWindow.Duration(), d.MetricGranularity.Duration()
+	if window == 0 {
+		window = DefaultMetricWindow
+	}
+	if granularity == 0 {
+		granularity = DefaultMetricGranularity
+	}
+	if granularity > window || window%granularity != 0 {
+		return 0, 0, fmt.Errorf("metric granularity %s must divide metric window %s", granularity, window)
+	}
+	return window, granularity, nil
 }
 
 // OutputConfig controls output formatting
@@ -102,6 +260,18 @@ type OutputConfig struct {
 	ColorEnabled bool                `json:"color_enabled"`
 	Timestamps   bool                `json:"timestamps"`
 	Columns      map[string][]string `json:"columns,omitempty"`
+
+	// ProviderOrder lists providers to render first, in this order; any
+	// others follow alphabetically
+	ProviderOrder []string `json:"provider_order,omitempty"`
+
+	// TagColumns lists resource tag keys shown as columns in wide output;
+	// when empty, wide output shows all tags in one column
+	TagColumns []string `json:"tag_columns,omitempty"`
+
+	// RedactFields lists resource fields (e.g. "name") or tag keys (e.g.
+	// "endpoint") whose values are replaced with **** in all output
+	RedactFields []string `json:"redact_fields,omitempty"`
 }
 
 // CacheConfig for cache settings
@@ -114,7 +284,9 @@ type CacheConfig struct {
 	CacheDir string   `json:"cache_dir,omitempty"`
 }
 
-// Duration is a wrapper for time.Duration that supports JSON
+// Duration is a wrapper for time.Duration that supports JSON. It is
+// written as a duration string such as "30s" or "1m"; a bare number is read
+// as seconds, so "timeout": 30 means 30s rather than 30ns.
 type Duration time.Duration
 
 func (d Duration) Duration() time.Duration {
@@ -128,11 +300,17 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 	}
 	switch value := v.(type) {
 	case float64:
-		*d = Duration(time.Duration(value))
+		if value < 0 {
+			return fmt.Errorf("invalid duration %v: must not be negative", value)
+		}
+		*d = Duration(time.Duration(value * float64(time.Second)))
 	case string:
 		dur, err := time.ParseDuration(value)
 		if err != nil {
-			return err
+			return fmt.Errorf("invalid duration %q (use a value such as \"30s\" or \"1m\")", value)
+		}
+		if dur < 0 {
+			return fmt.Errorf("invalid duration %q: must not be negative", value)
 		}
 		*d = Duration(dur)
 	default:
@@ -181,6 +359,31 @@ func (c *Config) Save(path string) error {
 	return nil
 }
 
+// Redacted returns a copy of the config with secrets masked, safe for
+// printing or logging
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.Providers = make(map[string]Provider, len(c.Providers))
+	for name, p := range c.Providers {
+		p.Auth.APIKey = MaskSecret(p.Auth.APIKey)
+		p.Auth.APISecret = MaskSecret(p.Auth.APISecret)
+		p.Auth.ClientSecret = MaskSecret(p.Auth.ClientSecret)
+		redacted.Providers[name] = p
+	}
+	return &redacted
+}
+
+// MaskSecret hides all but the last four characters of a secret
+func MaskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 4 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
 // GetEnabledProviders returns a list of enabled provider names
 func (c *Config) GetEnabledProviders() []string {
 	var providers []string
@@ -195,11 +398,13 @@ func (c *Config) GetEnabledProviders() []string {
 // DefaultConfig returns a config with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
-		Version: "1.0",
+		Version: CurrentVersion,
 		Defaults: Defaults{
-			RefreshInterval: Duration(30 * time.Second),
-			OutputFormat:    "table",
-			ShowCached:      true,
+			RefreshInterval:   Duration(30 * time.Second),
+			OutputFormat:      "table",
+			ShowCached:        true,
+			MetricWindow:      Duration(DefaultMetricWindow),
+			MetricGranularity: Duration(DefaultMetricGranularity),
 		},
 		Output: OutputConfig{
 			ColorEnabled: true,
@@ -218,11 +423,13 @@ func DefaultConfig() *Config {
 // GenerateSampleConfig creates a sample configuration file
 func GenerateSampleConfig() *Config {
 	return &Config{
-		Version: "1.0",
+		Version: CurrentVersion,
 		Defaults: Defaults{
-			RefreshInterval: Duration(30 * time.Second),
-			OutputFormat:    "table",
-			ShowCached:      true,
+			RefreshInterval:   Duration(30 * time.Second),
+			OutputFormat:      "table",
+			ShowCached:        true,
+			MetricWindow:      Duration(DefaultMetricWindow),
+			MetricGranularity: Duration(DefaultMetricGranularity),
 		},
 		Output: OutputConfig{
 			ColorEnabled: true,
@@ -252,7 +459,7 @@ func GenerateSampleConfig() *Config {
 					Timeout:           Duration(30 * time.Second),
 				},
 				Options: map[string]interface{}{
-					"account_id": "your-account-id",
+					"account_ids": []string{"your-account-id"},
 				},
 			},
 			"oracle": {
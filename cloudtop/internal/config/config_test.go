@@ -0,0 +1,66 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr string
+	}{
+		{in: `"30s"`, want: 30 * time.Second},
+		{in: `30`, want: 30 * time.Second},
+		{in: `1.5`, want: 1500 * time.Millisecond},
+		{in: `"1m"`, want: time.Minute},
+		{in: `"1h30m"`, want: 90 * time.Minute},
+		{in: `0`, want: 0},
+		{in: `-5`, wantErr: "must not be negative"},
+		{in: `"-30s"`, wantErr: "must not be negative"},
+		{in: `"30 seconds"`, wantErr: `invalid duration "30 seconds"`},
+		{in: `"30"`, wantErr: `invalid duration "30"`},
+		{in: `true`, wantErr: "invalid duration type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var d Duration
+			err := json.Unmarshal([]byte(tt.in), &d)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Unmarshal(%s) error = %v, want one containing %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s): %v", tt.in, err)
+			}
+			if d.Duration() != tt.want {
+				t.Errorf("Unmarshal(%s) = %s, want %s", tt.in, d.Duration(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationRoundTrip(t *testing.T) {
+	in := Defaults{RefreshInterval: Duration(90 * time.Second)}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"refresh_interval":"1m30s"`) {
+		t.Errorf("Marshal = %s, want refresh_interval written as a duration string", data)
+	}
+
+	var out Defaults
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.RefreshInterval != in.RefreshInterval {
+		t.Errorf("round trip = %s, want %s", out.RefreshInterval.Duration(), in.RefreshInterval.Duration())
+	}
+}
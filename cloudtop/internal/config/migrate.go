@@ -0,0 +1,145 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// CurrentVersion is the config version written by this build
+const CurrentVersion = "1.1"
+
+// Migration upgrades a raw config document from one version to the next.
+// Migrations operate on the decoded JSON so they can handle fields that no
+// longer exist on Config.
+type Migration struct {
+	From        string
+	To          string
+	Description string
+	Apply       func(raw map[string]interface{}) error
+}
+
+// migrations is the ordered upgrade path; append new steps to the end
+var migrations = []Migration{
+	{
+		From:        "1.0",
+		To:          "1.1",
+		Description: "rename provider option account_id to account_ids and fill in cache defaults",
+		Apply:       migrate1_0To1_1,
+	},
+}
+
+// RegisterMigration adds a migration step to the end of the upgrade path
+func RegisterMigration(m Migration) {
+	migrations = append(migrations, m)
+}
+
+// Migrate applies every migration needed to bring raw up to CurrentVersion
+// and returns the steps that ran. Documents without a version are treated
+// as "1.0".
+func Migrate(raw map[string]interface{}) ([]Migration, error) {
+	version, _ := raw["version"].(string)
+	if version == "" {
+		version = "1.0"
+	}
+
+	var applied []Migration
+	for _, m := range migrations {
+		if m.From != version {
+			continue
+		}
+		if err := m.Apply(raw); err != nil {
+			return applied, fmt.Errorf("migration %s -> %s failed: %w", m.From, m.To, err)
+		}
+		version = m.To
+		raw["version"] = version
+		applied = append(applied, m)
+	}
+
+	if version != CurrentVersion {
+		return applied, fmt.Errorf("no migration path from version %s to %s", version, CurrentVersion)
+	}
+	return applied, nil
+}
+
+// MigrateFile migrates the config at path in place. The original file is
+// copied to path + ".bak" before being overwritten. When dryRun is set the
+// migrated document is returned without writing anything.
+func MigrateFile(path string, dryRun bool) ([]Migration, []byte, error) {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(original, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	applied, err := Migrate(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrated, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if dryRun || len(applied) == 0 {
+		return applied, migrated, nil
+	}
+
+	if err := os.WriteFile(path+".bak", original, 0600); err != nil {
+		return nil, nil, fmt.Errorf("failed to write config backup: %w", err)
+	}
+	if err := os.WriteFile(path, migrated, 0600); err != nil {
+		return nil, nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return applied, migrated, nil
+}
+
+// migrate1_0To1_1 turns the single account_id option into an account_ids
+// list and adds cache settings introduced in 1.1
+func migrate1_0To1_1(raw map[string]interface{}) error {
+	if providers, ok := raw["providers"].(map[string]interface{}); ok {
+		for _, p := range providers {
+			provider, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			options, ok := provider["options"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if accountID, ok := options["account_id"]; ok {
+				if _, exists := options["account_ids"]; !exists {
+					options["account_ids"] = []interface{}{accountID}
+				}
+				delete(options, "account_id")
+			}
+		}
+	}
+
+	cache, ok := raw["cache"].(map[string]interface{})
+	if !ok {
+		cache = make(map[string]interface{})
+		raw["cache"] = cache
+	}
+	defaults := DefaultConfig().Cache
+	if _, ok := cache["enabled"]; !ok {
+		cache["enabled"] = defaults.Enabled
+	}
+	if _, ok := cache["backend"]; !ok {
+		cache["backend"] = defaults.Backend
+	}
+	if _, ok := cache["ttl"]; !ok {
+		cache["ttl"] = defaults.TTL.Duration().String()
+	}
+	if _, ok := cache["max_size"]; !ok {
+		cache["max_size"] = defaults.MaxSize
+	}
+
+	return nil
+}
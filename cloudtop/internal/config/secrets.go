@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecretResolver fetches a secret value for a parsed secret reference URI
+type SecretResolver interface {
+	Resolve(ref *url.URL) (string, error)
+}
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = map[string]SecretResolver{
+		"vault":   &VaultResolver{},
+		"envfile": &EnvFileResolver{},
+	}
+)
+
+// RegisterSecretResolver makes a resolver available for a URI scheme
+func RegisterSecretResolver(scheme string, r SecretResolver) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	resolvers[scheme] = r
+}
+
+// ResolveSecret resolves a secret reference such as vault://secret/data/app#token
+// or envfile:///etc/cloudtop.env#API_TOKEN using the resolver for its scheme
+func ResolveSecret(ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret reference %q: %w", ref, err)
+	}
+
+	resolversMu.RLock()
+	r, ok := resolvers[u.Scheme]
+	resolversMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("no secret resolver for scheme %q", u.Scheme)
+	}
+
+	return r.Resolve(u)
+}
+
+// refPath joins the host and path of a reference, so both vault://a/b and
+// envfile:///abs/path forms work
+func refPath(ref *url.URL) string {
+	return strings.TrimPrefix(ref.Host+ref.Path, "/")
+}
+
+// VaultResolver reads secrets from HashiCorp Vault using VAULT_ADDR and
+// VAULT_TOKEN. References take the form vault://<path>#<key>; both KV v1 and
+// KV v2 (path containing /data/) responses are supported.
+type VaultResolver struct {
+	Client *http.Client
+}
+
+func (v *VaultResolver) Resolve(ref *url.URL) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	token := os.Getenv("VAULT_TOKEN")
+	if addr == "" || token == "" {
+		return "", fmt.Errorf("VAULT_ADDR and VAULT_TOKEN must be set to resolve %s", ref)
+	}
+	if ref.Fragment == "" {
+		return "", fmt.Errorf("vault reference %s is missing a #key", ref)
+	}
+
+	client := v.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(addr, "/")+"/v1/"+refPath(ref), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %d for %s", resp.StatusCode, refPath(ref))
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	data := body.Data
+	// KV v2 nests the secret under data.data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	value, ok := data[ref.Fragment].(string)
+	if !ok {
+		return "", fmt.Errorf("key %q not found in vault secret %s", ref.Fragment, refPath(ref))
+	}
+	return value, nil
+}
+
+// EnvFileResolver reads KEY=VALUE pairs from a dotenv-style file.
+// References take the form envfile:///abs/path#KEY or envfile://~/path#KEY.
+type EnvFileResolver struct{}
+
+func (e *EnvFileResolver) Resolve(ref *url.URL) (string, error) {
+	if ref.Fragment == "" {
+		return "", fmt.Errorf("envfile reference %s is missing a #KEY", ref)
+	}
+
+	path := ref.Host + ref.Path
+	if strings.HasPrefix(path, "~") {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, path[1:])
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open env file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, found := strings.Cut(line, "=")
+		if !found || strings.TrimSpace(key) != ref.Fragment {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		return value, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	return "", fmt.Errorf("key %q not found in %s", ref.Fragment, path)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the burst of events a single save produces
+const watchDebounce = 250 * time.Millisecond
+
+// Watcher reports changes to a config file. It watches the file's
+// directory rather than the file, so saves that replace the file by
+// renaming a new one over it are still seen.
+type Watcher struct {
+	fsw     *fsnotify.Watcher
+	path    string
+	changes chan struct{}
+}
+
+// NewWatcher starts watching the config file at path
+func NewWatcher(path string) (*Watcher, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config path: %w", err)
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	if err := fsw.Add(filepath.Dir(abs)); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", path, err)
+	}
+
+	w := &Watcher{
+		fsw:     fsw,
+		path:    abs,
+		changes: make(chan struct{}, 1),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Changes receives a value after the file is written, created or replaced.
+// Changes arriving before the previous one is received are merged.
+func (w *Watcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	return w.fsw.Close()
+}
+
+func (w *Watcher) run() {
+	var fire <-chan time.Time
+	for {
+		select {
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != w.path || event.Op == fsnotify.Chmod {
+				continue
+			}
+			fire = time.After(watchDebounce)
+		case <-fire:
+			fire = nil
+			select {
+			case w.changes <- struct{}{}:
+			default:
+			}
+		case _, ok := <-w.fsw.Errors:
+			// Errors such as event queue overflow are not fatal; later
+			// events are still delivered
+			if !ok {
+				return
+			}
+		}
+	}
+}
@@ -15,6 +15,7 @@ const (
 	ErrorTypePermission
 	ErrorTypeValidation
 	ErrorTypeInternal
+	ErrorTypeTimeout
 )
 
 func (e ErrorType) String() string {
@@ -33,11 +34,29 @@ func (e ErrorType) String() string {
 		return "validation"
 	case ErrorTypeInternal:
 		return "internal"
+	case ErrorTypeTimeout:
+		return "timeout"
 	default:
 		return "unknown"
 	}
 }
 
+// MarshalText writes the type as its String form, e.g. "rate_limit"
+func (e ErrorType) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText reads a type written by MarshalText
+func (e *ErrorType) UnmarshalText(text []byte) error {
+	for t := ErrorTypeAuth; t <= ErrorTypeTimeout; t++ {
+		if t.String() == string(text) {
+			*e = t
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown error type %q", text)
+}
+
 // CloudtopError is a custom error type with metadata
 type CloudtopError struct {
 	Type      ErrorType
@@ -141,6 +160,18 @@ func NewInternalError(provider string, err error) *CloudtopError {
 	}
 }
 
+// NewTimeoutError reports a provider that did not respond within the
+// collection deadline
+func NewTimeoutError(provider string, err error) *CloudtopError {
+	return &CloudtopError{
+		Type:      ErrorTypeTimeout,
+		Provider:  provider,
+		Message:   "timed out",
+		Err:       err,
+		Retryable: true,
+	}
+}
+
 // ErrorHandler manages error handling strategies
 type ErrorHandler struct {
 	degradeGracefully bool
@@ -169,7 +200,7 @@ func (h *ErrorHandler) Handle(err error) bool {
 	case ErrorTypeAuth, ErrorTypePermission:
 		// Fatal errors - always fail
 		return false
-	case ErrorTypeNetwork, ErrorTypeRateLimit:
+	case ErrorTypeNetwork, ErrorTypeRateLimit, ErrorTypeTimeout:
 		// Retryable errors - can continue in degraded mode
 		return h.degradeGracefully
 	case ErrorTypeNotFound:
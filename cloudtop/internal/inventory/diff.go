@@ -0,0 +1,132 @@
+package inventory
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Change is a resource present in both snapshots whose attributes differ
+type Change struct {
+	Old Item `json:"old"`
+	New Item `json:"new"`
+
+	// Fields names the attributes that differ, e.g. "status" or "tags.env"
+	Fields []string `json:"fields"`
+}
+
+// Diff is the difference between two inventories
+type Diff struct {
+	Added   []Item
+	Removed []Item
+	Changed []Change
+
+	// Unchanged counts resources identical in both snapshots
+	Unchanged int
+
+	// Incomplete lists providers that failed in either snapshot; their
+	// additions and removals may not be real
+	Incomplete []string
+}
+
+// Empty reports whether the snapshots hold the same resources
+func (d *Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Compare reports the resources added, removed and changed between two
+// inventories. Resources are matched by provider, type and ID. Results are
+// sorted by that key.
+func Compare(before, after *Inventory) *Diff {
+	previous := make(map[string]Item, len(before.Items))
+	for _, item := range before.Items {
+		previous[item.Key()] = item
+	}
+
+	d := &Diff{}
+	seen := make(map[string]bool, len(after.Items))
+	for _, item := range after.Items {
+		key := item.Key()
+		seen[key] = true
+		prev, ok := previous[key]
+		if !ok {
+			d.Added = append(d.Added, item)
+			continue
+		}
+		if fields := changedFields(prev, item); len(fields) > 0 {
+			d.Changed = append(d.Changed, Change{Old: prev, New: item, Fields: fields})
+		} else {
+			d.Unchanged++
+		}
+	}
+	for _, item := range before.Items {
+		if !seen[item.Key()] {
+			d.Removed = append(d.Removed, item)
+		}
+	}
+
+	sortItems(d.Added)
+	sortItems(d.Removed)
+	sort.Slice(d.Changed, func(i, j int) bool {
+		return d.Changed[i].New.Key() < d.Changed[j].New.Key()
+	})
+
+	failed := make(map[string]bool)
+	for name := range before.Errors {
+		failed[name] = true
+	}
+	for name := range after.Errors {
+		failed[name] = true
+	}
+	for name := range failed {
+		d.Incomplete = append(d.Incomplete, name)
+	}
+	sort.Strings(d.Incomplete)
+
+	return d
+}
+
+// changedFields lists the attributes that differ between two versions of
+// a resource
+func changedFields(a, b Item) []string {
+	var fields []string
+	if a.Name != b.Name {
+		fields = append(fields, "name")
+	}
+	if a.Region != b.Region {
+		fields = append(fields, "region")
+	}
+	if a.Status != b.Status {
+		fields = append(fields, "status")
+	}
+	if !sameTime(a, b) {
+		fields = append(fields, "created_at")
+	}
+
+	var tagFields []string
+	for k, v := range a.Tags {
+		if bv, ok := b.Tags[k]; !ok || bv != v {
+			tagFields = append(tagFields, fmt.Sprintf("tags.%s", k))
+		}
+	}
+	for k := range b.Tags {
+		if _, ok := a.Tags[k]; !ok {
+			tagFields = append(tagFields, fmt.Sprintf("tags.%s", k))
+		}
+	}
+	sort.Strings(tagFields)
+
+	return append(fields, tagFields...)
+}
+
+func sameTime(a, b Item) bool {
+	if a.CreatedAt == nil || b.CreatedAt == nil {
+		return a.CreatedAt == nil && b.CreatedAt == nil
+	}
+	return a.CreatedAt.Equal(*b.CreatedAt)
+}
+
+func sortItems(items []Item) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Key() < items[j].Key()
+	})
+}
@@ -0,0 +1,122 @@
+// Package inventory captures point-in-time snapshots of cloud resources and
+// compares them, for asset inventory and drift detection.
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// FormatVersion is the version of the inventory file format written by
+// Save. Load rejects files with a newer version.
+const FormatVersion = 1
+
+// Inventory is a snapshot of resources across providers
+type Inventory struct {
+	FormatVersion int               `json:"format_version"`
+	ToolVersion   string            `json:"tool_version"`
+	CapturedAt    time.Time         `json:"captured_at"`
+	Providers     []string          `json:"providers"`
+	Errors        map[string]string `json:"errors,omitempty"`
+	Items         []Item            `json:"resources"`
+}
+
+// Item is the normalized form of one resource
+type Item struct {
+	Provider  string            `json:"provider"`
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Region    string            `json:"region,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	CreatedAt *time.Time        `json:"created_at,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+// Key identifies an item across snapshots
+func (i Item) Key() string {
+	return i.Provider + "/" + i.Type + "/" + i.ID
+}
+
+// New builds an inventory from collected resources. providers lists every
+// provider queried, including those that failed; errors holds their
+// failures. Items are sorted by provider, type and ID.
+func New(resources []provider.Resource, providers []string, errors map[string]error, toolVersion string, capturedAt time.Time) *Inventory {
+	inv := &Inventory{
+		FormatVersion: FormatVersion,
+		ToolVersion:   toolVersion,
+		CapturedAt:    capturedAt.UTC(),
+		Providers:     append([]string(nil), providers...),
+		Items:         make([]Item, 0, len(resources)),
+	}
+	sort.Strings(inv.Providers)
+
+	if len(errors) > 0 {
+		inv.Errors = make(map[string]string, len(errors))
+		for name, err := range errors {
+			inv.Errors[name] = err.Error()
+		}
+	}
+
+	for _, r := range resources {
+		item := Item{
+			Provider: r.Provider,
+			ID:       r.ID,
+			Name:     r.Name,
+			Type:     r.Type,
+			Region:   r.Region,
+			Status:   r.Status,
+		}
+		if !r.CreatedAt.IsZero() {
+			created := r.CreatedAt.UTC()
+			item.CreatedAt = &created
+		}
+		if len(r.Tags) > 0 {
+			item.Tags = r.Tags
+		}
+		inv.Items = append(inv.Items, item)
+	}
+	sort.Slice(inv.Items, func(a, b int) bool {
+		return inv.Items[a].Key() < inv.Items[b].Key()
+	})
+
+	return inv
+}
+
+// Save writes the inventory to path as indented JSON
+func (inv *Inventory) Save(path string) error {
+	data, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal inventory: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write inventory file: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads an inventory written by Save
+func Load(path string) (*Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory file: %w", err)
+	}
+
+	var inv Inventory
+	if err := json.Unmarshal(data, &inv); err != nil {
+		return nil, fmt.Errorf("failed to parse inventory file %s: %w", path, err)
+	}
+	if inv.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("inventory file %s has format version %d; this cloudtop supports up to %d",
+			path, inv.FormatVersion, FormatVersion)
+	}
+
+	return &inv, nil
+}
@@ -0,0 +1,82 @@
+// Package logging writes cloudtop's diagnostic messages to stderr at the
+// verbosity chosen with --quiet and --verbose. Results always go to stdout
+// and are unaffected.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Level is how much diagnostic output is written
+type Level int
+
+const (
+	// LevelQuiet writes errors only
+	LevelQuiet Level = iota
+	// LevelNormal writes errors and warnings
+	LevelNormal
+	// LevelVerbose also writes debug detail such as provider timings and
+	// HTTP requests
+	LevelVerbose
+)
+
+var (
+	mu    sync.Mutex
+	level           = LevelNormal
+	out   io.Writer = os.Stderr
+)
+
+// SetLevel sets the verbosity
+func SetLevel(l Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+}
+
+// SetOutput redirects messages, which go to stderr by default
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	out = w
+}
+
+// Quiet reports whether only errors are written
+func Quiet() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return level == LevelQuiet
+}
+
+// Verbose reports whether debug detail is written
+func Verbose() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return level >= LevelVerbose
+}
+
+// Errorf writes an "Error: " message at every level
+func Errorf(format string, args ...interface{}) {
+	write(LevelQuiet, "Error: ", format, args)
+}
+
+// Warnf writes a "Warning: " message unless quiet
+func Warnf(format string, args ...interface{}) {
+	write(LevelNormal, "Warning: ", format, args)
+}
+
+// Debugf writes a "debug: " message when verbose
+func Debugf(format string, args ...interface{}) {
+	write(LevelVerbose, "debug: ", format, args)
+}
+
+func write(min Level, prefix, format string, args []interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+	if level < min {
+		return
+	}
+	fmt.Fprintf(out, prefix+format+"\n", args...)
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import "sync"
+
+// DefaultHistorySamples is how many samples History keeps per resource
+const DefaultHistorySamples = 20
+
+// History keeps the most recent utilization samples per resource across
+// refresh cycles, for trend displays. Resources not recorded for as many
+// cycles as samples are kept are forgotten. It is safe for concurrent use.
+type History struct {
+	mu     sync.Mutex
+	size   int
+	cycle  int
+	series map[string]*historySeries
+}
+
+type historySeries struct {
+	values   []float64
+	lastSeen int
+}
+
+// NewHistory creates a history keeping size samples per resource
+func NewHistory(size int) *History {
+	if size <= 0 {
+		size = DefaultHistorySamples
+	}
+	return &History{size: size, series: make(map[string]*historySeries)}
+}
+
+// HistoryKey identifies a resource in a History
+func HistoryKey(provider, resourceID string) string {
+	return provider + "/" + resourceID
+}
+
+// Record appends a sample for the resource, dropping the oldest once the
+// history is full
+func (h *History) Record(key string, value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &historySeries{}
+		h.series[key] = s
+	}
+	s.values = append(s.values, value)
+	if len(s.values) > h.size {
+		s.values = s.values[len(s.values)-h.size:]
+	}
+	s.lastSeen = h.cycle
+}
+
+// EndCycle marks the end of a collection cycle and forgets resources that
+// have not been recorded recently
+func (h *History) EndCycle() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.cycle++
+	for key, s := range h.series {
+		if h.cycle-s.lastSeen > h.size {
+			delete(h.series, key)
+		}
+	}
+}
+
+// Samples returns a copy of the resource's samples, oldest first. A nil
+// History has no samples.
+func (h *History) Samples(key string) []float64 {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		return nil
+	}
+	return append([]float64(nil), s.values...)
+}
@@ -85,6 +85,10 @@ type StorageMetrics struct {
 	ListRequests    int64     `json:"list_requests"`
 	BytesDownloaded int64     `json:"bytes_downloaded"`
 	BytesUploaded   int64     `json:"bytes_uploaded"`
+
+	// SizeChangeBytes is the change in TotalSizeBytes over the period the
+	// request counts cover; negative when the bucket shrank
+	SizeChangeBytes int64 `json:"size_change_bytes"`
 }
 
 // DatabaseMetrics represents database metrics
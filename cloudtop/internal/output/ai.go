@@ -0,0 +1,121 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/afterdarksys/cloudtop/internal/metrics"
+)
+
+// AIFormatter renders AI inference usage per model
+type AIFormatter struct {
+	writer io.Writer
+	format string
+}
+
+// NewAIFormatter creates an AI usage formatter for the given output
+// format ("table", "wide", "json" or "jsonl")
+func NewAIFormatter(format string, w io.Writer) *AIFormatter {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &AIFormatter{writer: w, format: format}
+}
+
+// FormatAIMetrics prints per-model usage, busiest first
+func (f *AIFormatter) FormatAIMetrics(usage []metrics.AIMetrics) error {
+	sort.SliceStable(usage, func(i, j int) bool {
+		return usage[i].InferenceCount > usage[j].InferenceCount
+	})
+
+	switch f.format {
+	case "json":
+		encoder := json.NewEncoder(f.writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]interface{}{
+			"models": usage,
+			"total":  len(usage),
+		})
+	case "jsonl":
+		encoder := json.NewEncoder(f.writer)
+		for _, m := range usage {
+			if err := encoder.Encode(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(usage) == 0 {
+		fmt.Fprintln(f.writer, "No AI inference usage found")
+		return nil
+	}
+
+	headers := []string{"PROVIDER", "MODEL", "INFERENCES", "TOKENS", "AVG LATENCY"}
+	widths := []int{10, 44, 12, 12, 12}
+	if f.format == "wide" {
+		headers = append(headers, "P99 LATENCY", "AS OF")
+		widths = append(widths, 12, 16)
+	}
+
+	f.printRow(headers, widths)
+	f.printSeparator(widths)
+
+	var totalInferences, totalTokens int64
+	for _, m := range usage {
+		totalInferences += m.InferenceCount
+		totalTokens += m.TokensProcessed
+
+		row := []string{
+			m.Provider,
+			truncate(m.ResourceID, widths[1]),
+			fmt.Sprintf("%d", m.InferenceCount),
+			fmt.Sprintf("%d", m.TokensProcessed),
+			formatLatency(m.AvgLatencyMs),
+		}
+		if f.format == "wide" {
+			asOf := "-"
+			if !m.Timestamp.IsZero() {
+				asOf = m.Timestamp.Local().Format("2006-01-02 15:04")
+			}
+			row = append(row, formatLatency(m.P99LatencyMs), asOf)
+		}
+		f.printRow(row, widths)
+	}
+
+	fmt.Fprintf(f.writer, "\n%d models, %d inferences, %d tokens\n", len(usage), totalInferences, totalTokens)
+	return nil
+}
+
+// formatLatency renders a latency in milliseconds, or "-" when unknown
+func formatLatency(ms float64) string {
+	if ms <= 0 {
+		return "-"
+	}
+	if ms >= 1000 {
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return fmt.Sprintf("%.0fms", ms)
+}
+
+func (f *AIFormatter) printRow(columns []string, widths []int) {
+	for i, col := range columns {
+		format := fmt.Sprintf("%%-%ds  ", widths[i])
+		fmt.Fprintf(f.writer, format, col)
+	}
+	fmt.Fprintln(f.writer)
+}
+
+func (f *AIFormatter) printSeparator(widths []int) {
+	for i, w := range widths {
+		fmt.Fprint(f.writer, strings.Repeat("-", w))
+		if i < len(widths)-1 {
+			fmt.Fprint(f.writer, "  ")
+		}
+	}
+	fmt.Fprintln(f.writer)
+}
@@ -0,0 +1,214 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/afterdarksys/cloudtop/internal/metrics"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// Sort keys for compute metrics
+const (
+	SortByCPU = "cpu"
+	SortByMem = "mem"
+)
+
+const (
+	ansiRed   = "\033[31m"
+	ansiReset = "\033[0m"
+)
+
+// ComputeOptions controls how compute metrics are ordered and highlighted
+type ComputeOptions struct {
+	// SortBy orders instances by CPU ("cpu") or memory ("mem") usage,
+	// highest first; empty keeps provider and name order
+	SortBy string
+
+	// Threshold highlights instances whose CPU or memory usage is at or
+	// above this percentage; 0 disables highlighting
+	Threshold float64
+
+	// Color enables ANSI highlighting in table output
+	Color bool
+
+	// History supplies recent CPU samples per instance, shown as a
+	// sparkline in wide output when set
+	History *metrics.History
+}
+
+// ComputeFormatter renders compute instances with their usage metrics
+type ComputeFormatter struct {
+	writer io.Writer
+	format string
+	opts   ComputeOptions
+}
+
+// NewComputeFormatter creates an instance metrics formatter for the given
+// output format ("table", "wide", "json" or "jsonl")
+func NewComputeFormatter(format string, w io.Writer, opts ComputeOptions) *ComputeFormatter {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &ComputeFormatter{writer: w, format: format, opts: opts}
+}
+
+// SortInstances orders instances by the given usage key, highest first.
+// Instances without metrics sort last. Any other key orders by provider
+// and name.
+func SortInstances(instances []provider.InstanceMetrics, sortBy string) {
+	usage := func(im provider.InstanceMetrics) float64 {
+		if im.Metrics == nil {
+			return -1
+		}
+		if sortBy == SortByMem {
+			return im.Metrics.MemoryUsagePercent
+		}
+		return im.Metrics.CPUUsagePercent
+	}
+
+	sort.SliceStable(instances, func(i, j int) bool {
+		a, b := instances[i], instances[j]
+		if sortBy == SortByCPU || sortBy == SortByMem {
+			if ua, ub := usage(a), usage(b); ua != ub {
+				return ua > ub
+			}
+		}
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		return a.Name < b.Name
+	})
+}
+
+// OverThreshold reports whether an instance's CPU or memory usage is at or
+// above threshold. It is always false when threshold is 0 or the instance
+// has no metrics.
+func OverThreshold(im provider.InstanceMetrics, threshold float64) bool {
+	if threshold <= 0 || im.Metrics == nil {
+		return false
+	}
+	return im.Metrics.CPUUsagePercent >= threshold || im.Metrics.MemoryUsagePercent >= threshold
+}
+
+// FormatInstances prints instances with their CPU and memory usage
+func (f *ComputeFormatter) FormatInstances(instances []provider.InstanceMetrics) error {
+	SortInstances(instances, f.opts.SortBy)
+
+	switch f.format {
+	case "json":
+		encoder := json.NewEncoder(f.writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]interface{}{
+			"instances": instances,
+			"total":     len(instances),
+		})
+	case "jsonl":
+		encoder := json.NewEncoder(f.writer)
+		for _, im := range instances {
+			if err := encoder.Encode(im); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(instances) == 0 {
+		fmt.Fprintln(f.writer, "No compute instances found")
+		return nil
+	}
+
+	headers := []string{"PROVIDER", "NAME", "STATE", "CPU%", "MEM%", "CORES", "MEMORY"}
+	widths := []int{10, 30, 10, 6, 6, 5, 10}
+	if f.format == "wide" {
+		if f.opts.History != nil {
+			headers = append(headers, "CPU TREND")
+			widths = append(widths, metrics.DefaultHistorySamples)
+		}
+		headers = append(headers, "TYPE", "REGION", "ID")
+		widths = append(widths, 16, 14, 36)
+	}
+
+	f.printRow(headers, widths, false)
+	f.printSeparator(widths)
+
+	hot := 0
+	for _, im := range instances {
+		cpu, mem, cores, memory := "-", "-", "-", "-"
+		if im.CPUCores > 0 {
+			cores = fmt.Sprintf("%d", im.CPUCores)
+		}
+		if im.MemoryGB > 0 {
+			memory = fmt.Sprintf("%.1f GB", im.MemoryGB)
+		}
+		if m := im.Metrics; m != nil {
+			cpu = fmt.Sprintf("%.1f", m.CPUUsagePercent)
+			mem = fmt.Sprintf("%.1f", m.MemoryUsagePercent)
+			if m.CPUCores > 0 {
+				cores = fmt.Sprintf("%d", m.CPUCores)
+			}
+			if m.MemoryTotalBytes > 0 {
+				memory = FormatBytes(m.MemoryTotalBytes)
+			}
+		}
+		state := im.State
+		if state == "" {
+			state = im.Status
+		}
+
+		row := []string{
+			im.Provider,
+			truncate(im.Name, widths[1]),
+			truncate(state, widths[2]),
+			cpu,
+			mem,
+			cores,
+			memory,
+		}
+		if f.format == "wide" {
+			if f.opts.History != nil {
+				row = append(row, Sparkline(f.opts.History.Samples(metrics.HistoryKey(im.Provider, im.ID))))
+			}
+			row = append(row, truncate(im.InstanceType, 16), truncate(im.Region, 14), im.ID)
+		}
+
+		over := OverThreshold(im, f.opts.Threshold)
+		if over {
+			hot++
+		}
+		f.printRow(row, widths, over && f.opts.Color)
+	}
+
+	fmt.Fprintf(f.writer, "\n%d instances", len(instances))
+	if f.opts.Threshold > 0 {
+		fmt.Fprintf(f.writer, ", %d at or above %.0f%%", hot, f.opts.Threshold)
+	}
+	fmt.Fprintln(f.writer)
+	return nil
+}
+
+func (f *ComputeFormatter) printRow(columns []string, widths []int, highlight bool) {
+	var b strings.Builder
+	for i, col := range columns {
+		fmt.Fprintf(&b, fmt.Sprintf("%%-%ds  ", widths[i]), col)
+	}
+	line := strings.TrimRight(b.String(), " ")
+	if highlight {
+		line = ansiRed + line + ansiReset
+	}
+	fmt.Fprintln(f.writer, line)
+}
+
+func (f *ComputeFormatter) printSeparator(widths []int) {
+	for i, w := range widths {
+		fmt.Fprint(f.writer, strings.Repeat("-", w))
+		if i < len(widths)-1 {
+			fmt.Fprint(f.writer, "  ")
+		}
+	}
+	fmt.Fprintln(f.writer)
+}
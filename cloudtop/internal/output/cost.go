@@ -0,0 +1,188 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// Projection periods in hours
+const (
+	HoursPerDay   = 24
+	HoursPerMonth = 30 * 24
+)
+
+// stoppedStatuses are resource statuses that do not accrue compute charges
+var stoppedStatuses = map[string]bool{
+	"stopped":     true,
+	"stopping":    true,
+	"exited":      true,
+	"terminated":  true,
+	"terminating": true,
+	"deallocated": true,
+	"suspended":   true,
+	"deleted":     true,
+}
+
+// IsStopped reports whether a resource status means it is not running
+func IsStopped(status string) bool {
+	return stoppedStatuses[strings.ToLower(status)]
+}
+
+// CostLine is the projected cost of one provider's resources of one type
+type CostLine struct {
+	Provider   string  `json:"provider"`
+	Type       string  `json:"type"`
+	Count      int     `json:"count"`
+	HourlyCost float64 `json:"hourly_cost"`
+}
+
+// CostReport projects spend from the hourly prices providers report
+type CostReport struct {
+	Lines      []CostLine `json:"lines"`
+	HourlyCost float64    `json:"hourly_cost"`
+
+	// Stopped counts priced resources left out because they are stopped
+	Stopped int `json:"stopped_excluded"`
+
+	// IncludesStopped is set when stopped resources were counted
+	IncludesStopped bool `json:"includes_stopped"`
+}
+
+// Projected returns the report's total cost over the given number of hours
+func (r *CostReport) Projected(hours float64) float64 {
+	return r.HourlyCost * hours
+}
+
+// ProjectCost groups priced resources by provider and type. GPU instances
+// are priced by PricePerHour and other resources by HourlyRate; a resource
+// listed both ways is counted once, as a GPU instance. Resources without a
+// price are skipped, as are stopped resources unless includeStopped is set.
+// Lines are sorted by provider and type.
+func ProjectCost(resources []provider.Resource, gpus []provider.GPUInstance, includeStopped bool) *CostReport {
+	report := &CostReport{IncludesStopped: includeStopped}
+	lines := make(map[string]*CostLine)
+	seen := make(map[string]bool)
+
+	add := func(r provider.Resource, price float64) {
+		key := r.Provider + "/" + r.ID
+		if price <= 0 || seen[key] {
+			return
+		}
+		seen[key] = true
+		if !includeStopped && IsStopped(r.Status) {
+			report.Stopped++
+			return
+		}
+
+		lineKey := r.Provider + "/" + r.Type
+		line, ok := lines[lineKey]
+		if !ok {
+			line = &CostLine{Provider: r.Provider, Type: r.Type}
+			lines[lineKey] = line
+		}
+		line.Count++
+		line.HourlyCost += price
+		report.HourlyCost += price
+	}
+
+	for _, inst := range gpus {
+		price := inst.PricePerHour
+		if price <= 0 {
+			price = inst.HourlyRate
+		}
+		add(inst.Resource, price)
+	}
+	for _, r := range resources {
+		add(r, r.HourlyRate)
+	}
+
+	for _, line := range lines {
+		report.Lines = append(report.Lines, *line)
+	}
+	sort.Slice(report.Lines, func(i, j int) bool {
+		a, b := report.Lines[i], report.Lines[j]
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		return a.Type < b.Type
+	})
+
+	return report
+}
+
+// FormatCostReport prints the report as a table projecting each line over
+// 24 hours, and over 30 days when monthly is set, or as JSON
+func FormatCostReport(w io.Writer, report *CostReport, monthly, asJSON bool) error {
+	if w == nil {
+		w = os.Stdout
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]interface{}{
+			"lines":            report.Lines,
+			"hourly_cost":      report.HourlyCost,
+			"daily_cost":       report.Projected(HoursPerDay),
+			"monthly_cost":     report.Projected(HoursPerMonth),
+			"stopped_excluded": report.Stopped,
+			"includes_stopped": report.IncludesStopped,
+			"approximate":      true,
+		})
+	}
+
+	if len(report.Lines) == 0 {
+		fmt.Fprintln(w, "No priced resources found")
+	} else {
+		headers := []string{"PROVIDER", "TYPE", "COUNT", "$/HR", "24H"}
+		widths := []int{12, 18, 5, 10, 10}
+		if monthly {
+			headers = append(headers, "30D")
+			widths = append(widths, 12)
+		}
+
+		row := func(columns ...string) {
+			for i, col := range columns {
+				fmt.Fprintf(w, "%-*s  ", widths[i], col)
+			}
+			fmt.Fprintln(w)
+		}
+		costs := func(hourly float64) []string {
+			c := []string{fmt.Sprintf("$%.2f", hourly), fmt.Sprintf("$%.2f", hourly*HoursPerDay)}
+			if monthly {
+				c = append(c, fmt.Sprintf("$%.2f", hourly*HoursPerMonth))
+			}
+			return c
+		}
+		separator := func() {
+			parts := make([]string, len(widths))
+			for i, width := range widths {
+				parts[i] = strings.Repeat("-", width)
+			}
+			fmt.Fprintln(w, strings.Join(parts, "  "))
+		}
+
+		row(headers...)
+		separator()
+		total := 0
+		for _, line := range report.Lines {
+			row(append([]string{line.Provider, truncate(line.Type, 18), fmt.Sprintf("%d", line.Count)}, costs(line.HourlyCost)...)...)
+			total += line.Count
+		}
+		separator()
+		row(append([]string{"TOTAL", "", fmt.Sprintf("%d", total)}, costs(report.HourlyCost)...)...)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Estimates are approximate: list prices over continuous uptime, excluding storage, network and discounts.")
+	if report.Stopped > 0 {
+		fmt.Fprintf(w, "%d stopped resources excluded (use --include-stopped to count them)\n", report.Stopped)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// FormatResource prints every field of a single resource, one per line, or
+// the resource as indented JSON when asJSON is set
+func FormatResource(w io.Writer, r *provider.Resource, asJSON bool) error {
+	if w == nil {
+		w = os.Stdout
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	}
+
+	field := func(name, value string) {
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(w, "%-12s %s\n", name+":", value)
+	}
+
+	field("ID", r.ID)
+	field("Name", r.Name)
+	field("Provider", r.Provider)
+	field("Type", r.Type)
+	field("Region", r.Region)
+	field("Status", r.Status)
+	if !r.CreatedAt.IsZero() {
+		field("Created", r.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	}
+	if !r.UpdatedAt.IsZero() {
+		field("Updated", r.UpdatedAt.Format("2006-01-02 15:04:05 MST"))
+	}
+	if r.HourlyRate > 0 {
+		field("Cost", fmt.Sprintf("$%.4f/hr", r.HourlyRate))
+	}
+
+	if len(r.Tags) > 0 {
+		keys := make([]string, 0, len(r.Tags))
+		for k := range r.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Fprintln(w, "Tags:")
+		for _, k := range keys {
+			fmt.Fprintf(w, "  %s=%s\n", k, r.Tags[k])
+		}
+	}
+
+	return nil
+}
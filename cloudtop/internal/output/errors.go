@@ -0,0 +1,107 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	cterrors "github.com/afterdarksys/cloudtop/internal/errors"
+)
+
+const ansiYellow = "\033[33m"
+
+// errorGroups orders error types in the footer: failures that need the
+// user to act come first, transient ones after, unclassified errors last
+var errorGroups = []cterrors.ErrorType{
+	cterrors.ErrorTypeAuth,
+	cterrors.ErrorTypePermission,
+	cterrors.ErrorTypeValidation,
+	cterrors.ErrorTypeNotFound,
+	cterrors.ErrorTypeInternal,
+	cterrors.ErrorTypeRateLimit,
+	cterrors.ErrorTypeTimeout,
+	cterrors.ErrorTypeNetwork,
+}
+
+// errorHints suggest what to do about each type of error
+var errorHints = map[string]string{
+	cterrors.ErrorTypeAuth.String():       "check the provider's credentials; run 'cloudtop providers test-auth' to see where they resolve from",
+	cterrors.ErrorTypePermission.String(): "the credentials were accepted but lack access; grant the token or policy the needed scopes",
+	cterrors.ErrorTypeValidation.String(): "check the provider's config entry and the flags given",
+	cterrors.ErrorTypeNotFound.String():   "check the resource name or ID, and the provider's regions",
+	cterrors.ErrorTypeInternal.String():   "unexpected provider response; rerun with --verbose for details",
+	cterrors.ErrorTypeRateLimit.String():  "transient; retry later or lower rate_limit.requests_per_second",
+	cterrors.ErrorTypeTimeout.String():    "transient; retry, or raise the provider's timeout",
+	cterrors.ErrorTypeNetwork.String():    "transient; check connectivity, DNS and http.proxy_url, then retry",
+}
+
+// errorColor returns the ANSI color for an error type: red for failures
+// the user must fix, yellow for transient ones
+func errorColor(errType string) string {
+	switch errType {
+	case cterrors.ErrorTypeAuth.String(), cterrors.ErrorTypePermission.String():
+		return ansiRed
+	case cterrors.ErrorTypeRateLimit.String(), cterrors.ErrorTypeTimeout.String(), cterrors.ErrorTypeNetwork.String():
+		return ansiYellow
+	}
+	return ""
+}
+
+// printErrors writes provider errors grouped by CloudtopError type, each
+// group followed by a remediation hint. Errors of other types are listed
+// last under "other".
+func printErrors(w io.Writer, errs map[string]error, color bool) {
+	if len(errs) == 0 {
+		return
+	}
+
+	byType := make(map[string][]ErrorInfo)
+	for name, err := range errs {
+		info := NewErrorInfo(name, err)
+		errType := info.Type
+		if errType == "" {
+			errType = "other"
+		}
+		byType[errType] = append(byType[errType], info)
+	}
+
+	order := make([]string, 0, len(errorGroups)+1)
+	for _, t := range errorGroups {
+		order = append(order, t.String())
+	}
+	order = append(order, "other")
+
+	fmt.Fprintf(w, "\nErrors:\n")
+	for _, errType := range order {
+		infos := byType[errType]
+		if len(infos) == 0 {
+			continue
+		}
+		sort.Slice(infos, func(i, j int) bool { return infos[i].Provider < infos[j].Provider })
+
+		start, end := "", ""
+		if c := errorColor(errType); color && c != "" {
+			start, end = c, ansiReset
+		}
+		fmt.Fprintf(w, "  %s%s (%d):%s\n", start, errType, len(infos), end)
+		for _, info := range infos {
+			fmt.Fprintf(w, "    %s%s: %s%s\n", start, info.Provider, info.Message, end)
+		}
+		if hint := errorHints[errType]; hint != "" {
+			fmt.Fprintf(w, "    hint: %s\n", hint)
+		}
+	}
+}
+
+// printInitFailures notes the providers that could not be initialized and
+// so were not queried, as distinct from providers that returned nothing
+func printInitFailures(w io.Writer, init InitReport) {
+	failed := init.Failed()
+	if len(failed) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nNot initialized:\n")
+	for _, name := range failed {
+		fmt.Fprintf(w, "  %s: %s\n", name, init[name].Error)
+	}
+}
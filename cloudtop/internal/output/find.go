@@ -0,0 +1,122 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// FindFormatter renders resources from every provider in one flat list, as
+// used by `cloudtop find`
+type FindFormatter struct {
+	writer io.Writer
+	format string
+}
+
+// NewFindFormatter creates a flat resource formatter for the given output
+// format ("table", "wide", "json" or "jsonl")
+func NewFindFormatter(format string, w io.Writer) *FindFormatter {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &FindFormatter{writer: w, format: format}
+}
+
+// FlattenResources merges the resources of every provider result, sorted by
+// provider and then name. Resources missing a provider take the result's.
+func FlattenResources(result *CollectResult) []provider.Resource {
+	var resources []provider.Resource
+	for _, name := range OrderProviders(result.Results, nil) {
+		for _, r := range result.Results[name].Resources {
+			if r.Provider == "" {
+				r.Provider = name
+			}
+			resources = append(resources, r)
+		}
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Provider != resources[j].Provider {
+			return resources[i].Provider < resources[j].Provider
+		}
+		return strings.ToLower(resources[i].Name) < strings.ToLower(resources[j].Name)
+	})
+	return resources
+}
+
+// Format prints the matching resources across all providers
+func (f *FindFormatter) Format(result *CollectResult) error {
+	resources := FlattenResources(result)
+
+	switch f.format {
+	case "json":
+		encoder := json.NewEncoder(f.writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]interface{}{
+			"resources": resources,
+			"total":     len(resources),
+		})
+	case "jsonl":
+		encoder := json.NewEncoder(f.writer)
+		for _, r := range resources {
+			if err := encoder.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(resources) == 0 {
+		fmt.Fprintln(f.writer, "No matching resources found")
+		return nil
+	}
+
+	headers := []string{"PROVIDER", "NAME", "TYPE", "REGION", "STATUS"}
+	widths := []int{10, 30, 15, 15, 10}
+	if f.format == "wide" {
+		headers = append(headers, "ID")
+		widths = append(widths, 40)
+	}
+
+	f.printRow(headers, widths)
+	f.printSeparator(widths)
+
+	for _, r := range resources {
+		row := []string{
+			r.Provider,
+			truncate(r.Name, widths[1]),
+			r.Type,
+			r.Region,
+			r.Status,
+		}
+		if f.format == "wide" {
+			row = append(row, truncate(r.ID, widths[5]))
+		}
+		f.printRow(row, widths)
+	}
+
+	fmt.Fprintf(f.writer, "\n%d matching resources\n", len(resources))
+	return nil
+}
+
+func (f *FindFormatter) printRow(columns []string, widths []int) {
+	for i, col := range columns {
+		format := fmt.Sprintf("%%-%ds  ", widths[i])
+		fmt.Fprintf(f.writer, format, col)
+	}
+	fmt.Fprintln(f.writer)
+}
+
+func (f *FindFormatter) printSeparator(widths []int) {
+	for i, w := range widths {
+		fmt.Fprint(f.writer, strings.Repeat("-", w))
+		if i < len(widths)-1 {
+			fmt.Fprint(f.writer, "  ")
+		}
+	}
+	fmt.Fprintln(f.writer)
+}
@@ -1,7 +1,9 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +12,9 @@ import (
 	"time"
 
 	"github.com/afterdarksys/cloudtop/internal/config"
+	"github.com/afterdarksys/cloudtop/internal/metrics"
+	cterrors "github.com/afterdarksys/cloudtop/internal/errors"
+	"github.com/afterdarksys/cloudtop/internal/logging"
 	"github.com/afterdarksys/cloudtop/internal/provider"
 )
 
@@ -24,6 +29,32 @@ type CollectResult struct {
 	Errors    map[string]error
 	Timestamp time.Time
 	Duration  time.Duration
+
+	// Init records how each requested provider's initialization went.
+	// Providers that did not initialize were never queried, so they appear
+	// here but not in Results or Errors.
+	Init InitReport
+}
+
+// ProviderInit is the outcome of initializing one provider
+type ProviderInit struct {
+	Initialized bool   `json:"initialized"`
+	Error       string `json:"error,omitempty"`
+}
+
+// InitReport maps provider names to their initialization outcome
+type InitReport map[string]ProviderInit
+
+// Failed returns the providers that did not initialize, sorted by name
+func (r InitReport) Failed() []string {
+	var names []string
+	for name, init := range r {
+		if !init.Initialized {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 // ProviderResult contains results from a single provider
@@ -33,6 +64,70 @@ type ProviderResult struct {
 	Metrics   map[string]interface{}
 	Cached    bool
 	Duration  time.Duration
+	Filter    *FilterStats `json:",omitempty"`
+
+	// LastSuccess is when the provider returned this data, which is before
+	// the current collection for cached and stale results
+	LastSuccess time.Time
+
+	// Stale marks the last successful result served in place of one the
+	// provider failed to return; the failure is reported alongside it
+	Stale bool `json:",omitempty"`
+
+	// Total is the number of matching resources before Resources was cut
+	// to the per-provider cap; it is only set when truncated
+	Total int `json:",omitempty"`
+
+	// Partial marks a listing the provider may have stopped at the cap, so
+	// more resources than Total (or than Resources, when Total is unset)
+	// may exist
+	Partial bool `json:",omitempty"`
+}
+
+// Truncated reports whether Resources was cut to the per-provider cap, or
+// may have been by the provider
+func (r *ProviderResult) Truncated() bool {
+	return r.Total > len(r.Resources) || r.Partial
+}
+
+// ShownCount describes how many resources are shown out of how many, e.g.
+// "50 of 120", "50 of at least 120", or "the first 50" when the total is
+// unknown
+func (r *ProviderResult) ShownCount() string {
+	shown := len(r.Resources)
+	switch {
+	case r.Partial && r.Total > shown:
+		return fmt.Sprintf("%d of at least %d", shown, r.Total)
+	case r.Partial:
+		return fmt.Sprintf("the first %d", shown)
+	default:
+		return fmt.Sprintf("%d of %d", shown, r.Total)
+	}
+}
+
+// FilterStats counts resources dropped at each filter stage
+type FilterStats struct {
+	Fetched int `json:"fetched"`
+
+	// Merged counts duplicate entries merged away before filtering
+	Merged int `json:"merged_duplicates,omitempty"`
+
+	DroppedByType   int `json:"dropped_by_type"`
+	DroppedByStatus int `json:"dropped_by_status"`
+	DroppedByTime   int `json:"dropped_by_time"`
+	DroppedByTag    int `json:"dropped_by_tag"`
+	DroppedByQuery  int `json:"dropped_by_query"`
+	Kept            int `json:"kept"`
+}
+
+// String summarizes the stats on one line
+func (s *FilterStats) String() string {
+	merged := ""
+	if s.Merged > 0 {
+		merged = fmt.Sprintf(", merged %d duplicates", s.Merged)
+	}
+	return fmt.Sprintf("fetched %d%s, dropped %d by type, %d by status, %d by time, %d by tag, %d by query, kept %d",
+		s.Fetched, merged, s.DroppedByType, s.DroppedByStatus, s.DroppedByTime, s.DroppedByTag, s.DroppedByQuery, s.Kept)
 }
 
 // NewFormatter creates a new formatter based on format type
@@ -41,9 +136,18 @@ func NewFormatter(format string, cfg *config.OutputConfig, w io.Writer) Formatte
 		w = os.Stdout
 	}
 
+	var order []string
+	if cfg != nil {
+		order = cfg.ProviderOrder
+	}
+
 	switch format {
 	case "json":
-		return &JSONFormatter{writer: w}
+		return &JSONFormatter{writer: w, order: order}
+	case "jsonl":
+		jsonl := NewJSONLFormatter(w)
+		jsonl.order = order
+		return jsonl
 	case "wide":
 		return &TableFormatter{writer: w, config: cfg, wide: true}
 	default:
@@ -60,11 +164,11 @@ type TableFormatter struct {
 
 func (f *TableFormatter) Format(result *CollectResult) error {
 	// Group resources by provider
-	providers := make([]string, 0, len(result.Results))
-	for p := range result.Results {
-		providers = append(providers, p)
+	var order []string
+	if f.config != nil {
+		order = f.config.ProviderOrder
 	}
-	sort.Strings(providers)
+	providers := OrderProviders(result.Results, order)
 
 	for _, providerName := range providers {
 		provResult := result.Results[providerName]
@@ -81,9 +185,21 @@ func (f *TableFormatter) Format(result *CollectResult) error {
 		// Determine columns based on format
 		var headers []string
 		var widths []int
+		var tagKeys []string
+		if f.config != nil {
+			tagKeys = f.config.TagColumns
+		}
 		if f.wide {
 			headers = []string{"ID", "NAME", "TYPE", "REGION", "STATUS", "CREATED"}
 			widths = []int{20, 25, 15, 15, 10, 20}
+			if len(tagKeys) == 0 {
+				headers = append(headers, "TAGS")
+				widths = append(widths, 30)
+			}
+			for _, key := range tagKeys {
+				headers = append(headers, strings.ToUpper(key))
+				widths = append(widths, 12)
+			}
 		} else {
 			headers = []string{"NAME", "TYPE", "REGION", "STATUS"}
 			widths = []int{30, 15, 15, 10}
@@ -109,6 +225,12 @@ func (f *TableFormatter) Format(result *CollectResult) error {
 					resource.Status,
 					created,
 				}
+				if len(tagKeys) == 0 {
+					row = append(row, truncate(formatTags(resource.Tags), 30))
+				}
+				for i, key := range tagKeys {
+					row = append(row, truncate(resource.Tags[key], widths[6+i]))
+				}
 			} else {
 				row = []string{
 					truncate(resource.Name, widths[0]),
@@ -120,25 +242,41 @@ func (f *TableFormatter) Format(result *CollectResult) error {
 			f.printRow(row, widths)
 		}
 
-		if provResult.Cached {
-			fmt.Fprintf(f.writer, "(cached)\n")
+		if provResult.Truncated() {
+			fmt.Fprintf(f.writer, "(showing %s)\n", provResult.ShownCount())
+		}
+		if provResult.Stale {
+			fmt.Fprintf(f.writer, "(stale, as of %s)\n", provResult.LastSuccess.Local().Format("15:04"))
+		} else if provResult.Cached {
+			fmt.Fprintf(f.writer, "(cached, as of %s)\n", provResult.LastSuccess.Local().Format("15:04"))
 		}
 	}
 
-	// Print errors
-	if len(result.Errors) > 0 {
-		fmt.Fprintf(f.writer, "\nErrors:\n")
-		for p, err := range result.Errors {
-			fmt.Fprintf(f.writer, "  %s: %v\n", p, err)
-		}
+	// --quiet leaves only the tables
+	if logging.Quiet() {
+		return nil
 	}
 
+	// Print providers that never started, then collection errors
+	printInitFailures(f.writer, result.Init)
+	printErrors(f.writer, result.Errors, f.config != nil && f.config.ColorEnabled)
+
 	// Print summary
 	fmt.Fprintf(f.writer, "\nCompleted in %v\n", result.Duration.Round(time.Millisecond))
 
 	return nil
 }
 
+// formatTags renders tags as sorted key=value pairs
+func formatTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
 func (f *TableFormatter) printRow(columns []string, widths []int) {
 	for i, col := range columns {
 		format := fmt.Sprintf("%%-%ds  ", widths[i])
@@ -157,26 +295,55 @@ func (f *TableFormatter) printSeparator(widths []int) {
 	fmt.Fprintln(f.writer)
 }
 
+// ErrorInfo is a provider error in JSON output. Type and Retryable come
+// from a *errors.CloudtopError anywhere in the error chain; they are
+// omitted for other errors.
+type ErrorInfo struct {
+	Provider  string `json:"provider"`
+	Type      string `json:"type,omitempty"`
+	Message   string `json:"message"`
+	Retryable *bool  `json:"retryable,omitempty"`
+}
+
+// NewErrorInfo describes a provider's collection error
+func NewErrorInfo(providerName string, err error) ErrorInfo {
+	info := ErrorInfo{Provider: providerName, Message: err.Error()}
+
+	var ctErr *cterrors.CloudtopError
+	if errors.As(err, &ctErr) {
+		retryable := ctErr.IsRetryable()
+		info.Type = ctErr.Type.String()
+		info.Retryable = &retryable
+	}
+	return info
+}
+
 // JSONFormatter outputs results as JSON
 type JSONFormatter struct {
 	writer io.Writer
+	order  []string
 }
 
 func (f *JSONFormatter) Format(result *CollectResult) error {
 	output := struct {
 		Timestamp time.Time                     `json:"timestamp"`
 		Duration  string                        `json:"duration"`
-		Providers map[string]*ProviderResult    `json:"providers"`
-		Errors    map[string]string             `json:"errors,omitempty"`
+		Providers orderedResults                `json:"providers"`
+		Errors    map[string]ErrorInfo          `json:"errors,omitempty"`
+		Init      InitReport                    `json:"init,omitempty"`
 	}{
 		Timestamp: result.Timestamp,
 		Duration:  result.Duration.String(),
-		Providers: result.Results,
-		Errors:    make(map[string]string),
+		Providers: orderedResults{
+			names:   OrderProviders(result.Results, f.order),
+			results: result.Results,
+		},
+		Errors: make(map[string]ErrorInfo),
+		Init:   result.Init,
 	}
 
 	for p, err := range result.Errors {
-		output.Errors[p] = err.Error()
+		output.Errors[p] = NewErrorInfo(p, err)
 	}
 
 	encoder := json.NewEncoder(f.writer)
@@ -184,10 +351,107 @@ func (f *JSONFormatter) Format(result *CollectResult) error {
 	return encoder.Encode(output)
 }
 
+// orderedResults marshals provider results as a JSON object whose keys
+// follow names rather than Go's alphabetical map ordering
+type orderedResults struct {
+	names   []string
+	results map[string]*ProviderResult
+}
+
+func (o orderedResults) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range o.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(o.results[name])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// OrderProviders returns the provider names in results with those listed in
+// order first (in that order), followed by the rest alphabetically
+func OrderProviders(results map[string]*ProviderResult, order []string) []string {
+	names := make([]string, 0, len(results))
+	seen := make(map[string]bool, len(results))
+	for _, name := range order {
+		if _, ok := results[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
+	for name := range results {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(names, rest...)
+}
+
+// JSONLFormatter outputs one JSON object per resource per line. Unlike
+// JSONFormatter it can write provider results as they arrive instead of
+// buffering the whole CollectResult.
+type JSONLFormatter struct {
+	encoder *json.Encoder
+	order   []string
+}
+
+// NewJSONLFormatter creates a JSON-lines formatter writing to w
+func NewJSONLFormatter(w io.Writer) *JSONLFormatter {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &JSONLFormatter{encoder: json.NewEncoder(w)}
+}
+
+func (f *JSONLFormatter) Format(result *CollectResult) error {
+	for _, providerName := range OrderProviders(result.Results, f.order) {
+		if err := f.WriteProviderResult(result.Results[providerName]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteProviderResult writes a line for each resource in a single provider result
+func (f *JSONLFormatter) WriteProviderResult(result *ProviderResult) error {
+	for _, resource := range result.Resources {
+		if resource.Provider == "" {
+			resource.Provider = result.Provider
+		}
+		if err := f.encoder.Encode(resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SortByUtil orders GPU instances by utilization, busiest first
+const SortByUtil = "util"
+
 // GPUFormatter outputs GPU-specific results
 type GPUFormatter struct {
-	writer io.Writer
-	wide   bool
+	writer  io.Writer
+	wide    bool
+	history *metrics.History
+	top     int
+	sortBy  string
 }
 
 func NewGPUFormatter(wide bool, w io.Writer) *GPUFormatter {
@@ -197,17 +461,73 @@ func NewGPUFormatter(wide bool, w io.Writer) *GPUFormatter {
 	return &GPUFormatter{writer: w, wide: wide}
 }
 
+// WithHistory shows recent GPU utilization per instance as a sparkline in
+// wide output
+func (f *GPUFormatter) WithHistory(h *metrics.History) *GPUFormatter {
+	f.history = h
+	return f
+}
+
+// WithTop limits GPU tables to the first n rows after sorting; totals in
+// the footer still cover every row. 0 shows all rows.
+func (f *GPUFormatter) WithTop(n int) *GPUFormatter {
+	f.top = n
+	return f
+}
+
+// WithSort orders GPU instances; SortByUtil puts the busiest first using
+// the latest sample in the history, with unsampled instances last
+func (f *GPUFormatter) WithSort(sortBy string) *GPUFormatter {
+	f.sortBy = sortBy
+	return f
+}
+
+// latestUtilization returns an instance's most recent GPU utilization, or
+// -1 when it has no samples
+func (f *GPUFormatter) latestUtilization(inst provider.GPUInstance) float64 {
+	samples := f.history.Samples(metrics.HistoryKey(inst.Provider, inst.ID))
+	if len(samples) == 0 {
+		return -1
+	}
+	return samples[len(samples)-1]
+}
+
+// topRows returns how many of total rows to show
+func (f *GPUFormatter) topRows(total int) int {
+	if f.top > 0 && f.top < total {
+		return f.top
+	}
+	return total
+}
+
+// printTopNote notes when a table was cut by WithTop
+func (f *GPUFormatter) printTopNote(shown, total int) {
+	if shown < total {
+		fmt.Fprintf(f.writer, "(showing top %d of %d)\n", shown, total)
+	}
+}
+
 func (f *GPUFormatter) FormatGPUInstances(instances []provider.GPUInstance) error {
 	if len(instances) == 0 {
 		fmt.Fprintln(f.writer, "No GPU instances found")
 		return nil
 	}
 
+	if f.sortBy == SortByUtil {
+		sort.SliceStable(instances, func(i, j int) bool {
+			return f.latestUtilization(instances[i]) > f.latestUtilization(instances[j])
+		})
+	}
+
 	var headers []string
 	var widths []int
 	if f.wide {
 		headers = []string{"PROVIDER", "NAME", "GPU TYPE", "GPU COUNT", "GPU MEM", "CPU", "RAM", "STATUS", "$/HR"}
 		widths = []int{10, 20, 15, 9, 8, 5, 8, 10, 8}
+		if f.history != nil {
+			headers = append(headers, "GPU TREND")
+			widths = append(widths, metrics.DefaultHistorySamples)
+		}
 	} else {
 		headers = []string{"PROVIDER", "NAME", "GPU TYPE", "GPU", "STATUS", "$/HR"}
 		widths = []int{10, 20, 15, 4, 10, 8}
@@ -216,7 +536,8 @@ func (f *GPUFormatter) FormatGPUInstances(instances []provider.GPUInstance) erro
 	f.printRow(headers, widths)
 	f.printSeparator(widths)
 
-	for _, inst := range instances {
+	shown := f.topRows(len(instances))
+	for _, inst := range instances[:shown] {
 		var row []string
 		if f.wide {
 			row = []string{
@@ -230,6 +551,9 @@ func (f *GPUFormatter) FormatGPUInstances(instances []provider.GPUInstance) erro
 				inst.Status,
 				fmt.Sprintf("$%.2f", inst.PricePerHour),
 			}
+			if f.history != nil {
+				row = append(row, Sparkline(f.history.Samples(metrics.HistoryKey(inst.Provider, inst.ID))))
+			}
 		} else {
 			row = []string{
 				inst.Provider,
@@ -242,13 +566,26 @@ func (f *GPUFormatter) FormatGPUInstances(instances []provider.GPUInstance) erro
 		}
 		f.printRow(row, widths)
 	}
-
+	f.printTopNote(shown, len(instances))
+
+	if !logging.Quiet() {
+		gpus := 0
+		var hourly float64
+		for _, inst := range instances {
+			gpus += inst.GPUCount
+			hourly += inst.PricePerHour
+		}
+		fmt.Fprintf(f.writer, "\n%d instances, %d GPUs, $%.2f/hr\n", len(instances), gpus, hourly)
+	}
 	return nil
 }
 
-func (f *GPUFormatter) FormatGPUOfferings(offerings []provider.GPUOffering) error {
+// FormatGPUOfferings prints offerings cheapest first. Providers in failed
+// are listed in a footer, since the comparison is missing their prices.
+func (f *GPUFormatter) FormatGPUOfferings(offerings []provider.GPUOffering, failed map[string]error) error {
 	if len(offerings) == 0 {
 		fmt.Fprintln(f.writer, "No GPU offerings found")
+		f.printMissingProviders(failed)
 		return nil
 	}
 
@@ -270,7 +607,8 @@ func (f *GPUFormatter) FormatGPUOfferings(offerings []provider.GPUOffering) erro
 	f.printRow(headers, widths)
 	f.printSeparator(widths)
 
-	for _, offer := range offerings {
+	shown := f.topRows(len(offerings))
+	for _, offer := range offerings[:shown] {
 		avail := "No"
 		if offer.Available {
 			avail = "Yes"
@@ -301,6 +639,76 @@ func (f *GPUFormatter) FormatGPUOfferings(offerings []provider.GPUOffering) erro
 		}
 		f.printRow(row, widths)
 	}
+	f.printTopNote(shown, len(offerings))
+
+	if !logging.Quiet() {
+		available := 0
+		providers := make(map[string]bool)
+		for _, offer := range offerings {
+			if offer.Available {
+				available++
+			}
+			providers[offer.Provider] = true
+		}
+		fmt.Fprintf(f.writer, "\n%d offerings (%d available) from %d providers\n", len(offerings), available, len(providers))
+	}
+
+	f.printMissingProviders(failed)
+	return nil
+}
+
+// printMissingProviders notes the providers whose offerings could not be
+// collected
+func (f *GPUFormatter) printMissingProviders(failed map[string]error) {
+	if len(failed) == 0 || logging.Quiet() {
+		return
+	}
+	names := make([]string, 0, len(failed))
+	for name := range failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(f.writer, "\nIncomplete: no offerings from %s (failed); prices from these providers are not compared\n", strings.Join(names, ", "))
+}
+
+// PriceAlert reports a GPU offering whose price crossed a watch condition
+type PriceAlert struct {
+	Provider      string  `json:"provider"`
+	GPUType       string  `json:"gpu_type"`
+	Region        string  `json:"region"`
+	PreviousPrice float64 `json:"previous_price,omitempty"`
+	CurrentPrice  float64 `json:"current_price"`
+	DropPct       float64 `json:"drop_pct,omitempty"`
+	Reason        string  `json:"reason"`
+}
+
+func (f *GPUFormatter) FormatPriceAlerts(alerts []PriceAlert) error {
+	if len(alerts) == 0 {
+		fmt.Fprintln(f.writer, "No price alerts")
+		return nil
+	}
+
+	fmt.Fprintf(f.writer, "\nPrice alerts:\n")
+	headers := []string{"PROVIDER", "GPU TYPE", "REGION", "WAS", "NOW", "REASON"}
+	widths := []int{10, 18, 15, 8, 8, 20}
+
+	f.printRow(headers, widths)
+	f.printSeparator(widths)
+
+	for _, alert := range alerts {
+		was := "-"
+		if alert.PreviousPrice > 0 {
+			was = fmt.Sprintf("$%.2f", alert.PreviousPrice)
+		}
+		f.printRow([]string{
+			alert.Provider,
+			alert.GPUType,
+			truncate(alert.Region, widths[2]),
+			was,
+			fmt.Sprintf("$%.2f", alert.CurrentPrice),
+			alert.Reason,
+		}, widths)
+	}
 
 	return nil
 }
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/afterdarksys/cloudtop/internal/inventory"
+)
+
+// FormatInventoryDiff prints the resources added, removed and changed
+// between two inventories, or the diff as indented JSON when asJSON is set
+func FormatInventoryDiff(w io.Writer, before, after *inventory.Inventory, d *inventory.Diff, asJSON bool) error {
+	if w == nil {
+		w = os.Stdout
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]interface{}{
+			"old_captured_at": before.CapturedAt,
+			"new_captured_at": after.CapturedAt,
+			"added":           d.Added,
+			"removed":         d.Removed,
+			"changed":         d.Changed,
+			"unchanged":       d.Unchanged,
+			"incomplete":      d.Incomplete,
+		})
+	}
+
+	fmt.Fprintf(w, "Comparing %s -> %s\n\n",
+		before.CapturedAt.Local().Format("2006-01-02 15:04"), after.CapturedAt.Local().Format("2006-01-02 15:04"))
+
+	if d.Empty() {
+		fmt.Fprintln(w, "No changes")
+	}
+
+	item := func(mark string, i inventory.Item) {
+		fmt.Fprintf(w, "%s %-10s  %-16s  %s", mark, i.Provider, truncate(i.Type, 16), i.Name)
+		if i.ID != i.Name {
+			fmt.Fprintf(w, " (%s)", i.ID)
+		}
+		fmt.Fprintln(w)
+	}
+	for _, i := range d.Added {
+		item("+", i)
+	}
+	for _, i := range d.Removed {
+		item("-", i)
+	}
+	for _, c := range d.Changed {
+		item("~", c.New)
+		for _, field := range c.Fields {
+			from, to := fieldValue(c.Old, field), fieldValue(c.New, field)
+			fmt.Fprintf(w, "    %s: %s -> %s\n", field, from, to)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d added, %d removed, %d changed, %d unchanged\n",
+		len(d.Added), len(d.Removed), len(d.Changed), d.Unchanged)
+	if len(d.Incomplete) > 0 {
+		fmt.Fprintf(w, "Warning: %s failed in one of the snapshots; its changes may be incomplete\n",
+			strings.Join(d.Incomplete, ", "))
+	}
+	return nil
+}
+
+// fieldValue renders one attribute named by inventory.Change.Fields
+func fieldValue(i inventory.Item, field string) string {
+	var value string
+	switch field {
+	case "name":
+		value = i.Name
+	case "region":
+		value = i.Region
+	case "status":
+		value = i.Status
+	case "created_at":
+		if i.CreatedAt != nil {
+			value = i.CreatedAt.Format("2006-01-02 15:04:05")
+		}
+	default:
+		if key, ok := strings.CutPrefix(field, "tags."); ok {
+			value = i.Tags[key]
+		}
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
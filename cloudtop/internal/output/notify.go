@@ -0,0 +1,161 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// Notification gates for --notify-on
+const (
+	NotifyOnAlways = "always"
+	NotifyOnErrors = "errors"
+)
+
+// NotifySummary is what a notification reports about one collection
+type NotifySummary struct {
+	Timestamp time.Time
+	Counts    map[string]int    // resources (or GPU instances) per provider
+	Errors    map[string]string // provider errors
+
+	// GPU is set for GPU collections, which also report HourlyCost
+	GPU        bool
+	HourlyCost float64
+}
+
+// SummarizeResult builds a notification summary from a resource collection
+func SummarizeResult(result *CollectResult) *NotifySummary {
+	s := &NotifySummary{
+		Timestamp: result.Timestamp,
+		Counts:    make(map[string]int, len(result.Results)),
+		Errors:    errorStrings(result.Errors),
+	}
+	for name, r := range result.Results {
+		s.Counts[name] = len(r.Resources)
+	}
+	return s
+}
+
+// SummarizeGPU builds a notification summary from a GPU instance collection
+func SummarizeGPU(instances []provider.GPUInstance, errors map[string]error) *NotifySummary {
+	s := &NotifySummary{
+		Timestamp: time.Now(),
+		Counts:    make(map[string]int),
+		Errors:    errorStrings(errors),
+		GPU:       true,
+	}
+	for _, inst := range instances {
+		s.Counts[inst.Provider]++
+		s.HourlyCost += inst.PricePerHour
+	}
+	return s
+}
+
+func errorStrings(errs map[string]error) map[string]string {
+	out := make(map[string]string, len(errs))
+	for name, err := range errs {
+		out[name] = err.Error()
+	}
+	return out
+}
+
+// ShouldNotify reports whether the summary passes the --notify-on gate
+func (s *NotifySummary) ShouldNotify(on string) bool {
+	if on == NotifyOnErrors {
+		return len(s.Errors) > 0
+	}
+	return true
+}
+
+// SlackText renders the summary as Slack mrkdwn
+func (s *NotifySummary) SlackText() string {
+	var b strings.Builder
+
+	noun := "resources"
+	if s.GPU {
+		noun = "GPU instances"
+	}
+	total := 0
+	for _, n := range s.Counts {
+		total += n
+	}
+
+	status := ":white_check_mark:"
+	if len(s.Errors) > 0 {
+		status = ":warning:"
+	}
+	fmt.Fprintf(&b, "%s *cloudtop*: %d %s across %d providers", status, total, noun, len(s.Counts))
+	if !s.Timestamp.IsZero() {
+		fmt.Fprintf(&b, " (%s)", s.Timestamp.UTC().Format("2006-01-02 15:04 MST"))
+	}
+	b.WriteString("\n")
+
+	for _, name := range sortedKeys(s.Counts) {
+		fmt.Fprintf(&b, "• %s: %d\n", name, s.Counts[name])
+	}
+	if s.GPU {
+		fmt.Fprintf(&b, "Total hourly cost: *$%.2f/hr*\n", s.HourlyCost)
+	}
+
+	if len(s.Errors) > 0 {
+		fmt.Fprintf(&b, "*%d provider errors:*\n", len(s.Errors))
+		names := make([]string, 0, len(s.Errors))
+		for name := range s.Errors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(&b, "• %s: `%s`\n", name, s.Errors[name])
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// SlackPayload returns the JSON body for a Slack incoming webhook
+func (s *NotifySummary) SlackPayload() ([]byte, error) {
+	return json.Marshal(map[string]string{"text": s.SlackText()})
+}
+
+// SendSlack posts the summary to a Slack incoming webhook
+func SendSlack(ctx context.Context, client *http.Client, webhookURL string, s *NotifySummary) error {
+	payload, err := s.SlackPayload()
+	if err != nil {
+		return fmt.Errorf("failed to encode slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("invalid slack webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
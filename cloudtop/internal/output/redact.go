@@ -0,0 +1,127 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// RedactedValue replaces the value of a redacted field
+const RedactedValue = "****"
+
+// Redactor masks resource fields before output. Field names are the
+// resource's JSON names (id, name, region, status, ...); any other name
+// masks the value of the tag with that key, so "endpoint" masks the host
+// Neon reports in the "endpoint" tag. "tags" masks every tag value.
+type Redactor struct {
+	fields map[string]bool
+}
+
+// NewRedactor creates a redactor for the given field names, which are
+// matched case-insensitively. It returns nil when there is nothing to
+// redact; a nil Redactor leaves results unchanged.
+func NewRedactor(fields []string) *Redactor {
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if f = strings.ToLower(strings.TrimSpace(f)); f != "" {
+			set[f] = true
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	return &Redactor{fields: set}
+}
+
+// Resource returns a copy of r with the redacted fields masked
+func (rd *Redactor) Resource(r provider.Resource) provider.Resource {
+	if rd == nil {
+		return r
+	}
+
+	mask := func(name string, value *string) {
+		if rd.fields[name] && *value != "" {
+			*value = RedactedValue
+		}
+	}
+	mask("id", &r.ID)
+	mask("name", &r.Name)
+	mask("type", &r.Type)
+	mask("provider", &r.Provider)
+	mask("region", &r.Region)
+	mask("status", &r.Status)
+
+	if len(r.Tags) > 0 {
+		tags := make(map[string]string, len(r.Tags))
+		for k, v := range r.Tags {
+			if rd.fields["tags"] || rd.fields[strings.ToLower(k)] {
+				v = RedactedValue
+			}
+			tags[k] = v
+		}
+		r.Tags = tags
+	}
+	return r
+}
+
+// Resources returns a copy of resources with the redacted fields masked
+func (rd *Redactor) Resources(resources []provider.Resource) []provider.Resource {
+	if rd == nil {
+		return resources
+	}
+	out := make([]provider.Resource, len(resources))
+	for i, r := range resources {
+		out[i] = rd.Resource(r)
+	}
+	return out
+}
+
+// ProviderResult returns a copy of result with its resources redacted.
+// The original is left untouched, since it may be held by the cache.
+func (rd *Redactor) ProviderResult(result *ProviderResult) *ProviderResult {
+	if rd == nil || result == nil {
+		return result
+	}
+	redacted := *result
+	redacted.Resources = rd.Resources(result.Resources)
+	return &redacted
+}
+
+// Result returns a copy of result with every provider's resources redacted
+func (rd *Redactor) Result(result *CollectResult) *CollectResult {
+	if rd == nil || result == nil {
+		return result
+	}
+	redacted := *result
+	redacted.Results = make(map[string]*ProviderResult, len(result.Results))
+	for name, pr := range result.Results {
+		redacted.Results[name] = rd.ProviderResult(pr)
+	}
+	return &redacted
+}
+
+// GPUInstances returns a copy of instances with the redacted fields masked
+func (rd *Redactor) GPUInstances(instances []provider.GPUInstance) []provider.GPUInstance {
+	if rd == nil {
+		return instances
+	}
+	out := make([]provider.GPUInstance, len(instances))
+	for i, inst := range instances {
+		inst.Resource = rd.Resource(inst.Resource)
+		out[i] = inst
+	}
+	return out
+}
+
+// Instances returns a copy of instances with the redacted fields masked
+func (rd *Redactor) Instances(instances []provider.InstanceMetrics) []provider.InstanceMetrics {
+	if rd == nil {
+		return instances
+	}
+	out := make([]provider.InstanceMetrics, len(instances))
+	for i, inst := range instances {
+		inst.Resource = rd.Resource(inst.Resource)
+		out[i] = inst
+	}
+	return out
+}
@@ -0,0 +1,220 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaID identifies the published JSON output contract
+const SchemaID = "https://github.com/afterdarksys/cloudtop/schemas/output-v3.json"
+
+// ResultSchema returns the JSON Schema for the JSONFormatter envelope.
+// Changing the --json output shape requires updating this schema.
+func ResultSchema() map[string]interface{} {
+	resource := object(map[string]interface{}{
+		"id":          typed("string"),
+		"name":        typed("string"),
+		"type":        typed("string"),
+		"provider":    typed("string"),
+		"region":      typed("string"),
+		"status":      typed("string"),
+		"tags":        nullable("object", map[string]interface{}{"additionalProperties": typed("string")}),
+		"created_at":  dateTime(),
+		"updated_at":  dateTime(),
+		"hourly_rate": typed("number"),
+	}, "id", "name", "type", "provider", "region", "status", "tags", "created_at", "updated_at")
+
+	filterStats := object(map[string]interface{}{
+		"fetched":           typed("integer"),
+		"merged_duplicates": typed("integer"),
+		"dropped_by_type":   typed("integer"),
+		"dropped_by_status": typed("integer"),
+		"dropped_by_time":   typed("integer"),
+		"dropped_by_tag":    typed("integer"),
+		"dropped_by_query":  typed("integer"),
+		"kept":              typed("integer"),
+	}, "fetched", "dropped_by_type", "dropped_by_status", "dropped_by_time", "dropped_by_tag", "dropped_by_query", "kept")
+
+	providerResult := object(map[string]interface{}{
+		"Provider":    typed("string"),
+		"Resources":   nullable("array", map[string]interface{}{"items": resource}),
+		"Metrics":     nullable("object", nil),
+		"Cached":      typed("boolean"),
+		"Duration":    typed("integer"),
+		"Filter":      filterStats,
+		"Total":       typed("integer"),
+		"Partial":     typed("boolean"),
+		"LastSuccess": dateTime(),
+		"Stale":       typed("boolean"),
+	}, "Provider", "Resources", "Metrics", "Cached", "Duration", "LastSuccess")
+
+	errorInfo := object(map[string]interface{}{
+		"provider":  typed("string"),
+		"type":      typed("string"),
+		"message":   typed("string"),
+		"retryable": typed("boolean"),
+	}, "provider", "message")
+
+	providerInit := object(map[string]interface{}{
+		"initialized": typed("boolean"),
+		"error":       typed("string"),
+	}, "initialized")
+
+	schema := object(map[string]interface{}{
+		"timestamp": dateTime(),
+		"duration":  typed("string"),
+		"providers": map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": providerResult,
+		},
+		"errors": map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": errorInfo,
+		},
+		"init": map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": providerInit,
+		},
+	}, "timestamp", "duration", "providers")
+
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = SchemaID
+	schema["title"] = "cloudtop JSON output"
+	return schema
+}
+
+// ValidateResult checks a --json document against ResultSchema
+func ValidateResult(data []byte) error {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var problems []string
+	validate(ResultSchema(), doc, "$", &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("output does not match schema:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// validate implements the subset of JSON Schema used by ResultSchema: type
+// (single or list), required, properties, additionalProperties and items
+func validate(schema map[string]interface{}, value interface{}, path string, problems *[]string) {
+	if !matchesType(schema["type"], value) {
+		*problems = append(*problems, fmt.Sprintf("%s: expected %v, got %s", path, schema["type"], jsonType(value)))
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		props, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]string); ok {
+			for _, key := range required {
+				if _, ok := v[key]; !ok {
+					*problems = append(*problems, fmt.Sprintf("%s: missing required field %q", path, key))
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := path + "." + key
+			if propSchema, ok := props[key].(map[string]interface{}); ok {
+				validate(propSchema, v[key], childPath, problems)
+				continue
+			}
+			switch extra := schema["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					*problems = append(*problems, fmt.Sprintf("%s: unexpected field", childPath))
+				}
+			case map[string]interface{}:
+				validate(extra, v[key], childPath, problems)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validate(items, item, fmt.Sprintf("%s[%d]", path, i), problems)
+			}
+		}
+	}
+}
+
+func matchesType(want interface{}, value interface{}) bool {
+	switch t := want.(type) {
+	case nil:
+		return true
+	case string:
+		return typeMatches(t, value)
+	case []string:
+		for _, s := range t {
+			if typeMatches(s, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeMatches(want string, value interface{}) bool {
+	got := jsonType(value)
+	if want == "number" && got == "integer" {
+		return true
+	}
+	return want == got
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+func typed(t string) map[string]interface{} {
+	return map[string]interface{}{"type": t}
+}
+
+func nullable(t string, extra map[string]interface{}) map[string]interface{} {
+	s := map[string]interface{}{"type": []string{t, "null"}}
+	for k, v := range extra {
+		s[k] = v
+	}
+	return s
+}
+
+func dateTime() map[string]interface{} {
+	return map[string]interface{}{"type": "string", "format": "date-time"}
+}
+
+func object(props map[string]interface{}, required ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
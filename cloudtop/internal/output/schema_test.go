@@ -0,0 +1,117 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cterrors "github.com/afterdarksys/cloudtop/internal/errors"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// sampleResult is a CollectResult with every field of the --json output
+// set, including the ones omitted when empty
+func sampleResult() *CollectResult {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &CollectResult{
+		Timestamp: at,
+		Duration:  1500 * time.Millisecond,
+		Results: map[string]*ProviderResult{
+			"oracle": {
+				Provider: "oracle",
+				Resources: []provider.Resource{{
+					ID:         "ocid1.instance.oc1..a",
+					Name:       "web-1",
+					Type:       "compute",
+					Provider:   "oracle",
+					Region:     "us-ashburn-1",
+					Status:     "running",
+					Tags:       map[string]string{"env": "prod"},
+					CreatedAt:  at.Add(-24 * time.Hour),
+					UpdatedAt:  at,
+					HourlyRate: 0.25,
+				}},
+				Metrics:  map[string]interface{}{"cpu": 42.5},
+				Duration: 800 * time.Millisecond,
+				Filter: &FilterStats{
+					Fetched:         12,
+					Merged:          1,
+					DroppedByType:   2,
+					DroppedByStatus: 3,
+					DroppedByTime:   1,
+					DroppedByTag:    1,
+					DroppedByQuery:  1,
+					Kept:            3,
+				},
+				LastSuccess: at,
+				Total:       3,
+				Partial:     true,
+			},
+			"cloudflare": {
+				Provider:    "cloudflare",
+				Resources:   nil,
+				Metrics:     map[string]interface{}{},
+				Cached:      true,
+				Duration:    time.Millisecond,
+				LastSuccess: at.Add(-10 * time.Minute),
+				Stale:       true,
+			},
+		},
+		Errors: map[string]error{
+			"cloudflare": cterrors.NewRateLimitError("cloudflare", errors.New("429 Too Many Requests")),
+			"neon":       errors.New("connection reset"),
+		},
+		Init: InitReport{
+			"oracle":     {Initialized: true},
+			"cloudflare": {Initialized: true},
+			"neon":       {Initialized: true},
+			"azure":      {Initialized: false, Error: "missing credentials"},
+		},
+	}
+}
+
+func TestJSONOutputMatchesSchema(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("json", nil, &buf).Format(sampleResult()); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	if err := ValidateResult(buf.Bytes()); err != nil {
+		t.Errorf("%v\n%s", err, buf.String())
+	}
+}
+
+func TestValidateResultRejectsDrift(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "unknown top-level field",
+			doc:  `{"timestamp":"2025-03-01T12:00:00Z","duration":"1s","providers":{},"extra":1}`,
+			want: "$.extra: unexpected field",
+		},
+		{
+			name: "missing required field",
+			doc:  `{"timestamp":"2025-03-01T12:00:00Z","providers":{}}`,
+			want: `missing required field "duration"`,
+		},
+		{
+			name: "wrong init type",
+			doc:  `{"timestamp":"2025-03-01T12:00:00Z","duration":"1s","providers":{},"init":{"aws":{"initialized":"yes"}}}`,
+			want: "$.init.aws.initialized: expected boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResult([]byte(tt.doc))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ValidateResult = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package output
+
+import "strings"
+
+// sparkBlocks are the sparkline levels, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders utilization percentages (0-100) as a row of Unicode
+// block characters, one per sample, oldest first. It returns "-" when
+// there are no samples.
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := int(v / 100 * float64(len(sparkBlocks)-1))
+		if level < 0 {
+			level = 0
+		} else if level >= len(sparkBlocks) {
+			level = len(sparkBlocks) - 1
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
@@ -0,0 +1,162 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// StorageFormatter renders object storage buckets with their usage. CHANGE
+// and the request counts come from Bucket.Metrics and show "-" for buckets
+// without it.
+type StorageFormatter struct {
+	writer io.Writer
+	format string
+}
+
+// NewStorageFormatter creates a bucket formatter for the given output
+// format ("table", "wide", "json" or "jsonl")
+func NewStorageFormatter(format string, w io.Writer) *StorageFormatter {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &StorageFormatter{writer: w, format: format}
+}
+
+// FormatBuckets prints buckets largest first
+func (f *StorageFormatter) FormatBuckets(buckets []provider.Bucket) error {
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return buckets[i].SizeBytes > buckets[j].SizeBytes
+	})
+
+	switch f.format {
+	case "json":
+		encoder := json.NewEncoder(f.writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]interface{}{
+			"buckets": buckets,
+			"total":   len(buckets),
+		})
+	case "jsonl":
+		encoder := json.NewEncoder(f.writer)
+		for _, b := range buckets {
+			if err := encoder.Encode(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(buckets) == 0 {
+		fmt.Fprintln(f.writer, "No storage buckets found")
+		return nil
+	}
+
+	headers := []string{"PROVIDER", "TYPE", "BUCKET", "OBJECTS", "SIZE", "CHANGE"}
+	widths := []int{10, 6, 30, 12, 10, 11}
+	if f.format == "wide" {
+		headers = append(headers, "GETS", "PUTS", "DELETES", "LISTS", "CLASS", "CREATED", "AS OF")
+		widths = append(widths, 9, 9, 9, 9, 10, 10, 16)
+	}
+
+	f.printRow(headers, widths)
+	f.printSeparator(widths)
+
+	var totalObjects, totalBytes, totalChange int64
+	withMetrics := 0
+	for _, b := range buckets {
+		totalObjects += b.ObjectCount
+		totalBytes += b.SizeBytes
+
+		change := "-"
+		if b.Metrics != nil {
+			change = formatSizeChange(b.Metrics.SizeChangeBytes)
+			totalChange += b.Metrics.SizeChangeBytes
+			withMetrics++
+		}
+
+		row := []string{
+			b.Provider,
+			b.Type,
+			truncate(b.Name, widths[2]),
+			fmt.Sprintf("%d", b.ObjectCount),
+			FormatBytes(b.SizeBytes),
+			change,
+		}
+		if f.format == "wide" {
+			gets, puts, deletes, lists := "-", "-", "-", "-"
+			if m := b.Metrics; m != nil {
+				gets = fmt.Sprintf("%d", m.GetRequests)
+				puts = fmt.Sprintf("%d", m.PutRequests)
+				deletes = fmt.Sprintf("%d", m.DeleteRequests)
+				lists = fmt.Sprintf("%d", m.ListRequests)
+			}
+			row = append(row, gets, puts, deletes, lists)
+
+			created, asOf := "-", "-"
+			if !b.CreatedAt.IsZero() {
+				created = b.CreatedAt.Format("2006-01-02")
+			}
+			if !b.UpdatedAt.IsZero() {
+				asOf = b.UpdatedAt.Local().Format("2006-01-02 15:04")
+			}
+			row = append(row, b.StorageClass, created, asOf)
+		}
+		f.printRow(row, widths)
+	}
+
+	fmt.Fprintf(f.writer, "\n%d buckets, %d objects, %s", len(buckets), totalObjects, FormatBytes(totalBytes))
+	if withMetrics > 0 {
+		fmt.Fprintf(f.writer, " (%s)", formatSizeChange(totalChange))
+	}
+	fmt.Fprintln(f.writer)
+	return nil
+}
+
+// formatSizeChange renders a signed byte count such as "+1.5 GiB"
+func formatSizeChange(n int64) string {
+	switch {
+	case n > 0:
+		return "+" + FormatBytes(n)
+	case n < 0:
+		return "-" + FormatBytes(-n)
+	}
+	return "0 B"
+}
+
+// FormatBytes renders a byte count using binary units (e.g. "1.5 GiB")
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func (f *StorageFormatter) printRow(columns []string, widths []int) {
+	for i, col := range columns {
+		format := fmt.Sprintf("%%-%ds  ", widths[i])
+		fmt.Fprintf(f.writer, format, col)
+	}
+	fmt.Fprintln(f.writer)
+}
+
+func (f *StorageFormatter) printSeparator(widths []int) {
+	for i, w := range widths {
+		fmt.Fprint(f.writer, strings.Repeat("-", w))
+		if i < len(widths)-1 {
+			fmt.Fprint(f.writer, "  ")
+		}
+	}
+	fmt.Fprintln(f.writer)
+}
@@ -17,6 +17,9 @@ func init() {
 	})
 }
 
+// Compile-time check for the optional interface AzureProvider implements
+var _ provider.ComputeProvider = (*AzureProvider)(nil)
+
 // AzureProvider implements Provider interface for Azure (stub)
 type AzureProvider struct {
 	config         *provider.ProviderConfig
@@ -59,9 +62,9 @@ func (p *AzureProvider) ListServices(ctx context.Context) ([]provider.Service, e
 	return []provider.Service{
 		{ID: "vms", Name: "Virtual Machines", Type: "compute", Capabilities: []string{"compute", "metrics"}},
 		{ID: "aks", Name: "Azure Kubernetes Service", Type: "containers", Capabilities: []string{"containers", "kubernetes"}},
-		{ID: "functions", Name: "Azure Functions", Type: "serverless", Capabilities: []string{"compute", "metrics"}},
-		{ID: "storage", Name: "Blob Storage", Type: "storage", Capabilities: []string{"storage", "metrics"}},
-		{ID: "sql", Name: "Azure SQL", Type: "database", Capabilities: []string{"database", "metrics"}},
+		{ID: "functions", Name: "Azure Functions", Type: "serverless", Capabilities: []string{"metrics"}},
+		{ID: "storage", Name: "Blob Storage", Type: "storage", Capabilities: []string{"metrics"}},
+		{ID: "sql", Name: "Azure SQL", Type: "database", Capabilities: []string{"metrics"}},
 	}, nil
 }
 
@@ -0,0 +1,277 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/errors"
+	"github.com/afterdarksys/cloudtop/internal/metrics"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// aiUsageWindow is the period Workers AI usage is reported over
+const aiUsageWindow = time.Hour
+
+// aiUsageQuery reads Workers AI inference analytics per model from the
+// GraphQL Analytics API
+const aiUsageQuery = `query($accountTag: string!, $filter: AccountAiInferenceAdaptiveGroupsFilter_InputObject) {
+  viewer {
+    accounts(filter: {accountTag: $accountTag}) {
+      aiInferenceAdaptiveGroups(limit: 1000, filter: $filter) {
+        count
+        dimensions { modelId }
+        sum { totalInputTokens totalOutputTokens }
+        avg { inferenceTimeMs }
+        quantiles { inferenceTimeMsP99 }
+      }
+    }
+  }
+}`
+
+// Workers AI API types
+type cfAIModel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Task struct {
+		Name string `json:"name"`
+	} `json:"task"`
+	Properties []struct {
+		PropertyID string      `json:"property_id"`
+		Value      interface{} `json:"value"`
+	} `json:"properties"`
+}
+
+type cfAIUsageGroup struct {
+	Count      int64 `json:"count"`
+	Dimensions struct {
+		ModelID string `json:"modelId"`
+	} `json:"dimensions"`
+	Sum struct {
+		TotalInputTokens  int64 `json:"totalInputTokens"`
+		TotalOutputTokens int64 `json:"totalOutputTokens"`
+	} `json:"sum"`
+	Avg struct {
+		InferenceTimeMs float64 `json:"inferenceTimeMs"`
+	} `json:"avg"`
+	Quantiles struct {
+		InferenceTimeMsP99 float64 `json:"inferenceTimeMsP99"`
+	} `json:"quantiles"`
+}
+
+type cfGraphQLResponse struct {
+	Data struct {
+		Viewer struct {
+			Accounts []struct {
+				AIInferenceAdaptiveGroups []cfAIUsageGroup `json:"aiInferenceAdaptiveGroups"`
+			} `json:"accounts"`
+		} `json:"viewer"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// aiModelTypes maps Workers AI task names to AIModel types
+var aiModelTypes = map[string]string{
+	"Text Generation":              "llm",
+	"Text Embeddings":              "embedding",
+	"Text-to-Image":                "image",
+	"Image-to-Text":                "image",
+	"Image Classification":         "image",
+	"Object Detection":             "image",
+	"Automatic Speech Recognition": "speech",
+	"Text-to-Speech":               "speech",
+	"Translation":                  "translation",
+	"Summarization":                "llm",
+	"Text Classification":          "classification",
+}
+
+// ListModels returns the Workers AI model catalog
+func (p *CloudflareProvider) ListModels(ctx context.Context) ([]provider.AIModel, error) {
+	if p.accountID == "" {
+		return nil, errors.NewValidationError("cloudflare", "account_id required for Workers AI")
+	}
+
+	cfResp, err := p.doRequest(ctx, "GET", "/accounts/"+p.accountID+"/ai/models/search?per_page=1000")
+	if err != nil {
+		return nil, err
+	}
+
+	var models []cfAIModel
+	if err := json.Unmarshal(cfResp.Result, &models); err != nil {
+		return nil, errors.NewInternalError("cloudflare", fmt.Errorf("failed to parse AI models: %w", err))
+	}
+
+	result := make([]provider.AIModel, 0, len(models))
+	for _, m := range models {
+		model := provider.AIModel{
+			ID:       m.Name,
+			Name:     m.Name,
+			Provider: "cloudflare",
+			Type:     aiModelTypes[m.Task.Name],
+		}
+		if model.Type == "" {
+			model.Type = "other"
+		}
+		for _, prop := range m.Properties {
+			if prop.PropertyID == "max_total_tokens" || prop.PropertyID == "context_window" {
+				switch v := prop.Value.(type) {
+				case float64:
+					model.MaxTokens = int(v)
+				case string:
+					fmt.Sscanf(v, "%d", &model.MaxTokens)
+				}
+			}
+		}
+		result = append(result, model)
+	}
+	return result, nil
+}
+
+// GetAIMetrics returns Workers AI usage over the last hour for one model,
+// or summed over every model when resourceID is empty. Latency is the
+// inference-count weighted average; P99 is the highest of the models'.
+func (p *CloudflareProvider) GetAIMetrics(ctx context.Context, resourceID string) (*metrics.AIMetrics, error) {
+	groups, end, err := p.queryAIUsage(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	total := &metrics.AIMetrics{
+		ResourceID: resourceID,
+		Provider:   "cloudflare",
+		Timestamp:  end,
+	}
+	if resourceID == "" {
+		total.ResourceID = "workers-ai"
+	}
+
+	var latencySum float64
+	for _, g := range groups {
+		m := aiMetricsFromGroup(g, end)
+		total.InferenceCount += m.InferenceCount
+		total.TokensProcessed += m.TokensProcessed
+		latencySum += m.AvgLatencyMs * float64(m.InferenceCount)
+		if m.P99LatencyMs > total.P99LatencyMs {
+			total.P99LatencyMs = m.P99LatencyMs
+		}
+	}
+	if total.InferenceCount > 0 {
+		total.AvgLatencyMs = latencySum / float64(total.InferenceCount)
+	}
+	return total, nil
+}
+
+// ListAIMetrics returns Workers AI usage over the last hour for each model
+// that served any inference, busiest first
+func (p *CloudflareProvider) ListAIMetrics(ctx context.Context) ([]metrics.AIMetrics, error) {
+	groups, end, err := p.queryAIUsage(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]metrics.AIMetrics, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *aiMetricsFromGroup(g, end))
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].InferenceCount > result[j].InferenceCount
+	})
+	return result, nil
+}
+
+func aiMetricsFromGroup(g cfAIUsageGroup, end time.Time) *metrics.AIMetrics {
+	return &metrics.AIMetrics{
+		ResourceID:      g.Dimensions.ModelID,
+		Provider:        "cloudflare",
+		Timestamp:       end,
+		InferenceCount:  g.Count,
+		TokensProcessed: g.Sum.TotalInputTokens + g.Sum.TotalOutputTokens,
+		AvgLatencyMs:    g.Avg.InferenceTimeMs,
+		P99LatencyMs:    g.Quantiles.InferenceTimeMsP99,
+	}
+}
+
+// queryAIUsage returns the per-model Workers AI usage groups for the last
+// aiUsageWindow, limited to one model when modelID is set, along with the
+// end of the window
+func (p *CloudflareProvider) queryAIUsage(ctx context.Context, modelID string) ([]cfAIUsageGroup, time.Time, error) {
+	if p.accountID == "" {
+		return nil, time.Time{}, errors.NewValidationError("cloudflare", "account_id required for Workers AI")
+	}
+
+	end := time.Now().UTC().Truncate(time.Minute)
+	start := end.Add(-aiUsageWindow)
+	filter := map[string]interface{}{
+		"datetime_geq": start.Format(time.RFC3339),
+		"datetime_lt":  end.Format(time.RFC3339),
+	}
+	if modelID != "" {
+		filter["modelId"] = modelID
+	}
+
+	var resp cfGraphQLResponse
+	err := p.doGraphQL(ctx, aiUsageQuery, map[string]interface{}{
+		"accountTag": p.accountID,
+		"filter":     filter,
+	}, &resp)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(resp.Errors) > 0 {
+		return nil, time.Time{}, errors.NewNetworkError("cloudflare", fmt.Errorf("GraphQL error: %s", resp.Errors[0].Message))
+	}
+
+	var groups []cfAIUsageGroup
+	for _, account := range resp.Data.Viewer.Accounts {
+		groups = append(groups, account.AIInferenceAdaptiveGroups...)
+	}
+	return groups, end, nil
+}
+
+// doGraphQL posts a query to the GraphQL Analytics API and decodes the
+// response into out
+func (p *CloudflareProvider) doGraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return errors.NewRateLimitError("cloudflare", err)
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return errors.NewInternalError("cloudflare", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/graphql", bytes.NewReader(payload))
+	if err != nil {
+		return errors.NewInternalError("cloudflare", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errors.NewNetworkError("cloudflare", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.NewNetworkError("cloudflare", err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return errors.NewAuthError("cloudflare", fmt.Errorf("GraphQL analytics request denied: %s", string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return errors.NewInternalError("cloudflare", fmt.Errorf("failed to parse GraphQL response: %w", err))
+	}
+	return nil
+}
@@ -6,11 +6,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/afterdarksys/cloudtop/internal/errors"
 	"github.com/afterdarksys/cloudtop/internal/metrics"
 	"github.com/afterdarksys/cloudtop/internal/provider"
+	"github.com/afterdarksys/cloudtop/pkg/httpclient"
 	"github.com/afterdarksys/cloudtop/pkg/ratelimit"
 )
 
@@ -20,6 +24,14 @@ func init() {
 	})
 }
 
+// Compile-time checks for the optional interfaces CloudflareProvider implements
+var (
+	_ provider.StorageProvider = (*CloudflareProvider)(nil)
+	_ provider.AIProvider      = (*CloudflareProvider)(nil)
+	_ provider.ResourceGetter  = (*CloudflareProvider)(nil)
+	_ provider.MetricLimits    = (*CloudflareProvider)(nil)
+)
+
 // CloudflareProvider implements the Provider interface for Cloudflare
 type CloudflareProvider struct {
 	config    *provider.ProviderConfig
@@ -27,6 +39,10 @@ type CloudflareProvider struct {
 	accountID string
 	client    *http.Client
 	limiter   *ratelimit.Limiter
+
+	// etags makes GET requests conditional when the
+	// conditional_requests option is set; nil otherwise
+	etags *httpclient.ETagCache
 }
 
 const (
@@ -47,14 +63,24 @@ func (p *CloudflareProvider) Initialize(ctx context.Context, config *provider.Pr
 	}
 	p.apiToken = apiToken
 
-	// Get account ID from options
+	// Get account ID from options; account_ids replaced account_id in config 1.1
 	if accountID, ok := config.Options["account_id"].(string); ok {
 		p.accountID = accountID
+	} else if accountIDs, ok := config.Options["account_ids"].([]interface{}); ok && len(accountIDs) > 0 {
+		p.accountID, _ = accountIDs[0].(string)
+	} else if accountIDs, ok := config.Options["account_ids"].([]string); ok && len(accountIDs) > 0 {
+		p.accountID = accountIDs[0]
 	}
 
 	// Create HTTP client
-	p.client = &http.Client{
-		Timeout: 30 * time.Second,
+	client, err := httpclient.New(config.HTTP)
+	if err != nil {
+		return errors.NewValidationError("cloudflare", err.Error())
+	}
+	p.client = client
+
+	if enabled, _ := config.Options["conditional_requests"].(bool); enabled {
+		p.etags = httpclient.NewETagCache()
 	}
 
 	// Set up rate limiter
@@ -100,10 +126,10 @@ func (p *CloudflareProvider) HealthCheck(ctx context.Context) error {
 
 func (p *CloudflareProvider) ListServices(ctx context.Context) ([]provider.Service, error) {
 	return []provider.Service{
-		{ID: "workers", Name: "Cloudflare Workers", Type: "serverless", Capabilities: []string{"compute", "metrics"}},
+		{ID: "workers", Name: "Cloudflare Workers", Type: "serverless", Capabilities: []string{"metrics"}},
 		{ID: "r2", Name: "R2 Storage", Type: "storage", Capabilities: []string{"storage", "metrics"}},
-		{ID: "d1", Name: "D1 Database", Type: "database", Capabilities: []string{"database", "metrics"}},
-		{ID: "kv", Name: "Workers KV", Type: "storage", Capabilities: []string{"storage"}},
+		{ID: "d1", Name: "D1 Database", Type: "database", Capabilities: []string{"metrics"}},
+		{ID: "kv", Name: "Workers KV", Type: "storage", Capabilities: []string{}},
 		{ID: "ai", Name: "Cloudflare AI", Type: "ai", Capabilities: []string{"ai", "inference"}},
 		{ID: "pages", Name: "Cloudflare Pages", Type: "hosting", Capabilities: []string{"hosting", "deployments"}},
 	}, nil
@@ -121,7 +147,7 @@ func (p *CloudflareProvider) ListResources(ctx context.Context, filter *provider
 	}
 
 	// List R2 buckets
-	if filter == nil || len(filter.Types) == 0 || contains(filter.Types, "r2") {
+	if !filter.Full(len(resources)) && (filter == nil || len(filter.Types) == 0 || contains(filter.Types, "r2")) {
 		buckets, err := p.listR2Buckets(ctx)
 		if err == nil {
 			resources = append(resources, buckets...)
@@ -129,16 +155,35 @@ func (p *CloudflareProvider) ListResources(ctx context.Context, filter *provider
 	}
 
 	// List D1 databases
-	if filter == nil || len(filter.Types) == 0 || contains(filter.Types, "d1") {
+	if !filter.Full(len(resources)) && (filter == nil || len(filter.Types) == 0 || contains(filter.Types, "d1")) {
 		dbs, err := p.listD1Databases(ctx)
 		if err == nil {
 			resources = append(resources, dbs...)
 		}
 	}
 
+	if filter != nil && filter.CreatedAfter != nil {
+		filtered := resources[:0]
+		for _, r := range resources {
+			if filter.MatchesCreatedAfter(r) {
+				filtered = append(filtered, r)
+			}
+		}
+		resources = filtered
+	}
+
 	return resources, nil
 }
 
+// maxMetricWindow is the longest range the GraphQL Analytics adaptive
+// datasets accept in one query on most plans
+const maxMetricWindow = 24 * time.Hour
+
+// MaxMetricWindow returns the longest window a metrics request may span
+func (p *CloudflareProvider) MaxMetricWindow() time.Duration {
+	return maxMetricWindow
+}
+
 func (p *CloudflareProvider) GetMetrics(ctx context.Context, req *provider.MetricsRequest) (*provider.MetricsResponse, error) {
 	if err := p.limiter.Wait(ctx); err != nil {
 		return nil, errors.NewRateLimitError("cloudflare", err)
@@ -146,8 +191,28 @@ func (p *CloudflareProvider) GetMetrics(ctx context.Context, req *provider.Metri
 
 	metricsData := make(map[string]interface{})
 
-	// For Workers, get analytics
+	// R2 buckets get storage usage, with analytics read for the whole
+	// account at once; everything else is treated as a Worker
+	buckets := make(map[string]bool)
+	var r2Usage map[string]*metrics.StorageMetrics
+	if p.accountID != "" && len(req.ResourceIDs) > 0 {
+		if list, err := p.fetchR2Buckets(ctx); err == nil {
+			for _, b := range list {
+				buckets[b.Name] = true
+			}
+		}
+		if len(buckets) > 0 {
+			r2Usage, _ = p.queryR2Usage(ctx, "")
+		}
+	}
+
 	for _, resourceID := range req.ResourceIDs {
+		if buckets[resourceID] {
+			if usage, err := p.bucketUsage(ctx, resourceID); err == nil {
+				metricsData[resourceID] = withR2Usage(usage, r2Usage[resourceID])
+			}
+			continue
+		}
 		analytics, err := p.getWorkerAnalytics(ctx, resourceID)
 		if err == nil {
 			metricsData[resourceID] = analytics
@@ -191,6 +256,38 @@ type cfR2Bucket struct {
 	CreationDate time.Time `json:"creation_date"`
 }
 
+// cfR2BucketList is the current shape of the R2 bucket listing result; older
+// API versions returned a bare array
+type cfR2BucketList struct {
+	Buckets []cfR2Bucket `json:"buckets"`
+}
+
+// cfR2Usage is the R2 bucket usage result. Counts are returned as strings.
+type cfR2Usage struct {
+	End          time.Time `json:"end"`
+	PayloadSize  cfCount   `json:"payloadSize"`
+	MetadataSize cfCount   `json:"metadataSize"`
+	ObjectCount  cfCount   `json:"objectCount"`
+	UploadCount  cfCount   `json:"uploadCount"`
+}
+
+// cfCount decodes a count sent either as a JSON number or a numeric string
+type cfCount int64
+
+func (c *cfCount) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*c = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid count %s: %w", data, err)
+	}
+	*c = cfCount(n)
+	return nil
+}
+
 type cfD1Database struct {
 	UUID      string    `json:"uuid"`
 	Name      string    `json:"name"`
@@ -203,12 +300,37 @@ func (p *CloudflareProvider) doRequest(ctx context.Context, method, path string)
 		return nil, errors.NewRateLimitError("cloudflare", err)
 	}
 
+	body, err := p.fetch(ctx, method, path, p.etags != nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfResp cfResponse
+	if err := json.Unmarshal(body, &cfResp); err != nil {
+		return nil, errors.NewInternalError("cloudflare", fmt.Errorf("failed to parse response: %w", err))
+	}
+
+	if !cfResp.Success && len(cfResp.Errors) > 0 {
+		return nil, errors.NewNetworkError("cloudflare", fmt.Errorf("API error: %s", cfResp.Errors[0].Message))
+	}
+
+	return &cfResp, nil
+}
+
+// fetch sends a request and returns the response body. With conditional
+// set, a GET whose path has a cached ETag is sent with If-None-Match and a
+// 304 answered with the cached body, so unchanged listings cost no
+// transfer between refreshes.
+func (p *CloudflareProvider) fetch(ctx context.Context, method, path string, conditional bool) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, nil)
 	if err != nil {
 		return nil, errors.NewInternalError("cloudflare", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+p.apiToken)
 	req.Header.Set("Content-Type", "application/json")
+	if conditional {
+		p.etags.Prepare(req)
+	}
 
 	resp, err := p.client.Do(req)
 	if err != nil {
@@ -221,16 +343,15 @@ func (p *CloudflareProvider) doRequest(ctx context.Context, method, path string)
 		return nil, errors.NewNetworkError("cloudflare", err)
 	}
 
-	var cfResp cfResponse
-	if err := json.Unmarshal(body, &cfResp); err != nil {
-		return nil, errors.NewInternalError("cloudflare", fmt.Errorf("failed to parse response: %w", err))
-	}
-
-	if !cfResp.Success && len(cfResp.Errors) > 0 {
-		return nil, errors.NewNetworkError("cloudflare", fmt.Errorf("API error: %s", cfResp.Errors[0].Message))
+	if conditional {
+		cached, ok := p.etags.Resolve(req, resp, body)
+		if !ok {
+			// 304 for an entry dropped since the request was prepared
+			return p.fetch(ctx, method, path, false)
+		}
+		body = cached
 	}
-
-	return &cfResp, nil
+	return body, nil
 }
 
 func (p *CloudflareProvider) listWorkers(ctx context.Context) ([]provider.Resource, error) {
@@ -265,21 +386,58 @@ func (p *CloudflareProvider) listWorkers(ctx context.Context) ([]provider.Resour
 	return resources, nil
 }
 
-func (p *CloudflareProvider) listR2Buckets(ctx context.Context) ([]provider.Resource, error) {
+// GetResource fetches a single Worker by script name
+func (p *CloudflareProvider) GetResource(ctx context.Context, id string) (*provider.Resource, error) {
 	if p.accountID == "" {
-		return nil, errors.NewValidationError("cloudflare", "account_id required for R2")
+		return nil, errors.NewValidationError("cloudflare", "account_id required for Workers")
 	}
 
-	cfResp, err := p.doRequest(ctx, "GET", "/accounts/"+p.accountID+"/r2/buckets")
+	cfResp, err := p.doRequest(ctx, "GET", "/accounts/"+p.accountID+"/workers/services/"+url.PathEscape(id))
 	if err != nil {
 		return nil, err
 	}
+	if !cfResp.Success || len(cfResp.Result) == 0 || string(cfResp.Result) == "null" {
+		return nil, errors.NewNotFoundError("cloudflare", id)
+	}
 
-	var buckets []cfR2Bucket
-	if err := json.Unmarshal(cfResp.Result, &buckets); err != nil {
+	var service struct {
+		ID                 string    `json:"id"`
+		CreatedOn          time.Time `json:"created_on"`
+		ModifiedOn         time.Time `json:"modified_on"`
+		DefaultEnvironment struct {
+			Environment string `json:"environment"`
+		} `json:"default_environment"`
+	}
+	if err := json.Unmarshal(cfResp.Result, &service); err != nil {
 		return nil, errors.NewInternalError("cloudflare", err)
 	}
 
+	resource := &provider.Resource{
+		ID:        service.ID,
+		Name:      service.ID,
+		Type:      "workers",
+		Provider:  "cloudflare",
+		Region:    "global",
+		Status:    "active",
+		CreatedAt: service.CreatedOn,
+		UpdatedAt: service.ModifiedOn,
+	}
+	if env := service.DefaultEnvironment.Environment; env != "" {
+		resource.Tags = map[string]string{"environment": env}
+	}
+	return resource, nil
+}
+
+func (p *CloudflareProvider) listR2Buckets(ctx context.Context) ([]provider.Resource, error) {
+	if p.accountID == "" {
+		return nil, errors.NewValidationError("cloudflare", "account_id required for R2")
+	}
+
+	buckets, err := p.fetchR2Buckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	resources := make([]provider.Resource, 0, len(buckets))
 	for _, b := range buckets {
 		resources = append(resources, provider.Resource{
@@ -296,6 +454,118 @@ func (p *CloudflareProvider) listR2Buckets(ctx context.Context) ([]provider.Reso
 	return resources, nil
 }
 
+// fetchR2Buckets returns the account's R2 buckets
+func (p *CloudflareProvider) fetchR2Buckets(ctx context.Context) ([]cfR2Bucket, error) {
+	cfResp, err := p.doRequest(ctx, "GET", "/accounts/"+p.accountID+"/r2/buckets")
+	if err != nil {
+		return nil, err
+	}
+
+	var list cfR2BucketList
+	if err := json.Unmarshal(cfResp.Result, &list); err == nil {
+		return list.Buckets, nil
+	}
+	var buckets []cfR2Bucket
+	if err := json.Unmarshal(cfResp.Result, &buckets); err != nil {
+		return nil, errors.NewInternalError("cloudflare", err)
+	}
+	return buckets, nil
+}
+
+// ListBuckets returns R2 buckets with their current object count and size,
+// plus request counts and size change over the last day when analytics are
+// available. Buckets whose usage cannot be read are still listed, with zero
+// usage.
+func (p *CloudflareProvider) ListBuckets(ctx context.Context) ([]provider.Bucket, error) {
+	if p.accountID == "" {
+		return nil, errors.NewValidationError("cloudflare", "account_id required for R2")
+	}
+
+	buckets, err := p.fetchR2Buckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Analytics need a token with Account Analytics read; without it the
+	// listing still shows current usage
+	analytics, err := p.queryR2Usage(ctx, "")
+	if err != nil {
+		analytics = nil
+	}
+
+	result := make([]provider.Bucket, 0, len(buckets))
+	for _, b := range buckets {
+		bucket := provider.Bucket{
+			Resource: provider.Resource{
+				ID:        b.Name,
+				Name:      b.Name,
+				Type:      "r2",
+				Provider:  "cloudflare",
+				Region:    "global",
+				Status:    "active",
+				CreatedAt: b.CreationDate,
+			},
+			StorageClass: "Standard",
+		}
+		if usage, err := p.bucketUsage(ctx, b.Name); err == nil {
+			bucket.SizeBytes = usage.TotalSizeBytes
+			bucket.ObjectCount = usage.ObjectCount
+			bucket.UpdatedAt = usage.Timestamp
+			if analytics != nil {
+				bucket.Metrics = withR2Usage(usage, analytics[b.Name])
+			}
+		}
+		result = append(result, bucket)
+	}
+
+	return result, nil
+}
+
+// GetStorageMetrics returns the object count and stored bytes (payload plus
+// metadata) of an R2 bucket, with its request counts and size change over
+// the last day when analytics are available
+func (p *CloudflareProvider) GetStorageMetrics(ctx context.Context, bucketID string) (*metrics.StorageMetrics, error) {
+	usage, err := p.bucketUsage(ctx, bucketID)
+	if err != nil {
+		return nil, err
+	}
+	if analytics, err := p.queryR2Usage(ctx, bucketID); err == nil {
+		usage = withR2Usage(usage, analytics[bucketID])
+	}
+	return usage, nil
+}
+
+// bucketUsage returns an R2 bucket's current object count and stored bytes
+// from the usage endpoint
+func (p *CloudflareProvider) bucketUsage(ctx context.Context, bucketID string) (*metrics.StorageMetrics, error) {
+	if p.accountID == "" {
+		return nil, errors.NewValidationError("cloudflare", "account_id required for R2")
+	}
+
+	cfResp, err := p.doRequest(ctx, "GET", "/accounts/"+p.accountID+"/r2/buckets/"+url.PathEscape(bucketID)+"/usage")
+	if err != nil {
+		return nil, err
+	}
+
+	var usage cfR2Usage
+	if err := json.Unmarshal(cfResp.Result, &usage); err != nil {
+		return nil, errors.NewInternalError("cloudflare", fmt.Errorf("failed to parse R2 usage: %w", err))
+	}
+
+	timestamp := usage.End
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return &metrics.StorageMetrics{
+		ResourceID:     bucketID,
+		Provider:       "cloudflare",
+		Timestamp:      timestamp,
+		TotalSizeBytes: int64(usage.PayloadSize + usage.MetadataSize),
+		ObjectCount:    int64(usage.ObjectCount),
+	}, nil
+}
+
 func (p *CloudflareProvider) listD1Databases(ctx context.Context) ([]provider.Resource, error) {
 	if p.accountID == "" {
 		return nil, errors.NewValidationError("cloudflare", "account_id required for D1")
@@ -0,0 +1,181 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/errors"
+	"github.com/afterdarksys/cloudtop/internal/metrics"
+)
+
+// r2UsageWindow is the period R2 request counts and size change are
+// reported over
+const r2UsageWindow = 24 * time.Hour
+
+// r2UsageQuery reads R2 operations per bucket and action, and storage
+// samples per bucket, from the GraphQL Analytics API
+const r2UsageQuery = `query($accountTag: string!, $opsFilter: AccountR2OperationsAdaptiveGroupsFilter_InputObject, $storageFilter: AccountR2StorageAdaptiveGroupsFilter_InputObject) {
+  viewer {
+    accounts(filter: {accountTag: $accountTag}) {
+      r2OperationsAdaptiveGroups(limit: 10000, filter: $opsFilter) {
+        dimensions { bucketName actionType }
+        sum { requests responseObjectSize }
+      }
+      r2StorageAdaptiveGroups(limit: 10000, filter: $storageFilter, orderBy: [datetime_ASC]) {
+        dimensions { bucketName datetime }
+        max { payloadSize metadataSize objectCount }
+      }
+    }
+  }
+}`
+
+type cfR2OperationsGroup struct {
+	Dimensions struct {
+		BucketName string `json:"bucketName"`
+		ActionType string `json:"actionType"`
+	} `json:"dimensions"`
+	Sum struct {
+		Requests           int64 `json:"requests"`
+		ResponseObjectSize int64 `json:"responseObjectSize"`
+	} `json:"sum"`
+}
+
+type cfR2StorageGroup struct {
+	Dimensions struct {
+		BucketName string    `json:"bucketName"`
+		Datetime   time.Time `json:"datetime"`
+	} `json:"dimensions"`
+	Max struct {
+		PayloadSize  int64 `json:"payloadSize"`
+		MetadataSize int64 `json:"metadataSize"`
+		ObjectCount  int64 `json:"objectCount"`
+	} `json:"max"`
+}
+
+type cfR2GraphQLResponse struct {
+	Data struct {
+		Viewer struct {
+			Accounts []struct {
+				R2OperationsAdaptiveGroups []cfR2OperationsGroup `json:"r2OperationsAdaptiveGroups"`
+				R2StorageAdaptiveGroups    []cfR2StorageGroup    `json:"r2StorageAdaptiveGroups"`
+			} `json:"accounts"`
+		} `json:"viewer"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// r2ActionKinds groups R2 action types into the request counts of
+// StorageMetrics. Bucket configuration actions are not counted.
+var r2ActionKinds = map[string]string{
+	"GetObject":               "get",
+	"HeadObject":              "get",
+	"PutObject":               "put",
+	"CopyObject":              "put",
+	"CreateMultipartUpload":   "put",
+	"UploadPart":              "put",
+	"UploadPartCopy":          "put",
+	"CompleteMultipartUpload": "put",
+	"DeleteObject":            "delete",
+	"DeleteObjects":           "delete",
+	"AbortMultipartUpload":    "delete",
+	"ListObjects":             "list",
+	"ListObjectsV2":           "list",
+	"ListMultipartUploads":    "list",
+	"ListParts":               "list",
+}
+
+// queryR2Usage returns R2 usage over the last r2UsageWindow keyed by bucket
+// name, for one bucket when bucket is set or every bucket with activity.
+// Size and object count are the latest samples, and SizeChangeBytes is the
+// change since the first sample in the window.
+func (p *CloudflareProvider) queryR2Usage(ctx context.Context, bucket string) (map[string]*metrics.StorageMetrics, error) {
+	if p.accountID == "" {
+		return nil, errors.NewValidationError("cloudflare", "account_id required for R2")
+	}
+
+	end := time.Now().UTC().Truncate(time.Minute)
+	start := end.Add(-r2UsageWindow)
+	filter := func() map[string]interface{} {
+		f := map[string]interface{}{
+			"datetime_geq": start.Format(time.RFC3339),
+			"datetime_lt":  end.Format(time.RFC3339),
+		}
+		if bucket != "" {
+			f["bucketName"] = bucket
+		}
+		return f
+	}
+
+	var resp cfR2GraphQLResponse
+	err := p.doGraphQL(ctx, r2UsageQuery, map[string]interface{}{
+		"accountTag":    p.accountID,
+		"opsFilter":     filter(),
+		"storageFilter": filter(),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Errors) > 0 {
+		return nil, errors.NewNetworkError("cloudflare", fmt.Errorf("GraphQL error: %s", resp.Errors[0].Message))
+	}
+
+	usage := make(map[string]*metrics.StorageMetrics)
+	get := func(name string) *metrics.StorageMetrics {
+		m, ok := usage[name]
+		if !ok {
+			m = &metrics.StorageMetrics{ResourceID: name, Provider: "cloudflare", Timestamp: end}
+			usage[name] = m
+		}
+		return m
+	}
+
+	first := make(map[string]int64)
+	for _, account := range resp.Data.Viewer.Accounts {
+		for _, g := range account.R2OperationsAdaptiveGroups {
+			m := get(g.Dimensions.BucketName)
+			switch r2ActionKinds[g.Dimensions.ActionType] {
+			case "get":
+				m.GetRequests += g.Sum.Requests
+				m.BytesDownloaded += g.Sum.ResponseObjectSize
+			case "put":
+				m.PutRequests += g.Sum.Requests
+			case "delete":
+				m.DeleteRequests += g.Sum.Requests
+			case "list":
+				m.ListRequests += g.Sum.Requests
+			}
+		}
+
+		// Samples are in time order, so the last one is the current size
+		for _, g := range account.R2StorageAdaptiveGroups {
+			name := g.Dimensions.BucketName
+			size := g.Max.PayloadSize + g.Max.MetadataSize
+			if _, ok := first[name]; !ok {
+				first[name] = size
+			}
+			m := get(name)
+			m.TotalSizeBytes = size
+			m.ObjectCount = g.Max.ObjectCount
+			m.SizeChangeBytes = size - first[name]
+		}
+	}
+	return usage, nil
+}
+
+// withR2Usage copies request counts and size change from usage into m,
+// keeping m's size and object count
+func withR2Usage(m *metrics.StorageMetrics, usage *metrics.StorageMetrics) *metrics.StorageMetrics {
+	if usage == nil {
+		return m
+	}
+	m.GetRequests = usage.GetRequests
+	m.PutRequests = usage.PutRequests
+	m.DeleteRequests = usage.DeleteRequests
+	m.ListRequests = usage.ListRequests
+	m.BytesDownloaded = usage.BytesDownloaded
+	m.SizeChangeBytes = usage.SizeChangeBytes
+	return m
+}
@@ -0,0 +1,202 @@
+// Package external runs providers implemented as separate programs. The
+// program receives one JSON Request on stdin and writes one JSON Response to
+// stdout, so teams can add internal clouds without recompiling cloudtop.
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/errors"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// ProviderType is the registry name for external providers. Providers whose
+// config sets options.command are created with this type under their own
+// configured name.
+const ProviderType = "exec"
+
+// ProtocolVersion is sent with every request
+const ProtocolVersion = 1
+
+// DefaultTimeout bounds a single invocation unless options.timeout is set
+const DefaultTimeout = 30 * time.Second
+
+func init() {
+	provider.Register(ProviderType, func() provider.Provider {
+		return &ExecProvider{}
+	})
+}
+
+// Methods sent in Request.Method
+const (
+	MethodHealthCheck   = "health_check"
+	MethodListServices  = "list_services"
+	MethodListResources = "list_resources"
+)
+
+// Request is written to the program's stdin
+type Request struct {
+	Version     int                      `json:"version"`
+	Method      string                   `json:"method"`
+	Provider    string                   `json:"provider"`
+	Credentials map[string]string        `json:"credentials,omitempty"`
+	Options     map[string]interface{}   `json:"options,omitempty"`
+	Filter      *provider.ResourceFilter `json:"filter,omitempty"`
+}
+
+// Response is read from the program's stdout. A non-empty Error, or a
+// non-zero exit status, fails the call.
+type Response struct {
+	Resources []provider.Resource `json:"resources,omitempty"`
+	Services  []provider.Service  `json:"services,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+var _ provider.Provider = (*ExecProvider)(nil)
+
+// ExecProvider implements Provider by running an external program
+type ExecProvider struct {
+	config  *provider.ProviderConfig
+	name    string
+	command string
+	args    []string
+	timeout time.Duration
+}
+
+func (p *ExecProvider) Name() string {
+	return p.name
+}
+
+func (p *ExecProvider) Initialize(ctx context.Context, config *provider.ProviderConfig) error {
+	p.config = config
+	p.name = config.Name
+	if p.name == "" {
+		p.name = ProviderType
+	}
+
+	command, _ := config.Options["command"].(string)
+	if command == "" {
+		return errors.NewValidationError(p.name, "options.command is required for exec providers")
+	}
+	if strings.HasPrefix(command, "~") {
+		home, _ := os.UserHomeDir()
+		command = filepath.Join(home, command[1:])
+	}
+	p.command = command
+
+	switch args := config.Options["args"].(type) {
+	case []interface{}:
+		for _, a := range args {
+			s, ok := a.(string)
+			if !ok {
+				return errors.NewValidationError(p.name, "options.args must be a list of strings")
+			}
+			p.args = append(p.args, s)
+		}
+	case []string:
+		p.args = args
+	}
+
+	p.timeout = DefaultTimeout
+	if s, ok := config.Options["timeout"].(string); ok {
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return errors.NewValidationError(p.name, fmt.Sprintf("invalid options.timeout %q", s))
+		}
+		p.timeout = d
+	}
+
+	return nil
+}
+
+func (p *ExecProvider) HealthCheck(ctx context.Context) error {
+	_, err := p.call(ctx, MethodHealthCheck, nil)
+	return err
+}
+
+func (p *ExecProvider) ListServices(ctx context.Context) ([]provider.Service, error) {
+	resp, err := p.call(ctx, MethodListServices, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Services, nil
+}
+
+func (p *ExecProvider) ListResources(ctx context.Context, filter *provider.ResourceFilter) ([]provider.Resource, error) {
+	resp, err := p.call(ctx, MethodListResources, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Resources {
+		if resp.Resources[i].Provider == "" {
+			resp.Resources[i].Provider = p.name
+		}
+	}
+	return resp.Resources, nil
+}
+
+func (p *ExecProvider) GetMetrics(ctx context.Context, req *provider.MetricsRequest) (*provider.MetricsResponse, error) {
+	return &provider.MetricsResponse{
+		Provider:  p.name,
+		Metrics:   make(map[string]interface{}),
+		Timestamp: time.Now(),
+		Cached:    false,
+	}, nil
+}
+
+func (p *ExecProvider) Close() error {
+	return nil
+}
+
+// call runs the program once for method and decodes its response
+func (p *ExecProvider) call(ctx context.Context, method string, filter *provider.ResourceFilter) (*Response, error) {
+	input, err := json.Marshal(Request{
+		Version:     ProtocolVersion,
+		Method:      method,
+		Provider:    p.name,
+		Credentials: p.config.Credentials,
+		Options:     p.config.Options,
+		Filter:      filter,
+	})
+	if err != nil {
+		return nil, errors.NewInternalError(p.name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.command, p.args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, errors.NewNetworkError(p.name, fmt.Errorf("%s timed out after %v", p.command, p.timeout))
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, errors.NewInternalError(p.name, fmt.Errorf("%s %s: %w", p.command, method, err))
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, errors.NewInternalError(p.name, fmt.Errorf("invalid response from %s: %w", p.command, err))
+	}
+	if resp.Error != "" {
+		return nil, errors.NewInternalError(p.name, fmt.Errorf("%s", resp.Error))
+	}
+
+	return &resp, nil
+}
@@ -17,6 +17,12 @@ func init() {
 	})
 }
 
+// Compile-time checks for the optional interfaces GCPProvider implements
+var (
+	_ provider.ComputeProvider = (*GCPProvider)(nil)
+	_ provider.GPUProvider     = (*GCPProvider)(nil)
+)
+
 // GCPProvider implements Provider interface for GCP (stub)
 type GCPProvider struct {
 	config    *provider.ProviderConfig
@@ -59,9 +65,9 @@ func (p *GCPProvider) ListServices(ctx context.Context) ([]provider.Service, err
 	return []provider.Service{
 		{ID: "compute", Name: "Compute Engine", Type: "compute", Capabilities: []string{"compute", "metrics", "gpu"}},
 		{ID: "gke", Name: "Google Kubernetes Engine", Type: "containers", Capabilities: []string{"containers", "kubernetes"}},
-		{ID: "functions", Name: "Cloud Functions", Type: "serverless", Capabilities: []string{"compute", "metrics"}},
-		{ID: "gcs", Name: "Cloud Storage", Type: "storage", Capabilities: []string{"storage", "metrics"}},
-		{ID: "cloudsql", Name: "Cloud SQL", Type: "database", Capabilities: []string{"database", "metrics"}},
+		{ID: "functions", Name: "Cloud Functions", Type: "serverless", Capabilities: []string{"metrics"}},
+		{ID: "gcs", Name: "Cloud Storage", Type: "storage", Capabilities: []string{"metrics"}},
+		{ID: "cloudsql", Name: "Cloud SQL", Type: "database", Capabilities: []string{"metrics"}},
 	}, nil
 }
 
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
 
 	"github.com/afterdarksys/cloudtop/internal/errors"
 	"github.com/afterdarksys/cloudtop/internal/metrics"
 	"github.com/afterdarksys/cloudtop/internal/provider"
+	"github.com/afterdarksys/cloudtop/pkg/httpclient"
 	"github.com/afterdarksys/cloudtop/pkg/ratelimit"
 )
 
@@ -20,16 +23,29 @@ func init() {
 	})
 }
 
+// Compile-time checks for the optional interfaces NeonProvider implements
+var (
+	_ provider.DatabaseProvider = (*NeonProvider)(nil)
+	_ provider.ResourceGetter   = (*NeonProvider)(nil)
+)
+
 // NeonProvider implements the Provider and DatabaseProvider interfaces
 type NeonProvider struct {
 	config   *provider.ProviderConfig
 	apiKey   string
 	client   *http.Client
 	limiter  *ratelimit.Limiter
+
+	// concurrency bounds the per-project requests in flight
+	concurrency int
 }
 
 const baseURL = "https://console.neon.tech/api/v2"
 
+// defaultConcurrency is the number of projects whose branches or endpoints
+// are fetched at once, unless the "concurrency" option overrides it
+const defaultConcurrency = 4
+
 func (p *NeonProvider) Name() string {
 	return "neon"
 }
@@ -45,8 +61,15 @@ func (p *NeonProvider) Initialize(ctx context.Context, config *provider.Provider
 	p.apiKey = apiKey
 
 	// Create HTTP client
-	p.client = &http.Client{
-		Timeout: 30 * time.Second,
+	client, err := httpclient.New(config.HTTP)
+	if err != nil {
+		return errors.NewValidationError("neon", err.Error())
+	}
+	p.client = client
+
+	p.concurrency = defaultConcurrency
+	if n, ok := config.Options["concurrency"].(float64); ok && n >= 1 {
+		p.concurrency = int(n)
 	}
 
 	// Set up rate limiter
@@ -78,7 +101,7 @@ func (p *NeonProvider) ListServices(ctx context.Context) ([]provider.Service, er
 	return []provider.Service{
 		{ID: "projects", Name: "Neon Projects", Type: "database", Capabilities: []string{"database", "metrics"}},
 		{ID: "branches", Name: "Database Branches", Type: "database", Capabilities: []string{"database", "branching"}},
-		{ID: "endpoints", Name: "Compute Endpoints", Type: "compute", Capabilities: []string{"compute", "metrics"}},
+		{ID: "endpoints", Name: "Compute Endpoints", Type: "compute", Capabilities: []string{"metrics"}},
 	}, nil
 }
 
@@ -92,7 +115,7 @@ func (p *NeonProvider) ListResources(ctx context.Context, filter *provider.Resou
 	}
 
 	for _, proj := range projects {
-		resources = append(resources, provider.Resource{
+		resource := provider.Resource{
 			ID:        proj.ID,
 			Name:      proj.Name,
 			Type:      "project",
@@ -101,21 +124,27 @@ func (p *NeonProvider) ListResources(ctx context.Context, filter *provider.Resou
 			Status:    "active",
 			CreatedAt: proj.CreatedAt,
 			UpdatedAt: proj.UpdatedAt,
-		})
+		}
+		if filter.MatchesCreatedAfter(resource) {
+			resources = append(resources, resource)
+		}
 	}
 
 	// List endpoints for each project
-	for _, proj := range projects {
+	endpointsByProject := make([][]neonEndpoint, len(projects))
+	p.forEachProject(ctx, projects, func(i int, proj neonProject) {
 		endpoints, err := p.listEndpoints(ctx, proj.ID)
-		if err != nil {
-			continue
+		if err == nil {
+			endpointsByProject[i] = endpoints
 		}
+	})
+	for _, endpoints := range endpointsByProject {
 		for _, ep := range endpoints {
 			status := "active"
 			if ep.Disabled {
 				status = "disabled"
 			}
-			resources = append(resources, provider.Resource{
+			resource := provider.Resource{
 				ID:        ep.ID,
 				Name:      ep.ID,
 				Type:      "endpoint",
@@ -124,7 +153,13 @@ func (p *NeonProvider) ListResources(ctx context.Context, filter *provider.Resou
 				Status:    status,
 				CreatedAt: ep.CreatedAt,
 				UpdatedAt: ep.UpdatedAt,
-			})
+			}
+			if ep.Host != "" {
+				resource.Tags = map[string]string{"endpoint": ep.Host}
+			}
+			if filter.MatchesCreatedAfter(resource) {
+				resources = append(resources, resource)
+			}
 		}
 	}
 
@@ -154,6 +189,26 @@ func (p *NeonProvider) Close() error {
 	return nil
 }
 
+// GetResource fetches a single project by ID
+func (p *NeonProvider) GetResource(ctx context.Context, id string) (*provider.Resource, error) {
+	proj, err := p.getProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider.Resource{
+		ID:        proj.ID,
+		Name:      proj.Name,
+		Type:      "project",
+		Provider:  "neon",
+		Region:    proj.RegionID,
+		Status:    "active",
+		Tags:      map[string]string{"pg_version": proj.PgVersion},
+		CreatedAt: proj.CreatedAt,
+		UpdatedAt: proj.UpdatedAt,
+	}, nil
+}
+
 // DatabaseProvider interface
 func (p *NeonProvider) ListDatabases(ctx context.Context) ([]provider.Database, error) {
 	var databases []provider.Database
@@ -163,13 +218,16 @@ func (p *NeonProvider) ListDatabases(ctx context.Context) ([]provider.Database,
 		return nil, err
 	}
 
-	for _, proj := range projects {
+	branchesByProject := make([][]neonBranch, len(projects))
+	p.forEachProject(ctx, projects, func(i int, proj neonProject) {
 		branches, err := p.listBranches(ctx, proj.ID)
-		if err != nil {
-			continue
+		if err == nil {
+			branchesByProject[i] = branches
 		}
+	})
 
-		for _, branch := range branches {
+	for i, proj := range projects {
+		for _, branch := range branchesByProject[i] {
 			databases = append(databases, provider.Database{
 				Resource: provider.Resource{
 					ID:        branch.ID,
@@ -199,6 +257,36 @@ func (p *NeonProvider) GetDatabaseMetrics(ctx context.Context, dbID string) (*me
 	}, nil
 }
 
+// forEachProject calls fn for every project using at most p.concurrency
+// goroutines. Requests still pass through the shared rate limiter. fn gets
+// the project's index so callers can store results in per-project slots,
+// which keeps the final output in project order without locking.
+func (p *NeonProvider) forEachProject(ctx context.Context, projects []neonProject, fn func(i int, proj neonProject)) {
+	workers := p.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, proj := range projects {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+
+		wg.Add(1)
+		go func(i int, proj neonProject) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i, proj)
+		}(i, proj)
+	}
+	wg.Wait()
+}
+
 // API types
 type neonProject struct {
 	ID              string    `json:"id"`
@@ -238,6 +326,7 @@ type neonConsumption struct {
 
 // API response wrapper
 type neonResponse struct {
+	Project   *neonProject   `json:"project,omitempty"`
 	Projects  []neonProject  `json:"projects,omitempty"`
 	Branches  []neonBranch   `json:"branches,omitempty"`
 	Endpoints []neonEndpoint `json:"endpoints,omitempty"`
@@ -266,6 +355,9 @@ func (p *NeonProvider) doRequest(ctx context.Context, method, path string) ([]by
 		return nil, errors.NewNetworkError("neon", err)
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.NewNotFoundError("neon", path)
+	}
 	if resp.StatusCode >= 400 {
 		return nil, errors.NewNetworkError("neon", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body)))
 	}
@@ -287,6 +379,23 @@ func (p *NeonProvider) listProjects(ctx context.Context) ([]neonProject, error)
 	return resp.Projects, nil
 }
 
+func (p *NeonProvider) getProject(ctx context.Context, projectID string) (*neonProject, error) {
+	body, err := p.doRequest(ctx, "GET", "/projects/"+url.PathEscape(projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp neonResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.NewInternalError("neon", err)
+	}
+	if resp.Project == nil {
+		return nil, errors.NewNotFoundError("neon", projectID)
+	}
+
+	return resp.Project, nil
+}
+
 func (p *NeonProvider) listBranches(ctx context.Context, projectID string) ([]neonBranch, error) {
 	body, err := p.doRequest(ctx, "GET", "/projects/"+projectID+"/branches")
 	if err != nil {
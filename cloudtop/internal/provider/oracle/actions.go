@@ -0,0 +1,61 @@
+package oracle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/afterdarksys/cloudtop/internal/errors"
+)
+
+// OCI instance actions. Stop and reboot are the graceful variants: OCI
+// asks the OS to shut down and forces it after its own timeout.
+const (
+	ociActionStart  = "START"
+	ociActionStop   = "SOFTSTOP"
+	ociActionReboot = "SOFTRESET"
+)
+
+// StartInstance starts a stopped instance
+func (p *OracleProvider) StartInstance(ctx context.Context, instanceID string) error {
+	return p.instanceAction(ctx, instanceID, ociActionStart)
+}
+
+// StopInstance gracefully shuts an instance down
+func (p *OracleProvider) StopInstance(ctx context.Context, instanceID string) error {
+	return p.instanceAction(ctx, instanceID, ociActionStop)
+}
+
+// RebootInstance gracefully restarts an instance
+func (p *OracleProvider) RebootInstance(ctx context.Context, instanceID string) error {
+	return p.instanceAction(ctx, instanceID, ociActionReboot)
+}
+
+// GetInstanceState returns the instance's lifecycle state in lower case
+func (p *OracleProvider) GetInstanceState(ctx context.Context, instanceID string) (string, error) {
+	body, err := p.doRequest(ctx, "GET", p.instanceURL(instanceID))
+	if err != nil {
+		return "", err
+	}
+
+	var inst ociInstance
+	if err := json.Unmarshal(body, &inst); err != nil {
+		return "", errors.NewInternalError("oracle", err)
+	}
+	return strings.ToLower(inst.LifecycleState), nil
+}
+
+// instanceAction posts an InstanceAction for the instance
+func (p *OracleProvider) instanceAction(ctx context.Context, instanceID, action string) error {
+	if !strings.HasPrefix(instanceID, "ocid1.instance.") {
+		return errors.NewValidationError("oracle", fmt.Sprintf("%q is not an instance OCID", instanceID))
+	}
+	_, err := p.doRequestWithBody(ctx, "POST", p.instanceURL(instanceID)+"?action="+action, []byte{})
+	return err
+}
+
+func (p *OracleProvider) instanceURL(instanceID string) string {
+	return fmt.Sprintf("%s/20160918/instances/%s", p.getBaseURL("iaas"), url.PathEscape(instanceID))
+}
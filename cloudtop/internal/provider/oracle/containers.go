@@ -0,0 +1,229 @@
+package oracle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/errors"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// Resource types listed under the containers service
+const (
+	typeOKECluster        = "oke_cluster"
+	typeOKENodePool       = "oke_node_pool"
+	typeContainerInstance = "container_instance"
+)
+
+// Tag keys carrying container details, since Resource has no fields for them
+const (
+	tagKubernetesVersion = "kubernetes_version"
+	tagNodeCount         = "node_count"
+	tagNodeShape         = "node_shape"
+	tagClusterID         = "cluster_id"
+	tagContainerCount    = "container_count"
+	tagShape             = "shape"
+)
+
+// OKE and Container Instances API types
+type ociCluster struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	KubernetesVersion string `json:"kubernetesVersion"`
+	LifecycleState    string `json:"lifecycleState"`
+	Metadata          struct {
+		TimeCreated *time.Time `json:"timeCreated"`
+	} `json:"metadata"`
+
+	FreeformTags map[string]string                 `json:"freeformTags"`
+	DefinedTags  map[string]map[string]interface{} `json:"definedTags"`
+}
+
+type ociNodePool struct {
+	ID                string   `json:"id"`
+	ClusterID         string   `json:"clusterId"`
+	Name              string   `json:"name"`
+	KubernetesVersion string   `json:"kubernetesVersion"`
+	NodeShape         string   `json:"nodeShape"`
+	LifecycleState    string   `json:"lifecycleState"`
+	QuantityPerSubnet int      `json:"quantityPerSubnet"`
+	SubnetIDs         []string `json:"subnetIds"`
+	NodeConfigDetails *struct {
+		Size int `json:"size"`
+	} `json:"nodeConfigDetails"`
+
+	FreeformTags map[string]string                 `json:"freeformTags"`
+	DefinedTags  map[string]map[string]interface{} `json:"definedTags"`
+}
+
+// size returns the pool's node count, which older pools report per subnet
+func (np ociNodePool) size() int {
+	if np.NodeConfigDetails != nil {
+		return np.NodeConfigDetails.Size
+	}
+	return np.QuantityPerSubnet * len(np.SubnetIDs)
+}
+
+type ociContainerInstance struct {
+	ID             string    `json:"id"`
+	DisplayName    string    `json:"displayName"`
+	Shape          string    `json:"shape"`
+	ContainerCount int       `json:"containerCount"`
+	LifecycleState string    `json:"lifecycleState"`
+	TimeCreated    time.Time `json:"timeCreated"`
+
+	FreeformTags map[string]string                 `json:"freeformTags"`
+	DefinedTags  map[string]map[string]interface{} `json:"definedTags"`
+}
+
+// wantsContainers reports whether the filter asks for any container type
+func wantsContainers(filter *provider.ResourceFilter) bool {
+	for _, t := range []string{typeOKECluster, typeOKENodePool, typeContainerInstance} {
+		if filter.MatchesType(provider.Resource{Type: t}) {
+			return true
+		}
+	}
+	return false
+}
+
+// listContainerResources lists OKE clusters with their node pools, then
+// container instances. Kubernetes version and node counts are reported in
+// the kubernetes_version and node_count tags; a cluster's node count is
+// the total across its pools.
+func (p *OracleProvider) listContainerResources(ctx context.Context) ([]provider.Resource, error) {
+	clusters, err := p.listClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := p.listNodePools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]int)
+	var resources []provider.Resource
+	for _, np := range pools {
+		if strings.EqualFold(np.LifecycleState, "DELETED") {
+			continue
+		}
+		nodes[np.ClusterID] += np.size()
+
+		tags := mergeTags(np.FreeformTags, np.DefinedTags)
+		tags[tagKubernetesVersion] = np.KubernetesVersion
+		tags[tagNodeCount] = strconv.Itoa(np.size())
+		tags[tagNodeShape] = np.NodeShape
+		tags[tagClusterID] = np.ClusterID
+		resources = append(resources, provider.Resource{
+			ID:       np.ID,
+			Name:     np.Name,
+			Type:     typeOKENodePool,
+			Provider: "oracle",
+			Region:   p.region,
+			Status:   strings.ToLower(np.LifecycleState),
+			Tags:     tags,
+		})
+	}
+
+	clusterResources := make([]provider.Resource, 0, len(clusters))
+	for _, c := range clusters {
+		if strings.EqualFold(c.LifecycleState, "DELETED") {
+			continue
+		}
+		tags := mergeTags(c.FreeformTags, c.DefinedTags)
+		tags[tagKubernetesVersion] = c.KubernetesVersion
+		tags[tagNodeCount] = strconv.Itoa(nodes[c.ID])
+		r := provider.Resource{
+			ID:       c.ID,
+			Name:     c.Name,
+			Type:     typeOKECluster,
+			Provider: "oracle",
+			Region:   p.region,
+			Status:   strings.ToLower(c.LifecycleState),
+			Tags:     tags,
+		}
+		if c.Metadata.TimeCreated != nil {
+			r.CreatedAt = *c.Metadata.TimeCreated
+		}
+		clusterResources = append(clusterResources, r)
+	}
+	resources = append(clusterResources, resources...)
+
+	instances, err := p.listContainerInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ci := range instances {
+		tags := mergeTags(ci.FreeformTags, ci.DefinedTags)
+		tags[tagContainerCount] = strconv.Itoa(ci.ContainerCount)
+		tags[tagShape] = ci.Shape
+		resources = append(resources, provider.Resource{
+			ID:        ci.ID,
+			Name:      ci.DisplayName,
+			Type:      typeContainerInstance,
+			Provider:  "oracle",
+			Region:    p.region,
+			Status:    strings.ToLower(ci.LifecycleState),
+			Tags:      tags,
+			CreatedAt: ci.TimeCreated,
+		})
+	}
+
+	return resources, nil
+}
+
+func (p *OracleProvider) listClusters(ctx context.Context) ([]ociCluster, error) {
+	url := fmt.Sprintf("%s/20180222/clusters?compartmentId=%s",
+		p.getBaseURL("containerengine"), p.compartmentID)
+
+	body, err := p.doRequest(ctx, "GET", url)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []ociCluster
+	if err := json.Unmarshal(body, &clusters); err != nil {
+		return nil, errors.NewInternalError("oracle", err)
+	}
+
+	return clusters, nil
+}
+
+func (p *OracleProvider) listNodePools(ctx context.Context) ([]ociNodePool, error) {
+	url := fmt.Sprintf("%s/20180222/nodePools?compartmentId=%s",
+		p.getBaseURL("containerengine"), p.compartmentID)
+
+	body, err := p.doRequest(ctx, "GET", url)
+	if err != nil {
+		return nil, err
+	}
+
+	var pools []ociNodePool
+	if err := json.Unmarshal(body, &pools); err != nil {
+		return nil, errors.NewInternalError("oracle", err)
+	}
+
+	return pools, nil
+}
+
+func (p *OracleProvider) listContainerInstances(ctx context.Context) ([]ociContainerInstance, error) {
+	url := fmt.Sprintf("%s/20210415/containerInstances?compartmentId=%s",
+		p.getBaseURL("compute-containers"), p.compartmentID)
+
+	body, err := p.doRequest(ctx, "GET", url)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items []ociContainerInstance `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, errors.NewInternalError("oracle", err)
+	}
+
+	return result.Items, nil
+}
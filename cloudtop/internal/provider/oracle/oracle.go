@@ -2,6 +2,7 @@ package oracle
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/rand"
@@ -18,12 +19,14 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/afterdarksys/cloudtop/internal/errors"
 	"github.com/afterdarksys/cloudtop/internal/metrics"
 	"github.com/afterdarksys/cloudtop/internal/provider"
+	"github.com/afterdarksys/cloudtop/pkg/httpclient"
 	"github.com/afterdarksys/cloudtop/pkg/ratelimit"
 )
 
@@ -31,6 +34,7 @@ func init() {
 	provider.Register("oracle", func() provider.Provider {
 		return &OracleProvider{}
 	})
+	provider.RegisterServiceTypes("containers", typeOKECluster, typeOKENodePool, typeContainerInstance)
 }
 
 // OCI pricing table (hourly rates in USD for commercial regions)
@@ -87,6 +91,13 @@ func getShapePricing(shape string) float64 {
 	return 0.05
 }
 
+// Compile-time checks for the optional interfaces OracleProvider implements
+var (
+	_ provider.ComputeProvider = (*OracleProvider)(nil)
+	_ provider.GPUProvider     = (*OracleProvider)(nil)
+	_ provider.ComputeActions  = (*OracleProvider)(nil)
+)
+
 // OracleProvider implements Provider and GPUProvider interfaces
 type OracleProvider struct {
 	config        *provider.ProviderConfig
@@ -149,10 +160,18 @@ func (p *OracleProvider) Initialize(ctx context.Context, config *provider.Provid
 		IdleConnTimeout:     90 * time.Second,
 		TLSHandshakeTimeout: 10 * time.Second,
 	}
-	p.client = &http.Client{
-		Transport: transport,
-		Timeout:   60 * time.Second,
+	httpCfg := httpclient.Config{Timeout: 60 * time.Second}
+	if config.HTTP != nil {
+		httpCfg = *config.HTTP
+		if httpCfg.Timeout <= 0 {
+			httpCfg.Timeout = 60 * time.Second
+		}
+	}
+	client, err := httpclient.NewWithTransport(&httpCfg, transport)
+	if err != nil {
+		return errors.NewValidationError("oracle", err.Error())
 	}
+	p.client = client
 
 	// Set up rate limiter
 	if config.RateLimit != nil {
@@ -258,9 +277,9 @@ func (p *OracleProvider) ListServices(ctx context.Context) ([]provider.Service,
 	return []provider.Service{
 		{ID: "compute", Name: "Compute Instances", Type: "compute", Capabilities: []string{"compute", "metrics", "gpu"}},
 		{ID: "containers", Name: "Container Engine (OKE)", Type: "containers", Capabilities: []string{"containers", "kubernetes"}},
-		{ID: "autonomous_db", Name: "Autonomous Database", Type: "database", Capabilities: []string{"database", "metrics"}},
-		{ID: "object_storage", Name: "Object Storage", Type: "storage", Capabilities: []string{"storage", "metrics"}},
-		{ID: "functions", Name: "Functions", Type: "serverless", Capabilities: []string{"compute", "metrics"}},
+		{ID: "autonomous_db", Name: "Autonomous Database", Type: "database", Capabilities: []string{"metrics"}},
+		{ID: "object_storage", Name: "Object Storage", Type: "storage", Capabilities: []string{"metrics"}},
+		{ID: "functions", Name: "Functions", Type: "serverless", Capabilities: []string{"metrics"}},
 	}, nil
 }
 
@@ -277,6 +296,14 @@ func (p *OracleProvider) ListResources(ctx context.Context, filter *provider.Res
 		}
 	}
 
+	// List OKE clusters, their node pools and container instances
+	if !filter.Full(len(resources)) && wantsContainers(filter) {
+		containers, err := p.listContainerResources(ctx)
+		if err == nil {
+			resources = append(resources, containers...)
+		}
+	}
+
 	return resources, nil
 }
 
@@ -375,6 +402,29 @@ type ociInstance struct {
 	LifecycleState string    `json:"lifecycleState"`
 	Region         string    `json:"region"`
 	TimeCreated    time.Time `json:"timeCreated"`
+
+	FreeformTags map[string]string                 `json:"freeformTags"`
+	DefinedTags  map[string]map[string]interface{} `json:"definedTags"`
+}
+
+// tags merges the instance's freeform and defined tags
+func (inst ociInstance) tags() map[string]string {
+	return mergeTags(inst.FreeformTags, inst.DefinedTags)
+}
+
+// mergeTags merges freeform tags with defined tags, which are keyed as
+// "Namespace.key"
+func mergeTags(freeform map[string]string, defined map[string]map[string]interface{}) map[string]string {
+	tags := make(map[string]string, len(freeform))
+	for k, v := range freeform {
+		tags[k] = v
+	}
+	for ns, values := range defined {
+		for k, v := range values {
+			tags[ns+"."+k] = fmt.Sprint(v)
+		}
+	}
+	return tags
 }
 
 type ociShape struct {
@@ -393,17 +443,24 @@ func (p *OracleProvider) getBaseURL(service string) string {
 }
 
 func (p *OracleProvider) doRequest(ctx context.Context, method, requestURL string) ([]byte, error) {
+	return p.doRequestWithBody(ctx, method, requestURL, nil)
+}
+
+// doRequestWithBody sends a signed request. Authorization failures are
+// returned as permission errors; OCI reports them as 401, 403, or as 404
+// NotAuthorizedOrNotFound when the caller may not see the resource.
+func (p *OracleProvider) doRequestWithBody(ctx context.Context, method, requestURL string, reqBody []byte) ([]byte, error) {
 	if err := p.limiter.Wait(ctx); err != nil {
 		return nil, errors.NewRateLimitError("oracle", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, requestURL, nil)
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, errors.NewInternalError("oracle", err)
 	}
 
 	// Sign the request with OCI authentication
-	if err := p.signRequest(req); err != nil {
+	if err := p.signRequest(req, reqBody); err != nil {
 		return nil, errors.NewAuthError("oracle", err)
 	}
 
@@ -419,14 +476,22 @@ func (p *OracleProvider) doRequest(ctx context.Context, method, requestURL strin
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, errors.NewNetworkError("oracle", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body)))
+		apiErr := fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden,
+			resp.StatusCode == http.StatusNotFound && strings.Contains(string(body), "NotAuthorizedOrNotFound"):
+			return nil, errors.NewPermissionError("oracle", apiErr)
+		}
+		return nil, errors.NewNetworkError("oracle", apiErr)
 	}
 
 	return body, nil
 }
 
-// signRequest signs an HTTP request for OCI authentication
-func (p *OracleProvider) signRequest(req *http.Request) error {
+// signRequest signs an HTTP request for OCI authentication. Requests with
+// a body (POST, PUT, PATCH) also sign its length, type and SHA-256 digest,
+// as OCI requires.
+func (p *OracleProvider) signRequest(req *http.Request, body []byte) error {
 	// Required headers for GET requests
 	requiredHeaders := []string{"date", "(request-target)", "host"}
 
@@ -435,6 +500,15 @@ func (p *OracleProvider) signRequest(req *http.Request) error {
 	req.Header.Set("Date", dateStr)
 	req.Header.Set("Content-Type", "application/json")
 
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		digest := sha256.Sum256(body)
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		req.Header.Set("X-Content-Sha256", base64.StdEncoding.EncodeToString(digest[:]))
+		requiredHeaders = append(requiredHeaders, "content-length", "content-type", "x-content-sha256")
+	}
+
 	// Parse URL for host
 	parsedURL, err := url.Parse(req.URL.String())
 	if err != nil {
@@ -458,7 +532,7 @@ func (p *OracleProvider) signRequest(req *http.Request) error {
 		case "date":
 			value = fmt.Sprintf("%s: %s", header, dateStr)
 		default:
-			value = fmt.Sprintf("%s: %s", header, req.Header.Get(strings.Title(header)))
+			value = fmt.Sprintf("%s: %s", header, req.Header.Get(header))
 		}
 		signingParts = append(signingParts, value)
 	}
@@ -527,6 +601,7 @@ func (p *OracleProvider) listInstances(ctx context.Context, filter *provider.Res
 				Provider:  "oracle",
 				Region:    inst.Region,
 				Status:    strings.ToLower(inst.LifecycleState),
+				Tags:      inst.tags(),
 				CreatedAt: inst.TimeCreated,
 				HourlyRate: getShapePricing(inst.Shape),
 			},
@@ -537,6 +612,9 @@ func (p *OracleProvider) listInstances(ctx context.Context, filter *provider.Res
 		if filter != nil && len(filter.Status) > 0 && !contains(filter.Status, instance.Status) {
 			continue
 		}
+		if !filter.MatchesCreatedAfter(instance.Resource) {
+			continue
+		}
 
 		instances = append(instances, instance)
 	}
@@ -0,0 +1,652 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/config"
+	cterrors "github.com/afterdarksys/cloudtop/internal/errors"
+	"github.com/afterdarksys/cloudtop/internal/metrics"
+)
+
+// Recorded calls, keyed by these names in Recording.Calls
+const (
+	CallHealthCheck   = "health_check"
+	CallListServices  = "list_services"
+	CallListResources = "list_resources"
+	CallGetMetrics    = "get_metrics"
+
+	CallListInstances      = "list_instances"
+	CallGetInstanceMetrics = "get_instance_metrics"
+	CallListGPUInstances   = "list_gpu_instances"
+	CallGetGPUMetrics      = "get_gpu_metrics"
+	CallGetGPUAvailability = "get_gpu_availability"
+	CallListFunctions      = "list_functions"
+	CallGetFunctionMetrics = "get_function_metrics"
+	CallListBuckets        = "list_buckets"
+	CallGetStorageMetrics  = "get_storage_metrics"
+	CallListDatabases      = "list_databases"
+	CallGetDatabaseMetrics = "get_database_metrics"
+	CallListModels         = "list_models"
+	CallGetAIMetrics       = "get_ai_metrics"
+	CallListAIMetrics      = "list_ai_metrics"
+	CallGetResource        = "get_resource"
+	CallStartInstance      = "start_instance"
+	CallStopInstance       = "stop_instance"
+	CallRebootInstance     = "reboot_instance"
+	CallGetInstanceState   = "get_instance_state"
+)
+
+// Recording is one provider's recorded responses, stored as
+// <dir>/<provider>.json. Each call keeps its latest response. Calls taking
+// an ID are keyed by call and ID, e.g. "get_gpu_metrics:i-123"; filters are
+// not part of the key, so replay serves the same list whatever filter it is
+// asked with.
+type Recording struct {
+	Provider   string                  `json:"provider"`
+	RecordedAt time.Time               `json:"recorded_at"`
+	Calls      map[string]RecordedCall `json:"calls"`
+
+	// Capabilities and MaxMetricWindow are those of the recorded provider,
+	// so replay offers the same optional interfaces and metric limits
+	Capabilities    []string        `json:"capabilities,omitempty"`
+	MaxMetricWindow config.Duration `json:"max_metric_window,omitempty"`
+}
+
+// RecordedCall is a call's response: its result as JSON, or its error
+type RecordedCall struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+
+	// ErrorDetail is set when the error was a CloudtopError, so replay
+	// returns one of the same type and retryability
+	ErrorDetail *RecordedError `json:"error_detail,omitempty"`
+}
+
+// RecordedError is a recorded CloudtopError. The error it wrapped is kept
+// as text.
+type RecordedError struct {
+	Type      cterrors.ErrorType `json:"type"`
+	Provider  string             `json:"provider,omitempty"`
+	Message   string             `json:"message"`
+	Cause     string             `json:"cause,omitempty"`
+	Retryable bool               `json:"retryable"`
+}
+
+// recordError returns what is recorded for err
+func recordError(err error) RecordedCall {
+	entry := RecordedCall{Error: err.Error()}
+	var ctErr *cterrors.CloudtopError
+	if errors.As(err, &ctErr) {
+		entry.ErrorDetail = &RecordedError{
+			Type:      ctErr.Type,
+			Provider:  ctErr.Provider,
+			Message:   ctErr.Message,
+			Retryable: ctErr.Retryable,
+		}
+		if ctErr.Err != nil {
+			entry.ErrorDetail.Cause = ctErr.Err.Error()
+		}
+	}
+	return entry
+}
+
+// err returns the error a recorded call replays
+func (c RecordedCall) err() error {
+	if c.ErrorDetail == nil {
+		return errors.New(c.Error)
+	}
+	d := c.ErrorDetail
+	ctErr := &cterrors.CloudtopError{
+		Type:      d.Type,
+		Provider:  d.Provider,
+		Message:   d.Message,
+		Retryable: d.Retryable,
+	}
+	if d.Cause != "" {
+		ctErr.Err = errors.New(d.Cause)
+	}
+	return ctErr
+}
+
+// callKey returns the Recording.Calls key of a call for one ID
+func callKey(call, id string) string {
+	return call + ":" + id
+}
+
+// RecordingPath returns where the recording for a provider is stored
+func RecordingPath(dir, name string) string {
+	return filepath.Join(dir, name+".json")
+}
+
+// LoadRecording reads the recording for a provider from dir
+func LoadRecording(dir, name string) (*Recording, error) {
+	data, err := os.ReadFile(RecordingPath(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no recording for %s in %s", name, dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	var rec Recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to parse recording %s: %w", RecordingPath(dir, name), err)
+	}
+	return &rec, nil
+}
+
+// RecordedProviders returns the names of the providers recorded in dir,
+// sorted
+func RecordedProviders(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recordings: %w", err)
+	}
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		names = append(names, strings.TrimSuffix(filepath.Base(m), ".json"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+var (
+	_ ComputeActions     = (*RecordingProvider)(nil)
+	_ GPUProvider        = (*RecordingProvider)(nil)
+	_ ServerlessProvider = (*RecordingProvider)(nil)
+	_ StorageProvider    = (*RecordingProvider)(nil)
+	_ DatabaseProvider   = (*RecordingProvider)(nil)
+	_ AIProvider         = (*RecordingProvider)(nil)
+	_ ResourceGetter     = (*RecordingProvider)(nil)
+	_ MetricLimits       = (*RecordingProvider)(nil)
+	_ CapabilitySet      = (*RecordingProvider)(nil)
+)
+
+// RecordingProvider wraps a provider and saves each response it returns to
+// a recording that ReplayProvider can serve back. It has the methods of
+// every optional interface but, as a CapabilitySet, supports only those of
+// the wrapped provider; check for them with As.
+type RecordingProvider struct {
+	Provider
+	name string
+	dir  string
+
+	mu  sync.Mutex
+	rec Recording
+}
+
+// NewRecordingProvider records the responses of p, an initialized
+// provider, to its recording in dir under name
+func NewRecordingProvider(name string, p Provider, dir string) *RecordingProvider {
+	rec := Recording{Provider: name, Calls: make(map[string]RecordedCall), Capabilities: Capabilities(p)}
+	if limits, ok := p.(MetricLimits); ok {
+		rec.MaxMetricWindow = config.Duration(limits.MaxMetricWindow())
+	}
+	return &RecordingProvider{
+		Provider: p,
+		name:     name,
+		dir:      dir,
+		rec:      rec,
+	}
+}
+
+// SupportedCapabilities returns the wrapped provider's capabilities
+func (p *RecordingProvider) SupportedCapabilities() []string {
+	return p.rec.Capabilities
+}
+
+// MaxMetricWindow returns the wrapped provider's limit, or 0 for none
+func (p *RecordingProvider) MaxMetricWindow() time.Duration {
+	return p.rec.MaxMetricWindow.Duration()
+}
+
+func (p *RecordingProvider) HealthCheck(ctx context.Context) error {
+	err := p.Provider.HealthCheck(ctx)
+	return p.record(CallHealthCheck, nil, err)
+}
+
+func (p *RecordingProvider) ListServices(ctx context.Context) ([]Service, error) {
+	services, err := p.Provider.ListServices(ctx)
+	return services, p.record(CallListServices, services, err)
+}
+
+func (p *RecordingProvider) ListResources(ctx context.Context, filter *ResourceFilter) ([]Resource, error) {
+	resources, err := p.Provider.ListResources(ctx, filter)
+	return resources, p.record(CallListResources, resources, err)
+}
+
+func (p *RecordingProvider) GetMetrics(ctx context.Context, req *MetricsRequest) (*MetricsResponse, error) {
+	resp, err := p.Provider.GetMetrics(ctx, req)
+	return resp, p.record(CallGetMetrics, resp, err)
+}
+
+func (p *RecordingProvider) ListInstances(ctx context.Context, filter *InstanceFilter) ([]Instance, error) {
+	c, err := wrapped[ComputeProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := c.ListInstances(ctx, filter)
+	return instances, p.record(CallListInstances, instances, err)
+}
+
+func (p *RecordingProvider) GetInstanceMetrics(ctx context.Context, instanceID string) (*metrics.ComputeMetrics, error) {
+	c, err := wrapped[ComputeProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	m, err := c.GetInstanceMetrics(ctx, instanceID)
+	return m, p.record(callKey(CallGetInstanceMetrics, instanceID), m, err)
+}
+
+func (p *RecordingProvider) ListGPUInstances(ctx context.Context, filter *GPUFilter) ([]GPUInstance, error) {
+	g, err := wrapped[GPUProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := g.ListGPUInstances(ctx, filter)
+	return instances, p.record(CallListGPUInstances, instances, err)
+}
+
+func (p *RecordingProvider) GetGPUMetrics(ctx context.Context, instanceID string) (*metrics.GPUMetrics, error) {
+	g, err := wrapped[GPUProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	m, err := g.GetGPUMetrics(ctx, instanceID)
+	return m, p.record(callKey(CallGetGPUMetrics, instanceID), m, err)
+}
+
+func (p *RecordingProvider) GetGPUAvailability(ctx context.Context) ([]GPUOffering, error) {
+	g, err := wrapped[GPUProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	offerings, err := g.GetGPUAvailability(ctx)
+	return offerings, p.record(CallGetGPUAvailability, offerings, err)
+}
+
+func (p *RecordingProvider) ListFunctions(ctx context.Context, filter *FunctionFilter) ([]Function, error) {
+	s, err := wrapped[ServerlessProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	functions, err := s.ListFunctions(ctx, filter)
+	return functions, p.record(CallListFunctions, functions, err)
+}
+
+func (p *RecordingProvider) GetFunctionMetrics(ctx context.Context, functionID string) (*metrics.FunctionMetrics, error) {
+	s, err := wrapped[ServerlessProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.GetFunctionMetrics(ctx, functionID)
+	return m, p.record(callKey(CallGetFunctionMetrics, functionID), m, err)
+}
+
+func (p *RecordingProvider) ListBuckets(ctx context.Context) ([]Bucket, error) {
+	s, err := wrapped[StorageProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := s.ListBuckets(ctx)
+	return buckets, p.record(CallListBuckets, buckets, err)
+}
+
+func (p *RecordingProvider) GetStorageMetrics(ctx context.Context, bucketID string) (*metrics.StorageMetrics, error) {
+	s, err := wrapped[StorageProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.GetStorageMetrics(ctx, bucketID)
+	return m, p.record(callKey(CallGetStorageMetrics, bucketID), m, err)
+}
+
+func (p *RecordingProvider) ListDatabases(ctx context.Context) ([]Database, error) {
+	d, err := wrapped[DatabaseProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	databases, err := d.ListDatabases(ctx)
+	return databases, p.record(CallListDatabases, databases, err)
+}
+
+func (p *RecordingProvider) GetDatabaseMetrics(ctx context.Context, dbID string) (*metrics.DatabaseMetrics, error) {
+	d, err := wrapped[DatabaseProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	m, err := d.GetDatabaseMetrics(ctx, dbID)
+	return m, p.record(callKey(CallGetDatabaseMetrics, dbID), m, err)
+}
+
+func (p *RecordingProvider) ListModels(ctx context.Context) ([]AIModel, error) {
+	a, err := wrapped[AIProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	models, err := a.ListModels(ctx)
+	return models, p.record(CallListModels, models, err)
+}
+
+func (p *RecordingProvider) GetAIMetrics(ctx context.Context, resourceID string) (*metrics.AIMetrics, error) {
+	a, err := wrapped[AIProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	m, err := a.GetAIMetrics(ctx, resourceID)
+	return m, p.record(callKey(CallGetAIMetrics, resourceID), m, err)
+}
+
+func (p *RecordingProvider) ListAIMetrics(ctx context.Context) ([]metrics.AIMetrics, error) {
+	a, err := wrapped[AIProvider](p)
+	if err != nil {
+		return nil, err
+	}
+	m, err := a.ListAIMetrics(ctx)
+	return m, p.record(CallListAIMetrics, m, err)
+}
+
+func (p *RecordingProvider) GetResource(ctx context.Context, id string) (*Resource, error) {
+	g, err := wrapped[ResourceGetter](p)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := g.GetResource(ctx, id)
+	return resource, p.record(callKey(CallGetResource, id), resource, err)
+}
+
+func (p *RecordingProvider) StartInstance(ctx context.Context, instanceID string) error {
+	a, err := wrapped[ComputeActions](p)
+	if err != nil {
+		return err
+	}
+	return p.record(callKey(CallStartInstance, instanceID), nil, a.StartInstance(ctx, instanceID))
+}
+
+func (p *RecordingProvider) StopInstance(ctx context.Context, instanceID string) error {
+	a, err := wrapped[ComputeActions](p)
+	if err != nil {
+		return err
+	}
+	return p.record(callKey(CallStopInstance, instanceID), nil, a.StopInstance(ctx, instanceID))
+}
+
+func (p *RecordingProvider) RebootInstance(ctx context.Context, instanceID string) error {
+	a, err := wrapped[ComputeActions](p)
+	if err != nil {
+		return err
+	}
+	return p.record(callKey(CallRebootInstance, instanceID), nil, a.RebootInstance(ctx, instanceID))
+}
+
+func (p *RecordingProvider) GetInstanceState(ctx context.Context, instanceID string) (string, error) {
+	a, err := wrapped[ComputeActions](p)
+	if err != nil {
+		return "", err
+	}
+	state, err := a.GetInstanceState(ctx, instanceID)
+	return state, p.record(callKey(CallGetInstanceState, instanceID), state, err)
+}
+
+// wrapped returns the provider p records as T, or an error if it does not
+// support T
+func wrapped[T Provider](p *RecordingProvider) (T, error) {
+	t, ok := As[T](p.Provider)
+	if !ok {
+		return t, fmt.Errorf("%s does not support %s", p.name, reflect.TypeOf((*T)(nil)).Elem().Name())
+	}
+	return t, nil
+}
+
+// record saves a call's response and rewrites the recording file. It
+// returns the call's error, or the error saving it.
+func (p *RecordingProvider) record(call string, result interface{}, callErr error) error {
+	entry := RecordedCall{}
+	if callErr != nil {
+		entry = recordError(callErr)
+	} else if result != nil {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to record %s: %w", call, err)
+		}
+		entry.Result = data
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.rec.Calls[call] = entry
+	p.rec.RecordedAt = time.Now().UTC()
+
+	data, err := json.MarshalIndent(p.rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal recording: %w", err)
+	}
+	if err := os.MkdirAll(p.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	if err := writeFileAtomic(RecordingPath(p.dir, p.name), append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return callErr
+}
+
+// writeFileAtomic writes data to a temporary file beside path and renames it
+// into place, so a crash or a concurrent replay never sees half a recording.
+// The file is created with mode 0600.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+var (
+	_ ComputeActions     = (*ReplayProvider)(nil)
+	_ GPUProvider        = (*ReplayProvider)(nil)
+	_ ServerlessProvider = (*ReplayProvider)(nil)
+	_ StorageProvider    = (*ReplayProvider)(nil)
+	_ DatabaseProvider   = (*ReplayProvider)(nil)
+	_ AIProvider         = (*ReplayProvider)(nil)
+	_ ResourceGetter     = (*ReplayProvider)(nil)
+	_ MetricLimits       = (*ReplayProvider)(nil)
+	_ CapabilitySet      = (*ReplayProvider)(nil)
+)
+
+// ReplayProvider serves a provider's recorded responses without network
+// access or credentials. A recorded error is returned as the call's error,
+// and a call that was never recorded fails. It supports the capabilities
+// the recorded provider had.
+type ReplayProvider struct {
+	name string
+	dir  string
+	rec  *Recording
+}
+
+// NewReplayProvider creates a provider that replays the recording for name
+// in dir once initialized
+func NewReplayProvider(name, dir string) *ReplayProvider {
+	return &ReplayProvider{name: name, dir: dir}
+}
+
+func (p *ReplayProvider) Name() string {
+	return p.name
+}
+
+// Initialize loads the recording; config is ignored
+func (p *ReplayProvider) Initialize(ctx context.Context, config *ProviderConfig) error {
+	rec, err := LoadRecording(p.dir, p.name)
+	if err != nil {
+		return err
+	}
+	p.rec = rec
+	return nil
+}
+
+// SupportedCapabilities returns the recorded provider's capabilities, or
+// none before Initialize
+func (p *ReplayProvider) SupportedCapabilities() []string {
+	if p.rec == nil {
+		return nil
+	}
+	return p.rec.Capabilities
+}
+
+// MaxMetricWindow returns the recorded provider's limit, or 0 for none
+func (p *ReplayProvider) MaxMetricWindow() time.Duration {
+	if p.rec == nil {
+		return 0
+	}
+	return p.rec.MaxMetricWindow.Duration()
+}
+
+func (p *ReplayProvider) HealthCheck(ctx context.Context) error {
+	return p.replay(CallHealthCheck, nil)
+}
+
+func (p *ReplayProvider) ListServices(ctx context.Context) ([]Service, error) {
+	return replayed[[]Service](p, CallListServices)
+}
+
+func (p *ReplayProvider) ListResources(ctx context.Context, filter *ResourceFilter) ([]Resource, error) {
+	return replayed[[]Resource](p, CallListResources)
+}
+
+func (p *ReplayProvider) GetMetrics(ctx context.Context, req *MetricsRequest) (*MetricsResponse, error) {
+	return replayed[*MetricsResponse](p, CallGetMetrics)
+}
+
+func (p *ReplayProvider) ListInstances(ctx context.Context, filter *InstanceFilter) ([]Instance, error) {
+	return replayed[[]Instance](p, CallListInstances)
+}
+
+func (p *ReplayProvider) GetInstanceMetrics(ctx context.Context, instanceID string) (*metrics.ComputeMetrics, error) {
+	return replayed[*metrics.ComputeMetrics](p, callKey(CallGetInstanceMetrics, instanceID))
+}
+
+func (p *ReplayProvider) ListGPUInstances(ctx context.Context, filter *GPUFilter) ([]GPUInstance, error) {
+	return replayed[[]GPUInstance](p, CallListGPUInstances)
+}
+
+func (p *ReplayProvider) GetGPUMetrics(ctx context.Context, instanceID string) (*metrics.GPUMetrics, error) {
+	return replayed[*metrics.GPUMetrics](p, callKey(CallGetGPUMetrics, instanceID))
+}
+
+func (p *ReplayProvider) GetGPUAvailability(ctx context.Context) ([]GPUOffering, error) {
+	return replayed[[]GPUOffering](p, CallGetGPUAvailability)
+}
+
+func (p *ReplayProvider) ListFunctions(ctx context.Context, filter *FunctionFilter) ([]Function, error) {
+	return replayed[[]Function](p, CallListFunctions)
+}
+
+func (p *ReplayProvider) GetFunctionMetrics(ctx context.Context, functionID string) (*metrics.FunctionMetrics, error) {
+	return replayed[*metrics.FunctionMetrics](p, callKey(CallGetFunctionMetrics, functionID))
+}
+
+func (p *ReplayProvider) ListBuckets(ctx context.Context) ([]Bucket, error) {
+	return replayed[[]Bucket](p, CallListBuckets)
+}
+
+func (p *ReplayProvider) GetStorageMetrics(ctx context.Context, bucketID string) (*metrics.StorageMetrics, error) {
+	return replayed[*metrics.StorageMetrics](p, callKey(CallGetStorageMetrics, bucketID))
+}
+
+func (p *ReplayProvider) ListDatabases(ctx context.Context) ([]Database, error) {
+	return replayed[[]Database](p, CallListDatabases)
+}
+
+func (p *ReplayProvider) GetDatabaseMetrics(ctx context.Context, dbID string) (*metrics.DatabaseMetrics, error) {
+	return replayed[*metrics.DatabaseMetrics](p, callKey(CallGetDatabaseMetrics, dbID))
+}
+
+func (p *ReplayProvider) ListModels(ctx context.Context) ([]AIModel, error) {
+	return replayed[[]AIModel](p, CallListModels)
+}
+
+func (p *ReplayProvider) GetAIMetrics(ctx context.Context, resourceID string) (*metrics.AIMetrics, error) {
+	return replayed[*metrics.AIMetrics](p, callKey(CallGetAIMetrics, resourceID))
+}
+
+func (p *ReplayProvider) ListAIMetrics(ctx context.Context) ([]metrics.AIMetrics, error) {
+	return replayed[[]metrics.AIMetrics](p, CallListAIMetrics)
+}
+
+func (p *ReplayProvider) GetResource(ctx context.Context, id string) (*Resource, error) {
+	return replayed[*Resource](p, callKey(CallGetResource, id))
+}
+
+func (p *ReplayProvider) StartInstance(ctx context.Context, instanceID string) error {
+	return p.replay(callKey(CallStartInstance, instanceID), nil)
+}
+
+func (p *ReplayProvider) StopInstance(ctx context.Context, instanceID string) error {
+	return p.replay(callKey(CallStopInstance, instanceID), nil)
+}
+
+func (p *ReplayProvider) RebootInstance(ctx context.Context, instanceID string) error {
+	return p.replay(callKey(CallRebootInstance, instanceID), nil)
+}
+
+func (p *ReplayProvider) GetInstanceState(ctx context.Context, instanceID string) (string, error) {
+	return replayed[string](p, callKey(CallGetInstanceState, instanceID))
+}
+
+func (p *ReplayProvider) Close() error {
+	return nil
+}
+
+// replayed returns a recorded call's result as T, or its error
+func replayed[T any](p *ReplayProvider, call string) (T, error) {
+	var out T
+	if err := p.replay(call, &out); err != nil {
+		var none T
+		return none, err
+	}
+	return out, nil
+}
+
+// replay decodes a recorded call's result into out, or returns its error
+func (p *ReplayProvider) replay(call string, out interface{}) error {
+	if p.rec == nil {
+		return fmt.Errorf("%s: recording not loaded", p.name)
+	}
+	entry, ok := p.rec.Calls[call]
+	if !ok {
+		return fmt.Errorf("%s: no recorded %s response", p.name, call)
+	}
+	if entry.Error != "" {
+		return entry.err()
+	}
+	if out == nil || len(entry.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(entry.Result, out); err != nil {
+		return fmt.Errorf("%s: invalid recorded %s response: %w", p.name, call, err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	cterrors "github.com/afterdarksys/cloudtop/internal/errors"
+	"github.com/afterdarksys/cloudtop/internal/metrics"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+)
+
+// gpuStub is a GPU provider that can also fetch single resources. Calls
+// not set up here panic through the nil embedded Provider.
+type gpuStub struct {
+	provider.Provider
+	instances []provider.GPUInstance
+	err       error
+}
+
+func (s *gpuStub) Name() string { return "stub" }
+
+func (s *gpuStub) HealthCheck(ctx context.Context) error { return s.err }
+
+func (s *gpuStub) ListGPUInstances(ctx context.Context, filter *provider.GPUFilter) ([]provider.GPUInstance, error) {
+	return s.instances, s.err
+}
+
+func (s *gpuStub) GetGPUMetrics(ctx context.Context, instanceID string) (*metrics.GPUMetrics, error) {
+	return nil, s.err
+}
+
+func (s *gpuStub) GetGPUAvailability(ctx context.Context) ([]provider.GPUOffering, error) {
+	return nil, s.err
+}
+
+func (s *gpuStub) GetResource(ctx context.Context, id string) (*provider.Resource, error) {
+	for _, inst := range s.instances {
+		if inst.ID == id {
+			r := inst.Resource
+			return &r, nil
+		}
+	}
+	return nil, cterrors.NewNotFoundError("stub", id)
+}
+
+func (s *gpuStub) MaxMetricWindow() time.Duration { return 6 * time.Hour }
+
+func replayOf(t *testing.T, dir string) *provider.ReplayProvider {
+	t.Helper()
+	p := provider.NewReplayProvider("stub", dir)
+	if err := p.Initialize(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestRecordingKeepsCapabilities(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	stub := &gpuStub{instances: []provider.GPUInstance{
+		{Instance: provider.Instance{Resource: provider.Resource{ID: "i-1", Name: "trainer"}}, GPUType: "A100", GPUCount: 8},
+	}}
+	recorder := provider.NewRecordingProvider("stub", stub, dir)
+
+	gpu, ok := provider.As[provider.GPUProvider](recorder)
+	if !ok {
+		t.Fatal("recording hides GPUProvider")
+	}
+	if _, ok := provider.As[provider.StorageProvider](recorder); ok {
+		t.Error("recording claims StorageProvider, which the stub lacks")
+	}
+	if _, err := recorder.ListBuckets(ctx); err == nil {
+		t.Error("ListBuckets on a recording of a provider without storage succeeded")
+	}
+
+	if _, err := gpu.ListGPUInstances(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	getter, _ := provider.As[provider.ResourceGetter](recorder)
+	if _, err := getter.GetResource(ctx, "i-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	replay := replayOf(t, dir)
+	want := []string{"gpu", "get"}
+	for name, p := range map[string]provider.Provider{"recording": recorder, "replay": replay} {
+		if got := provider.Capabilities(p); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s capabilities = %v, want %v", name, got, want)
+		}
+		if got := p.(provider.MetricLimits).MaxMetricWindow(); got != 6*time.Hour {
+			t.Errorf("%s MaxMetricWindow = %v, want 6h", name, got)
+		}
+	}
+
+	instances, err := replay.ListGPUInstances(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(instances, stub.instances) {
+		t.Errorf("replayed GPU instances = %+v, want %+v", instances, stub.instances)
+	}
+	if r, err := replay.GetResource(ctx, "i-1"); err != nil || r.Name != "trainer" {
+		t.Errorf("replayed GetResource(i-1) = %+v, %v", r, err)
+	}
+	if _, err := replay.GetResource(ctx, "i-2"); err == nil {
+		t.Error("GetResource of an ID never recorded succeeded")
+	}
+	if _, ok := provider.As[provider.ComputeActions](replay); ok {
+		t.Error("replay claims ComputeActions, which the stub lacks")
+	}
+}
+
+func TestReplayKeepsErrorType(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	callErr := cterrors.NewRateLimitError("stub", errors.New("429 Too Many Requests"))
+	recorder := provider.NewRecordingProvider("stub", &gpuStub{err: callErr}, dir)
+
+	if err := recorder.HealthCheck(ctx); err != callErr {
+		t.Fatalf("HealthCheck = %v, want the provider's error", err)
+	}
+	if _, err := recorder.ListGPUInstances(ctx, nil); err != callErr {
+		t.Fatalf("ListGPUInstances = %v, want the provider's error", err)
+	}
+	if _, err := recorder.GetResource(ctx, "gone"); err == nil {
+		t.Fatal("GetResource of a missing ID succeeded")
+	}
+
+	replay := replayOf(t, dir)
+	for call, err := range map[string]error{
+		"HealthCheck":      replay.HealthCheck(ctx),
+		"ListGPUInstances": func() error { _, err := replay.ListGPUInstances(ctx, nil); return err }(),
+	} {
+		var ctErr *cterrors.CloudtopError
+		if !errors.As(err, &ctErr) {
+			t.Errorf("%s replayed %T %v, want a CloudtopError", call, err, err)
+			continue
+		}
+		if ctErr.Type != cterrors.ErrorTypeRateLimit || !cterrors.IsRetryableError(err) {
+			t.Errorf("%s replayed type %s retryable %t, want a retryable rate_limit error", call, ctErr.Type, ctErr.Retryable)
+		}
+		if err.Error() != callErr.Error() {
+			t.Errorf("%s replayed %q, want %q", call, err, callErr)
+		}
+	}
+
+	_, err := replay.GetResource(ctx, "gone")
+	var ctErr *cterrors.CloudtopError
+	if !errors.As(err, &ctErr) || ctErr.Type != cterrors.ErrorTypeNotFound || ctErr.Retryable {
+		t.Errorf("replayed GetResource(gone) = %v, want a not-found error", err)
+	}
+}
+
+func TestRecordingWritesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	recorder := provider.NewRecordingProvider("stub", &gpuStub{}, dir)
+	for i := 0; i < 3; i++ {
+		if err := recorder.HealthCheck(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if !reflect.DeepEqual(names, []string{"stub.json"}) {
+		t.Errorf("recording directory holds %v, want only stub.json", names)
+	}
+
+	info, err := os.Stat(provider.RecordingPath(dir, "stub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("recording mode = %v, want 0600", mode)
+	}
+	if _, err := provider.LoadRecording(dir, "stub"); err != nil {
+		t.Error(err)
+	}
+}
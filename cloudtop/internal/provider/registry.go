@@ -2,6 +2,8 @@ package provider
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"sync"
 )
 
@@ -82,3 +84,122 @@ func ListRegistered() []string {
 func IsRegistered(name string) bool {
 	return registry.Exists(name)
 }
+
+// CapabilitySet is implemented by wrappers such as RecordingProvider that
+// have the methods of every optional interface but support only the
+// capabilities of the provider behind them. Capabilities and As report what
+// it lists rather than the wrapper's method set.
+type CapabilitySet interface {
+	SupportedCapabilities() []string
+}
+
+// Capabilities lists the optional provider interfaces p implements, such as
+// "compute" or "gpu". It only inspects types, so p need not be initialized.
+func Capabilities(p Provider) []string {
+	if set, ok := p.(CapabilitySet); ok {
+		return append([]string(nil), set.SupportedCapabilities()...)
+	}
+
+	var caps []string
+	if _, ok := p.(ComputeProvider); ok {
+		caps = append(caps, "compute")
+	}
+	if _, ok := p.(GPUProvider); ok {
+		caps = append(caps, "gpu")
+	}
+	if _, ok := p.(ServerlessProvider); ok {
+		caps = append(caps, "serverless")
+	}
+	if _, ok := p.(StorageProvider); ok {
+		caps = append(caps, "storage")
+	}
+	if _, ok := p.(DatabaseProvider); ok {
+		caps = append(caps, "database")
+	}
+	if _, ok := p.(AIProvider); ok {
+		caps = append(caps, "ai")
+	}
+	if _, ok := p.(ResourceGetter); ok {
+		caps = append(caps, "get")
+	}
+	if _, ok := p.(ComputeActions); ok {
+		caps = append(caps, "actions")
+	}
+	return caps
+}
+
+// As returns p as T, one of the optional interfaces such as GPUProvider,
+// if p supports it. Use it rather than a type assertion, which a
+// CapabilitySet wrapper satisfies whatever it wraps.
+func As[T Provider](p Provider) (T, bool) {
+	t, ok := p.(T)
+	set, wrapped := p.(CapabilitySet)
+	if !ok || !wrapped {
+		return t, ok
+	}
+
+	iface := reflect.TypeOf((*T)(nil)).Elem().Name()
+	for _, c := range set.SupportedCapabilities() {
+		if capabilityInterfaces[c] == iface {
+			return t, true
+		}
+	}
+	var none T
+	return none, false
+}
+
+// capabilityInterfaces names the interface behind each capability reported
+// by Capabilities
+var capabilityInterfaces = map[string]string{
+	"compute":    "ComputeProvider",
+	"gpu":        "GPUProvider",
+	"serverless": "ServerlessProvider",
+	"storage":    "StorageProvider",
+	"database":   "DatabaseProvider",
+	"ai":         "AIProvider",
+	"get":        "ResourceGetter",
+	"actions":    "ComputeActions",
+}
+
+// AssertCapabilities returns an error naming each of caps that p does not
+// implement, so a provider that drops a method is caught rather than
+// silently losing the capability. Unknown capability names are errors too.
+func AssertCapabilities(p Provider, caps ...string) error {
+	has := make(map[string]bool)
+	for _, c := range Capabilities(p) {
+		has[c] = true
+	}
+
+	var missing []string
+	for _, c := range caps {
+		iface, known := capabilityInterfaces[c]
+		switch {
+		case !known:
+			missing = append(missing, fmt.Sprintf("%s (unknown capability)", c))
+		case !has[c]:
+			missing = append(missing, fmt.Sprintf("%s (%s)", c, iface))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("provider %s does not implement %s", p.Name(), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// AdvertisedCapabilities returns the interface-backed capabilities that
+// services list in their Capabilities, such as "storage" or "gpu". A
+// service's Type is not a claim: ListResources serves every type. Pass the
+// result to AssertCapabilities to check a provider against its ListServices.
+func AdvertisedCapabilities(services []Service) []string {
+	seen := make(map[string]bool)
+	var caps []string
+	for _, s := range services {
+		for _, c := range s.Capabilities {
+			if _, ok := capabilityInterfaces[c]; ok && !seen[c] {
+				seen[c] = true
+				caps = append(caps, c)
+			}
+		}
+	}
+	return caps
+}
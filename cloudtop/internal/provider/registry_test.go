@@ -0,0 +1,97 @@
+package provider_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/afterdarksys/cloudtop/internal/provider"
+	_ "github.com/afterdarksys/cloudtop/internal/provider/azure"
+	_ "github.com/afterdarksys/cloudtop/internal/provider/cloudflare"
+	_ "github.com/afterdarksys/cloudtop/internal/provider/gcp"
+	_ "github.com/afterdarksys/cloudtop/internal/provider/neon"
+	_ "github.com/afterdarksys/cloudtop/internal/provider/oracle"
+	_ "github.com/afterdarksys/cloudtop/internal/provider/runpod"
+	_ "github.com/afterdarksys/cloudtop/internal/provider/vastai"
+)
+
+func TestProviderCapabilities(t *testing.T) {
+	tests := []struct {
+		name string
+		caps []string
+	}{
+		{"azure", []string{"compute"}},
+		{"cloudflare", []string{"storage", "ai", "get"}},
+		{"gcp", []string{"compute", "gpu"}},
+		{"neon", []string{"database", "get"}},
+		{"oracle", []string{"compute", "gpu", "actions"}},
+		{"runpod", []string{"gpu", "get"}},
+		{"vastai", []string{"gpu"}},
+	}
+
+	var names []string
+	for _, tt := range tests {
+		names = append(names, tt.name)
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := provider.Create(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := provider.Capabilities(p); !reflect.DeepEqual(got, tt.caps) {
+				t.Errorf("Capabilities = %v, want %v", got, tt.caps)
+			}
+			recorder := provider.NewRecordingProvider(tt.name, p, t.TempDir())
+			if got := provider.Capabilities(recorder); !reflect.DeepEqual(got, tt.caps) {
+				t.Errorf("Capabilities of its recording = %v, want %v", got, tt.caps)
+			}
+
+			services, err := p.ListServices(context.Background())
+			if err != nil {
+				t.Fatalf("ListServices: %v", err)
+			}
+			if err := provider.AssertCapabilities(p, provider.AdvertisedCapabilities(services)...); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	registered := provider.ListRegistered()
+	sort.Strings(registered)
+	if !reflect.DeepEqual(registered, names) {
+		t.Errorf("registered providers = %v, want a row for each of them (have %v)", registered, names)
+	}
+}
+
+type stubProvider struct{ provider.Provider }
+
+func (stubProvider) Name() string { return "stub" }
+
+func TestAssertCapabilities(t *testing.T) {
+	err := provider.AssertCapabilities(stubProvider{}, "gpu", "teleport")
+	if err == nil {
+		t.Fatal("AssertCapabilities = nil, want an error")
+	}
+	for _, want := range []string{"gpu (GPUProvider)", "teleport (unknown capability)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	if err := provider.AssertCapabilities(stubProvider{}); err != nil {
+		t.Errorf("AssertCapabilities with no capabilities = %v, want nil", err)
+	}
+}
+
+func TestAdvertisedCapabilities(t *testing.T) {
+	services := []provider.Service{
+		{ID: "pods", Type: "compute", Capabilities: []string{"gpu", "metrics"}},
+		{ID: "buckets", Type: "storage", Capabilities: []string{"storage", "gpu"}},
+		{ID: "functions", Type: "serverless", Capabilities: []string{"metrics"}},
+	}
+	want := []string{"gpu", "storage"}
+	if got := provider.AdvertisedCapabilities(services); !reflect.DeepEqual(got, want) {
+		t.Errorf("AdvertisedCapabilities = %v, want %v", got, want)
+	}
+}
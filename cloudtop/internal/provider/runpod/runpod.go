@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/afterdarksys/cloudtop/internal/errors"
 	"github.com/afterdarksys/cloudtop/internal/metrics"
 	"github.com/afterdarksys/cloudtop/internal/provider"
+	"github.com/afterdarksys/cloudtop/pkg/httpclient"
 	"github.com/afterdarksys/cloudtop/pkg/ratelimit"
 )
 
@@ -21,6 +23,12 @@ func init() {
 	})
 }
 
+// Compile-time checks for the optional interfaces RunPodProvider implements
+var (
+	_ provider.GPUProvider    = (*RunPodProvider)(nil)
+	_ provider.ResourceGetter = (*RunPodProvider)(nil)
+)
+
 // RunPodProvider implements Provider and GPUProvider interfaces
 type RunPodProvider struct {
 	config  *provider.ProviderConfig
@@ -46,9 +54,11 @@ func (p *RunPodProvider) Initialize(ctx context.Context, config *provider.Provid
 	p.apiKey = apiKey
 
 	// Create HTTP client
-	p.client = &http.Client{
-		Timeout: 30 * time.Second,
+	client, err := httpclient.New(config.HTTP)
+	if err != nil {
+		return errors.NewValidationError("runpod", err.Error())
 	}
+	p.client = client
 
 	// Set up rate limiter
 	if config.RateLimit != nil {
@@ -78,7 +88,7 @@ func (p *RunPodProvider) HealthCheck(ctx context.Context) error {
 
 func (p *RunPodProvider) ListServices(ctx context.Context) ([]provider.Service, error) {
 	return []provider.Service{
-		{ID: "pods", Name: "GPU Pods", Type: "compute", Capabilities: []string{"gpu", "compute", "metrics"}},
+		{ID: "pods", Name: "GPU Pods", Type: "compute", Capabilities: []string{"gpu", "metrics"}},
 		{ID: "serverless", Name: "Serverless GPU", Type: "serverless", Capabilities: []string{"gpu", "inference"}},
 		{ID: "templates", Name: "Pod Templates", Type: "templates", Capabilities: []string{"templates"}},
 	}, nil
@@ -105,6 +115,28 @@ func (p *RunPodProvider) ListResources(ctx context.Context, filter *provider.Res
 	return resources, nil
 }
 
+// GetResource fetches a single pod by ID
+func (p *RunPodProvider) GetResource(ctx context.Context, id string) (*provider.Resource, error) {
+	pod, err := p.getPod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider.Resource{
+		ID:       pod.ID,
+		Name:     pod.Name,
+		Type:     "gpu_pod",
+		Provider: "runpod",
+		Region:   pod.DataCenter,
+		Status:   pod.DesiredStatus,
+		Tags: map[string]string{
+			"gpu_type":  pod.GPUType,
+			"gpu_count": strconv.Itoa(pod.GPUCount),
+		},
+		HourlyRate: pod.CostPerHr,
+	}, nil
+}
+
 func (p *RunPodProvider) GetMetrics(ctx context.Context, req *provider.MetricsRequest) (*provider.MetricsResponse, error) {
 	return &provider.MetricsResponse{
 		Provider:  "runpod",
@@ -315,6 +347,43 @@ func (p *RunPodProvider) listPods(ctx context.Context) ([]runpodPod, error) {
 	return result.Myself.Pods, nil
 }
 
+func (p *RunPodProvider) getPod(ctx context.Context, podID string) (*runpodPod, error) {
+	query := `
+		query Pod($input: PodFilter) {
+			pod(input: $input) {
+				id
+				name
+				gpuTypeId
+				gpuCount
+				vcpuCount
+				memoryInGb
+				costPerHr
+				desiredStatus
+				dataCenterId
+			}
+		}
+	`
+
+	data, err := p.doGraphQL(ctx, query, map[string]interface{}{
+		"input": map[string]string{"podId": podID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Pod *runpodPod `json:"pod"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errors.NewInternalError("runpod", err)
+	}
+	if result.Pod == nil {
+		return nil, errors.NewNotFoundError("runpod", podID)
+	}
+
+	return result.Pod, nil
+}
+
 func (p *RunPodProvider) listGPUTypes(ctx context.Context) ([]runpodGPUType, error) {
 	query := `
 		query {
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/afterdarksys/cloudtop/internal/metrics"
+	"github.com/afterdarksys/cloudtop/pkg/httpclient"
 )
 
 // Provider is the core interface that all cloud providers must implement
@@ -96,8 +99,51 @@ type AIProvider interface {
 	// ListModels returns available AI models
 	ListModels(ctx context.Context) ([]AIModel, error)
 
-	// GetAIMetrics retrieves AI workload metrics
+	// GetAIMetrics retrieves AI workload metrics for one model or
+	// endpoint, or for the whole account when resourceID is empty
 	GetAIMetrics(ctx context.Context, resourceID string) (*metrics.AIMetrics, error)
+
+	// ListAIMetrics returns AI workload metrics for each model or endpoint
+	// with recent usage
+	ListAIMetrics(ctx context.Context) ([]metrics.AIMetrics, error)
+}
+
+// ResourceGetter is implemented by providers whose API can fetch a single
+// resource directly instead of listing everything
+type ResourceGetter interface {
+	Provider
+
+	// GetResource returns the resource with the given ID, or a not-found
+	// error if it does not exist
+	GetResource(ctx context.Context, id string) (*Resource, error)
+}
+
+// ComputeActions extends ComputeProvider with instance lifecycle actions.
+// Each action returns once the provider has accepted it; the instance then
+// moves through the provider's transitional states, which
+// GetInstanceState reports.
+type ComputeActions interface {
+	ComputeProvider
+
+	// StartInstance starts a stopped instance
+	StartInstance(ctx context.Context, instanceID string) error
+
+	// StopInstance shuts an instance down
+	StopInstance(ctx context.Context, instanceID string) error
+
+	// RebootInstance restarts a running instance
+	RebootInstance(ctx context.Context, instanceID string) error
+
+	// GetInstanceState returns the instance's current lifecycle state in
+	// lower case, e.g. "running", "stopping" or "stopped"
+	GetInstanceState(ctx context.Context, instanceID string) (string, error)
+}
+
+// MetricLimits is implemented by providers whose metrics API caps how far
+// back a single request may look
+type MetricLimits interface {
+	// MaxMetricWindow returns the longest window a metrics request may span
+	MaxMetricWindow() time.Duration
 }
 
 // ProviderConfig holds provider-specific configuration
@@ -108,6 +154,7 @@ type ProviderConfig struct {
 	Options     map[string]interface{} `json:"options"`
 	RateLimit   *RateLimitConfig       `json:"rate_limit,omitempty"`
 	Cache       *CacheConfig           `json:"cache,omitempty"`
+	HTTP        *httpclient.Config     `json:"http,omitempty"`
 }
 
 // RateLimitConfig defines rate limiting parameters
@@ -124,7 +171,11 @@ type CacheConfig struct {
 	MaxSize int           `json:"max_size"`
 }
 
-// Service represents a cloud service
+// Service represents a cloud service. Type is the category of the resources
+// ListResources returns for it, which every provider serves. Capabilities
+// describe what else the provider offers for the service; one that names an
+// optional interface (see Capabilities) is a claim that the provider
+// implements it, checked by AdvertisedCapabilities and AssertCapabilities.
 type Service struct {
 	ID           string   `json:"id"`
 	Name         string   `json:"name"`
@@ -159,6 +210,13 @@ type Instance struct {
 	State        string  `json:"state"`
 }
 
+// InstanceMetrics pairs an instance with its latest usage metrics. Metrics
+// is nil when the provider could not report them.
+type InstanceMetrics struct {
+	Instance
+	Metrics *metrics.ComputeMetrics `json:"metrics,omitempty"`
+}
+
 // GPUInstance represents a GPU-enabled instance
 type GPUInstance struct {
 	Instance
@@ -199,6 +257,10 @@ type Bucket struct {
 	SizeBytes    int64  `json:"size_bytes"`
 	ObjectCount  int64  `json:"object_count"`
 	StorageClass string `json:"storage_class"`
+
+	// Metrics holds request counts and size change, when the provider
+	// reports them
+	Metrics *metrics.StorageMetrics `json:"metrics,omitempty"`
 }
 
 // Database represents a database instance
@@ -246,6 +308,122 @@ type ResourceFilter struct {
 	Tags        map[string]string `json:"tags,omitempty"`
 	Status      []string          `json:"status,omitempty"`
 	NamePattern string            `json:"name_pattern,omitempty"`
+
+	// CreatedAfter limits results to resources created after this time
+	CreatedAfter *time.Time `json:"created_after,omitempty"`
+
+	// Query limits results to resources whose name or ID contains this
+	// substring, ignoring case
+	Query string `json:"query,omitempty"`
+
+	// MaxResults is a hint that the caller shows at most this many
+	// resources; providers may stop paging once they have that many.
+	// Callers only set it when no other filter is set, since resources
+	// the caller filters out would still count toward it. 0 means no limit.
+	MaxResults int `json:"max_results,omitempty"`
+}
+
+// Selective reports whether the filter can drop listed resources: whether
+// any of Types, Status, CreatedAfter, Tags or Query is set
+func (f *ResourceFilter) Selective() bool {
+	return f != nil && (len(f.Types) > 0 || len(f.Status) > 0 || f.CreatedAfter != nil || len(f.Tags) > 0 || f.Query != "")
+}
+
+// Full reports whether n resources already satisfy the MaxResults cap
+func (f *ResourceFilter) Full(n int) bool {
+	return f != nil && f.MaxResults > 0 && n >= f.MaxResults
+}
+
+// serviceTypes maps a service name to the resource types listed under it,
+// for providers whose resources are typed more finely than their services
+var serviceTypes = make(map[string][]string)
+
+// RegisterServiceTypes declares resource types that belong to a service, so
+// a Types filter naming the service (e.g. --service containers) keeps them.
+// It is called from provider init functions.
+func RegisterServiceTypes(service string, types ...string) {
+	service = strings.ToLower(service)
+	serviceTypes[service] = append(serviceTypes[service], types...)
+}
+
+// MatchesType reports whether a resource passes the Types filter
+func (f *ResourceFilter) MatchesType(r Resource) bool {
+	if f == nil || len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if strings.EqualFold(t, r.Type) {
+			return true
+		}
+		for _, st := range serviceTypes[strings.ToLower(t)] {
+			if strings.EqualFold(st, r.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchesStatus reports whether a resource passes the Status filter
+func (f *ResourceFilter) MatchesStatus(r Resource) bool {
+	if f == nil || len(f.Status) == 0 {
+		return true
+	}
+	for _, s := range f.Status {
+		if strings.EqualFold(s, r.Status) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesTags reports whether a resource carries every tag in the Tags
+// filter. An empty filter value only requires the key to be present.
+func (f *ResourceFilter) MatchesTags(r Resource) bool {
+	if f == nil || len(f.Tags) == 0 {
+		return true
+	}
+	for key, want := range f.Tags {
+		got, ok := r.Tags[key]
+		if !ok || (want != "" && !strings.EqualFold(want, got)) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTagFilter parses "key=value" (or bare "key") arguments into a tag
+// filter map
+func ParseTagFilter(args []string) (map[string]string, error) {
+	tags := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, _ := strings.Cut(arg, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid tag filter %q: expected key=value", arg)
+		}
+		tags[key] = strings.TrimSpace(value)
+	}
+	return tags, nil
+}
+
+// MatchesQuery reports whether a resource's name or ID contains the Query
+// substring, ignoring case
+func (f *ResourceFilter) MatchesQuery(r Resource) bool {
+	if f == nil || f.Query == "" {
+		return true
+	}
+	q := strings.ToLower(f.Query)
+	return strings.Contains(strings.ToLower(r.Name), q) || strings.Contains(strings.ToLower(r.ID), q)
+}
+
+// MatchesCreatedAfter reports whether a resource passes the CreatedAfter
+// filter. Resources without a creation time always pass.
+func (f *ResourceFilter) MatchesCreatedAfter(r Resource) bool {
+	if f == nil || f.CreatedAfter == nil || r.CreatedAt.IsZero() {
+		return true
+	}
+	return r.CreatedAt.After(*f.CreatedAfter)
 }
 
 // InstanceFilter for filtering instances
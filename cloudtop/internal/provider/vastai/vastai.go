@@ -11,6 +11,7 @@ import (
 	"github.com/afterdarksys/cloudtop/internal/errors"
 	"github.com/afterdarksys/cloudtop/internal/metrics"
 	"github.com/afterdarksys/cloudtop/internal/provider"
+	"github.com/afterdarksys/cloudtop/pkg/httpclient"
 	"github.com/afterdarksys/cloudtop/pkg/ratelimit"
 )
 
@@ -20,6 +21,9 @@ func init() {
 	})
 }
 
+// Compile-time check for the optional interface VastAIProvider implements
+var _ provider.GPUProvider = (*VastAIProvider)(nil)
+
 // VastAIProvider implements Provider and GPUProvider interfaces
 type VastAIProvider struct {
 	config  *provider.ProviderConfig
@@ -45,9 +49,11 @@ func (p *VastAIProvider) Initialize(ctx context.Context, config *provider.Provid
 	p.apiKey = apiKey
 
 	// Create HTTP client
-	p.client = &http.Client{
-		Timeout: 30 * time.Second,
+	client, err := httpclient.New(config.HTTP)
+	if err != nil {
+		return errors.NewValidationError("vastai", err.Error())
 	}
+	p.client = client
 
 	// Set up rate limiter
 	if config.RateLimit != nil {
@@ -76,7 +82,7 @@ func (p *VastAIProvider) HealthCheck(ctx context.Context) error {
 
 func (p *VastAIProvider) ListServices(ctx context.Context) ([]provider.Service, error) {
 	return []provider.Service{
-		{ID: "instances", Name: "GPU Instances", Type: "compute", Capabilities: []string{"gpu", "compute", "metrics"}},
+		{ID: "instances", Name: "GPU Instances", Type: "compute", Capabilities: []string{"gpu", "metrics"}},
 		{ID: "marketplace", Name: "GPU Marketplace", Type: "marketplace", Capabilities: []string{"gpu", "pricing"}},
 	}, nil
 }
@@ -0,0 +1,542 @@
+// Package tui is cloudtop's interactive dashboard. Each provider's
+// resources are a collapsible panel; the keyboard moves between panels and
+// filters them as you type, and a bar totals GPU instances and their hourly
+// cost. Data is refreshed from a collector on an interval.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/collector"
+	"github.com/afterdarksys/cloudtop/internal/output"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+)
+
+// DefaultInterval is the refresh interval when none is given
+const DefaultInterval = 30 * time.Second
+
+// ANSI attributes used to style the dashboard
+const (
+	reverse = "\033[7m"
+	bold    = "\033[1m"
+	dim     = "\033[2m"
+	reset   = "\033[0m"
+)
+
+// Options configures a Dashboard
+type Options struct {
+	// Interval between refreshes; zero means DefaultInterval
+	Interval time.Duration
+
+	// Request is the collection made on every refresh
+	Request *collector.CollectRequest
+
+	// Redactor masks fields before they are shown; nil shows everything
+	Redactor *output.Redactor
+
+	// Order lists providers to show first (see output.OrderProviders)
+	Order []string
+}
+
+// Dashboard is the interactive view over a collector, run as a bubbletea
+// program
+type Dashboard struct {
+	col  *collector.Collector
+	opts Options
+	in   io.Reader
+	out  io.Writer
+
+	// ctx bounds refreshes; it is set by Run
+	ctx context.Context
+
+	// Terminal size, from the last tea.WindowSizeMsg
+	width  int
+	height int
+
+	// Latest collection
+	result    *output.CollectResult
+	hasGPU    bool
+	gpus      []provider.GPUInstance
+	gpuErrors map[string]error
+	loading   bool
+	lastErr   error
+
+	// View state
+	collapsed map[string]bool
+	cursor    int
+	offset    int
+	filter    string
+	typing    bool
+
+	// follow keeps the selected panel on screen; paging turns it off
+	// until the selection moves
+	follow bool
+}
+
+// New creates a dashboard over col that reads keys from the terminal on
+// stdin and draws to stdout
+func New(col *collector.Collector, opts Options) *Dashboard {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.Request == nil {
+		opts.Request = &collector.CollectRequest{}
+	}
+	return &Dashboard{
+		col:       col,
+		opts:      opts,
+		in:        os.Stdin,
+		out:       os.Stdout,
+		ctx:       context.Background(),
+		width:     80,
+		height:    24,
+		collapsed: make(map[string]bool),
+		follow:    true,
+	}
+}
+
+// snapshot is the outcome of one refresh
+type snapshot struct {
+	result    *output.CollectResult
+	hasGPU    bool
+	gpus      []provider.GPUInstance
+	gpuErrors map[string]error
+	err       error
+}
+
+// collect refreshes resources and, when any provider has GPUs, GPU
+// instances
+func (d *Dashboard) collect(ctx context.Context) snapshot {
+	// Collect fills in defaults, so each refresh gets its own copy
+	req := *d.opts.Request
+	result, err := d.col.Collect(ctx, &req)
+	if err != nil {
+		return snapshot{err: err}
+	}
+	s := snapshot{result: d.opts.Redactor.Result(result)}
+	if s.hasGPU = d.hasGPUProviders(); s.hasGPU {
+		gpus, gpuErrors := d.col.CollectGPU(ctx, &provider.GPUFilter{})
+		s.gpus, s.gpuErrors = d.opts.Redactor.GPUInstances(gpus), gpuErrors
+	}
+	return s
+}
+
+func (d *Dashboard) hasGPUProviders() bool {
+	for _, p := range d.col.GetProviders() {
+		if _, ok := provider.As[provider.GPUProvider](p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Run shows the dashboard until q or Ctrl+C is pressed or ctx is done. The
+// terminal is restored, and stdin no longer read, before it returns.
+func (d *Dashboard) Run(ctx context.Context) error {
+	if d.in == os.Stdin && !term.IsTerminal(os.Stdin.Fd()) {
+		return errors.New("the dashboard needs an interactive terminal")
+	}
+
+	d.ctx = ctx
+	prog := tea.NewProgram(d,
+		tea.WithContext(ctx),
+		tea.WithInput(d.in),
+		tea.WithOutput(d.out),
+		tea.WithAltScreen(),
+	)
+	_, err := prog.Run()
+	if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// snapshotMsg delivers a finished refresh; tickMsg asks for the next one
+type (
+	snapshotMsg snapshot
+	tickMsg     struct{}
+)
+
+// Init starts the first refresh and the refresh interval
+func (d *Dashboard) Init() tea.Cmd {
+	return tea.Batch(d.refresh(), d.tick())
+}
+
+// refresh starts a collection unless one is already running
+func (d *Dashboard) refresh() tea.Cmd {
+	if d.loading {
+		return nil
+	}
+	d.loading = true
+	ctx := d.ctx
+	return func() tea.Msg { return snapshotMsg(d.collect(ctx)) }
+}
+
+func (d *Dashboard) tick() tea.Cmd {
+	return tea.Tick(d.opts.Interval, func(time.Time) tea.Msg { return tickMsg{} })
+}
+
+// Update applies a refresh, tick, resize or key press
+func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case snapshotMsg:
+		d.loading = false
+		if msg.err != nil {
+			d.lastErr = msg.err
+			return d, nil
+		}
+		d.lastErr = nil
+		d.result, d.hasGPU, d.gpus, d.gpuErrors = msg.result, msg.hasGPU, msg.gpus, msg.gpuErrors
+	case tickMsg:
+		return d, tea.Batch(d.refresh(), d.tick())
+	case tea.WindowSizeMsg:
+		d.width, d.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		return d, d.handleKey(msg)
+	}
+	return d, nil
+}
+
+// handleKey applies a key press, returning tea.Quit to quit or a refresh
+func (d *Dashboard) handleKey(k tea.KeyMsg) tea.Cmd {
+	if k.Type == tea.KeyCtrlC {
+		return tea.Quit
+	}
+
+	if d.typing {
+		switch k.Type {
+		case tea.KeyEnter:
+			d.typing = false
+		case tea.KeyEsc:
+			d.typing = false
+			d.filter = ""
+		case tea.KeyBackspace:
+			if r := []rune(d.filter); len(r) > 0 {
+				d.filter = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			d.filter += string(k.Runes)
+		}
+		d.cursor, d.offset, d.follow = 0, 0, true
+		return nil
+	}
+
+	panels := d.panels()
+	d.follow = true
+	var cmd tea.Cmd
+	switch k.String() {
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j":
+		if d.cursor < len(panels)-1 {
+			d.cursor++
+		}
+	case "home", "g":
+		d.cursor = 0
+	case "end", "G":
+		d.cursor = len(panels) - 1
+	case "pgup":
+		d.offset -= d.bodyHeight()
+		d.follow = false
+	case "pgdown":
+		d.offset += d.bodyHeight()
+		d.follow = false
+	case "enter", " ":
+		if d.cursor < len(panels) {
+			name := panels[d.cursor].name
+			d.collapsed[name] = !d.collapsed[name]
+		}
+	case "c":
+		// Collapse every panel, or expand them all if they already are
+		all := true
+		for _, p := range panels {
+			all = all && d.collapsed[p.name]
+		}
+		for _, p := range panels {
+			d.collapsed[p.name] = !all
+		}
+	case "/":
+		d.typing = true
+	case "esc":
+		d.filter = ""
+	case "r":
+		cmd = d.refresh()
+	case "q":
+		cmd = tea.Quit
+	}
+	if d.cursor < 0 {
+		d.cursor = 0
+	}
+	return cmd
+}
+
+// panel is one provider's resources that match the filter
+type panel struct {
+	name      string
+	resources []provider.Resource
+	total     int
+	result    *output.ProviderResult
+	err       error
+}
+
+// panels returns a panel per provider with results or an error, in the
+// configured order
+func (d *Dashboard) panels() []panel {
+	if d.result == nil {
+		return nil
+	}
+
+	// Providers that only failed still get a panel to show the error
+	names := make(map[string]*output.ProviderResult, len(d.result.Results)+len(d.result.Errors))
+	for name, r := range d.result.Results {
+		names[name] = r
+	}
+	for name := range d.result.Errors {
+		if _, ok := names[name]; !ok {
+			names[name] = nil
+		}
+	}
+
+	filter := strings.ToLower(d.filter)
+	var panels []panel
+	for _, name := range output.OrderProviders(names, d.opts.Order) {
+		p := panel{name: name, result: names[name], err: d.result.Errors[name]}
+		if p.result != nil {
+			p.total = len(p.result.Resources)
+			for _, r := range p.result.Resources {
+				if matches(r, filter) {
+					p.resources = append(p.resources, r)
+				}
+			}
+		}
+		if filter != "" && len(p.resources) == 0 && p.err == nil {
+			continue
+		}
+		panels = append(panels, p)
+	}
+	return panels
+}
+
+// matches reports whether any of a resource's shown fields contains filter,
+// which must be lower case
+func matches(r provider.Resource, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	for _, field := range []string{r.Name, r.ID, r.Type, r.Region, r.Status} {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// headerLines and footerLines are the rows around the scrolling body
+const (
+	headerLines = 3
+	footerLines = 2
+)
+
+func (d *Dashboard) bodyHeight() int {
+	if body := d.height - headerLines - footerLines; body > 1 {
+		return body
+	}
+	return 1
+}
+
+// View renders the whole screen
+func (d *Dashboard) View() string {
+	width := d.width
+	panels := d.panels()
+	if d.cursor >= len(panels) {
+		d.cursor = len(panels) - 1
+	}
+	if d.cursor < 0 {
+		d.cursor = 0
+	}
+
+	body, cursorLine := d.body(panels, width)
+
+	// Keep the selected panel's header on screen
+	height := d.bodyHeight()
+	if d.follow && cursorLine >= 0 && (cursorLine < d.offset || cursorLine >= d.offset+height) {
+		if cursorLine < d.offset {
+			d.offset = cursorLine
+		} else {
+			d.offset = cursorLine - height + 1
+		}
+	}
+	if last := len(body) - height; d.offset > last {
+		d.offset = last
+	}
+	if d.offset < 0 {
+		d.offset = 0
+	}
+
+	var lines []string
+	line := func(s string) {
+		lines = append(lines, s)
+	}
+
+	line(bold + fit(d.title(), width) + reset)
+	line(fit(d.gpuBar(), width))
+	line("")
+
+	end := d.offset + height
+	if end > len(body) {
+		end = len(body)
+	}
+	for _, l := range body[d.offset:end] {
+		line(l)
+	}
+	for i := end - d.offset; i < height; i++ {
+		line("")
+	}
+
+	line(dim + fit(d.status(), width) + reset)
+	line(fit(d.help(), width))
+	return strings.Join(lines, "\n")
+}
+
+// body renders the panels, returning the lines and the index of the
+// selected panel's header line (-1 without panels)
+func (d *Dashboard) body(panels []panel, width int) ([]string, int) {
+	if d.result == nil {
+		return []string{"Collecting..."}, -1
+	}
+
+	var lines []string
+	cursorLine := -1
+	switch {
+	case len(panels) == 0 && d.filter != "":
+		lines = append(lines, fmt.Sprintf("Nothing matches %q", d.filter), "")
+	case len(panels) == 0:
+		lines = append(lines, "No resources found", "")
+	}
+	for i, p := range panels {
+		marker := "▾"
+		if d.collapsed[p.name] {
+			marker = "▸"
+		}
+		count := fmt.Sprintf("%d resources", p.total)
+		if d.filter != "" {
+			count = fmt.Sprintf("%d of %d resources", len(p.resources), p.total)
+		}
+		header := fmt.Sprintf("%s %s  %s", marker, strings.ToUpper(p.name), count)
+		if p.result != nil && p.result.Stale {
+			header += fmt.Sprintf("  (stale, as of %s)", p.result.LastSuccess.Local().Format("15:04"))
+		}
+		if p.err != nil {
+			header += "  (error)"
+		}
+		header = fit(header, width)
+		if i == d.cursor {
+			cursorLine = len(lines)
+			header = reverse + header + reset
+		}
+		lines = append(lines, header)
+
+		if d.collapsed[p.name] {
+			continue
+		}
+		if p.err != nil {
+			lines = append(lines, fit("    error: "+p.err.Error(), width))
+		}
+		for _, r := range p.resources {
+			lines = append(lines, fit(fmt.Sprintf("    %-30s  %-15s  %-15s  %s",
+				fit(r.Name, 30), fit(r.Type, 15), fit(r.Region, 15), r.Status), width))
+		}
+		lines = append(lines, "")
+	}
+
+	// Providers that never started are listed after the panels
+	if failed := d.result.Init.Failed(); len(failed) > 0 {
+		lines = append(lines, "Not initialized:")
+		for _, name := range failed {
+			lines = append(lines, fit(fmt.Sprintf("    %s: %s", name, d.result.Init[name].Error), width))
+		}
+	}
+	return lines, cursorLine
+}
+
+// title summarizes the latest collection
+func (d *Dashboard) title() string {
+	if d.result == nil {
+		return "cloudtop"
+	}
+	resources := 0
+	for _, r := range d.result.Results {
+		resources += len(r.Resources)
+	}
+	return fmt.Sprintf("cloudtop - %d providers, %d resources - updated %s, every %v",
+		len(d.result.Results), resources, d.result.Timestamp.Local().Format("15:04:05"), d.opts.Interval)
+}
+
+// gpuBar sums GPU instance counts and hourly cost
+func (d *Dashboard) gpuBar() string {
+	if d.result == nil {
+		return ""
+	}
+	if !d.hasGPU {
+		return "GPU: no GPU providers"
+	}
+
+	gpus := 0
+	var hourly float64
+	for _, inst := range d.gpus {
+		gpus += inst.GPUCount
+		hourly += inst.PricePerHour
+	}
+	bar := fmt.Sprintf("GPU: %d instances, %d GPUs, $%.2f/hr ($%.2f/day)", len(d.gpus), gpus, hourly, hourly*24)
+	if n := len(d.gpuErrors); n > 0 {
+		bar += fmt.Sprintf(" - %d provider(s) failed", n)
+	}
+	return bar
+}
+
+// status shows the filter being typed, refresh progress and the last error
+func (d *Dashboard) status() string {
+	var parts []string
+	switch {
+	case d.typing:
+		parts = append(parts, "filter: "+d.filter+"_")
+	case d.filter != "":
+		parts = append(parts, fmt.Sprintf("filter: %q (esc to clear)", d.filter))
+	}
+	if d.loading {
+		parts = append(parts, "refreshing...")
+	}
+	if d.lastErr != nil {
+		parts = append(parts, "refresh failed: "+d.lastErr.Error())
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (d *Dashboard) help() string {
+	if d.typing {
+		return "type to filter  enter keep  esc clear"
+	}
+	return "↑/↓ move  enter collapse  c all  / filter  r refresh  q quit"
+}
+
+// fit cuts s to width runes
+func fit(s string, width int) string {
+	r := []rune(s)
+	if width <= 0 || len(r) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(r[:width])
+	}
+	return string(r[:width-3]) + "..."
+}
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/internal/collector"
+	"github.com/afterdarksys/cloudtop/internal/output"
+	"github.com/afterdarksys/cloudtop/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestDashboard returns a dashboard showing oracle and cloudflare
+// resources, as if a refresh had finished
+func newTestDashboard(t *testing.T) *Dashboard {
+	t.Helper()
+	d := New(collector.NewCollector(nil, nil), Options{Order: []string{"oracle", "cloudflare"}})
+	d.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	d.Update(snapshotMsg{result: &output.CollectResult{
+		Timestamp: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Results: map[string]*output.ProviderResult{
+			"oracle": {Provider: "oracle", Resources: []provider.Resource{
+				{ID: "i-1", Name: "web-1", Type: "compute", Status: "running"},
+				{ID: "i-2", Name: "db-1", Type: "compute", Status: "stopped"},
+			}},
+			"cloudflare": {Provider: "cloudflare", Resources: []provider.Resource{
+				{ID: "w-1", Name: "web-worker", Type: "workers", Status: "active"},
+			}},
+		},
+		Errors: map[string]error{"neon": errors.New("connection reset")},
+	}})
+	return d
+}
+
+func keyRunes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func press(d *Dashboard, keys ...tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		_, cmd = d.Update(k)
+	}
+	return cmd
+}
+
+func TestDashboardNavigation(t *testing.T) {
+	d := newTestDashboard(t)
+
+	if got := len(d.panels()); got != 3 {
+		t.Fatalf("%d panels, want oracle, cloudflare and neon", got)
+	}
+
+	press(d, tea.KeyMsg{Type: tea.KeyDown}, keyRunes("j"), keyRunes("j"))
+	if d.cursor != 2 {
+		t.Errorf("cursor after moving past the end = %d, want 2", d.cursor)
+	}
+	press(d, keyRunes("g"))
+	if d.cursor != 0 {
+		t.Errorf("cursor after g = %d, want 0", d.cursor)
+	}
+
+	press(d, tea.KeyMsg{Type: tea.KeyEnter})
+	if !d.collapsed["oracle"] {
+		t.Error("enter did not collapse the selected panel")
+	}
+	if view := d.View(); strings.Contains(view, "web-1") || !strings.Contains(view, "web-worker") {
+		t.Errorf("collapsed oracle panel still shows its resources:\n%s", view)
+	}
+
+	press(d, keyRunes("c"))
+	if !d.collapsed["cloudflare"] || !d.collapsed["neon"] {
+		t.Error("c did not collapse every panel")
+	}
+	press(d, keyRunes("c"))
+	if d.collapsed["oracle"] || d.collapsed["cloudflare"] {
+		t.Error("c with every panel collapsed did not expand them")
+	}
+}
+
+func TestDashboardFilter(t *testing.T) {
+	d := newTestDashboard(t)
+
+	press(d, keyRunes("/"), keyRunes("w"), keyRunes("e"), keyRunes("x"), tea.KeyMsg{Type: tea.KeyBackspace}, keyRunes("b"))
+	if !d.typing || d.filter != "web" {
+		t.Fatalf("typing %t with filter %q, want typing \"web\"", d.typing, d.filter)
+	}
+
+	// Keys that navigate are typed into the filter while typing
+	press(d, keyRunes("q"), tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyEnter})
+	if d.typing || d.filter != "web" {
+		t.Fatalf("after enter typing %t with filter %q, want the filter kept", d.typing, d.filter)
+	}
+
+	view := d.View()
+	for _, want := range []string{"web-1", "web-worker", "1 of 2 resources", "NEON"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("filtered view does not show %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "db-1") {
+		t.Errorf("filtered view shows db-1:\n%s", view)
+	}
+
+	press(d, tea.KeyMsg{Type: tea.KeyEsc})
+	if d.filter != "" {
+		t.Errorf("filter after esc = %q, want it cleared", d.filter)
+	}
+}
+
+func TestDashboardQuit(t *testing.T) {
+	for _, k := range []tea.KeyMsg{keyRunes("q"), {Type: tea.KeyCtrlC}} {
+		d := newTestDashboard(t)
+		cmd := press(d, k)
+		if cmd == nil {
+			t.Fatalf("%s: no command, want tea.Quit", k)
+		}
+		if _, ok := cmd().(tea.QuitMsg); !ok {
+			t.Errorf("%s: command is not tea.Quit", k)
+		}
+	}
+}
+
+func TestDashboardViewFitsWidth(t *testing.T) {
+	d := newTestDashboard(t)
+	d.Update(tea.WindowSizeMsg{Width: 40, Height: 12})
+
+	lines := strings.Split(d.View(), "\n")
+	if len(lines) != 12 {
+		t.Errorf("view has %d lines, want the terminal height 12", len(lines))
+	}
+	for _, l := range lines {
+		plain := strings.NewReplacer(reverse, "", bold, "", dim, "", reset, "").Replace(l)
+		if n := len([]rune(plain)); n > 40 {
+			t.Errorf("line %q is %d runes wide, want at most 40", plain, n)
+		}
+	}
+}
+
+// TestRunStopsReadingInput checks that Run quits on q and leaves its input
+// alone afterwards, so the shell gets keys typed after the dashboard closes
+func TestRunStopsReadingInput(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	d := New(collector.NewCollector(nil, nil), Options{Interval: time.Hour})
+	d.in, d.out = r, &bytes.Buffer{}
+
+	done := make(chan error, 1)
+	go func() { done <- d.Run(context.Background()) }()
+
+	if _, err := w.Write([]byte("q")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after q")
+	}
+
+	if _, err := w.Write([]byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	r.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 1)
+	if n, err := r.Read(buf); err != nil || string(buf[:n]) != "x" {
+		t.Errorf("reading input after Run = %q, %v; want the key typed after it returned", buf[:n], err)
+	}
+}
+
+func TestRunStopsWithContext(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	d := New(collector.NewCollector(nil, nil), Options{Interval: time.Hour})
+	d.in, d.out = r, &bytes.Buffer{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- d.Run(ctx) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run after cancel = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after its context was cancelled")
+	}
+}
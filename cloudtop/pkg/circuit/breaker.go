@@ -0,0 +1,182 @@
+package circuit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/pkg/clock"
+)
+
+// State is the current state of a circuit breaker
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// OpenError is returned while a breaker is short-circuiting calls
+type OpenError struct {
+	Name    string
+	Until   time.Time
+	LastErr error
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("circuit open for %s until %s (last error: %v)",
+		e.Name, e.Until.Format(time.Kitchen), e.LastErr)
+}
+
+func (e *OpenError) Unwrap() error {
+	return e.LastErr
+}
+
+// IsRetryable reports false so retry loops do not spin on an open circuit
+func (e *OpenError) IsRetryable() bool {
+	return false
+}
+
+// Breaker opens after a run of consecutive failures, rejects calls for a
+// cooldown window, then lets a single probe through (half-open) to decide
+// whether to close again
+type Breaker struct {
+	mu        sync.Mutex
+	name      string
+	threshold int
+	cooldown  time.Duration
+	state     State
+	failures  int
+	openedAt  time.Time
+	lastErr   error
+	probing   bool
+	clock     clock.Clock
+}
+
+// NewBreaker creates a breaker that opens after threshold consecutive failures
+func NewBreaker(name string, threshold int, cooldown time.Duration) *Breaker {
+	return NewBreakerWithClock(name, threshold, cooldown, clock.System())
+}
+
+// NewBreakerWithClock creates a breaker whose cooldown is timed by clk
+func NewBreakerWithClock(name string, threshold int, cooldown time.Duration, clk clock.Clock) *Breaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+	return &Breaker{name: name, threshold: threshold, cooldown: cooldown, clock: clk}
+}
+
+// Allow returns an *OpenError if the call should be short-circuited
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		until := b.openedAt.Add(b.cooldown)
+		if b.clock.Now().Before(until) {
+			return &OpenError{Name: b.name, Until: until, LastErr: b.lastErr}
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return &OpenError{Name: b.name, Until: b.clock.Now(), LastErr: b.lastErr}
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Success records a successful call, closing the breaker
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+	b.lastErr = nil
+	b.probing = false
+}
+
+// Failure records a failed call, opening the breaker once the threshold is
+// reached or immediately if a half-open probe fails
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.lastErr = err
+	b.probing = false
+
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = b.clock.Now()
+	}
+}
+
+// State returns the breaker's current state
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Group holds one breaker per key, created on first use
+type Group struct {
+	mu        sync.Mutex
+	breakers  map[string]*Breaker
+	threshold int
+	cooldown  time.Duration
+	clock     clock.Clock
+}
+
+// NewGroup creates a group whose breakers share threshold and cooldown
+func NewGroup(threshold int, cooldown time.Duration) *Group {
+	return NewGroupWithClock(threshold, cooldown, clock.System())
+}
+
+// NewGroupWithClock creates a group whose breakers are timed by clk
+func NewGroupWithClock(threshold int, cooldown time.Duration, clk clock.Clock) *Group {
+	return &Group{
+		breakers:  make(map[string]*Breaker),
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     clk,
+	}
+}
+
+// Get returns the breaker for name
+func (g *Group) Get(name string) *Breaker {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.breakers[name]
+	if !ok {
+		b = NewBreakerWithClock(name, g.threshold, g.cooldown, g.clock)
+		g.breakers[name] = b
+	}
+	return b
+}
+
+// Reset discards every breaker, so each key starts closed again
+func (g *Group) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.breakers = make(map[string]*Breaker)
+}
@@ -0,0 +1,56 @@
+package circuit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/afterdarksys/cloudtop/pkg/clock"
+)
+
+func TestBreakerCooldown(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	b := NewGroupWithClock(2, time.Minute, clk).Get("aws")
+	callErr := errors.New("throttled")
+
+	b.Failure(callErr)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() below threshold = %v, want nil", err)
+	}
+	b.Failure(callErr)
+	if b.State() != StateOpen {
+		t.Fatalf("state after %d failures = %s, want open", 2, b.State())
+	}
+
+	clk.Advance(59 * time.Second)
+	var open *OpenError
+	if err := b.Allow(); !errors.As(err, &open) {
+		t.Fatalf("Allow() during cooldown = %v, want *OpenError", err)
+	}
+	if want := clk.Now().Add(time.Second); !open.Until.Equal(want) {
+		t.Errorf("Until = %s, want %s", open.Until, want)
+	}
+
+	clk.Advance(time.Second)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() after cooldown = %v, want the half-open probe", err)
+	}
+	if err := b.Allow(); !errors.As(err, &open) {
+		t.Fatalf("second Allow() while probing = %v, want *OpenError", err)
+	}
+
+	// A failed probe reopens for a full cooldown
+	b.Failure(callErr)
+	clk.Advance(30 * time.Second)
+	if err := b.Allow(); err == nil {
+		t.Fatal("Allow() after failed probe = nil, want *OpenError")
+	}
+	clk.Advance(30 * time.Second)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() after second cooldown = %v, want nil", err)
+	}
+	b.Success()
+	if b.State() != StateClosed {
+		t.Errorf("state after successful probe = %s, want closed", b.State())
+	}
+}
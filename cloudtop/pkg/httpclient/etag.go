@@ -0,0 +1,65 @@
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+)
+
+// ETagCache remembers the ETag and body of successful GET responses by
+// URL, so repeat requests can be sent with If-None-Match and a 304 Not
+// Modified answered from the cache. It is safe for concurrent use.
+type ETagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+// NewETagCache creates an empty ETag cache
+func NewETagCache() *ETagCache {
+	return &ETagCache{entries: make(map[string]etagEntry)}
+}
+
+// Prepare adds If-None-Match to a GET request whose URL has a cached ETag
+func (c *ETagCache) Prepare(req *http.Request) {
+	if req.Method != http.MethodGet {
+		return
+	}
+	c.mu.Lock()
+	entry, ok := c.entries[req.URL.String()]
+	c.mu.Unlock()
+	if ok {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+}
+
+// Resolve returns the body to use for a response to req. A 304 returns the
+// cached body; a 200 carrying an ETag is cached and returned as is. ok is
+// false for a 304 with nothing cached, which the caller should retry
+// unconditionally.
+func (c *ETagCache) Resolve(req *http.Request, resp *http.Response, body []byte) (result []byte, ok bool) {
+	key := req.URL.String()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if resp.StatusCode == http.StatusNotModified {
+		entry, found := c.entries[key]
+		if !found {
+			return nil, false
+		}
+		return entry.body, true
+	}
+
+	if req.Method == http.MethodGet && resp.StatusCode == http.StatusOK {
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			c.entries[key] = etagEntry{etag: etag, body: body}
+		} else {
+			delete(c.entries, key)
+		}
+	}
+	return body, true
+}
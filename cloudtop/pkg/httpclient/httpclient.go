@@ -0,0 +1,139 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// DefaultTimeout is used when no timeout is configured
+const DefaultTimeout = 30 * time.Second
+
+// DebugLog, when set, receives a one-line summary of every request made by
+// clients created afterwards. Only the method, host, path, status and
+// duration are logged, so credentials in headers and queries stay out.
+var DebugLog func(format string, args ...interface{})
+
+// Config controls how provider HTTP clients connect
+type Config struct {
+	ProxyURL           string        `json:"proxy_url,omitempty"`
+	CABundle           string        `json:"ca_bundle,omitempty"`
+	InsecureSkipVerify bool          `json:"insecure_skip_verify,omitempty"`
+	Timeout            time.Duration `json:"timeout,omitempty"`
+}
+
+// New creates an HTTP client from config. A nil or empty config yields a
+// client with DefaultTimeout and Go's default transport.
+func New(cfg *Config) (*http.Client, error) {
+	return NewWithTransport(cfg, nil)
+}
+
+// NewWithTransport creates an HTTP client that applies cfg on top of base, a
+// provider-specific transport. A nil base uses Go's default transport.
+func NewWithTransport(cfg *Config, base *http.Transport) (*http.Client, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	client := &http.Client{Timeout: timeout}
+	if base != nil {
+		client.Transport = base
+	}
+
+	if cfg.ProxyURL == "" && cfg.CABundle == "" && !cfg.InsecureSkipVerify {
+		return withDebug(client), nil
+	}
+
+	var transport *http.Transport
+	if base != nil {
+		transport = base.Clone()
+	} else {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", cfg.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if cfg.CABundle != "" || cfg.InsecureSkipVerify {
+		tlsConfig := &tls.Config{}
+		if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		}
+		tlsConfig.InsecureSkipVerify = cfg.InsecureSkipVerify
+
+		if cfg.CABundle != "" {
+			pool, err := loadCABundle(cfg.CABundle)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	client.Transport = transport
+	return withDebug(client), nil
+}
+
+// withDebug wraps the client's transport to log requests when DebugLog is
+// set
+func withDebug(client *http.Client) *http.Client {
+	if DebugLog == nil {
+		return client
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = &debugTransport{base: base, log: DebugLog}
+	return client
+}
+
+// debugTransport logs each request it sends
+type debugTransport struct {
+	base http.RoundTripper
+	log  func(format string, args ...interface{})
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.log("http: %s %s%s failed after %v: %v", req.Method, req.URL.Host, req.URL.Path, elapsed, err)
+		return resp, err
+	}
+	t.log("http: %s %s%s -> %d (%v)", req.Method, req.URL.Host, req.URL.Path, resp.StatusCode, elapsed)
+	return resp, nil
+}
+
+// loadCABundle adds the PEM certificates in path to the system pool
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
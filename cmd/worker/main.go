@@ -10,6 +10,7 @@ import (
 
 	"github.com/afterdarksys/adsops-utils/internal/config"
 	"github.com/afterdarksys/adsops-utils/internal/pkg/logger"
+	"github.com/afterdarksys/adsops-utils/internal/store"
 	"go.uber.org/zap"
 )
 
@@ -42,6 +43,14 @@ func main() {
 	// - Cleanup jobs
 
 	// Start workers
+	st, err := store.New(&cfg.Database)
+	if err != nil {
+		zapLogger.Warn("Database unavailable, ACL expiry sweeper disabled", zap.Error(err))
+	} else {
+		defer st.Close()
+		go store.NewACLSweeper(st.Tickets, store.DefaultACLSweepInterval, nil, zapLogger).Run(ctx)
+	}
+
 	go func() {
 		for {
 			select {
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultACLExpiryWindow is how far ahead ListExpiringACLs looks when no
+// window is given
+const defaultACLExpiryWindow = 7 * 24 * time.Hour
+
+// ListExpiringACLs handles GET /api/v1/acls/expiring
+//
+// Returns the active ticket ACLs that expire within ?within=<duration>
+// (default 168h), soonest first, each with its ticket, principal and the
+// reason it was granted, so access reviewers can renew or let them lapse.
+// Pass ?as_of=<RFC3339> to evaluate against a different point in time.
+func (h *TicketHandler) ListExpiringACLs(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	within := defaultACLExpiryWindow
+	if w := c.Query("within"); w != "" {
+		d, err := time.ParseDuration(w)
+		if err != nil || d <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid within duration"})
+			return
+		}
+		within = d
+	}
+
+	now := time.Now().UTC()
+	if asOf := c.Query("as_of"); asOf != "" {
+		t, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid as_of timestamp"})
+			return
+		}
+		now = t
+	}
+
+	expiring, err := h.store.Tickets.ExpiringACLs(c.Request.Context(), orgID.(uuid.UUID), now, within)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"expiring": expiring,
+		"total":    len(expiring),
+		"within":   within.String(),
+		"as_of":    now,
+	})
+}
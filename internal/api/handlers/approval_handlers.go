@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// ListOverdueApprovals handles GET /api/v1/approvals/overdue
+//
+// Returns submitted tickets whose approval deadline has passed with approval
+// types still outstanding, oldest deadline first. Pass ?as_of=<RFC3339> to
+// evaluate against a different point in time.
+func (h *TicketHandler) ListOverdueApprovals(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	now := time.Now().UTC()
+	if asOf := c.Query("as_of"); asOf != "" {
+		t, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid as_of timestamp"})
+			return
+		}
+		now = t
+	}
+
+	tickets, err := h.store.Tickets.OverdueApprovals(c.Request.Context(), orgID.(uuid.UUID), now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	overdue := make([]models.OverdueApproval, 0, len(tickets))
+	for i := range tickets {
+		overdue = append(overdue, models.NewOverdueApproval(&tickets[i], now))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"overdue": overdue,
+		"total":   len(overdue),
+		"as_of":   now,
+	})
+}
+
+// GetTicketApprovers handles GET /api/v1/tickets/:id/approvers
+//
+// Returns the users who can approve each approval type the ticket requires:
+// the members of the type's approver group who are active approvers for
+// that type, excluding the ticket's creator. Approval types with no approver
+// group, or no eligible members, are listed under "unrouted".
+func (h *TicketHandler) GetTicketApprovers(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ticket ID"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	ticket, err := h.store.Tickets.GetByID(ctx, orgID.(uuid.UUID), ticketID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ticket not found"})
+		return
+	}
+
+	approverGroups, err := h.store.Groups.ApproverGroups(ctx, orgID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	groupIDs := make([]uuid.UUID, 0, len(approverGroups))
+	for _, approvalType := range ticket.RequiresApprovalTypes {
+		if groupID, ok := approverGroups[approvalType]; ok {
+			groupIDs = append(groupIDs, groupID)
+		}
+	}
+	members, err := h.store.Groups.MembersByGroup(ctx, groupIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	users := make(map[uuid.UUID]*models.User)
+	for _, groupMembers := range members {
+		for i := range groupMembers {
+			users[groupMembers[i].ID] = &groupMembers[i]
+		}
+	}
+
+	resolved := models.ResolveApprovers(ticket, approverGroups, members)
+	approvers := make(map[models.ApprovalType][]models.UserSummary, len(resolved))
+	unrouted := []models.ApprovalType{}
+	for _, approvalType := range ticket.RequiresApprovalTypes {
+		ids, ok := resolved[approvalType]
+		if !ok {
+			unrouted = append(unrouted, approvalType)
+			continue
+		}
+		summaries := make([]models.UserSummary, 0, len(ids))
+		for _, id := range ids {
+			summaries = append(summaries, users[id].ToSummary())
+		}
+		approvers[approvalType] = summaries
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ticket_id": ticket.ID,
+		"approvers": approvers,
+		"unrouted":  unrouted,
+	})
+}
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/store"
+)
+
+// resolveMentions looks up the users @-mentioned in a comment. On
+// confidential tickets every mentioned user must be able to see the full
+// ticket; otherwise the offending addresses are returned in denied.
+func (h *TicketHandler) resolveMentions(c *gin.Context, ticket *models.Ticket, text string) (mentioned []models.User, denied []string, err error) {
+	emails := models.ParseMentions(text)
+	if len(emails) == 0 {
+		return nil, nil, nil
+	}
+
+	ctx := c.Request.Context()
+	users, err := h.store.Users.GetByEmails(ctx, ticket.OrganizationID, emails)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, u := range users {
+		if ticket.IsConfidential {
+			roles := make([]string, len(u.Roles))
+			for i, r := range u.Roles {
+				roles[i] = string(r)
+			}
+			role, err := h.store.ACLs.EffectiveRole(ctx, ticket, u.ID, roles)
+			if err != nil || !role.CanViewConfidential() {
+				denied = append(denied, u.Email)
+				continue
+			}
+		}
+		mentioned = append(mentioned, u)
+	}
+
+	return mentioned, denied, nil
+}
+
+// CreateComment handles POST /api/v1/tickets/:id/comments
+//
+// Set parent_comment_id to reply to an existing comment. Replies are limited
+// to one level: a reply to a reply is rejected. Users @-mentioned by email
+// are recorded on the comment, added as ticket watchers and notified.
+func (h *TicketHandler) CreateComment(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ticket ID"})
+		return
+	}
+
+	var input models.CreateCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Comment == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "comment is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	ticket, err := h.store.Tickets.GetByID(ctx, orgID.(uuid.UUID), ticketID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ticket not found"})
+		return
+	}
+	if !h.ticketRole(c, ticket).CanComment() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not permitted to comment on this ticket"})
+		return
+	}
+
+	mentioned, denied, err := h.resolveMentions(c, ticket, input.Comment)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(denied) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  "mentioned users do not have access to this confidential ticket",
+			"emails": denied,
+		})
+		return
+	}
+	// Mentions come from the comment text only, so every recorded mention
+	// has passed the access check above
+	input.MentionedUsers = nil
+	for _, u := range mentioned {
+		input.MentionedUsers = append(input.MentionedUsers, u.ID)
+	}
+
+	comment, err := h.store.Comments.Create(ctx, orgID.(uuid.UUID), ticketID, userID.(uuid.UUID), &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrCommentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "parent comment not found"})
+		case errors.Is(err, store.ErrInvalidCommentParent):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	changes := map[string]interface{}{
+		"comment_id":  comment.ID.String(),
+		"is_internal": comment.IsInternal,
+	}
+	if comment.ParentCommentID != nil {
+		changes["parent_comment_id"] = comment.ParentCommentID.String()
+	}
+	if len(mentioned) > 0 {
+		emails := make([]string, len(mentioned))
+		for i, u := range mentioned {
+			emails[i] = u.Email
+		}
+		changes["mentioned_users"] = emails
+	}
+	h.store.Audit.LogTicketAccess(ctx, ticketID, userID.(uuid.UUID), "comment_create", nil, nil, changes)
+
+	h.notifyMentions(c, ticket, comment.ID, mentioned)
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// notifyMentions subscribes mentioned users to the ticket and sends a
+// ticket.mentioned webhook. Failures are not surfaced; the comment is saved.
+func (h *TicketHandler) notifyMentions(c *gin.Context, ticket *models.Ticket, commentID uuid.UUID, mentioned []models.User) {
+	if len(mentioned) == 0 {
+		return
+	}
+
+	ctx := c.Request.Context()
+	summaries := make([]models.UserSummary, 0, len(mentioned))
+	for _, u := range mentioned {
+		// AddWatcher is a no-op for existing watchers
+		h.store.Tickets.AddWatcher(ctx, ticket.OrganizationID, ticket.ID, u.ID)
+		summaries = append(summaries, u.ToSummary())
+	}
+
+	if h.webhooks != nil {
+		h.webhooks.DispatchMention(ticket, commentID, summaries)
+	}
+}
+
+// ListComments handles GET /api/v1/tickets/:id/comments
+//
+// Comments are returned as threads: top-level comments in creation order,
+// each with its replies nested under "replies". Pass ?flat=true to get a
+// flat list in creation order, with depth set on every comment.
+func (h *TicketHandler) ListComments(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ticket ID"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	ticket, err := h.store.Tickets.GetByID(ctx, orgID.(uuid.UUID), ticketID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ticket not found"})
+		return
+	}
+	role := h.ticketRole(c, ticket)
+
+	comments, err := h.store.Comments.ListByTicket(ctx, orgID.(uuid.UUID), ticketID, role.CanViewConfidential())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	comments = ticket.RedactedComments(role, comments)
+
+	if c.Query("flat") == "true" {
+		c.JSON(http.StatusOK, gin.H{
+			"comments": comments,
+			"total":    len(comments),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comments": models.ThreadComments(comments),
+		"total":    len(comments),
+	})
+}
+
+// UpdateComment handles PATCH /api/v1/comments/:id
+func (h *TicketHandler) UpdateComment(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		return
+	}
+
+	var input models.UpdateCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Comment == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "comment is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	existing, err := h.store.Comments.GetByID(ctx, orgID.(uuid.UUID), commentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+		return
+	}
+	if !existing.CanEdit(userID.(uuid.UUID)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "comments can only be edited by their author within 15 minutes"})
+		return
+	}
+
+	comment, err := h.store.Comments.Update(ctx, orgID.(uuid.UUID), commentID, &input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes := map[string]interface{}{
+		"comment_id": comment.ID.String(),
+		"comment":    map[string]interface{}{"old": existing.Comment, "new": comment.Comment},
+	}
+	h.store.Audit.LogTicketAccess(ctx, comment.TicketID, userID.(uuid.UUID), "comment_edit", nil, nil, changes)
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment handles DELETE /api/v1/comments/:id
+func (h *TicketHandler) DeleteComment(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+	userID, _ := c.Get("user_id")
+
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	existing, err := h.store.Comments.GetByID(ctx, orgID.(uuid.UUID), commentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+		return
+	}
+
+	isAdmin := false
+	if ticket, err := h.store.Tickets.GetByID(ctx, orgID.(uuid.UUID), existing.TicketID); err == nil {
+		isAdmin = h.ticketRole(c, ticket) == models.TicketACLRoleAdmin
+	}
+	if !existing.CanDelete(userID.(uuid.UUID), isAdmin) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not permitted to delete this comment"})
+		return
+	}
+
+	if err := h.store.Comments.Delete(ctx, orgID.(uuid.UUID), commentID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes := map[string]interface{}{
+		"comment_id": existing.ID.String(),
+		"comment":    existing.Comment,
+	}
+	h.store.Audit.LogTicketAccess(ctx, existing.TicketID, userID.(uuid.UUID), "comment_delete", nil, nil, changes)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment deleted",
+	})
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// commentDB is a database/sql connector serving the two queries
+// ListComments makes: ticket ACL grants, of which there are none, and the
+// ticket's comments, each a row of the store's comment columns
+type commentDB struct {
+	comments [][]driver.Value
+}
+
+func (db commentDB) Connect(context.Context) (driver.Conn, error) { return db, nil }
+func (db commentDB) Driver() driver.Driver                        { return nil }
+func (db commentDB) Close() error                                 { return nil }
+func (db commentDB) Begin() (driver.Tx, error)                    { return nil, errors.New("not supported") }
+
+func (db commentDB) Prepare(query string) (driver.Stmt, error) {
+	return commentStmt{db: db, query: query}, nil
+}
+
+type commentStmt struct {
+	db    commentDB
+	query string
+}
+
+func (s commentStmt) Close() error  { return nil }
+func (s commentStmt) NumInput() int { return -1 }
+
+func (s commentStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s commentStmt) Query(args []driver.Value) (driver.Rows, error) {
+	switch {
+	case strings.Contains(s.query, "ticket_acls"):
+		return &commentRows{columns: []string{"acl_role"}}, nil
+	case strings.Contains(s.query, "ticket_comments"):
+		// Column 6 is is_internal; the third argument is includeInternal
+		includeInternal := args[2].(bool)
+		rows := &commentRows{columns: make([]string, 14)}
+		for _, row := range s.db.comments {
+			if includeInternal || !row[6].(bool) {
+				rows.values = append(rows.values, row)
+			}
+		}
+		return rows, nil
+	}
+	return nil, errors.New("unexpected query: " + s.query)
+}
+
+type commentRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *commentRows) Columns() []string { return r.columns }
+func (r *commentRows) Close() error      { return nil }
+
+func (r *commentRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestListCommentsRedactsConfidentialTicket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	orgID, ownerID, viewerID := uuid.New(), uuid.New(), uuid.New()
+	tickets := store.NewMemoryTicketStore()
+	ticket, err := tickets.Create(ctx, orgID, ownerID, &models.CreateTicketInput{
+		Title:          "Rotate HSM keys",
+		Description:    "Rotate the payment HSM master keys",
+		IsConfidential: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	comment := func(text string, internal bool) []driver.Value {
+		return []driver.Value{
+			uuid.NewString(), ticket.ID.String(), orgID.String(), ownerID.String(), nil,
+			text, internal, []byte("{}"), []byte("{https://files.example.com/keys.txt}"),
+			now, now, nil, true, []byte(`[{"previous_comment":"old key is 1234"}]`),
+		}
+	}
+	db := sql.OpenDB(commentDB{comments: [][]driver.Value{
+		comment("new key ceremony is Tuesday", false),
+		comment("internal: vault path secret/hsm", true),
+	}})
+	defer db.Close()
+
+	s := store.NewFromDB(db)
+	s.Tickets = tickets
+	h := NewTicketHandler(s, nil)
+
+	list := func(userID uuid.UUID) []models.Comment {
+		t.Helper()
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("org_id", orgID)
+			c.Set("user_id", userID)
+		})
+		router.GET("/tickets/:id/comments", h.ListComments)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tickets/"+ticket.ID.String()+"/comments?flat=true", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", w.Code, w.Body)
+		}
+		var resp struct {
+			Comments []models.Comment `json:"comments"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Comments
+	}
+
+	viewed := list(viewerID)
+	if len(viewed) != 1 {
+		t.Fatalf("viewer got %d comments, want only the non-internal one", len(viewed))
+	}
+	if c := viewed[0]; c.Comment != models.RedactedMarker || len(c.AttachmentURLs) != 0 || len(c.EditHistory) != 0 {
+		t.Errorf("viewer got comment %q with attachments %v and edit history %s, want it redacted", c.Comment, c.AttachmentURLs, c.EditHistory)
+	}
+
+	owned := list(ownerID)
+	if len(owned) != 2 || owned[0].Comment != "new key ceremony is Tuesday" || len(owned[0].AttachmentURLs) != 1 {
+		t.Errorf("owner got %+v, want both comments in full", owned)
+	}
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ComplianceReport handles GET /api/v1/reports/compliance/:framework
+//
+// :framework is a framework, a comma-separated list of them, or "all".
+// Supported query params: from, to (RFC3339 or YYYY-MM-DD), which bound
+// ticket creation and audit entry times. The report is JSON by default;
+// send "Accept: text/markdown" or "Accept: text/html" for a document to
+// hand to auditors.
+func (h *ReportHandler) ComplianceReport(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	frameworks, err := parseReportFrameworks(c.Param("framework"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	from, to, err := parseReportRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.buildComplianceReport(c.Request.Context(), orgID.(uuid.UUID), frameworks, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	accept := c.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, "text/markdown"):
+		c.Header("Content-Type", "text/markdown; charset=utf-8")
+		c.Status(http.StatusOK)
+		writeComplianceMarkdown(c.Writer, report)
+	case strings.Contains(accept, "text/html"):
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := complianceHTML.Execute(c.Writer, report); err != nil {
+			c.Error(err)
+		}
+	default:
+		c.JSON(http.StatusOK, gin.H{"report": report})
+	}
+}
+
+// parseReportFrameworks parses the :framework path param
+func parseReportFrameworks(value string) ([]models.ComplianceFramework, error) {
+	if strings.EqualFold(value, "all") {
+		var frameworks []models.ComplianceFramework
+		for _, info := range models.ListComplianceFrameworks() {
+			frameworks = append(frameworks, info.Framework)
+		}
+		return frameworks, nil
+	}
+
+	var frameworks []models.ComplianceFramework
+	seen := make(map[models.ComplianceFramework]bool)
+	for _, part := range strings.Split(value, ",") {
+		f := models.ComplianceFramework(strings.ToLower(strings.TrimSpace(part)))
+		if !f.Valid() {
+			return nil, fmt.Errorf("unknown compliance framework: %s", part)
+		}
+		if !seen[f] {
+			seen[f] = true
+			frameworks = append(frameworks, f)
+		}
+	}
+	return frameworks, nil
+}
+
+// buildComplianceReport gathers the tickets and compliance-relevant audit
+// entries for each framework
+func (h *ReportHandler) buildComplianceReport(ctx context.Context, orgID uuid.UUID, frameworks []models.ComplianceFramework, from, to *time.Time) (*models.ComplianceReport, error) {
+	tickets, err := h.store.Tickets.ComplianceTickets(ctx, orgID, frameworks, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ComplianceReport{
+		OrganizationID: orgID,
+		FromDate:       from,
+		ToDate:         to,
+		GeneratedAt:    time.Now().UTC(),
+	}
+
+	relevant := true
+	for _, f := range frameworks {
+		framework := f
+		filter := &models.AuditLogFilter{
+			IsComplianceRelevant: &relevant,
+			Framework:            &framework,
+			FromDate:             from,
+			ToDate:               to,
+			Page:                 1,
+			PerPage:              100,
+		}
+
+		var entries []models.TicketAuditLog
+		for {
+			logs, total, err := h.store.Audit.GetOrganizationAuditLog(ctx, orgID, filter)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, logs...)
+			if filter.Page*filter.PerPage >= total || len(logs) == 0 {
+				break
+			}
+			filter.Page++
+		}
+
+		report.Frameworks = append(report.Frameworks, models.NewFrameworkCompliance(f, tickets, entries))
+	}
+
+	return report, nil
+}
+
+// reportRange describes a report's date range for documents
+func reportRange(from, to *time.Time) string {
+	switch {
+	case from != nil && to != nil:
+		return from.Format("2006-01-02") + " to " + to.Format("2006-01-02")
+	case from != nil:
+		return "since " + from.Format("2006-01-02")
+	case to != nil:
+		return "through " + to.Format("2006-01-02")
+	}
+	return "all time"
+}
+
+func joinApprovals(approvals []models.ApprovalType) string {
+	if len(approvals) == 0 {
+		return "none"
+	}
+	names := make([]string, len(approvals))
+	for i, a := range approvals {
+		names[i] = string(a)
+	}
+	return strings.Join(names, ", ")
+}
+
+// markdownCell escapes a value for a Markdown table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// writeComplianceMarkdown renders the report as a Markdown document
+func writeComplianceMarkdown(w io.Writer, r *models.ComplianceReport) {
+	fmt.Fprintln(w, "# Compliance Report")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "- Period: %s\n", reportRange(r.FromDate, r.ToDate))
+	fmt.Fprintf(w, "- Generated: %s\n", r.GeneratedAt.Format(time.RFC3339))
+
+	for _, f := range r.Frameworks {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "## %s\n\n", f.Framework.DisplayName)
+		if f.Framework.Description != "" {
+			fmt.Fprintf(w, "%s\n\n", f.Framework.Description)
+		}
+		fmt.Fprintf(w, "- Required approvals: %s\n", joinApprovals(f.Framework.RequiredApprovals))
+		fmt.Fprintf(w, "- Record retention: %d years\n", f.Framework.RetentionYears)
+		fmt.Fprintf(w, "- Tickets with complete approvals: %d of %d\n", f.CompleteTickets, f.TotalTickets)
+		fmt.Fprintf(w, "- Compliance-relevant audit entries: %d\n\n", len(f.AuditEntries))
+
+		if len(f.Tickets) == 0 {
+			fmt.Fprintln(w, "No tickets in this period.")
+		} else {
+			fmt.Fprintln(w, "| Ticket | Title | Status | Created | Missing approvals |")
+			fmt.Fprintln(w, "|---|---|---|---|---|")
+			for _, t := range f.Tickets {
+				missing := "-"
+				if !t.Complete {
+					missing = joinApprovals(t.MissingApprovals)
+				}
+				fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n",
+					t.TicketNumber, markdownCell(t.Title), t.Status, t.CreatedAt.Format("2006-01-02"), missing)
+			}
+		}
+
+		if len(f.AuditEntries) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "### Audit entries")
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "| Time | Ticket | Action | Category | Reviewed |")
+			fmt.Fprintln(w, "|---|---|---|---|---|")
+			for _, e := range f.AuditEntries {
+				reviewed := "no"
+				if e.ReviewedAt != nil {
+					reviewed = e.ReviewedAt.Format("2006-01-02")
+				} else if !e.RequiresReview {
+					reviewed = "n/a"
+				}
+				fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n",
+					e.CreatedAt.UTC().Format(time.RFC3339), e.TicketID, markdownCell(e.Action), e.ActionCategory, reviewed)
+			}
+		}
+	}
+}
+
+var complianceHTML = template.Must(template.New("compliance").Funcs(template.FuncMap{
+	"period":    reportRange,
+	"approvals": joinApprovals,
+	"date":      func(t time.Time) string { return t.Format("2006-01-02") },
+	"timestamp": func(t time.Time) string { return t.UTC().Format(time.RFC3339) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Compliance Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+.incomplete { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Compliance Report</h1>
+<p>Period: {{period .FromDate .ToDate}}<br>Generated: {{timestamp .GeneratedAt}}</p>
+{{range .Frameworks}}
+<h2>{{.Framework.DisplayName}}</h2>
+{{with .Framework.Description}}<p>{{.}}</p>{{end}}
+<ul>
+<li>Required approvals: {{approvals .Framework.RequiredApprovals}}</li>
+<li>Record retention: {{.Framework.RetentionYears}} years</li>
+<li>Tickets with complete approvals: {{.CompleteTickets}} of {{.TotalTickets}}</li>
+<li>Compliance-relevant audit entries: {{len .AuditEntries}}</li>
+</ul>
+{{if .Tickets}}
+<table>
+<tr><th>Ticket</th><th>Title</th><th>Status</th><th>Created</th><th>Missing approvals</th></tr>
+{{range .Tickets}}<tr><td>{{.TicketNumber}}</td><td>{{.Title}}</td><td>{{.Status}}</td><td>{{date .CreatedAt}}</td>{{if .Complete}}<td>-</td>{{else}}<td class="incomplete">{{approvals .MissingApprovals}}</td>{{end}}</tr>
+{{end}}</table>
+{{else}}
+<p>No tickets in this period.</p>
+{{end}}
+{{if .AuditEntries}}
+<h3>Audit entries</h3>
+<table>
+<tr><th>Time</th><th>Ticket</th><th>Action</th><th>Category</th><th>Reviewed</th></tr>
+{{range .AuditEntries}}<tr><td>{{timestamp .CreatedAt}}</td><td>{{.TicketID}}</td><td>{{.Action}}</td><td>{{.ActionCategory}}</td><td>{{if .ReviewedAt}}{{date .ReviewedAt}}{{else if .RequiresReview}}no{{else}}n/a{{end}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
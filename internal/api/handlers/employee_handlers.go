@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/store"
+)
+
+// EmployeeHandler handles employee directory requests
+type EmployeeHandler struct {
+	store *store.Store
+}
+
+// NewEmployeeHandler creates a new employee handler
+func NewEmployeeHandler(s *store.Store) *EmployeeHandler {
+	return &EmployeeHandler{store: s}
+}
+
+// SearchEmployees handles GET /api/v1/employees
+//
+// Supported query params: search, department, employee_type, office_location,
+// manager_id, consulting_company, skill, certification, match_all,
+// include_inactive, page, per_page, sort_by, sort_order. skill and
+// certification may be repeated or comma-separated; employees holding any of
+// them match unless match_all=true.
+func (h *EmployeeHandler) SearchEmployees(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	filter := &models.EmployeeSearchFilter{
+		Search:         c.Query("search"),
+		Skills:         splitQueryList(c.QueryArray("skill")),
+		Certifications: splitQueryList(c.QueryArray("certification")),
+		SortBy:         c.Query("sort_by"),
+		SortOrder:      c.Query("sort_order"),
+	}
+	if v := c.Query("department"); v != "" {
+		filter.Department = &v
+	}
+	if v := c.Query("office_location"); v != "" {
+		filter.OfficeLocation = &v
+	}
+	if v := c.Query("consulting_company"); v != "" {
+		filter.ConsultingCompany = &v
+	}
+	if v := c.Query("employee_type"); v != "" {
+		t := models.EmployeeType(v)
+		if !t.Valid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee_type: " + v})
+			return
+		}
+		filter.EmployeeType = &t
+	}
+	if v := c.Query("manager_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manager_id"})
+			return
+		}
+		filter.ManagerID = &id
+	}
+	filter.MatchAll, _ = strconv.ParseBool(c.Query("match_all"))
+	filter.IncludeInactive, _ = strconv.ParseBool(c.Query("include_inactive"))
+	filter.Page, _ = strconv.Atoi(c.Query("page"))
+	filter.PerPage, _ = strconv.Atoi(c.Query("per_page"))
+
+	employees, total, err := h.store.Employees.Search(c.Request.Context(), orgID.(uuid.UUID), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"employees": employees,
+		"total":     total,
+		"page":      filter.Page,
+		"per_page":  filter.PerPage,
+	})
+}
+
+// ListSkills handles GET /api/v1/employees/skills
+//
+// Lists distinct skills with the number of employees holding each, for
+// autocomplete. Pass ?type=certifications to list certifications instead.
+func (h *EmployeeHandler) ListSkills(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	kind := c.DefaultQuery("type", "skills")
+	if kind != "skills" && kind != "certifications" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be skills or certifications"})
+		return
+	}
+
+	skills, err := h.store.Employees.ListSkills(c.Request.Context(), orgID.(uuid.UUID), kind == "certifications")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		kind:    skills,
+		"total": len(skills),
+	})
+}
+
+// splitQueryList flattens repeated and comma-separated query values
+func splitQueryList(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+// OrgChart handles GET /api/v1/employees/org-chart
+//
+// Returns the organization's reporting tree. Pass ?root=<id> (a user or
+// employee profile ID) to return only that employee's subtree, along with
+// the chain of managers they report to.
+func (h *EmployeeHandler) OrgChart(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	profiles, err := h.store.Employees.ListDirectory(c.Request.Context(), orgID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	chart := models.BuildOrgChart(profiles)
+
+	rootParam := c.Query("root")
+	if rootParam == "" {
+		c.JSON(http.StatusOK, gin.H{
+			"org_chart": chart,
+			"total":     len(profiles),
+		})
+		return
+	}
+
+	rootID, err := uuid.Parse(rootParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid root ID"})
+		return
+	}
+	node := chart.Find(rootID)
+	if node == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "employee not found"})
+		return
+	}
+
+	var chain []models.EmployeeDirectoryEntry
+	for i := range profiles {
+		if profiles[i].UserID == node.Employee.UserID {
+			for _, m := range profiles[i].ReportingChain(profiles) {
+				chain = append(chain, m.ToDirectoryEntry())
+			}
+			break
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"org_chart":       node,
+		"reporting_chain": chain,
+		"cycles":          chart.Cycles,
+	})
+}
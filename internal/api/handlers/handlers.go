@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/afterdarksys/adsops-utils/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
@@ -212,6 +213,11 @@ func APIDocumentation(c *gin.Context) {
                 <span class="path">/v1/approvals</span>
                 <span class="desc">List pending approvals</span>
             </div>
+            <div class="endpoint">
+                <span class="method get">GET</span>
+                <span class="path">/v1/approvals/overdue</span>
+                <span class="desc">Tickets past their approval deadline</span>
+            </div>
             <div class="endpoint">
                 <span class="method post">POST</span>
                 <span class="path">/v1/approvals/:id/approve</span>
@@ -330,6 +336,9 @@ func CloseTicket(c *gin.Context)        { notImplemented(c) }
 func ReopenTicket(c *gin.Context)       { notImplemented(c) }
 func GetTicketRevisions(c *gin.Context) { notImplemented(c) }
 func GetTicketAudit(c *gin.Context)     { notImplemented(c) }
+func GetTicketRollup(c *gin.Context)    { notImplemented(c) }
+func GetTicketConflicts(c *gin.Context) { notImplemented(c) }
+func GetTicketApprovers(c *gin.Context) { notImplemented(c) }
 
 // Additional ticket endpoints
 func GetTicketQueue(c *gin.Context)     { notImplemented(c) }
@@ -339,6 +348,10 @@ func UnlinkRepository(c *gin.Context)   { notImplemented(c) }
 func AddWatcher(c *gin.Context)         { notImplemented(c) }
 func RemoveWatcher(c *gin.Context)      { notImplemented(c) }
 
+// Label handlers - implemented on TicketHandler in label_handlers.go
+func ListLabels(c *gin.Context)         { notImplemented(c) }
+func RenameLabel(c *gin.Context)        { notImplemented(c) }
+
 // Repository handlers
 func ListRepositories(c *gin.Context)   { notImplemented(c) }
 func CreateRepository(c *gin.Context)   { notImplemented(c) }
@@ -364,13 +377,17 @@ func RemoveGroupMember(c *gin.Context)  { notImplemented(c) }
 
 // Employee directory handlers
 func SearchEmployees(c *gin.Context)    { notImplemented(c) }
+func CreateEmployee(c *gin.Context)     { notImplemented(c) }
 func GetEmployee(c *gin.Context)        { notImplemented(c) }
 func UpdateEmployee(c *gin.Context)     { notImplemented(c) }
+func OrgChart(c *gin.Context)           { notImplemented(c) }
+func ListSkills(c *gin.Context)         { notImplemented(c) }
 
 // Ticket ACL handlers
 func GetTicketACLs(c *gin.Context)      { notImplemented(c) }
 func GrantTicketACL(c *gin.Context)     { notImplemented(c) }
 func RevokeTicketACL(c *gin.Context)    { notImplemented(c) }
+func ListExpiringACLs(c *gin.Context)   { notImplemented(c) }
 
 // Failed signup handlers
 func CollectFailedSignupContact(c *gin.Context) { notImplemented(c) }
@@ -386,6 +403,7 @@ func RequestUpdate(c *gin.Context)      { notImplemented(c) }
 func ApproveByToken(c *gin.Context)     { notImplemented(c) }
 func DenyByToken(c *gin.Context)        { notImplemented(c) }
 func GetApprovalByToken(c *gin.Context) { notImplemented(c) }
+func ListOverdueApprovals(c *gin.Context) { notImplemented(c) }
 
 // Comment handlers
 func CreateComment(c *gin.Context)      { notImplemented(c) }
@@ -404,11 +422,20 @@ func EnableUserMFA(c *gin.Context)      { notImplemented(c) }
 func DisableUserMFA(c *gin.Context)     { notImplemented(c) }
 
 // Compliance handlers
-func ListComplianceFrameworks(c *gin.Context) { notImplemented(c) }
 func ListComplianceTemplates(c *gin.Context)  { notImplemented(c) }
 func CreateComplianceTemplate(c *gin.Context) { notImplemented(c) }
 
-// Report handlers
+// ListComplianceFrameworks returns the compliance framework registry
+func ListComplianceFrameworks(c *gin.Context) {
+	frameworks := models.ListComplianceFrameworks()
+	c.JSON(http.StatusOK, gin.H{
+		"frameworks": frameworks,
+		"total":      len(frameworks),
+	})
+}
+
+// Report handlers - AuditReport and ComplianceReport are implemented on
+// ReportHandler in report_handlers.go and compliance_report.go
 func AuditReport(c *gin.Context)        { notImplemented(c) }
 func ComplianceReport(c *gin.Context)   { notImplemented(c) }
 func UserActivityReport(c *gin.Context) { notImplemented(c) }
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// ListLabels handles GET /api/v1/labels
+func (h *TicketHandler) ListLabels(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	labels, err := h.store.Tickets.ListLabels(c.Request.Context(), orgID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"labels": labels,
+		"total":  len(labels),
+	})
+}
+
+// RenameLabel handles POST /api/v1/labels/rename
+func (h *TicketHandler) RenameLabel(c *gin.Context) {
+	var input models.RenameLabelInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.From = strings.TrimSpace(input.From)
+	input.To = strings.TrimSpace(input.To)
+	if input.From == "" || input.To == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to labels are required"})
+		return
+	}
+	if input.From == input.To {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to labels must differ"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	orgID, _ := c.Get("org_id")
+
+	updated, err := h.store.Tickets.RenameLabel(c.Request.Context(), orgID.(uuid.UUID), userID.(uuid.UUID), input.From, input.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"from":            input.From,
+		"to":              input.To,
+		"tickets_updated": updated,
+	})
+}
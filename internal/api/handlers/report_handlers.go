@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/store"
+)
+
+// ReportHandler handles compliance and audit reporting requests
+type ReportHandler struct {
+	store *store.Store
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(s *store.Store) *ReportHandler {
+	return &ReportHandler{store: s}
+}
+
+// AuditReport handles GET /api/v1/reports/audit
+//
+// Supported query params: from, to (RFC3339 or YYYY-MM-DD), action_category,
+// is_compliance_relevant, framework, page, per_page. Send "Accept: text/csv"
+// to receive every matching entry as a CSV export instead of JSON.
+func (h *ReportHandler) AuditReport(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	filter, err := parseAuditLogFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if strings.Contains(c.GetHeader("Accept"), "text/csv") {
+		h.writeAuditCSV(c, orgID.(uuid.UUID), filter)
+		return
+	}
+
+	report, err := h.store.Audit.SummarizeAuditLog(ctx, orgID.(uuid.UUID), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, _, err := h.store.Audit.GetOrganizationAuditLog(ctx, orgID.(uuid.UUID), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	report.Entries = entries
+	report.GeneratedAt = time.Now().UTC()
+
+	c.JSON(http.StatusOK, gin.H{
+		"report":   report,
+		"page":     filter.Page,
+		"per_page": filter.PerPage,
+	})
+}
+
+// writeAuditCSV streams all matching audit entries as CSV
+func (h *ReportHandler) writeAuditCSV(c *gin.Context, orgID uuid.UUID, filter *models.AuditLogFilter) {
+	filter.Page = 1
+	filter.PerPage = 100
+
+	filename := fmt.Sprintf("audit-report-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{
+		"id", "created_at", "ticket_id", "user_id", "action", "action_category",
+		"is_compliance_relevant", "compliance_frameworks", "requires_review", "reviewed_at",
+	})
+
+	for {
+		logs, total, err := h.store.Audit.GetOrganizationAuditLog(c.Request.Context(), orgID, filter)
+		if err != nil {
+			// Headers are already sent; record the failure in the export itself
+			w.Write([]string{"error", err.Error()})
+			break
+		}
+
+		for _, log := range logs {
+			userID := ""
+			if log.UserID != nil {
+				userID = log.UserID.String()
+			}
+			reviewedAt := ""
+			if log.ReviewedAt != nil {
+				reviewedAt = log.ReviewedAt.UTC().Format(time.RFC3339)
+			}
+			frameworks := make([]string, len(log.ComplianceFrameworks))
+			for i, f := range log.ComplianceFrameworks {
+				frameworks[i] = string(f)
+			}
+
+			w.Write([]string{
+				log.ID.String(),
+				log.CreatedAt.UTC().Format(time.RFC3339),
+				log.TicketID.String(),
+				userID,
+				log.Action,
+				log.ActionCategory,
+				strconv.FormatBool(log.IsComplianceRelevant),
+				strings.Join(frameworks, ";"),
+				strconv.FormatBool(log.RequiresReview),
+				reviewedAt,
+			})
+		}
+
+		if filter.Page*filter.PerPage >= total || len(logs) == 0 {
+			break
+		}
+		filter.Page++
+	}
+
+	w.Flush()
+}
+
+// parseAuditLogFilter builds an audit log filter from query params
+func parseAuditLogFilter(c *gin.Context) (*models.AuditLogFilter, error) {
+	filter := &models.AuditLogFilter{}
+
+	var err error
+	if filter.FromDate, filter.ToDate, err = parseReportRange(c); err != nil {
+		return nil, err
+	}
+
+	if category := c.Query("action_category"); category != "" {
+		filter.ActionCategory = &category
+	}
+	if relevant := c.Query("is_compliance_relevant"); relevant != "" {
+		b, err := strconv.ParseBool(relevant)
+		if err != nil {
+			return nil, fmt.Errorf("invalid is_compliance_relevant value: %s", relevant)
+		}
+		filter.IsComplianceRelevant = &b
+	}
+	if framework := c.Query("framework"); framework != "" {
+		f := models.ComplianceFramework(framework)
+		if !f.Valid() {
+			return nil, fmt.Errorf("unknown compliance framework: %s", framework)
+		}
+		filter.Framework = &f
+	}
+
+	filter.Page, _ = strconv.Atoi(c.Query("page"))
+	filter.PerPage, _ = strconv.Atoi(c.Query("per_page"))
+	filter.SetDefaults()
+
+	return filter, nil
+}
+
+// parseReportRange reads the from and to query params. Either may be
+// absent; a bare to date includes the whole day.
+func parseReportRange(c *gin.Context) (from, to *time.Time, err error) {
+	if value := c.Query("from"); value != "" {
+		t, err := parseReportDate(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid from date: %s", value)
+		}
+		from = &t
+	}
+	if value := c.Query("to"); value != "" {
+		t, err := parseReportDate(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid to date: %s", value)
+		}
+		if len(value) == len("2006-01-02") {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		to = &t
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, nil, fmt.Errorf("to date must be after from date")
+	}
+	return from, to, nil
+}
+
+// parseReportDate accepts RFC3339 timestamps or plain YYYY-MM-DD dates
+func parseReportDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
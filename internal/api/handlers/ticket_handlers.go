@@ -1,22 +1,67 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/notify"
 	"github.com/afterdarksys/adsops-utils/internal/store"
 )
 
 // TicketHandler handles ticket-related HTTP requests
 type TicketHandler struct {
-	store *store.Store
+	store    *store.Store
+	webhooks *notify.WebhookDispatcher
 }
 
-// NewTicketHandler creates a new ticket handler
-func NewTicketHandler(s *store.Store) *TicketHandler {
-	return &TicketHandler{store: s}
+// NewTicketHandler creates a new ticket handler. webhooks may be nil when
+// webhook delivery is disabled.
+func NewTicketHandler(s *store.Store, webhooks *notify.WebhookDispatcher) *TicketHandler {
+	return &TicketHandler{store: s, webhooks: webhooks}
+}
+
+// emit sends a webhook event for the ticket, reloading it to get current state
+func (h *TicketHandler) emit(c *gin.Context, event notify.WebhookEvent, orgID, ticketID uuid.UUID) {
+	if h.webhooks == nil {
+		return
+	}
+	ticket, err := h.store.Tickets.GetByID(c.Request.Context(), orgID, ticketID)
+	if err != nil {
+		return
+	}
+	h.webhooks.Dispatch(event, ticket)
+}
+
+// ticketRole resolves the caller's effective role on a ticket. Lookup
+// failures fall back to the viewer role so confidential content is never
+// leaked.
+func (h *TicketHandler) ticketRole(c *gin.Context, ticket *models.Ticket) models.TicketACLRole {
+	userID, _ := c.Get("user_id")
+	uid, _ := userID.(uuid.UUID)
+	roles, _ := c.Get("roles")
+	userRoles, _ := roles.([]string)
+
+	role, err := h.store.ACLs.EffectiveRole(c.Request.Context(), ticket, uid, userRoles)
+	if err != nil {
+		return models.TicketACLRoleViewer
+	}
+	return role
+}
+
+// redact applies confidentiality rules for the calling user
+func (h *TicketHandler) redact(c *gin.Context, ticket *models.Ticket) *models.Ticket {
+	if !ticket.IsConfidential {
+		return ticket
+	}
+	return ticket.Redacted(h.ticketRole(c, ticket))
 }
 
 // CreateTicket handles POST /api/v1/tickets
@@ -49,6 +94,11 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 		ticket.Status = models.TicketStatusSubmitted
 	}
 
+	h.webhooks.Dispatch(notify.EventTicketCreated, ticket)
+	if input.Submit {
+		h.webhooks.Dispatch(notify.EventTicketSubmitted, ticket)
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"ticket": ticket,
 	})
@@ -87,6 +137,9 @@ func (h *TicketHandler) ListTickets(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	for i := range tickets {
+		tickets[i] = *h.redact(c, &tickets[i])
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"tickets": tickets,
@@ -120,12 +173,42 @@ func (h *TicketHandler) GetTicket(c *gin.Context) {
 	repos, _ := h.store.Repositories.GetTicketRepositories(c.Request.Context(), ticketID)
 	ticket.Repositories = repos
 
+	setTicketETag(c, ticket)
 	c.JSON(http.StatusOK, gin.H{
-		"ticket": ticket,
+		"ticket": h.redact(c, ticket),
+	})
+}
+
+// GetTicketRollup handles GET /api/v1/tickets/:id/rollup
+func (h *TicketHandler) GetTicketRollup(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ticket ID"})
+		return
+	}
+
+	if _, err := h.store.Tickets.GetByID(c.Request.Context(), orgID.(uuid.UUID), ticketID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ticket not found"})
+		return
+	}
+
+	rollup, err := h.store.Tickets.EpicRollup(c.Request.Context(), orgID.(uuid.UUID), ticketID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rollup": rollup,
 	})
 }
 
 // UpdateTicket handles PATCH /api/v1/tickets/:id
+//
+// Requests sent as application/merge-patch+json are applied as RFC 7386
+// merge patches; anything else is bound to UpdateTicketInput.
 func (h *TicketHandler) UpdateTicket(c *gin.Context) {
 	orgID, _ := c.Get("org_id")
 	userID, _ := c.Get("user_id")
@@ -136,13 +219,28 @@ func (h *TicketHandler) UpdateTicket(c *gin.Context) {
 		return
 	}
 
+	if c.ContentType() == models.MergePatchContentType {
+		h.patchTicket(c, orgID.(uuid.UUID), userID.(uuid.UUID), ticketID)
+		return
+	}
+
 	var input models.UpdateTicketInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	version, ok := requireVersion(c, input.Version)
+	if !ok {
+		return
+	}
+	input.Version = &version
+
 	ticket, err := h.store.Tickets.Update(c.Request.Context(), orgID.(uuid.UUID), ticketID, &input)
+	if errors.Is(err, store.ErrVersionConflict) {
+		h.versionConflict(c, orgID.(uuid.UUID), ticketID)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -151,11 +249,189 @@ func (h *TicketHandler) UpdateTicket(c *gin.Context) {
 	// Log audit
 	h.store.Audit.LogTicketEdit(c.Request.Context(), ticketID, userID.(uuid.UUID), nil, nil, nil)
 
+	setTicketETag(c, ticket)
 	c.JSON(http.StatusOK, gin.H{
 		"ticket": ticket,
 	})
 }
 
+// patchTicket applies a JSON merge patch onto the current ticket, validates
+// the result and saves it as a new revision
+func (h *TicketHandler) patchTicket(c *gin.Context, orgID, userID, ticketID uuid.UUID) {
+	ctx := c.Request.Context()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	// A "version" member is the precondition, not part of the patch
+	patch, bodyVersion, err := splitPatchVersion(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	version, ok := requireVersion(c, bodyVersion)
+	if !ok {
+		return
+	}
+
+	before, err := h.store.Tickets.GetByID(ctx, orgID, ticketID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ticket not found"})
+		return
+	}
+	if before.Version != version {
+		h.versionConflict(c, orgID, ticketID)
+		return
+	}
+	if !before.CanEdit() {
+		c.JSON(http.StatusConflict, gin.H{"error": "ticket cannot be edited in current status"})
+		return
+	}
+
+	after, err := before.ApplyMergePatch(patch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := after.Validate(); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	ticket, err := h.store.Tickets.Patch(ctx, orgID, userID, before, after)
+	if errors.Is(err, store.ErrVersionConflict) {
+		h.versionConflict(c, orgID, ticketID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes := make(map[string]interface{})
+	for field, change := range models.DiffTickets(before, ticket) {
+		changes[field] = change
+	}
+	h.store.Audit.LogTicketEdit(ctx, ticketID, userID, nil, nil, changes)
+
+	setTicketETag(c, ticket)
+	c.JSON(http.StatusOK, gin.H{
+		"ticket": ticket,
+	})
+}
+
+// requireVersion returns the ticket version the client based its edit on,
+// taken from the If-Match header or, failing that, the request body. It
+// writes an error response and returns false when neither is usable.
+func requireVersion(c *gin.Context, bodyVersion *int) (int, bool) {
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		tag := strings.TrimPrefix(strings.TrimSpace(ifMatch), "W/")
+		version, err := strconv.Atoi(strings.Trim(tag, `"`))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "If-Match must be a ticket version"})
+			return 0, false
+		}
+		return version, true
+	}
+	if bodyVersion != nil {
+		return *bodyVersion, true
+	}
+
+	c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header or version is required"})
+	return 0, false
+}
+
+// splitPatchVersion removes the "version" member from a merge patch,
+// returning the remaining patch and the version if one was present
+func splitPatchVersion(body []byte) ([]byte, *int, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, nil, fmt.Errorf("merge patch must be a JSON object: %w", err)
+	}
+
+	raw, ok := fields["version"]
+	if !ok {
+		return body, nil, nil
+	}
+
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return nil, nil, fmt.Errorf("version must be an integer")
+	}
+	delete(fields, "version")
+
+	patch, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	return patch, &version, nil
+}
+
+// versionConflict reports a stale edit along with the ticket's current
+// version so the client can refetch and retry
+func (h *TicketHandler) versionConflict(c *gin.Context, orgID, ticketID uuid.UUID) {
+	response := gin.H{"error": store.ErrVersionConflict.Error()}
+	if current, err := h.store.Tickets.GetByID(c.Request.Context(), orgID, ticketID); err == nil {
+		response["current_version"] = current.Version
+		setTicketETag(c, current)
+	}
+	c.JSON(http.StatusConflict, response)
+}
+
+// setTicketETag exposes the ticket version for use in If-Match
+func setTicketETag(c *gin.Context, ticket *models.Ticket) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, ticket.Version))
+}
+
+// GetTicketConflicts handles GET /api/v1/tickets/:id/conflicts
+//
+// Lists other tickets scheduled against any of this ticket's affected
+// systems during an overlapping change window.
+func (h *TicketHandler) GetTicketConflicts(c *gin.Context) {
+	orgID, _ := c.Get("org_id")
+
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ticket ID"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	ticket, err := h.store.Tickets.GetByID(ctx, orgID.(uuid.UUID), ticketID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ticket not found"})
+		return
+	}
+	if !ticket.HasScheduledWindow() {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "ticket has no scheduled window"})
+		return
+	}
+
+	start, end := *ticket.ScheduledStart, *ticket.ScheduledEnd
+	tickets, err := h.store.Tickets.ConflictingTickets(ctx, orgID.(uuid.UUID), ticket.ID, start, end, ticket.AffectedSystems)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	conflicts := make([]models.TicketConflict, 0, len(tickets))
+	for i := range tickets {
+		if conflict, ok := tickets[i].ConflictWith(start, end, ticket.AffectedSystems); ok {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scheduled_start": start,
+		"scheduled_end":   end,
+		"conflicts":       conflicts,
+		"total":           len(conflicts),
+	})
+}
+
 // SubmitTicket handles POST /api/v1/tickets/:id/submit
 func (h *TicketHandler) SubmitTicket(c *gin.Context) {
 	orgID, _ := c.Get("org_id")
@@ -174,6 +450,7 @@ func (h *TicketHandler) SubmitTicket(c *gin.Context) {
 
 	// Log status change
 	h.store.Audit.LogTicketStatusChange(c.Request.Context(), ticketID, userID.(uuid.UUID), "draft", "submitted", nil, nil)
+	h.emit(c, notify.EventTicketSubmitted, orgID.(uuid.UUID), ticketID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Ticket submitted for approval",
@@ -227,6 +504,7 @@ func (h *TicketHandler) CloseTicket(c *gin.Context) {
 
 	// Log status change
 	h.store.Audit.LogTicketStatusChange(c.Request.Context(), ticketID, userID.(uuid.UUID), "completed", "closed", nil, nil)
+	h.emit(c, notify.EventTicketClosed, orgID.(uuid.UUID), ticketID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Ticket closed",
@@ -76,12 +76,22 @@ func NewRouter(cfg *config.Config, logger *zap.Logger) *gin.Engine {
 				tickets.POST("/:id/reopen", handlers.ReopenTicket)
 				tickets.GET("/:id/revisions", handlers.GetTicketRevisions)
 				tickets.GET("/:id/audit", handlers.GetTicketAudit)
+				tickets.GET("/:id/rollup", handlers.GetTicketRollup)
+				tickets.GET("/:id/conflicts", handlers.GetTicketConflicts)
+				tickets.GET("/:id/approvers", handlers.GetTicketApprovers)
 
 				// Comments
 				tickets.POST("/:id/comments", handlers.CreateComment)
 				tickets.GET("/:id/comments", handlers.ListComments)
 			}
 
+			// Labels
+			labels := protected.Group("/labels")
+			{
+				labels.GET("", handlers.ListLabels)
+				labels.POST("/rename", handlers.RenameLabel)
+			}
+
 			// Comments (for editing/deleting by ID)
 			comments := protected.Group("/comments")
 			{
@@ -89,16 +99,35 @@ func NewRouter(cfg *config.Config, logger *zap.Logger) *gin.Engine {
 				comments.DELETE("/:id", handlers.DeleteComment)
 			}
 
+			// Employee directory
+			employees := protected.Group("/employees")
+			{
+				employees.GET("", handlers.SearchEmployees)
+				employees.POST("", handlers.CreateEmployee)
+				employees.GET("/org-chart", handlers.OrgChart)
+				employees.GET("/skills", handlers.ListSkills)
+				employees.GET("/:id", handlers.GetEmployee)
+				employees.PATCH("/:id", handlers.UpdateEmployee)
+			}
+
 			// Approvals
 			approvals := protected.Group("/approvals")
 			{
 				approvals.GET("", handlers.ListApprovals)
+				approvals.GET("/overdue", handlers.ListOverdueApprovals)
 				approvals.GET("/:id", handlers.GetApproval)
 				approvals.POST("/:id/approve", handlers.Approve)
 				approvals.POST("/:id/deny", handlers.Deny)
 				approvals.POST("/:id/request-update", handlers.RequestUpdate)
 			}
 
+			// Ticket ACL review
+			acls := protected.Group("/acls")
+			acls.Use(middleware.RequireRole("admin", "auditor"))
+			{
+				acls.GET("/expiring", handlers.ListExpiringACLs)
+			}
+
 			// Users (admin only)
 			users := protected.Group("/users")
 			users.Use(middleware.RequireRole("admin"))
@@ -0,0 +1,59 @@
+package employee
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/apiclient"
+	"github.com/google/uuid"
+)
+
+// employeeClient calls the user and employee APIs
+type employeeClient struct {
+	*apiclient.Client
+}
+
+// newEmployeeClient returns a client for the changes API, with apiURL and
+// token overriding the configured values when set
+func newEmployeeClient(apiURL, token string) (*employeeClient, error) {
+	client, err := apiclient.FromConfig(apiclient.ChangesAPI, apiURL, token)
+	if err != nil {
+		return nil, err
+	}
+	return &employeeClient{client}, nil
+}
+
+// findEmployee returns the directory entry of the employee with the given
+// email, including inactive ones, or nil when there is none
+func (c *employeeClient) findEmployee(email string) (*models.EmployeeDirectoryEntry, error) {
+	query := url.Values{"search": {email}, "include_inactive": {"true"}}
+	var result struct {
+		Employees []models.EmployeeDirectoryEntry `json:"employees"`
+	}
+	if err := c.JSON(http.MethodGet, "/v1/employees?"+query.Encode(), nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to look up %s: %w", email, err)
+	}
+	for _, e := range result.Employees {
+		if normalizeEmail(e.Email) == normalizeEmail(email) {
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+// findUserID returns the user ID of the employee with the given email, or
+// uuid.Nil when there is none
+func (c *employeeClient) findUserID(email string) (uuid.UUID, error) {
+	e, err := c.findEmployee(email)
+	if err != nil || e == nil {
+		return uuid.Nil, err
+	}
+	return e.UserID, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
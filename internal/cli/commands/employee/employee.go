@@ -15,7 +15,9 @@ Commands:
   list        List all employees
   get         Get employee details
   create      Create a new employee
+  import      Create employees in bulk from a CSV file
   update      Update employee information
+  offboard    Run the offboarding checklist for a departing employee
   credentials Manage certificates, licenses, and degrees
   recovery    Manage recovery questions`,
 }
@@ -24,28 +26,13 @@ func init() {
 	EmployeeCmd.AddCommand(listCmd)
 	EmployeeCmd.AddCommand(getCmd)
 	EmployeeCmd.AddCommand(createCmd)
+	EmployeeCmd.AddCommand(importCmd)
 	EmployeeCmd.AddCommand(updateCmd)
+	EmployeeCmd.AddCommand(offboardCmd)
 	EmployeeCmd.AddCommand(credentialsCmd)
 	EmployeeCmd.AddCommand(recoveryCmd)
 }
 
-var listCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all employees",
-	Long:  `List all employees in the corporate directory with optional filters.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		department, _ := cmd.Flags().GetString("department")
-		role, _ := cmd.Flags().GetString("role")
-		active, _ := cmd.Flags().GetBool("active")
-
-		// TODO: Call API to list employees
-		_ = department
-		_ = role
-		_ = active
-		cmd.Println("Listing employees...")
-	},
-}
-
 var getCmd = &cobra.Command{
 	Use:   "get [email]",
 	Short: "Get employee details",
@@ -127,11 +114,6 @@ Subcommands:
 }
 
 func init() {
-	// List flags
-	listCmd.Flags().StringP("department", "d", "", "Filter by department")
-	listCmd.Flags().StringP("role", "r", "", "Filter by role")
-	listCmd.Flags().Bool("active", true, "Show only active employees")
-
 	// Create flags
 	createCmd.Flags().StringP("email", "e", "", "Employee email (required)")
 	createCmd.Flags().String("first-name", "", "First name")
@@ -0,0 +1,326 @@
+package employee
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"os"
+	"strings"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Create employees in bulk from a CSV file",
+	Long: `Create employees from a CSV file, one per row.
+
+The first row names the columns, in any order:
+
+  email            Work email (required)
+  first_name       First name
+  last_name        Last name
+  department       Department
+  employee_type    full_time, contractor, consultant, intern or vendor (required)
+  manager_email    Email of the employee's manager
+  clearance        none, confidential, secret, top_secret or ts_sci
+
+A manager may be an existing employee or another row in the file; rows are
+created after their manager. Rows that fail validation or creation are
+reported and skipped, and the rest are still imported.
+
+Examples:
+  # Check the file without creating anything
+  changes employee import staff.csv --dry-run
+
+  # Import it
+  changes employee import staff.csv`,
+	Args: cobra.ExactArgs(1),
+	Run:  runImport,
+}
+
+func init() {
+	importCmd.Flags().Bool("dry-run", false, "Validate the file and show what would be created")
+	importCmd.Flags().String("api-url", "", "API URL (default: from config or https://api.changes.afterdarksys.com)")
+	importCmd.Flags().String("token", "", "API authentication token (or set CHANGES_API_TOKEN env var)")
+}
+
+// ImportRow is one employee read from an import file
+type ImportRow struct {
+	Line         int
+	Email        string
+	FirstName    string
+	LastName     string
+	ManagerEmail string
+	Profile      models.CreateEmployeeProfileInput
+
+	// Err is set when the row failed validation
+	Err error
+}
+
+// importColumns maps accepted header names to the canonical column
+var importColumns = map[string]string{
+	"email":              "email",
+	"first_name":         "first_name",
+	"last_name":          "last_name",
+	"department":         "department",
+	"employee_type":      "employee_type",
+	"manager_email":      "manager_email",
+	"clearance":          "clearance",
+	"security_clearance": "clearance",
+}
+
+// ReadImportFile parses an employee import CSV. Header names are matched
+// case-insensitively and unknown columns are an error. Each row is
+// validated on its own, so a bad row sets its Err rather than failing the
+// file; an email repeated in the file fails every row after the first.
+func ReadImportFile(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		canonical, ok := importColumns[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		columns[canonical] = i
+	}
+	for _, required := range []string{"email", "employee_type"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	var rows []ImportRow
+	seen := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		row := ImportRow{
+			Line:         line,
+			Email:        normalizeEmail(field("email")),
+			FirstName:    field("first_name"),
+			LastName:     field("last_name"),
+			ManagerEmail: normalizeEmail(field("manager_email")),
+			Profile: models.CreateEmployeeProfileInput{
+				EmployeeType:      models.EmployeeType(strings.ToLower(field("employee_type"))),
+				SecurityClearance: models.SecurityClearance(strings.ToLower(field("clearance"))),
+			},
+		}
+		if dept := field("department"); dept != "" {
+			row.Profile.Department = &dept
+		}
+		row.Err = validateImportRow(row)
+
+		if first, dup := seen[row.Email]; dup && row.Err == nil {
+			row.Err = fmt.Errorf("email %s already appears on line %d", row.Email, first)
+		} else if !dup && row.Email != "" {
+			seen[row.Email] = line
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func validateImportRow(row ImportRow) error {
+	if row.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	if _, err := mail.ParseAddress(row.Email); err != nil {
+		return fmt.Errorf("invalid email %q", row.Email)
+	}
+	if row.ManagerEmail != "" {
+		if _, err := mail.ParseAddress(row.ManagerEmail); err != nil {
+			return fmt.Errorf("invalid manager_email %q", row.ManagerEmail)
+		}
+		if row.ManagerEmail == row.Email {
+			return fmt.Errorf("employee cannot be their own manager")
+		}
+	}
+	if err := row.Profile.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// fullName joins the row's names, falling back to the email
+func (r ImportRow) fullName() string {
+	name := strings.TrimSpace(r.FirstName + " " + r.LastName)
+	if name == "" {
+		return r.Email
+	}
+	return name
+}
+
+// create creates the row's user and then its employee profile, returning
+// the new user's ID
+func (c *employeeClient) create(row ImportRow) (uuid.UUID, error) {
+	var user models.User
+	err := c.JSON(http.MethodPost, "/v1/users", models.CreateUserInput{
+		Email:    row.Email,
+		FullName: row.fullName(),
+		Roles:    []models.UserRole{models.UserRoleUser},
+	}, &user)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	profile := row.Profile
+	profile.UserID = user.ID
+	if err := c.JSON(http.MethodPost, "/v1/employees", profile, nil); err != nil {
+		return user.ID, fmt.Errorf("user created but profile failed: %w", err)
+	}
+	return user.ID, nil
+}
+
+func runImport(cmd *cobra.Command, args []string) {
+	path := args[0]
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	apiURL, _ := cmd.Flags().GetString("api-url")
+	token, _ := cmd.Flags().GetString("token")
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	rows, err := ReadImportFile(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	if dryRun {
+		fmt.Println("DRY RUN - no changes will be made")
+		fmt.Println()
+	}
+	fmt.Printf("Importing %d employees from %s\n\n", len(rows), path)
+
+	client, err := newEmployeeClient(apiURL, token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	inFile := make(map[string]bool, len(rows))
+	var pending []ImportRow
+	failed := 0
+	for _, row := range rows {
+		if row.Err != nil {
+			fmt.Printf("  ! line %d %s: %v\n", row.Line, row.Email, row.Err)
+			failed++
+			continue
+		}
+		inFile[row.Email] = true
+		pending = append(pending, row)
+	}
+
+	// Create rows whose manager is known, repeating until no more can be
+	// resolved, so managers listed in the file are created first
+	created := make(map[string]uuid.UUID)
+	managers := make(map[string]uuid.UUID)
+	imported := 0
+	for len(pending) > 0 {
+		var waiting []ImportRow
+		for _, row := range pending {
+			if row.ManagerEmail != "" {
+				if inFile[row.ManagerEmail] {
+					id, ok := created[row.ManagerEmail]
+					if !ok {
+						waiting = append(waiting, row)
+						continue
+					}
+					row.Profile.ManagerID = &id
+				} else {
+					id, ok := managers[row.ManagerEmail]
+					if !ok {
+						if id, err = client.findUserID(row.ManagerEmail); err != nil {
+							fmt.Printf("  ! line %d %s: %v\n", row.Line, row.Email, err)
+							failed++
+							continue
+						}
+						managers[row.ManagerEmail] = id
+					}
+					if id == uuid.Nil {
+						fmt.Printf("  ! line %d %s: manager %s not found\n", row.Line, row.Email, row.ManagerEmail)
+						failed++
+						continue
+					}
+					row.Profile.ManagerID = &id
+				}
+			}
+
+			if dryRun {
+				// Stand-in ID so rows managed by this one can proceed
+				created[row.Email] = uuid.New()
+				fmt.Printf("  + %s (%s)\n", row.Email, row.Profile.EmployeeType)
+				imported++
+				continue
+			}
+			id, err := client.create(row)
+			if err != nil {
+				fmt.Printf("  ! line %d %s: %v\n", row.Line, row.Email, err)
+				failed++
+				continue
+			}
+			created[row.Email] = id
+			fmt.Printf("  + %s (%s)\n", row.Email, row.Profile.EmployeeType)
+			imported++
+		}
+
+		if len(waiting) == len(pending) {
+			for _, row := range waiting {
+				fmt.Printf("  ! line %d %s: manager %s was not imported\n", row.Line, row.Email, row.ManagerEmail)
+				failed++
+			}
+			break
+		}
+		pending = waiting
+	}
+	fmt.Println()
+
+	if dryRun {
+		fmt.Printf("Would create %d employees (%d rows skipped)\n", imported, failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Printf("Import complete: %d created, %d failed\n", imported, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
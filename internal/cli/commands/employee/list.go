@@ -0,0 +1,366 @@
+package employee
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all employees",
+	Long: `List employees in the corporate directory with optional filters.
+
+Results are paged (--page, --per-page up to 100). Use --output json for
+machine-readable output, and --fields to choose the columns (or JSON keys)
+printed, in order. Fields:
+
+  id, user_id, full_name, email, job_title, department, employee_type,
+  consulting_company, office_location, office_phone, mobile_phone,
+  out_of_office, manager
+
+Examples:
+  # Engineering contractors in Austin
+  changes employee list -d Engineering --employee-type contractor --office-location Austin
+
+  # Direct reports of a manager
+  changes employee list --manager jane@afterdarksys.com
+
+  # Every employee as JSON, 100 at a time
+  changes employee list --output json --per-page 100 --page 2
+
+  # Just names and emails
+  changes employee list --fields full_name,email`,
+	Args: cobra.NoArgs,
+	Run:  runList,
+}
+
+func init() {
+	listCmd.Flags().StringP("department", "d", "", "Filter by department")
+	listCmd.Flags().String("employee-type", "", "Filter by type (full_time, contractor, consultant, intern, vendor)")
+	listCmd.Flags().String("office-location", "", "Filter by office location")
+	listCmd.Flags().String("manager", "", "Filter by manager (email or user ID)")
+	listCmd.Flags().String("consulting-company", "", "Filter by consulting company")
+	listCmd.Flags().StringP("search", "s", "", "Search name, email and job title")
+	listCmd.Flags().StringSlice("skill", nil, "Filter by skill (repeatable)")
+	listCmd.Flags().StringSlice("certification", nil, "Filter by certification (repeatable)")
+	listCmd.Flags().Bool("match-all", false, "Require every --skill and --certification instead of any")
+	listCmd.Flags().Bool("active", true, "Show only active employees")
+	listCmd.Flags().Int("page", 1, "Page of results")
+	listCmd.Flags().Int("per-page", 50, "Results per page (max 100)")
+	listCmd.Flags().String("sort-by", "", "Sort by full_name, email, department, job_title or hire_date")
+	listCmd.Flags().String("sort-order", "", "Sort order (asc or desc)")
+	listCmd.Flags().StringSlice("fields", nil, "Fields to print, in order (default: full_name,email,job_title,department,employee_type,office_location)")
+	listCmd.Flags().String("api-url", "", "API URL (default: from config or https://api.changes.afterdarksys.com)")
+	listCmd.Flags().String("token", "", "API authentication token (or set CHANGES_API_TOKEN env var)")
+}
+
+// employeeFields are the fields --fields can select, named by their JSON keys
+var employeeFields = []string{
+	"id", "user_id", "full_name", "email", "job_title", "department", "employee_type",
+	"consulting_company", "office_location", "office_phone", "mobile_phone",
+	"out_of_office", "manager",
+}
+
+var defaultEmployeeFields = []string{
+	"full_name", "email", "job_title", "department", "employee_type", "office_location",
+}
+
+// parseEmployeeFields validates a --fields selection, returning the default
+// columns when it is empty
+func parseEmployeeFields(fields []string) ([]string, error) {
+	if len(fields) == 0 {
+		return defaultEmployeeFields, nil
+	}
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		known := false
+		for _, name := range employeeFields {
+			if f == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown field %q (fields: %s)", f, strings.Join(employeeFields, ", "))
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+// employeeFieldValue returns a field of an entry as table text
+func employeeFieldValue(e *models.EmployeeDirectoryEntry, field string) string {
+	str := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	switch field {
+	case "id":
+		return e.ID.String()
+	case "user_id":
+		return e.UserID.String()
+	case "full_name":
+		return e.FullName
+	case "email":
+		return e.Email
+	case "job_title":
+		return str(e.JobTitle)
+	case "department":
+		return str(e.Department)
+	case "employee_type":
+		return string(e.EmployeeType)
+	case "consulting_company":
+		return str(e.ConsultingCompany)
+	case "office_location":
+		return str(e.OfficeLocation)
+	case "office_phone":
+		return str(e.OfficePhone)
+	case "mobile_phone":
+		return str(e.MobilePhone)
+	case "out_of_office":
+		if e.OutOfOffice {
+			return "yes"
+		}
+		return "no"
+	case "manager":
+		if e.Manager == nil {
+			return ""
+		}
+		return e.Manager.Email
+	}
+	return ""
+}
+
+// selectEmployeeFields returns entries as JSON objects holding only fields
+func selectEmployeeFields(entries []models.EmployeeDirectoryEntry, fields []string) ([]map[string]interface{}, error) {
+	out := make([]map[string]interface{}, 0, len(entries))
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]interface{}
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+		selected := make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			selected[f] = all[f]
+		}
+		out = append(out, selected)
+	}
+	return out, nil
+}
+
+// employeeSearchQuery encodes a filter as GET /v1/employees query parameters
+func employeeSearchQuery(f *models.EmployeeSearchFilter) url.Values {
+	query := url.Values{}
+	set := func(key string, value *string) {
+		if value != nil && *value != "" {
+			query.Set(key, *value)
+		}
+	}
+	set("department", f.Department)
+	set("office_location", f.OfficeLocation)
+	set("consulting_company", f.ConsultingCompany)
+	if f.EmployeeType != nil {
+		query.Set("employee_type", string(*f.EmployeeType))
+	}
+	if f.ManagerID != nil {
+		query.Set("manager_id", f.ManagerID.String())
+	}
+	if f.Search != "" {
+		query.Set("search", f.Search)
+	}
+	for _, s := range f.Skills {
+		query.Add("skill", s)
+	}
+	for _, c := range f.Certifications {
+		query.Add("certification", c)
+	}
+	if f.MatchAll {
+		query.Set("match_all", "true")
+	}
+	if f.IncludeInactive {
+		query.Set("include_inactive", "true")
+	}
+	query.Set("page", strconv.Itoa(f.Page))
+	query.Set("per_page", strconv.Itoa(f.PerPage))
+	query.Set("sort_by", f.SortBy)
+	query.Set("sort_order", f.SortOrder)
+	return query
+}
+
+// employeePage is one page of GET /v1/employees results
+type employeePage struct {
+	Employees []models.EmployeeDirectoryEntry `json:"employees"`
+	Total     int                             `json:"total"`
+	Page      int                             `json:"page"`
+	PerPage   int                             `json:"per_page"`
+}
+
+// searchEmployees returns one page of directory entries matching filter
+func (c *employeeClient) searchEmployees(f *models.EmployeeSearchFilter) (*employeePage, error) {
+	var page employeePage
+	if err := c.JSON(http.MethodGet, "/v1/employees?"+employeeSearchQuery(f).Encode(), nil, &page); err != nil {
+		return nil, fmt.Errorf("failed to list employees: %w", err)
+	}
+	return &page, nil
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	department, _ := cmd.Flags().GetString("department")
+	employeeType, _ := cmd.Flags().GetString("employee-type")
+	officeLocation, _ := cmd.Flags().GetString("office-location")
+	manager, _ := cmd.Flags().GetString("manager")
+	consultingCompany, _ := cmd.Flags().GetString("consulting-company")
+	search, _ := cmd.Flags().GetString("search")
+	skills, _ := cmd.Flags().GetStringSlice("skill")
+	certifications, _ := cmd.Flags().GetStringSlice("certification")
+	matchAll, _ := cmd.Flags().GetBool("match-all")
+	active, _ := cmd.Flags().GetBool("active")
+	pageNum, _ := cmd.Flags().GetInt("page")
+	perPage, _ := cmd.Flags().GetInt("per-page")
+	sortBy, _ := cmd.Flags().GetString("sort-by")
+	sortOrder, _ := cmd.Flags().GetString("sort-order")
+	fieldList, _ := cmd.Flags().GetStringSlice("fields")
+	output, _ := cmd.Flags().GetString("output")
+	apiURL, _ := cmd.Flags().GetString("api-url")
+	token, _ := cmd.Flags().GetString("token")
+
+	if output != "table" && output != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unsupported --output %q for employee list (use table or json)\n", output)
+		os.Exit(1)
+	}
+	fields, err := parseEmployeeFields(fieldList)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if pageNum < 1 || perPage < 1 || perPage > 100 {
+		fmt.Fprintln(os.Stderr, "Error: --page must be at least 1 and --per-page between 1 and 100")
+		os.Exit(1)
+	}
+	if sortOrder != "" && sortOrder != "asc" && sortOrder != "desc" {
+		fmt.Fprintf(os.Stderr, "Error: invalid --sort-order %q (use asc or desc)\n", sortOrder)
+		os.Exit(1)
+	}
+
+	filter := &models.EmployeeSearchFilter{
+		Search:          search,
+		Skills:          skills,
+		Certifications:  certifications,
+		MatchAll:        matchAll,
+		IncludeInactive: !active,
+		Page:            pageNum,
+		PerPage:         perPage,
+		SortBy:          sortBy,
+		SortOrder:       sortOrder,
+	}
+	if department != "" {
+		filter.Department = &department
+	}
+	if officeLocation != "" {
+		filter.OfficeLocation = &officeLocation
+	}
+	if consultingCompany != "" {
+		filter.ConsultingCompany = &consultingCompany
+	}
+	if employeeType != "" {
+		t := models.EmployeeType(strings.ToLower(employeeType))
+		if !t.Valid() {
+			fmt.Fprintf(os.Stderr, "Error: invalid --employee-type %q (use full_time, contractor, consultant, intern or vendor)\n", employeeType)
+			os.Exit(1)
+		}
+		filter.EmployeeType = &t
+	}
+	filter.SetDefaults()
+
+	client, err := newEmployeeClient(apiURL, token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if manager != "" {
+		id, err := uuid.Parse(manager)
+		if err != nil {
+			if id, err = client.findUserID(manager); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if id == uuid.Nil {
+				fmt.Fprintf(os.Stderr, "Error: no employee found with email %s\n", manager)
+				os.Exit(1)
+			}
+		}
+		filter.ManagerID = &id
+	}
+
+	page, err := client.searchEmployees(filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if output == "json" {
+		var employees interface{} = page.Employees
+		if len(fieldList) > 0 {
+			if employees, err = selectEmployeeFields(page.Employees, fields); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(map[string]interface{}{
+			"employees": employees,
+			"total":     page.Total,
+			"page":      filter.Page,
+			"per_page":  filter.PerPage,
+		})
+		return
+	}
+
+	if len(page.Employees) == 0 {
+		fmt.Println("No employees found.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(fields))
+	dashes := make([]string, len(fields))
+	for i, f := range fields {
+		headers[i] = strings.ToUpper(strings.ReplaceAll(f, "_", " "))
+		dashes[i] = strings.Repeat("-", len(headers[i]))
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	fmt.Fprintln(w, strings.Join(dashes, "\t"))
+	for i := range page.Employees {
+		values := make([]string, len(fields))
+		for j, f := range fields {
+			values[j] = employeeFieldValue(&page.Employees[i], f)
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	w.Flush()
+
+	first := filter.Offset() + 1
+	fmt.Printf("\nShowing %d-%d of %d employees", first, filter.Offset()+len(page.Employees), page.Total)
+	if filter.Offset()+len(page.Employees) < page.Total {
+		fmt.Printf(" (next: --page %d)", filter.Page+1)
+	}
+	fmt.Println()
+}
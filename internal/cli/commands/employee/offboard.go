@@ -0,0 +1,140 @@
+package employee
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/cli/commands/entitlement"
+	"github.com/afterdarksys/adsops-utils/internal/cli/commands/group"
+	"github.com/afterdarksys/adsops-utils/internal/cli/commands/user"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var offboardCmd = &cobra.Command{
+	Use:   "offboard [email]",
+	Short: "Run the offboarding checklist for a departing employee",
+	Long: `Run the offboarding checklist for an employee, contractor, consultant or
+vendor leaving the organization:
+
+  1. Set the termination date on their employee profile
+  2. Revoke SSH proxy access, ending active sessions
+  3. Remove them from every group
+  4. Freeze their entitlements
+
+Every step is attempted even if an earlier one fails, and the result of each
+is printed. Freezing entitlements needs 'changes entitlement login' (or
+ENTITLEMENTS_API_KEY), and revoking SSH access needs 'changes auth login'.
+
+Examples:
+  # Preview the checklist
+  changes employee offboard jane@vendor.com --dry-run
+
+  # Offboard at the end of a contract
+  changes employee offboard jane@vendor.com --reason "Contract ended" --date 2026-06-30`,
+	Args: cobra.ExactArgs(1),
+	Run:  runOffboard,
+}
+
+func init() {
+	offboardCmd.Flags().Bool("dry-run", false, "Show the checklist without changing anything")
+	offboardCmd.Flags().String("reason", "Offboarding", "Reason recorded with the entitlement freeze")
+	offboardCmd.Flags().String("date", "", "Termination date (YYYY-MM-DD, default today)")
+	offboardCmd.Flags().String("api-url", "", "API URL (default: from config or https://api.changes.afterdarksys.com)")
+	offboardCmd.Flags().String("token", "", "API authentication token (or set CHANGES_API_TOKEN env var)")
+}
+
+func runOffboard(cmd *cobra.Command, args []string) {
+	email := normalizeEmail(args[0])
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	reason, _ := cmd.Flags().GetString("reason")
+	date, _ := cmd.Flags().GetString("date")
+	apiURL, _ := cmd.Flags().GetString("api-url")
+	token, _ := cmd.Flags().GetString("token")
+
+	terminated := time.Now().UTC().Truncate(24 * time.Hour)
+	if date != "" {
+		t, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --date %q (use YYYY-MM-DD)\n", date)
+			os.Exit(1)
+		}
+		terminated = t
+	}
+
+	client, err := newEmployeeClient(apiURL, token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	emp, err := client.findEmployee(email)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if emp == nil {
+		fmt.Fprintf(os.Stderr, "Error: no employee found with email %s\n", email)
+		os.Exit(1)
+	}
+
+	if dryRun {
+		fmt.Println("DRY RUN - no changes will be made")
+		fmt.Println()
+	}
+	fmt.Printf("Offboarding %s <%s> (%s)\n\n", emp.FullName, email, emp.EmployeeType)
+
+	failed := 0
+	step := func(desc string, run func() error) {
+		if dryRun {
+			fmt.Printf("  [ ] %s\n", desc)
+			return
+		}
+		if err := run(); err != nil {
+			fmt.Printf("  [!] %s: %v\n", desc, err)
+			failed++
+			return
+		}
+		fmt.Printf("  [x] %s\n", desc)
+	}
+
+	step("Set termination date to "+terminated.Format("2006-01-02"), func() error {
+		input := models.UpdateEmployeeProfileInput{TerminationDate: &terminated}
+		return client.JSON(http.MethodPatch, "/v1/employees/"+url.PathEscape(emp.ID.String()), input, nil)
+	})
+
+	step("Revoke SSH proxy access", func() error {
+		return user.RevokeSSHAccess(email)
+	})
+
+	groups, err := group.MemberGroups(apiURL, token, email)
+	if err != nil {
+		fmt.Printf("  [!] Remove from groups: %v\n", err)
+		failed++
+	} else if len(groups) == 0 {
+		fmt.Println("  [-] Remove from groups: not a member of any")
+	}
+	for _, g := range groups {
+		name := g.Name
+		step("Remove from group "+name, func() error {
+			return group.RemoveMember(apiURL, token, name, email)
+		})
+	}
+
+	step("Freeze entitlements", func() error {
+		return entitlement.FreezeUser(emp.UserID.String(), reason)
+	})
+
+	fmt.Println()
+	if dryRun {
+		fmt.Println("Run without --dry-run to apply the checklist.")
+		return
+	}
+	if failed > 0 {
+		fmt.Printf("Offboarding incomplete: %d steps failed\n", failed)
+		os.Exit(1)
+	}
+	fmt.Printf("Offboarding complete for %s\n", email)
+}
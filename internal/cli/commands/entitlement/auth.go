@@ -0,0 +1,149 @@
+package entitlement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/pkg/apiclient"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/clock"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/fsutil"
+)
+
+// apiKeyLifetime is how long a stored API key login is kept; the keys
+// themselves don't expire
+const apiKeyLifetime = 365 * 24 * time.Hour
+
+// Login errors returned by authStore.Current
+var (
+	errNotAuthenticated = errors.New("not authenticated")
+	errSessionExpired   = errors.New("session expired")
+)
+
+// authStore keeps the login saved by 'changes entitlement login' and decides
+// when it has expired. Clock is the time source for expiry and for stamping
+// new logins.
+type authStore struct {
+	Path  string
+	Clock clock.Clock
+
+	// refresh exchanges a refresh token for a new login
+	refresh func(refreshToken string) (*tokenResponse, error)
+}
+
+// tokenResponse is the body of a successful login or token refresh
+type tokenResponse struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"`
+}
+
+// newAuthStore returns the auth store at the default path using the system
+// clock
+func newAuthStore() *authStore {
+	return &authStore{
+		Path:    getAuthConfigPath(),
+		Clock:   clock.System(),
+		refresh: refreshToken,
+	}
+}
+
+// Load reads the saved login
+func (s *authStore) Load() (*AuthConfig, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var auth AuthConfig
+	if err := json.Unmarshal(data, &auth); err != nil {
+		return nil, err
+	}
+
+	return &auth, nil
+}
+
+// Save writes auth as the saved login
+func (s *authStore) Save(auth AuthConfig) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(auth, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFileAtomic(s.Path, data, 0600)
+}
+
+// ExpiresAt returns when a token issued now and valid for lifetime expires
+func (s *authStore) ExpiresAt(lifetime time.Duration) time.Time {
+	return s.Clock.Now().Add(lifetime)
+}
+
+// Current returns the login to use: ENTITLEMENTS_API_KEY when set,
+// otherwise the saved login, refreshed first if it has expired
+func (s *authStore) Current() (*AuthConfig, error) {
+	if apiKey, err := apiclient.EntitlementsAPI.Token(""); err == nil {
+		return &AuthConfig{
+			AccessToken: apiKey,
+			IsAdmin:     true,
+		}, nil
+	}
+
+	auth, err := s.Load()
+	if err != nil {
+		return nil, errNotAuthenticated
+	}
+	if !s.Clock.Now().After(auth.ExpiresAt) {
+		return auth, nil
+	}
+
+	if auth.RefreshToken == "" || s.refresh == nil {
+		return nil, errSessionExpired
+	}
+	result, err := s.refresh(auth.RefreshToken)
+	if err != nil {
+		return nil, errSessionExpired
+	}
+
+	refreshed := &AuthConfig{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		ExpiresAt:    s.ExpiresAt(time.Duration(result.ExpiresIn) * time.Second),
+		Email:        auth.Email,
+		UserID:       auth.UserID,
+		IsAdmin:      auth.IsAdmin,
+	}
+	s.Save(*refreshed)
+
+	return refreshed, nil
+}
+
+// mustGetAuth returns the current login, exiting when there is none
+func mustGetAuth() *AuthConfig {
+	auth, err := newAuthStore().Current()
+	switch {
+	case errors.Is(err, errNotAuthenticated):
+		fmt.Fprintln(os.Stderr, "Not authenticated. Run 'changes entitlement login' first.")
+		os.Exit(1)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "Session expired. Run 'changes entitlement login' again.")
+		os.Exit(1)
+	}
+	return auth
+}
+
+// refreshToken exchanges a refresh token for a new access token
+func refreshToken(token string) (*tokenResponse, error) {
+	var result tokenResponse
+	if err := newClient("").JSON(http.MethodPost, "/api/auth/token/refresh", map[string]string{"refreshToken": token}, &result); err != nil {
+		return nil, fmt.Errorf("refresh failed: %w", err)
+	}
+	return &result, nil
+}
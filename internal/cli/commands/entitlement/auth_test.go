@@ -0,0 +1,90 @@
+package entitlement
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/pkg/clock"
+)
+
+func newTestAuthStore(t *testing.T, now time.Time) (*authStore, *clock.Fake) {
+	t.Helper()
+	t.Setenv("ENTITLEMENTS_API_KEY", "")
+	clk := clock.NewFake(now)
+	return &authStore{Path: filepath.Join(t.TempDir(), "auth.json"), Clock: clk}, clk
+}
+
+func TestAuthStoreExpiry(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	store, clk := newTestAuthStore(t, start)
+
+	if _, err := store.Current(); !errors.Is(err, errNotAuthenticated) {
+		t.Fatalf("Current() with no login = %v, want errNotAuthenticated", err)
+	}
+
+	if err := store.Save(AuthConfig{AccessToken: "a1", ExpiresAt: store.ExpiresAt(time.Hour)}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	clk.Advance(time.Hour)
+	auth, err := store.Current()
+	if err != nil || auth.AccessToken != "a1" {
+		t.Fatalf("Current() at expiry = %v, %v; want the saved login", auth, err)
+	}
+
+	clk.Advance(time.Second)
+	if _, err := store.Current(); !errors.Is(err, errSessionExpired) {
+		t.Fatalf("Current() after expiry without refresh token = %v, want errSessionExpired", err)
+	}
+}
+
+func TestAuthStoreRefresh(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	store, clk := newTestAuthStore(t, start)
+
+	var refreshedWith string
+	store.refresh = func(token string) (*tokenResponse, error) {
+		refreshedWith = token
+		return &tokenResponse{AccessToken: "a2", RefreshToken: "r2", ExpiresIn: 3600}, nil
+	}
+
+	saved := AuthConfig{AccessToken: "a1", RefreshToken: "r1", ExpiresAt: start, Email: "ops@example.com", IsAdmin: true}
+	if err := store.Save(saved); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	clk.Advance(time.Minute)
+
+	auth, err := store.Current()
+	if err != nil {
+		t.Fatalf("Current: %v", err)
+	}
+	if refreshedWith != "r1" {
+		t.Errorf("refreshed with %q, want r1", refreshedWith)
+	}
+	want := AuthConfig{
+		AccessToken:  "a2",
+		RefreshToken: "r2",
+		ExpiresAt:    start.Add(time.Minute + time.Hour),
+		Email:        "ops@example.com",
+		IsAdmin:      true,
+	}
+	if *auth != want {
+		t.Errorf("Current() = %+v, want %+v", *auth, want)
+	}
+
+	stored, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !stored.ExpiresAt.Equal(want.ExpiresAt) || stored.AccessToken != "a2" {
+		t.Errorf("saved login = %+v, want the refreshed one", *stored)
+	}
+
+	store.refresh = func(string) (*tokenResponse, error) { return nil, errors.New("refresh failed") }
+	clk.Advance(2 * time.Hour)
+	if _, err := store.Current(); !errors.Is(err, errSessionExpired) {
+		t.Errorf("Current() with failing refresh = %v, want errSessionExpired", err)
+	}
+}
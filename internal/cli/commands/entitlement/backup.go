@@ -0,0 +1,275 @@
+package entitlement
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/pkg/fsutil"
+	"github.com/spf13/cobra"
+)
+
+// ============================================
+// EXPORT / IMPORT (Admin)
+// ============================================
+
+// grantPageSize is the number of grants requested per page on export
+const grantPageSize = 100
+
+// GrantBackup is the file written by 'entitlement export'
+type GrantBackup struct {
+	ExportedAt time.Time          `json:"exportedAt"`
+	ExportedBy string             `json:"exportedBy"`
+	Grants     []EntitlementGrant `json:"grants"`
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all admin-granted entitlements (admin)",
+	Long: `Write every active admin grant to a JSON file, for backup and disaster
+recovery. Restore the file with 'changes entitlement import'.
+
+Examples:
+  # Snapshot all grants
+  changes entitlement export --out grants.json
+
+  # Include revoked grants for the record
+  changes entitlement export --out grants.json --include-revoked`,
+	Run: runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Re-create grants from an export (admin)",
+	Long: `Re-create the grants in a file written by 'changes entitlement export'.
+A grant is only created when the user has no active grant for the same
+product, so importing the same file twice is safe. Revoked and expired
+grants in the file are skipped.
+
+Examples:
+  # Preview what would be restored
+  changes entitlement import grants.json --dry-run
+
+  # Restore missing grants
+  changes entitlement import grants.json`,
+	Args: cobra.ExactArgs(1),
+	Run:  runImport,
+}
+
+func init() {
+	exportCmd.Flags().String("out", "", "File to write the grants to (required)")
+	exportCmd.Flags().Bool("include-revoked", false, "Also export revoked grants")
+	exportCmd.MarkFlagRequired("out")
+
+	importCmd.Flags().Bool("dry-run", false, "Show the grants that would be created without creating them")
+}
+
+// grantPage is one page of the admin grants endpoint, paginated like the
+// audit log: by nextCursor when the server returns one, otherwise by offset
+type grantPage struct {
+	Grants     []EntitlementGrant `json:"grants"`
+	NextCursor string             `json:"nextCursor"`
+}
+
+// fetchAllGrants pages through every admin grant, including revoked ones
+// when includeRevoked is set
+func fetchAllGrants(auth *AuthConfig, includeRevoked bool) ([]EntitlementGrant, error) {
+	var grants []EntitlementGrant
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(grantPageSize))
+	if includeRevoked {
+		query.Set("includeRevoked", "true")
+	}
+
+	for offset := 0; ; {
+		resp, err := makeAuthenticatedRequest("GET", "/api/entitlements/admin/grants?"+query.Encode(), nil, auth)
+		if err != nil {
+			return nil, err
+		}
+
+		var page grantPage
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return nil, fmt.Errorf("invalid response: %v", err)
+		}
+		grants = append(grants, page.Grants...)
+
+		if len(page.Grants) == 0 {
+			return grants, nil
+		}
+		if page.NextCursor != "" {
+			query.Set("cursor", page.NextCursor)
+			continue
+		}
+		if len(page.Grants) < grantPageSize {
+			return grants, nil
+		}
+		offset += len(page.Grants)
+		query.Set("offset", strconv.Itoa(offset))
+	}
+}
+
+// grantKey identifies a grant for matching on import
+func grantKey(userID, productCode string) string {
+	return userID + "/" + productCode
+}
+
+// MissingGrants returns the grants in backup that should be re-created:
+// those neither revoked nor expired at now whose user has no active grant
+// for the same product in existing. Each user and product appears once.
+func MissingGrants(backup, existing []EntitlementGrant, now time.Time) []EntitlementGrant {
+	active := make(map[string]bool, len(existing))
+	for _, g := range existing {
+		if g.RevokedAt == nil {
+			active[grantKey(g.UserID, g.ProductCode)] = true
+		}
+	}
+
+	var missing []EntitlementGrant
+	for _, g := range backup {
+		if g.RevokedAt != nil || (g.ExpiresAt != nil && !g.ExpiresAt.After(now)) {
+			continue
+		}
+		key := grantKey(g.UserID, g.ProductCode)
+		if active[key] {
+			continue
+		}
+		active[key] = true
+		missing = append(missing, g)
+	}
+	return missing
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	auth := mustGetAuth()
+	out, _ := cmd.Flags().GetString("out")
+	includeRevoked, _ := cmd.Flags().GetBool("include-revoked")
+
+	grants, err := fetchAllGrants(auth, includeRevoked)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		return grantKey(grants[i].UserID, grants[i].ProductCode) < grantKey(grants[j].UserID, grants[j].ProductCode)
+	})
+
+	backup := GrantBackup{
+		ExportedAt: time.Now().UTC(),
+		ExportedBy: auth.Email,
+		Grants:     grants,
+	}
+	if backup.Grants == nil {
+		backup.Grants = []EntitlementGrant{}
+	}
+
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := fsutil.WriteFileAtomic(out, data, 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", out, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Exported %d grants to %s\n", len(grants), out)
+}
+
+func runImport(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	var backup GrantBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s is not a grant export: %v\n", args[0], err)
+		os.Exit(1)
+	}
+
+	auth := mustGetAuth()
+	existing, err := fetchAllGrants(auth, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching current grants: %v\n", err)
+		os.Exit(1)
+	}
+
+	missing := MissingGrants(backup.Grants, existing, time.Now())
+	if len(missing) == 0 {
+		fmt.Printf("All %d grants in %s are already present\n", len(backup.Grants), args[0])
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tPRODUCT\tEXPIRES\tRESULT")
+	fmt.Fprintln(w, "----\t-------\t-------\t------")
+
+	failed := 0
+	for _, g := range missing {
+		expires := "Never"
+		if g.ExpiresAt != nil {
+			expires = g.ExpiresAt.Format("2006-01-02")
+		}
+
+		result := "would create"
+		if !dryRun {
+			if err := restoreGrant(auth, g); err != nil {
+				result = "failed: " + err.Error()
+				failed++
+			} else {
+				result = "created"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", g.UserID, g.ProductCode, expires, result)
+	}
+	w.Flush()
+
+	if dryRun {
+		fmt.Printf("\n%d of %d grants would be created (dry run)\n", len(missing), len(backup.Grants))
+		return
+	}
+	fmt.Printf("\nCreated %d of %d missing grants\n", len(missing)-failed, len(missing))
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// restoreGrant re-creates g through the grant endpoint, keeping its reason
+// and expiry
+func restoreGrant(auth *AuthConfig, g EntitlementGrant) error {
+	reason := "Restored from backup"
+	if g.Reason != "" {
+		reason = g.Reason + " (restored from backup)"
+	}
+	body := map[string]interface{}{
+		"userId":      g.UserID,
+		"productCode": g.ProductCode,
+		"reason":      reason,
+	}
+	if g.ExpiresAt != nil {
+		body["expiresAt"] = g.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	bodyBytes, _ := json.Marshal(body)
+	resp, err := makeAuthenticatedRequest("POST", "/api/entitlements/admin/grant", bodyBytes, auth)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	}
+	json.Unmarshal(resp, &result)
+	if !result.Success {
+		return fmt.Errorf("%s", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package entitlement
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// checkResult is the response of the entitlement check endpoints
+type checkResult struct {
+	HasAccess   bool         `json:"hasAccess"`
+	Entitlement *Entitlement `json:"entitlement"`
+	Reason      string       `json:"reason"`
+}
+
+// checkAccess asks the API whether a user can use a feature on a domain.
+// An empty userID checks the logged-in user; any other user needs the admin
+// endpoint.
+func checkAccess(auth *AuthConfig, userID, domain, feature string) (*checkResult, error) {
+	query := url.Values{"domain": {domain}, "feature": {feature}}
+	endpoint := "/api/entitlements/check?" + query.Encode()
+	if userID != "" {
+		query.Set("userId", userID)
+		endpoint = "/api/entitlements/admin/check?" + query.Encode()
+	}
+
+	resp, err := makeAuthenticatedRequest("GET", endpoint, nil, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	var result checkResult
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("invalid check response: %w", err)
+	}
+	return &result, nil
+}
+
+// checkFromReader checks each domain:feature pair read from r, one per
+// line, and writes a result line per pair to w. Repeated pairs reuse the
+// first answer. It returns false if any pair was invalid, denied or could
+// not be checked.
+func checkFromReader(r io.Reader, w io.Writer, auth *AuthConfig, userID string) bool {
+	type answer struct {
+		granted bool
+		detail  string
+	}
+	answers := make(map[string]answer)
+
+	ok := true
+	checked := 0
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		domain, feature, found := strings.Cut(line, ":")
+		domain, feature = strings.TrimSpace(domain), strings.TrimSpace(feature)
+		if !found || domain == "" || feature == "" {
+			fmt.Fprintf(w, "INVALID  %s  (line %d: expected domain:feature)\n", line, lineNo)
+			ok = false
+			continue
+		}
+
+		pair := domain + ":" + feature
+		a, seen := answers[pair]
+		if !seen {
+			result, err := checkAccess(auth, userID, domain, feature)
+			switch {
+			case err != nil:
+				a = answer{detail: "error: " + err.Error()}
+			case result.HasAccess && result.Entitlement != nil:
+				a = answer{granted: true, detail: "via " + result.Entitlement.ProductName}
+			case result.HasAccess:
+				a = answer{granted: true}
+			default:
+				a = answer{detail: result.Reason}
+			}
+			answers[pair] = a
+		}
+		checked++
+
+		status := "GRANTED"
+		if !a.granted {
+			status = "DENIED "
+			ok = false
+		}
+		if a.detail != "" {
+			fmt.Fprintf(w, "%s  %s  (%s)\n", status, pair, a.detail)
+		} else {
+			fmt.Fprintf(w, "%s  %s\n", status, pair)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(w, "ERROR    reading input: %v\n", err)
+		return false
+	}
+
+	if checked == 0 {
+		fmt.Fprintln(w, "No features to check.")
+		return ok
+	}
+	if ok {
+		fmt.Fprintf(w, "\nPASS: all %d feature(s) granted\n", checked)
+	} else {
+		fmt.Fprintln(w, "\nFAIL: one or more features denied or not checked")
+	}
+	return ok
+}
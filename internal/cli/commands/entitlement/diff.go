@@ -0,0 +1,210 @@
+package entitlement
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// ============================================
+// DIFF
+// ============================================
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <user-a> <user-b>",
+	Short: "Compare two users' entitlements (admin)",
+	Long: `Compare the entitlements of two users side by side: products only one
+of them has, and shared products whose tier or features differ.
+
+Examples:
+  # Compare two users across all domains
+  changes entitlement diff user-123 user-456
+
+  # Compare on one domain
+  changes entitlement diff user-123 user-456 --domain getthis.money`,
+	Args: cobra.ExactArgs(2),
+	Run:  runDiff,
+}
+
+func init() {
+	diffCmd.Flags().String("domain", "", "Only compare entitlements on this domain")
+	diffCmd.Flags().Bool("all", false, "Also list shared entitlements that are identical")
+}
+
+// EntitlementPair holds the two users' versions of one product entitlement
+type EntitlementPair struct {
+	A, B Entitlement
+
+	// FeaturesOnlyA and FeaturesOnlyB list features present on one side only
+	FeaturesOnlyA []string
+	FeaturesOnlyB []string
+}
+
+// TierDiffers reports whether the two users hold different tiers
+func (p EntitlementPair) TierDiffers() bool {
+	return !strings.EqualFold(p.A.Tier, p.B.Tier)
+}
+
+// Differs reports whether the tier or feature set differs
+func (p EntitlementPair) Differs() bool {
+	return p.TierDiffers() || len(p.FeaturesOnlyA) > 0 || len(p.FeaturesOnlyB) > 0
+}
+
+// EntitlementDiff is the result of comparing two users' entitlements
+type EntitlementDiff struct {
+	OnlyA  []Entitlement
+	OnlyB  []Entitlement
+	Shared []EntitlementPair
+}
+
+// entitlementKey identifies a product on a domain
+func entitlementKey(e Entitlement) string {
+	return e.Domain + "\x00" + e.ProductCode
+}
+
+// DiffEntitlements compares two entitlement lists by product and domain.
+// Results are sorted by domain and then product code.
+func DiffEntitlements(a, b []Entitlement) EntitlementDiff {
+	byKey := make(map[string]Entitlement, len(b))
+	for _, e := range b {
+		byKey[entitlementKey(e)] = e
+	}
+
+	var diff EntitlementDiff
+	seen := make(map[string]bool, len(a))
+	for _, ea := range a {
+		key := entitlementKey(ea)
+		seen[key] = true
+		eb, ok := byKey[key]
+		if !ok {
+			diff.OnlyA = append(diff.OnlyA, ea)
+			continue
+		}
+		diff.Shared = append(diff.Shared, EntitlementPair{
+			A:             ea,
+			B:             eb,
+			FeaturesOnlyA: missingFeatures(ea.Features, eb.Features),
+			FeaturesOnlyB: missingFeatures(eb.Features, ea.Features),
+		})
+	}
+	for _, eb := range b {
+		if !seen[entitlementKey(eb)] {
+			diff.OnlyB = append(diff.OnlyB, eb)
+		}
+	}
+
+	sortEntitlements(diff.OnlyA)
+	sortEntitlements(diff.OnlyB)
+	sort.Slice(diff.Shared, func(i, j int) bool {
+		return entitlementKey(diff.Shared[i].A) < entitlementKey(diff.Shared[j].A)
+	})
+	return diff
+}
+
+// missingFeatures returns the features in have that are not in other, sorted
+func missingFeatures(have, other []string) []string {
+	set := make(map[string]bool, len(other))
+	for _, f := range other {
+		set[f] = true
+	}
+	var missing []string
+	for _, f := range have {
+		if !set[f] {
+			missing = append(missing, f)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func sortEntitlements(list []Entitlement) {
+	sort.Slice(list, func(i, j int) bool {
+		return entitlementKey(list[i]) < entitlementKey(list[j])
+	})
+}
+
+// fetchUserEntitlements loads a user's entitlements via the admin lookup
+func fetchUserEntitlements(auth *AuthConfig, userID, domain string) ([]Entitlement, error) {
+	endpoint := fmt.Sprintf("/api/entitlements/admin/user/%s", url.PathEscape(userID))
+	if domain != "" {
+		endpoint += fmt.Sprintf("/domain/%s", url.PathEscape(domain))
+	}
+
+	resp, err := makeAuthenticatedRequest("GET", endpoint, nil, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Entitlements []Entitlement `json:"entitlements"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("invalid response: %v", err)
+	}
+	return result.Entitlements, nil
+}
+
+func runDiff(cmd *cobra.Command, args []string) {
+	auth := mustGetAuth()
+	domain, _ := cmd.Flags().GetString("domain")
+	showAll, _ := cmd.Flags().GetBool("all")
+	userA, userB := args[0], args[1]
+
+	a, err := fetchUserEntitlements(auth, userA, domain)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching %s: %v\n", userA, err)
+		os.Exit(1)
+	}
+	b, err := fetchUserEntitlements(auth, userB, domain)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching %s: %v\n", userB, err)
+		os.Exit(1)
+	}
+
+	diff := DiffEntitlements(a, b)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "PRODUCT\tDOMAIN\t%s\t%s\tDIFFERENCE\n", userA, userB)
+	fmt.Fprintf(w, "-------\t------\t%s\t%s\t----------\n",
+		strings.Repeat("-", len(userA)), strings.Repeat("-", len(userB)))
+
+	for _, e := range diff.OnlyA {
+		fmt.Fprintf(w, "%s\t%s\t%s\t-\tonly %s\n", e.ProductName, e.Domain, e.Tier, userA)
+	}
+	for _, e := range diff.OnlyB {
+		fmt.Fprintf(w, "%s\t%s\t-\t%s\tonly %s\n", e.ProductName, e.Domain, e.Tier, userB)
+	}
+
+	same := 0
+	for _, p := range diff.Shared {
+		if !p.Differs() {
+			same++
+			if showAll {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\tsame\n", p.A.ProductName, p.A.Domain, p.A.Tier, p.B.Tier)
+			}
+			continue
+		}
+
+		var notes []string
+		if p.TierDiffers() {
+			notes = append(notes, "tier")
+		}
+		if len(p.FeaturesOnlyA) > 0 {
+			notes = append(notes, fmt.Sprintf("%s also has: %s", userA, strings.Join(p.FeaturesOnlyA, ", ")))
+		}
+		if len(p.FeaturesOnlyB) > 0 {
+			notes = append(notes, fmt.Sprintf("%s also has: %s", userB, strings.Join(p.FeaturesOnlyB, ", ")))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.A.ProductName, p.A.Domain, p.A.Tier, p.B.Tier, strings.Join(notes, "; "))
+	}
+	w.Flush()
+
+	fmt.Printf("\nOnly %s: %d, only %s: %d, shared: %d (%d identical)\n",
+		userA, len(diff.OnlyA), userB, len(diff.OnlyB), len(diff.Shared), same)
+}
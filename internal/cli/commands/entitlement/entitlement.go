@@ -1,23 +1,21 @@
 package entitlement
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
-	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/afterdarksys/adsops-utils/internal/pkg/apiclient"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-)
-
-// API endpoints
-const (
-	defaultAPIURL = "https://billing.afterdarksys.com"
 )
 
 // AuthConfig represents stored auth configuration
@@ -67,16 +65,27 @@ Commands:
   logout      Clear stored credentials
   list        List entitlements for a user
   check       Check if user has access to a feature
+  request     Request an entitlement
+  requests    List your entitlement requests
   usage       View usage metrics for a user
   grant       Grant an entitlement to a user (admin)
   revoke      Revoke an entitlement grant (admin)
   approvers   List users who can approve entitlements
   users       List users for a domain/application
   log         View entitlement audit log
+  diff        Compare two users' entitlements (admin)
+  export      Export all admin grants to a file (admin)
+  import      Re-create missing grants from an export (admin)
 
 Authentication:
   The CLI stores credentials in ~/.adsops-utils/entitlements-auth.json
-  You can also set ENTITLEMENTS_API_KEY environment variable`,
+  You can also set ENTITLEMENTS_API_KEY environment variable
+
+Network:
+  Requests time out after --timeout (default 30s). GET requests are retried
+  --retries times (default 3) with backoff on network errors, 429 (honoring
+  Retry-After) and 5xx; other requests only when the connection fails.
+  ENTITLEMENTS_TIMEOUT and ENTITLEMENTS_RETRIES set the defaults.`,
 }
 
 func init() {
@@ -85,6 +94,8 @@ func init() {
 	EntitlementCmd.AddCommand(logoutCmd)
 	EntitlementCmd.AddCommand(listCmd)
 	EntitlementCmd.AddCommand(checkCmd)
+	EntitlementCmd.AddCommand(requestCmd)
+	EntitlementCmd.AddCommand(requestsCmd)
 	EntitlementCmd.AddCommand(usageCmd)
 	EntitlementCmd.AddCommand(grantCmd)
 	EntitlementCmd.AddCommand(revokeCmd)
@@ -93,6 +104,9 @@ func init() {
 	EntitlementCmd.AddCommand(logCmd)
 	EntitlementCmd.AddCommand(freezeCmd)
 	EntitlementCmd.AddCommand(unfreezeCmd)
+	EntitlementCmd.AddCommand(diffCmd)
+	EntitlementCmd.AddCommand(exportCmd)
+	EntitlementCmd.AddCommand(importCmd)
 }
 
 // ============================================
@@ -124,14 +138,13 @@ func init() {
 func runLogin(cmd *cobra.Command, args []string) {
 	apiKey, _ := cmd.Flags().GetString("api-key")
 	email, _ := cmd.Flags().GetString("email")
+	store := newAuthStore()
 
 	if apiKey != "" {
 		// Validate API key by making a test request
-		client := &http.Client{Timeout: 10 * time.Second}
 		req, _ := http.NewRequest("GET", getAPIURL()+"/api/entitlements", nil)
-		req.Header.Set("Authorization", "Bearer "+apiKey)
 
-		resp, err := client.Do(req)
+		resp, err := newClient(apiKey).Do(req)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: Failed to connect to API: %v\n", err)
 			os.Exit(1)
@@ -146,10 +159,10 @@ func runLogin(cmd *cobra.Command, args []string) {
 		// Save auth config
 		auth := AuthConfig{
 			AccessToken: apiKey,
-			ExpiresAt:   time.Now().Add(365 * 24 * time.Hour), // API keys don't expire
+			ExpiresAt:   store.ExpiresAt(apiKeyLifetime),
 			IsAdmin:     true,
 		}
-		if err := saveAuthConfig(auth); err != nil {
+		if err := store.Save(auth); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving credentials: %v\n", err)
 			os.Exit(1)
 		}
@@ -169,12 +182,11 @@ func runLogin(cmd *cobra.Command, args []string) {
 	fmt.Scanln(&password)
 
 	// Make login request
-	client := &http.Client{Timeout: 10 * time.Second}
 	loginBody := fmt.Sprintf(`{"email":"%s","password":"%s"}`, email, password)
 	req, _ := http.NewRequest("POST", getAPIURL()+"/api/auth/login", strings.NewReader(loginBody))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
+	resp, err := newClient("").Do(req)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: Failed to connect to API: %v\n", err)
 		os.Exit(1)
@@ -202,12 +214,12 @@ func runLogin(cmd *cobra.Command, args []string) {
 	auth := AuthConfig{
 		AccessToken:  loginResp.AccessToken,
 		RefreshToken: loginResp.RefreshToken,
-		ExpiresAt:    time.Now().Add(time.Duration(loginResp.ExpiresIn) * time.Second),
+		ExpiresAt:    store.ExpiresAt(time.Duration(loginResp.ExpiresIn) * time.Second),
 		Email:        loginResp.User.Email,
 		UserID:       loginResp.User.ID,
 		IsAdmin:      loginResp.User.IsAdmin,
 	}
-	if err := saveAuthConfig(auth); err != nil {
+	if err := store.Save(auth); err != nil {
 		fmt.Fprintf(os.Stderr, "Error saving credentials: %v\n", err)
 		os.Exit(1)
 	}
@@ -326,47 +338,58 @@ var checkCmd = &cobra.Command{
 	Short: "Check if user has access to a feature",
 	Long: `Check if a user has access to a specific feature on a domain.
 
+With --stdin, domain:feature pairs are read one per line from standard input
+and a result is printed for each. Blank lines and lines starting with # are
+ignored, and each distinct pair is checked once. The command exits with
+status 1 if any feature is denied or cannot be checked, so it can gate a
+deployment.
+
 Examples:
   # Check your own access
   changes entitlement check --domain getthis.money --feature payouts
 
   # Check another user's access (admin)
-  changes entitlement check --user user-123 --domain merklemart.com --feature unlimited_listings`,
+  changes entitlement check --user user-123 --domain merklemart.com --feature unlimited_listings
+
+  # Gate a deployment on every feature the app needs
+  changes entitlement check --stdin < required-features.txt`,
 	Run: runCheck,
 }
 
 func init() {
 	checkCmd.Flags().String("user", "", "User ID to check (admin only)")
-	checkCmd.Flags().String("domain", "", "Domain to check (required)")
-	checkCmd.Flags().String("feature", "", "Feature to check (required)")
-	checkCmd.MarkFlagRequired("domain")
-	checkCmd.MarkFlagRequired("feature")
+	checkCmd.Flags().String("domain", "", "Domain to check (required without --stdin)")
+	checkCmd.Flags().String("feature", "", "Feature to check (required without --stdin)")
+	checkCmd.Flags().Bool("stdin", false, "Read domain:feature pairs from standard input, one per line")
+	checkCmd.MarkFlagsMutuallyExclusive("stdin", "domain")
+	checkCmd.MarkFlagsMutuallyExclusive("stdin", "feature")
 }
 
 func runCheck(cmd *cobra.Command, args []string) {
-	auth := mustGetAuth()
 	domain, _ := cmd.Flags().GetString("domain")
 	feature, _ := cmd.Flags().GetString("feature")
 	userID, _ := cmd.Flags().GetString("user")
+	fromStdin, _ := cmd.Flags().GetBool("stdin")
 
-	endpoint := fmt.Sprintf("/api/entitlements/check?domain=%s&feature=%s", domain, feature)
-	if userID != "" {
-		endpoint = fmt.Sprintf("/api/entitlements/admin/check?userId=%s&domain=%s&feature=%s", userID, domain, feature)
+	if fromStdin {
+		auth := mustGetAuth()
+		if !checkFromReader(os.Stdin, os.Stdout, auth, userID) {
+			os.Exit(1)
+		}
+		return
+	}
+	if domain == "" || feature == "" {
+		fmt.Fprintln(os.Stderr, "Error: --domain and --feature are required (or use --stdin)")
+		os.Exit(1)
 	}
 
-	resp, err := makeAuthenticatedRequest("GET", endpoint, nil, auth)
+	auth := mustGetAuth()
+	result, err := checkAccess(auth, userID, domain, feature)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	var result struct {
-		HasAccess   bool         `json:"hasAccess"`
-		Entitlement *Entitlement `json:"entitlement"`
-		Reason      string       `json:"reason"`
-	}
-	json.Unmarshal(resp, &result)
-
 	if result.HasAccess {
 		fmt.Printf("Access: GRANTED\n")
 		if result.Entitlement != nil {
@@ -390,12 +413,19 @@ var usageCmd = &cobra.Command{
 	Short: "View usage metrics for a user",
 	Long: `View usage metrics and limits for a user.
 
+With --alert-at the command prints a warning and exits with status 2 when any
+reported metric is at or above that percentage of its limit, so it can run as
+a scheduled quota monitor.
+
 Examples:
   # View your usage
   changes entitlement usage --domain getthis.money --metric api_calls
 
   # View all usage for a domain
-  changes entitlement usage --domain merklemart.com`,
+  changes entitlement usage --domain merklemart.com
+
+  # Report headroom for every limited metric and alert at 80%
+  changes entitlement usage --domain merklemart.com --all-metrics --alert-at 80`,
 	Run: runUsage,
 }
 
@@ -403,14 +433,42 @@ func init() {
 	usageCmd.Flags().String("user", "", "User ID (admin only)")
 	usageCmd.Flags().String("domain", "", "Domain to check (required)")
 	usageCmd.Flags().String("metric", "", "Specific metric to check")
+	usageCmd.Flags().Int("alert-at", 0, "Warn and exit with status 2 when usage reaches this percentage of a limit")
+	usageCmd.Flags().Bool("all-metrics", false, "Report every limited metric on the domain with its headroom")
 	usageCmd.MarkFlagRequired("domain")
 }
 
-func runUsage(cmd *cobra.Command, args []string) {
-	auth := mustGetAuth()
-	domain, _ := cmd.Flags().GetString("domain")
-	metric, _ := cmd.Flags().GetString("metric")
+// exitAlert is the exit status when --alert-at is exceeded, distinct from
+// the status 1 used for errors
+const exitAlert = 2
+
+// UsageResult is the usage of one metric against its limit
+type UsageResult struct {
+	Metric     string `json:"metric,omitempty"`
+	Allowed    bool   `json:"allowed"`
+	Current    int    `json:"current"`
+	Limit      int    `json:"limit"`
+	Percentage int    `json:"percentage"`
+	ResetAt    string `json:"resetAt"`
+}
+
+// Headroom returns how much of the limit is left, or -1 if unlimited
+func (u UsageResult) Headroom() int {
+	if u.Limit <= 0 {
+		return -1
+	}
+	if u.Current >= u.Limit {
+		return 0
+	}
+	return u.Limit - u.Current
+}
 
+// AtThreshold reports whether usage has reached pct percent of the limit
+func (u UsageResult) AtThreshold(pct int) bool {
+	return pct > 0 && u.Limit > 0 && u.Percentage >= pct
+}
+
+func fetchUsage(auth *AuthConfig, domain, metric string) (*UsageResult, error) {
 	endpoint := fmt.Sprintf("/api/entitlements/usage?domain=%s", domain)
 	if metric != "" {
 		endpoint += "&metric=" + metric
@@ -418,19 +476,65 @@ func runUsage(cmd *cobra.Command, args []string) {
 
 	resp, err := makeAuthenticatedRequest("GET", endpoint, nil, auth)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		return nil, err
+	}
+
+	var result UsageResult
+	json.Unmarshal(resp, &result)
+	result.Metric = metric
+	return &result, nil
+}
+
+// limitedMetrics returns the metric names that carry a limit in the caller's
+// entitlements on domain, sorted
+func limitedMetrics(auth *AuthConfig, domain string) ([]string, error) {
+	resp, err := makeAuthenticatedRequest("GET", fmt.Sprintf("/api/entitlements/domain/%s", domain), nil, auth)
+	if err != nil {
+		return nil, err
 	}
 
 	var result struct {
-		Allowed    bool   `json:"allowed"`
-		Current    int    `json:"current"`
-		Limit      int    `json:"limit"`
-		Percentage int    `json:"percentage"`
-		ResetAt    string `json:"resetAt"`
+		Entitlements []Entitlement `json:"entitlements"`
 	}
 	json.Unmarshal(resp, &result)
 
+	seen := make(map[string]bool)
+	var metrics []string
+	for _, e := range result.Entitlements {
+		for name := range e.Limits {
+			if !seen[name] {
+				seen[name] = true
+				metrics = append(metrics, name)
+			}
+		}
+	}
+	sort.Strings(metrics)
+	return metrics, nil
+}
+
+func runUsage(cmd *cobra.Command, args []string) {
+	auth := mustGetAuth()
+	domain, _ := cmd.Flags().GetString("domain")
+	metric, _ := cmd.Flags().GetString("metric")
+	alertAt, _ := cmd.Flags().GetInt("alert-at")
+	allMetrics, _ := cmd.Flags().GetBool("all-metrics")
+
+	if alertAt < 0 || alertAt > 100 {
+		fmt.Fprintln(os.Stderr, "Error: --alert-at must be between 0 and 100")
+		os.Exit(1)
+	}
+
+	if allMetrics {
+		runUsageAllMetrics(auth, domain, alertAt)
+		return
+	}
+
+	result, err := fetchUsage(auth, domain, metric)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	fmt.Printf("Domain:     %s\n", domain)
 	if metric != "" {
 		fmt.Printf("Metric:     %s\n", metric)
@@ -442,6 +546,74 @@ func runUsage(cmd *cobra.Command, args []string) {
 	} else {
 		fmt.Println("LIMIT EXCEEDED")
 	}
+
+	if result.AtThreshold(alertAt) {
+		name := metric
+		if name == "" {
+			name = domain
+		}
+		fmt.Fprintf(os.Stderr, "WARNING: %s usage at %d%% (alert threshold %d%%)\n", name, result.Percentage, alertAt)
+		os.Exit(exitAlert)
+	}
+}
+
+// runUsageAllMetrics reports usage and headroom for every limited metric
+func runUsageAllMetrics(auth *AuthConfig, domain string, alertAt int) {
+	metrics, err := limitedMetrics(auth, domain)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(metrics) == 0 {
+		fmt.Printf("No limited metrics on %s\n", domain)
+		return
+	}
+
+	var alerts []UsageResult
+	failed := 0
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METRIC\tUSED\tLIMIT\tPCT\tHEADROOM\tSTATUS")
+	fmt.Fprintln(w, "------\t----\t-----\t---\t--------\t------")
+	for _, m := range metrics {
+		result, err := fetchUsage(auth, domain, m)
+		if err != nil {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\tERROR: %v\n", m, err)
+			failed++
+			continue
+		}
+
+		headroom := "unlimited"
+		if h := result.Headroom(); h >= 0 {
+			headroom = fmt.Sprintf("%d", h)
+		}
+		status := "OK"
+		switch {
+		case !result.Allowed:
+			status = "LIMIT EXCEEDED"
+		case result.AtThreshold(alertAt):
+			status = "ALERT"
+		}
+		if result.AtThreshold(alertAt) {
+			alerts = append(alerts, *result)
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d%%\t%s\t%s\n", m, result.Current, result.Limit, result.Percentage, headroom, status)
+	}
+	w.Flush()
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d metrics could not be fetched\n", failed)
+	}
+	for _, a := range alerts {
+		fmt.Fprintf(os.Stderr, "WARNING: %s usage at %d%% (alert threshold %d%%)\n", a.Metric, a.Percentage, alertAt)
+	}
+	if len(alerts) > 0 {
+		os.Exit(exitAlert)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
 }
 
 // ============================================
@@ -458,7 +630,10 @@ Examples:
   changes entitlement grant --user user-123 --product GTM-PRO --reason "Promotional offer"
 
   # Grant with expiration
-  changes entitlement grant --user user-123 --product MM-SELLER-PRO --expires 2025-12-31`,
+  changes entitlement grant --user user-123 --product MM-SELLER-PRO --expires 2025-12-31
+
+  # Approve a user's request (see 'changes entitlement requests')
+  changes entitlement grant --user user-123 --product GTM-PRO --request req-456`,
 	Run: runGrant,
 }
 
@@ -467,6 +642,7 @@ func init() {
 	grantCmd.Flags().String("product", "", "Product code (required)")
 	grantCmd.Flags().String("reason", "", "Reason for grant")
 	grantCmd.Flags().String("expires", "", "Expiration date (YYYY-MM-DD)")
+	grantCmd.Flags().String("request", "", "Request ID this grant approves")
 	grantCmd.MarkFlagRequired("user")
 	grantCmd.MarkFlagRequired("product")
 }
@@ -477,6 +653,7 @@ func runGrant(cmd *cobra.Command, args []string) {
 	product, _ := cmd.Flags().GetString("product")
 	reason, _ := cmd.Flags().GetString("reason")
 	expires, _ := cmd.Flags().GetString("expires")
+	requestID, _ := cmd.Flags().GetString("request")
 
 	body := map[string]interface{}{
 		"userId":      userID,
@@ -485,6 +662,9 @@ func runGrant(cmd *cobra.Command, args []string) {
 	if reason != "" {
 		body["reason"] = reason
 	}
+	if requestID != "" {
+		body["requestId"] = requestID
+	}
 	if expires != "" {
 		body["expiresAt"] = expires + "T23:59:59Z"
 	}
@@ -504,6 +684,9 @@ func runGrant(cmd *cobra.Command, args []string) {
 
 	if result.Success {
 		fmt.Printf("Successfully granted %s to user %s\n", product, userID)
+		if requestID != "" {
+			fmt.Printf("Request %s approved\n", requestID)
+		}
 	} else {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", result.Error)
 		os.Exit(1)
@@ -693,6 +876,10 @@ var logCmd = &cobra.Command{
 	Short: "View entitlement audit log",
 	Long: `View audit log of entitlement changes (grants, revocations, usage).
 
+For compliance exports use --output csv or json, which write complete,
+untruncated rows; add --all to page through the whole log instead of stopping
+at --limit.
+
 Examples:
   # View recent log entries
   changes entitlement log
@@ -701,7 +888,10 @@ Examples:
   changes entitlement log --user user-123
 
   # Filter by action
-  changes entitlement log --action grant`,
+  changes entitlement log --action grant
+
+  # Export everything from Q1 as CSV
+  changes entitlement log --all --from 2026-01-01 --to 2026-03-31 --output csv > audit.csv`,
 	Run: runLog,
 }
 
@@ -709,7 +899,65 @@ func init() {
 	logCmd.Flags().String("user", "", "Filter by user ID")
 	logCmd.Flags().String("domain", "", "Filter by domain")
 	logCmd.Flags().String("action", "", "Filter by action (grant, revoke, usage)")
-	logCmd.Flags().Int("limit", 20, "Maximum number of results")
+	logCmd.Flags().Int("limit", 20, "Maximum number of results (page size with --all)")
+	logCmd.Flags().Bool("all", false, "Fetch the entire log, following pagination")
+	logCmd.Flags().String("from", "", "Only entries on or after this date (YYYY-MM-DD)")
+	logCmd.Flags().String("to", "", "Only entries on or before this date (YYYY-MM-DD)")
+	logCmd.Flags().StringP("output", "o", "table", "Output format: table, csv or json")
+}
+
+// AuditLogEntry is one entitlement audit log record
+type AuditLogEntry struct {
+	ID        string    `json:"id"`
+	Action    string    `json:"action"`
+	UserID    string    `json:"userId"`
+	UserEmail string    `json:"userEmail"`
+	Product   string    `json:"productCode"`
+	Domain    string    `json:"domain"`
+	Actor     string    `json:"actorEmail"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// auditLogPage is one page of the admin log endpoint. The server returns
+// nextCursor when cursor pagination is available; otherwise pages are
+// requested by offset until a short page comes back.
+type auditLogPage struct {
+	Entries    []AuditLogEntry `json:"entries"`
+	NextCursor string          `json:"nextCursor"`
+}
+
+// fetchAuditLog loads audit log entries matching query. With all set it
+// keeps requesting pages of pageSize until the log is exhausted.
+func fetchAuditLog(auth *AuthConfig, query url.Values, pageSize int, all bool) ([]AuditLogEntry, error) {
+	var entries []AuditLogEntry
+	query.Set("limit", strconv.Itoa(pageSize))
+
+	for offset := 0; ; {
+		resp, err := makeAuthenticatedRequest("GET", "/api/entitlements/admin/log?"+query.Encode(), nil, auth)
+		if err != nil {
+			return nil, err
+		}
+
+		var page auditLogPage
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return nil, fmt.Errorf("invalid response: %v", err)
+		}
+		entries = append(entries, page.Entries...)
+
+		if !all || len(page.Entries) == 0 {
+			return entries, nil
+		}
+		if page.NextCursor != "" {
+			query.Set("cursor", page.NextCursor)
+			continue
+		}
+		if len(page.Entries) < pageSize {
+			return entries, nil
+		}
+		offset += len(page.Entries)
+		query.Set("offset", strconv.Itoa(offset))
+	}
 }
 
 func runLog(cmd *cobra.Command, args []string) {
@@ -718,43 +966,71 @@ func runLog(cmd *cobra.Command, args []string) {
 	domain, _ := cmd.Flags().GetString("domain")
 	action, _ := cmd.Flags().GetString("action")
 	limit, _ := cmd.Flags().GetInt("limit")
+	all, _ := cmd.Flags().GetBool("all")
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	format, _ := cmd.Flags().GetString("output")
 
-	endpoint := fmt.Sprintf("/api/entitlements/admin/log?limit=%d", limit)
+	if format != "table" && format != "csv" && format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown output format %q (use table, csv or json)\n", format)
+		os.Exit(1)
+	}
+	if limit <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: --limit must be positive")
+		os.Exit(1)
+	}
+
+	query := url.Values{}
 	if userID != "" {
-		endpoint += "&userId=" + userID
+		query.Set("userId", userID)
 	}
 	if domain != "" {
-		endpoint += "&domain=" + domain
+		query.Set("domain", domain)
 	}
 	if action != "" {
-		endpoint += "&action=" + action
+		query.Set("action", action)
+	}
+	for name, value := range map[string]string{"from": from, "to": to} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --%s date %q (use YYYY-MM-DD)\n", name, value)
+			os.Exit(1)
+		}
+		query.Set(name, value)
 	}
 
-	resp, err := makeAuthenticatedRequest("GET", endpoint, nil, auth)
+	entries, err := fetchAuditLog(auth, query, limit, all)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	var result struct {
-		Entries []struct {
-			ID        string    `json:"id"`
-			Action    string    `json:"action"`
-			UserID    string    `json:"userId"`
-			UserEmail string    `json:"userEmail"`
-			Product   string    `json:"productCode"`
-			Domain    string    `json:"domain"`
-			Actor     string    `json:"actorEmail"`
-			Reason    string    `json:"reason"`
-			CreatedAt time.Time `json:"createdAt"`
-		} `json:"entries"`
+	switch format {
+	case "json":
+		if entries == nil {
+			entries = []AuditLogEntry{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(entries); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	case "csv":
+		if err := writeAuditLogCSV(os.Stdout, entries); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
-	json.Unmarshal(resp, &result)
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "TIMESTAMP\tACTION\tUSER\tPRODUCT\tACTOR\tREASON")
 	fmt.Fprintln(w, "---------\t------\t----\t-------\t-----\t------")
-	for _, e := range result.Entries {
+	for _, e := range entries {
 		reason := e.Reason
 		if len(reason) > 30 {
 			reason = reason[:27] + "..."
@@ -770,6 +1046,28 @@ func runLog(cmd *cobra.Command, args []string) {
 	w.Flush()
 }
 
+// writeAuditLogCSV writes entries as CSV with a header row and full,
+// untruncated values
+func writeAuditLogCSV(out io.Writer, entries []AuditLogEntry) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"id", "created_at", "action", "user_id", "user_email", "product_code", "domain", "actor_email", "reason"})
+	for _, e := range entries {
+		w.Write([]string{
+			e.ID,
+			e.CreatedAt.UTC().Format(time.RFC3339),
+			e.Action,
+			e.UserID,
+			e.UserEmail,
+			e.Product,
+			e.Domain,
+			e.Actor,
+			e.Reason,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
 // ============================================
 // FREEZE / UNFREEZE
 // ============================================
@@ -792,23 +1090,28 @@ func init() {
 }
 
 func runFreeze(cmd *cobra.Command, args []string) {
-	auth := mustGetAuth()
 	userID := args[0]
 	reason, _ := cmd.Flags().GetString("reason")
 
+	if err := FreezeUser(userID, reason); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Entitlements frozen for user %s\n", userID)
+}
+
+// FreezeUser freezes all of a user's entitlements. Like the entitlement
+// commands, it exits when the CLI is not logged in to the entitlements API.
+func FreezeUser(userID, reason string) error {
 	body := map[string]interface{}{
 		"frozen": true,
 		"reason": reason,
 	}
 	bodyBytes, _ := json.Marshal(body)
 
-	_, err := makeAuthenticatedRequest("POST", "/api/entitlements/admin/user/"+userID+"/freeze", bodyBytes, auth)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Entitlements frozen for user %s\n", userID)
+	_, err := makeAuthenticatedRequest("POST", "/api/entitlements/admin/user/"+url.PathEscape(userID)+"/freeze", bodyBytes, mustGetAuth())
+	return err
 }
 
 var unfreezeCmd = &cobra.Command{
@@ -846,153 +1149,13 @@ func runUnfreeze(cmd *cobra.Command, args []string) {
 // ============================================
 
 func getAPIURL() string {
-	if url := viper.GetString("entitlements_api_url"); url != "" {
-		return url
-	}
-	if url := os.Getenv("ENTITLEMENTS_API_URL"); url != "" {
-		return url
-	}
-	return defaultAPIURL
+	return apiclient.EntitlementsAPI.URL("")
 }
 
 func getAuthConfigPath() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".adsops-utils", "entitlements-auth.json")
-}
-
-func saveAuthConfig(auth AuthConfig) error {
-	path := getAuthConfigPath()
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return err
-	}
-
-	data, err := json.MarshalIndent(auth, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(path, data, 0600)
-}
-
-func loadAuthConfig() (*AuthConfig, error) {
-	path := getAuthConfigPath()
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	var auth AuthConfig
-	if err := json.Unmarshal(data, &auth); err != nil {
-		return nil, err
-	}
-
-	return &auth, nil
-}
-
-func mustGetAuth() *AuthConfig {
-	// Check for API key in environment
-	if apiKey := os.Getenv("ENTITLEMENTS_API_KEY"); apiKey != "" {
-		return &AuthConfig{
-			AccessToken: apiKey,
-			IsAdmin:     true,
-		}
-	}
-
-	auth, err := loadAuthConfig()
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "Not authenticated. Run 'changes entitlement login' first.")
-		os.Exit(1)
-	}
-
-	// Check if token is expired
-	if time.Now().After(auth.ExpiresAt) {
-		// Try to refresh
-		if auth.RefreshToken != "" {
-			newAuth, err := refreshToken(auth)
-			if err != nil {
-				fmt.Fprintln(os.Stderr, "Session expired. Run 'changes entitlement login' again.")
-				os.Exit(1)
-			}
-			return newAuth
-		}
-		fmt.Fprintln(os.Stderr, "Session expired. Run 'changes entitlement login' again.")
-		os.Exit(1)
-	}
-
-	return auth
-}
-
-func refreshToken(auth *AuthConfig) (*AuthConfig, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-	body := fmt.Sprintf(`{"refreshToken":"%s"}`, auth.RefreshToken)
-	req, _ := http.NewRequest("POST", getAPIURL()+"/api/auth/token/refresh", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("refresh failed")
-	}
-
-	var result struct {
-		AccessToken  string `json:"accessToken"`
-		RefreshToken string `json:"refreshToken"`
-		ExpiresIn    int    `json:"expiresIn"`
-	}
-	json.NewDecoder(resp.Body).Decode(&result)
-
-	newAuth := &AuthConfig{
-		AccessToken:  result.AccessToken,
-		RefreshToken: result.RefreshToken,
-		ExpiresAt:    time.Now().Add(time.Duration(result.ExpiresIn) * time.Second),
-		Email:        auth.Email,
-		UserID:       auth.UserID,
-		IsAdmin:      auth.IsAdmin,
-	}
-	saveAuthConfig(*newAuth)
-
-	return newAuth, nil
+	return apiclient.EntitlementsAPI.SessionPath()
 }
 
 func makeAuthenticatedRequest(method, endpoint string, body []byte, auth *AuthConfig) ([]byte, error) {
-	client := &http.Client{Timeout: 30 * time.Second}
-
-	var req *http.Request
-	var err error
-	if body != nil {
-		req, err = http.NewRequest(method, getAPIURL()+endpoint, strings.NewReader(string(body)))
-	} else {
-		req, err = http.NewRequest(method, getAPIURL()+endpoint, nil)
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", "Bearer "+auth.AccessToken)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %v", err)
-	}
-	defer resp.Body.Close()
-
-	respBody, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode == 401 {
-		return nil, fmt.Errorf("unauthorized - please login again")
-	}
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("forbidden - insufficient privileges")
-	}
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
-	}
-
-	return respBody, nil
+	return newClient(auth.AccessToken).Request(method, endpoint, body)
 }
@@ -0,0 +1,117 @@
+package entitlement
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// ============================================
+// GROUP ENTITLEMENTS
+// ============================================
+
+// GroupEntitlements returns the entitlements conferred by membership of an
+// ACL group, optionally limited to one domain. Like the entitlement
+// commands, it exits when the CLI is not logged in to the entitlements API.
+func GroupEntitlements(group, domain string) ([]Entitlement, error) {
+	endpoint := fmt.Sprintf("/api/entitlements/admin/group/%s", url.PathEscape(group))
+	if domain != "" {
+		endpoint += fmt.Sprintf("/domain/%s", url.PathEscape(domain))
+	}
+
+	resp, err := makeAuthenticatedRequest("GET", endpoint, nil, mustGetAuth())
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Entitlements []Entitlement `json:"entitlements"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("invalid response: %v", err)
+	}
+	sortEntitlements(result.Entitlements)
+	return result.Entitlements, nil
+}
+
+// EffectiveEntitlement is one product entitlement merged across every
+// group that confers it
+type EffectiveEntitlement struct {
+	Entitlement
+
+	// Tiers lists the distinct tiers granted, sorted. Entitlement.Tier is
+	// the first of them.
+	Tiers []string
+
+	// Groups lists the groups the entitlement comes from, sorted
+	Groups []string
+}
+
+// EffectiveEntitlements merges per-group entitlements into their union,
+// keyed by product and domain. Features are combined, each limit takes the
+// largest value granted, and the entitlement expires with the latest grant
+// (never, if any grant has no expiry). Results are sorted by domain and then
+// product code.
+func EffectiveEntitlements(byGroup map[string][]Entitlement) []EffectiveEntitlement {
+	merged := make(map[string]*EffectiveEntitlement)
+	features := make(map[string]map[string]bool)
+	var keys []string
+
+	for group, list := range byGroup {
+		for _, e := range list {
+			key := entitlementKey(e)
+			eff, ok := merged[key]
+			if !ok {
+				eff = &EffectiveEntitlement{Entitlement: e}
+				eff.Features = nil
+				eff.Limits = make(map[string]int)
+				merged[key] = eff
+				features[key] = make(map[string]bool)
+				keys = append(keys, key)
+			} else if eff.ExpiresAt != nil && (e.ExpiresAt == nil || e.ExpiresAt.After(*eff.ExpiresAt)) {
+				eff.ExpiresAt = e.ExpiresAt
+			}
+
+			eff.Groups = appendUnique(eff.Groups, group)
+			if e.Tier != "" {
+				eff.Tiers = appendUnique(eff.Tiers, e.Tier)
+			}
+			for _, f := range e.Features {
+				features[key][f] = true
+			}
+			for name, limit := range e.Limits {
+				if cur, ok := eff.Limits[name]; !ok || limit > cur {
+					eff.Limits[name] = limit
+				}
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	result := make([]EffectiveEntitlement, 0, len(keys))
+	for _, key := range keys {
+		eff := merged[key]
+		for f := range features[key] {
+			eff.Features = append(eff.Features, f)
+		}
+		sort.Strings(eff.Features)
+		sort.Strings(eff.Tiers)
+		sort.Strings(eff.Groups)
+		if len(eff.Tiers) > 0 {
+			eff.Tier = eff.Tiers[0]
+		}
+		eff.Source = "group"
+		result = append(result, *eff)
+	}
+	return result
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,68 @@
+package entitlement
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/pkg/apiclient"
+)
+
+// HTTP defaults, overridable with --timeout/--retries or the
+// ENTITLEMENTS_TIMEOUT/ENTITLEMENTS_RETRIES environment variables
+const (
+	defaultTimeout = apiclient.DefaultTimeout
+	defaultRetries = apiclient.DefaultRetries
+)
+
+var (
+	flagTimeout time.Duration
+	flagRetries int
+
+	// sleep waits between retries
+	sleep = time.Sleep
+)
+
+func init() {
+	EntitlementCmd.PersistentFlags().DurationVar(&flagTimeout, "timeout", defaultTimeout, "Per-request timeout for API calls (env ENTITLEMENTS_TIMEOUT)")
+	EntitlementCmd.PersistentFlags().IntVar(&flagRetries, "retries", defaultRetries, "Retries for failed API calls (env ENTITLEMENTS_RETRIES)")
+}
+
+// httpSettings resolves the timeout and retry count. Flags set on the command
+// line win over the environment, which wins over the defaults.
+func httpSettings() (time.Duration, int) {
+	timeout, retries := flagTimeout, flagRetries
+
+	flags := EntitlementCmd.PersistentFlags()
+	if !flags.Changed("timeout") {
+		if v := os.Getenv("ENTITLEMENTS_TIMEOUT"); v != "" {
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				timeout = d
+			}
+		}
+	}
+	if !flags.Changed("retries") {
+		if v := os.Getenv("ENTITLEMENTS_RETRIES"); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+				retries = n
+			}
+		}
+	}
+
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	if retries < 0 {
+		retries = 0
+	}
+	return timeout, retries
+}
+
+// newClient returns an API client for token with the configured timeout
+// and retries
+func newClient(token string) *apiclient.Client {
+	client := apiclient.New(getAPIURL(), token)
+	client.Timeout, client.Retries = httpSettings()
+	client.Sleep = sleep
+	return client
+}
@@ -0,0 +1,159 @@
+package entitlement
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// ============================================
+// REQUEST (Self-service)
+// ============================================
+
+// EntitlementRequest is a user's request for an entitlement, awaiting or
+// past admin review
+type EntitlementRequest struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"userId"`
+	ProductCode string     `json:"productCode"`
+	Domain      string     `json:"domain,omitempty"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"` // pending, approved, denied, cancelled
+	CreatedAt   time.Time  `json:"createdAt"`
+	DecidedAt   *time.Time `json:"decidedAt,omitempty"`
+	DecidedBy   string     `json:"decidedBy,omitempty"`
+	Note        string     `json:"note,omitempty"`
+}
+
+var requestCmd = &cobra.Command{
+	Use:   "request",
+	Short: "Request an entitlement",
+	Long: `Ask for an entitlement you do not have. The request joins the approvals
+queue, where an admin approves it with 'changes entitlement grant --request'.
+
+Examples:
+  # Request a product
+  changes entitlement request --product GTM-PRO --reason "Running the Q3 payout batch"
+
+  # Check on your requests
+  changes entitlement requests`,
+	Run: runRequest,
+}
+
+var requestsCmd = &cobra.Command{
+	Use:   "requests",
+	Short: "List your entitlement requests",
+	Long: `List your entitlement requests and their status. Only pending requests
+are shown unless --status or --all is given.
+
+Examples:
+  # Pending requests
+  changes entitlement requests
+
+  # Everything you have requested
+  changes entitlement requests --all`,
+	Run: runRequests,
+}
+
+func init() {
+	requestCmd.Flags().String("product", "", "Product code (required)")
+	requestCmd.Flags().String("domain", "", "Domain the product is for")
+	requestCmd.Flags().String("reason", "", "Why you need it (required)")
+	requestCmd.MarkFlagRequired("product")
+	requestCmd.MarkFlagRequired("reason")
+
+	requestsCmd.Flags().String("status", "pending", "Filter by status (pending, approved, denied, cancelled)")
+	requestsCmd.Flags().Bool("all", false, "Show requests in every status")
+}
+
+func runRequest(cmd *cobra.Command, args []string) {
+	auth := mustGetAuth()
+	product, _ := cmd.Flags().GetString("product")
+	domain, _ := cmd.Flags().GetString("domain")
+	reason, _ := cmd.Flags().GetString("reason")
+
+	body := map[string]interface{}{
+		"productCode": product,
+		"reason":      reason,
+	}
+	if domain != "" {
+		body["domain"] = domain
+	}
+
+	bodyBytes, _ := json.Marshal(body)
+	resp, err := makeAuthenticatedRequest("POST", "/api/entitlements/request", bodyBytes, auth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var result struct {
+		Success   bool   `json:"success"`
+		RequestID string `json:"requestId"`
+		Status    string `json:"status"`
+		Error     string `json:"error"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+		os.Exit(1)
+	}
+	if !result.Success {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", result.Error)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Submitted request %s for %s (%s)\n", result.RequestID, product, result.Status)
+	fmt.Println("Track it with: changes entitlement requests")
+}
+
+func runRequests(cmd *cobra.Command, args []string) {
+	auth := mustGetAuth()
+	status, _ := cmd.Flags().GetString("status")
+	showAll, _ := cmd.Flags().GetBool("all")
+
+	endpoint := "/api/entitlements/requests"
+	if !showAll && status != "" {
+		endpoint += "?" + url.Values{"status": {status}}.Encode()
+	}
+
+	resp, err := makeAuthenticatedRequest("GET", endpoint, nil, auth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var result struct {
+		Requests []EntitlementRequest `json:"requests"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tPRODUCT\tSTATUS\tREQUESTED\tDECIDED\tNOTE")
+	fmt.Fprintln(w, "--\t-------\t------\t---------\t-------\t----")
+	for _, r := range result.Requests {
+		decided := "-"
+		if r.DecidedAt != nil {
+			decided = r.DecidedAt.Format("2006-01-02")
+			if r.DecidedBy != "" {
+				decided += " by " + r.DecidedBy
+			}
+		}
+		note := r.Note
+		if note == "" {
+			note = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.ID, r.ProductCode, r.Status, r.CreatedAt.Format("2006-01-02"), decided, note)
+	}
+	w.Flush()
+
+	fmt.Printf("\nTotal: %d requests\n", len(result.Requests))
+}
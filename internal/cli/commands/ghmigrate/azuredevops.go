@@ -0,0 +1,289 @@
+package ghmigrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// azureDevOpsClosedStates are the work item states treated as closed
+var azureDevOpsClosedStates = []string{"Closed", "Done", "Removed", "Resolved"}
+
+// azureDevOpsBatchSize is the maximum number of IDs per work item fetch
+const azureDevOpsBatchSize = 200
+
+// AzureDevOpsWorkItem represents an Azure Boards work item
+type AzureDevOpsWorkItem struct {
+	ID     int `json:"id"`
+	Fields struct {
+		Title        string               `json:"System.Title"`
+		Description  string               `json:"System.Description"`
+		State        string               `json:"System.State"`
+		WorkItemType string               `json:"System.WorkItemType"`
+		Tags         string               `json:"System.Tags"`
+		CreatedBy    *AzureDevOpsIdentity `json:"System.CreatedBy"`
+		AssignedTo   *AzureDevOpsIdentity `json:"System.AssignedTo"`
+		CreatedDate  time.Time            `json:"System.CreatedDate"`
+		ChangedDate  time.Time            `json:"System.ChangedDate"`
+		ClosedDate   *time.Time           `json:"Microsoft.VSTS.Common.ClosedDate"`
+		CommentCount int                  `json:"System.CommentCount"`
+	} `json:"fields"`
+}
+
+// AzureDevOpsIdentity represents an Azure DevOps user reference
+type AzureDevOpsIdentity struct {
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+// AzureDevOpsComment represents a work item comment
+type AzureDevOpsComment struct {
+	ID          int64                `json:"id"`
+	Text        string               `json:"text"`
+	CreatedBy   *AzureDevOpsIdentity `json:"createdBy"`
+	CreatedDate time.Time            `json:"createdDate"`
+}
+
+// AzureDevOpsClient handles Azure DevOps work item API interactions
+type AzureDevOpsClient struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewAzureDevOpsClient creates a new Azure DevOps API client. The token is a
+// personal access token with Work Items (Read) scope.
+func NewAzureDevOpsClient(baseURL, token string) *AzureDevOpsClient {
+	if baseURL == "" {
+		baseURL = "https://dev.azure.com"
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return &AzureDevOpsClient{
+		BaseURL:    baseURL,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Provider identifies the source
+func (c *AzureDevOpsClient) Provider() models.RepositoryProvider {
+	return models.RepositoryProviderAzureDevOps
+}
+
+// doRequest performs an authenticated HTTP request
+func (c *AzureDevOpsClient) doRequest(method, reqURL string, payload interface{}) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, reqURL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		// PATs are sent as the password with an empty username
+		req.SetBasicAuth("", c.Token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// ListIssues queries a project's work items with WIQL and fetches them in
+// batches. Labels are matched against work item tags.
+func (c *AzureDevOpsClient) ListIssues(organization, project string, state string, labels []string, limit int) ([]Issue, error) {
+	query := "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project"
+	switch state {
+	case "open":
+		query += " AND [System.State] NOT IN (" + wiqlList(azureDevOpsClosedStates) + ")"
+	case "closed":
+		query += " AND [System.State] IN (" + wiqlList(azureDevOpsClosedStates) + ")"
+	}
+	for _, label := range labels {
+		query += fmt.Sprintf(" AND [System.Tags] CONTAINS '%s'", strings.ReplaceAll(label, "'", "''"))
+	}
+	query += " ORDER BY [System.CreatedDate] DESC"
+
+	wiqlURL := fmt.Sprintf("%s/%s/%s/_apis/wit/wiql?api-version=7.0&$top=%d",
+		c.BaseURL, url.PathEscape(organization), url.PathEscape(project), limit)
+	body, err := c.doRequest(http.MethodPost, wiqlURL, map[string]string{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		WorkItems []struct {
+			ID int `json:"id"`
+		} `json:"workItems"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse query result: %w", err)
+	}
+
+	ids := make([]string, 0, len(result.WorkItems))
+	for _, wi := range result.WorkItems {
+		ids = append(ids, fmt.Sprintf("%d", wi.ID))
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	var allIssues []Issue
+	for start := 0; start < len(ids); start += azureDevOpsBatchSize {
+		end := start + azureDevOpsBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		itemsURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workitems?ids=%s&api-version=7.0",
+			c.BaseURL, url.PathEscape(organization), url.PathEscape(project), strings.Join(ids[start:end], ","))
+		body, err := c.doRequest(http.MethodGet, itemsURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var batch struct {
+			Value []AzureDevOpsWorkItem `json:"value"`
+		}
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return nil, fmt.Errorf("failed to parse work items: %w", err)
+		}
+
+		for _, wi := range batch.Value {
+			allIssues = append(allIssues, wi.normalize(c.BaseURL, organization, project))
+		}
+	}
+
+	return allIssues, nil
+}
+
+// GetIssueComments fetches all comments for a work item
+func (c *AzureDevOpsClient) GetIssueComments(organization, project string, issueNumber int) ([]IssueComment, error) {
+	baseURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workItems/%d/comments?api-version=7.0-preview.3",
+		c.BaseURL, url.PathEscape(organization), url.PathEscape(project), issueNumber)
+
+	var comments []IssueComment
+	continuation := ""
+	for {
+		reqURL := baseURL
+		if continuation != "" {
+			reqURL += "&continuationToken=" + url.QueryEscape(continuation)
+		}
+
+		body, err := c.doRequest(http.MethodGet, reqURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Comments          []AzureDevOpsComment `json:"comments"`
+			ContinuationToken string               `json:"continuationToken"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse comments: %w", err)
+		}
+
+		for _, ac := range page.Comments {
+			comments = append(comments, IssueComment{
+				ID:        ac.ID,
+				Body:      ac.Text,
+				Author:    ac.CreatedBy.login(),
+				CreatedAt: ac.CreatedDate,
+			})
+		}
+
+		if page.ContinuationToken == "" {
+			break
+		}
+		continuation = page.ContinuationToken
+	}
+
+	return comments, nil
+}
+
+// normalize converts a work item to the common form
+func (wi AzureDevOpsWorkItem) normalize(baseURL, organization, project string) Issue {
+	state := "open"
+	for _, s := range azureDevOpsClosedStates {
+		if strings.EqualFold(wi.Fields.State, s) {
+			state = "closed"
+		}
+	}
+
+	var labels []string
+	if wi.Fields.WorkItemType != "" {
+		labels = append(labels, wi.Fields.WorkItemType)
+	}
+	for _, tag := range strings.Split(wi.Fields.Tags, ";") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			labels = append(labels, tag)
+		}
+	}
+
+	return Issue{
+		Number:       wi.ID,
+		Title:        wi.Fields.Title,
+		Body:         wi.Fields.Description,
+		State:        state,
+		Labels:       labels,
+		Author:       wi.Fields.CreatedBy.login(),
+		Assignee:     wi.Fields.AssignedTo.login(),
+		CreatedAt:    wi.Fields.CreatedDate,
+		UpdatedAt:    wi.Fields.ChangedDate,
+		ClosedAt:     wi.Fields.ClosedDate,
+		URL:          fmt.Sprintf("%s/%s/%s/_workitems/edit/%d", baseURL, url.PathEscape(organization), url.PathEscape(project), wi.ID),
+		CommentCount: wi.Fields.CommentCount,
+	}
+}
+
+// login returns the identity's unique name, which is usually an email
+func (i *AzureDevOpsIdentity) login() string {
+	if i == nil {
+		return ""
+	}
+	if i.UniqueName != "" {
+		return i.UniqueName
+	}
+	return i.DisplayName
+}
+
+// wiqlList renders a quoted WIQL value list
+func wiqlList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + v + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,297 @@
+package ghmigrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// bitbucketOpenStates are the Bitbucket issue states treated as open
+var bitbucketOpenStates = []string{"new", "open", "on hold"}
+
+// BitbucketIssue represents a Bitbucket Cloud issue
+type BitbucketIssue struct {
+	ID        int            `json:"id"`
+	Title     string         `json:"title"`
+	Content   BitbucketText  `json:"content"`
+	State     string         `json:"state"`
+	Kind      string         `json:"kind"`
+	Priority  string         `json:"priority"`
+	Reporter  *BitbucketUser `json:"reporter"`
+	Assignee  *BitbucketUser `json:"assignee"`
+	Component *struct {
+		Name string `json:"name"`
+	} `json:"component"`
+	CreatedOn time.Time `json:"created_on"`
+	UpdatedOn time.Time `json:"updated_on"`
+	Links     struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// BitbucketText holds Bitbucket's rendered-markup fields
+type BitbucketText struct {
+	Raw string `json:"raw"`
+}
+
+// BitbucketUser represents a Bitbucket account
+type BitbucketUser struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+// BitbucketComment represents a Bitbucket issue comment
+type BitbucketComment struct {
+	ID        int64          `json:"id"`
+	Content   BitbucketText  `json:"content"`
+	User      *BitbucketUser `json:"user"`
+	CreatedOn time.Time      `json:"created_on"`
+}
+
+// bitbucketPage is the paginated envelope used by the Bitbucket 2.0 API
+type bitbucketPage struct {
+	Values json.RawMessage `json:"values"`
+	Next   string          `json:"next"`
+}
+
+// BitbucketClient handles Bitbucket Cloud API interactions
+type BitbucketClient struct {
+	BaseURL    string
+	Token      string
+	Username   string
+	HTTPClient *http.Client
+}
+
+// NewBitbucketClient creates a new Bitbucket API client. With a username the
+// token is sent as an app password; otherwise it is used as a bearer token.
+func NewBitbucketClient(baseURL, token, username string) *BitbucketClient {
+	if baseURL == "" {
+		baseURL = "https://api.bitbucket.org/2.0"
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return &BitbucketClient{
+		BaseURL:    baseURL,
+		Token:      token,
+		Username:   username,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Provider identifies the source
+func (c *BitbucketClient) Provider() models.RepositoryProvider {
+	return models.RepositoryProviderBitbucket
+}
+
+// doRequest performs an authenticated GET against a full URL
+func (c *BitbucketClient) doRequest(reqURL string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		if c.Username != "" {
+			req.SetBasicAuth(c.Username, c.Token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+c.Token)
+		}
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// ListIssues fetches issues from a repository's issue tracker. Bitbucket has
+// no labels, so the label filter matches kind, priority and component.
+func (c *BitbucketClient) ListIssues(workspace, repo string, state string, labels []string, limit int) ([]Issue, error) {
+	var clauses []string
+	switch state {
+	case "open":
+		clauses = append(clauses, bitbucketStateQuery(bitbucketOpenStates, "="))
+	case "closed":
+		clauses = append(clauses, bitbucketStateQuery(bitbucketOpenStates, "!="))
+	}
+
+	params := url.Values{}
+	params.Set("pagelen", "50")
+	params.Set("sort", "-created_on")
+	if len(clauses) > 0 {
+		params.Set("q", strings.Join(clauses, " AND "))
+	}
+
+	next := fmt.Sprintf("%s/repositories/%s/%s/issues?%s", c.BaseURL, workspace, repo, params.Encode())
+
+	var allIssues []Issue
+	for next != "" && len(allIssues) < limit {
+		body, err := c.doRequest(next)
+		if err != nil {
+			return nil, err
+		}
+
+		var page bitbucketPage
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse issues: %w", err)
+		}
+		var issues []BitbucketIssue
+		if err := json.Unmarshal(page.Values, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse issues: %w", err)
+		}
+
+		for _, bi := range issues {
+			issue := bi.normalize()
+			if hasLabels(issue.Labels, labels) {
+				allIssues = append(allIssues, issue)
+			}
+		}
+		next = page.Next
+	}
+
+	if len(allIssues) > limit {
+		allIssues = allIssues[:limit]
+	}
+
+	return allIssues, nil
+}
+
+// GetIssueComments fetches all comments for an issue
+func (c *BitbucketClient) GetIssueComments(workspace, repo string, issueNumber int) ([]IssueComment, error) {
+	next := fmt.Sprintf("%s/repositories/%s/%s/issues/%d/comments?pagelen=100", c.BaseURL, workspace, repo, issueNumber)
+
+	var comments []IssueComment
+	for next != "" {
+		body, err := c.doRequest(next)
+		if err != nil {
+			return nil, err
+		}
+
+		var page bitbucketPage
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse comments: %w", err)
+		}
+		var values []BitbucketComment
+		if err := json.Unmarshal(page.Values, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse comments: %w", err)
+		}
+
+		for _, bc := range values {
+			// Bitbucket records state changes as comments with no text
+			if bc.Content.Raw == "" {
+				continue
+			}
+			comments = append(comments, IssueComment{
+				ID:        bc.ID,
+				Body:      bc.Content.Raw,
+				Author:    bc.User.login(),
+				CreatedAt: bc.CreatedOn,
+			})
+		}
+		next = page.Next
+	}
+
+	return comments, nil
+}
+
+// normalize converts a Bitbucket issue to the common form
+func (bi BitbucketIssue) normalize() Issue {
+	state := "closed"
+	for _, s := range bitbucketOpenStates {
+		if bi.State == s {
+			state = "open"
+		}
+	}
+
+	var labels []string
+	for _, l := range []string{bi.Kind, bi.Priority} {
+		if l != "" {
+			labels = append(labels, l)
+		}
+	}
+	if bi.Component != nil && bi.Component.Name != "" {
+		labels = append(labels, bi.Component.Name)
+	}
+
+	issue := Issue{
+		Number:    bi.ID,
+		Title:     bi.Title,
+		Body:      bi.Content.Raw,
+		State:     state,
+		Labels:    labels,
+		Author:    bi.Reporter.login(),
+		Assignee:  bi.Assignee.login(),
+		CreatedAt: bi.CreatedOn,
+		UpdatedAt: bi.UpdatedOn,
+		URL:       bi.Links.HTML.Href,
+		// Comment counts are not part of the issue payload
+		CommentCount: -1,
+	}
+	if state == "closed" {
+		closed := bi.UpdatedOn
+		issue.ClosedAt = &closed
+	}
+	return issue
+}
+
+// login returns the most stable identifier available for a user
+func (u *BitbucketUser) login() string {
+	if u == nil {
+		return ""
+	}
+	if u.Nickname != "" {
+		return u.Nickname
+	}
+	return u.DisplayName
+}
+
+// bitbucketStateQuery builds a BBQL clause over the given states
+func bitbucketStateQuery(states []string, op string) string {
+	joiner := " OR "
+	if op == "!=" {
+		joiner = " AND "
+	}
+	parts := make([]string, len(states))
+	for i, s := range states {
+		parts[i] = fmt.Sprintf("state %s %q", op, s)
+	}
+	return "(" + strings.Join(parts, joiner) + ")"
+}
+
+// hasLabels reports whether every wanted label is present (case-insensitive)
+func hasLabels(have, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if strings.EqualFold(h, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package ghmigrate
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// checkpointer persists migration state after every imported issue so an
+// interrupted import resumes where it stopped
+type checkpointer struct {
+	mu     sync.Mutex
+	state  *MigrationState
+	dryRun bool
+}
+
+func newCheckpointer(state *MigrationState, dryRun bool) *checkpointer {
+	return &checkpointer{state: state, dryRun: dryRun}
+}
+
+// migrated reports whether the issue is already recorded
+func (c *checkpointer) migrated(provider models.RepositoryProvider, repo string, issueNumber int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return isAlreadyMigrated(c.state, provider, repo, issueNumber)
+}
+
+// record adds a migration record and writes the state file
+func (c *checkpointer) record(r MigrationRecord) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.state.Migrations = append(c.state.Migrations, r)
+	return c.saveLocked()
+}
+
+// flush writes the current state file
+func (c *checkpointer) flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.saveLocked()
+}
+
+func (c *checkpointer) saveLocked() error {
+	if c.dryRun {
+		return nil
+	}
+	c.state.UpdatedAt = time.Now().UTC()
+	return saveMigrationState(c.state)
+}
+
+// handleInterrupts flushes state and exits on SIGINT or SIGTERM. The
+// returned function stops listening.
+func (c *checkpointer) handleInterrupts() func() {
+	sigCh := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigCh:
+			fmt.Println()
+			if err := c.flush(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to save migration state: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("Interrupted - progress saved; re-run the import to resume.")
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
@@ -0,0 +1,55 @@
+package ghmigrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// issueFingerprint hashes an issue's title and body so mirrored copies of
+// it in other repos match. Whitespace runs are collapsed and case is
+// ignored, so re-wrapped or re-indented copies still match.
+func issueFingerprint(issue Issue) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.Join(strings.Fields(s), " "))
+	}
+	sum := sha256.Sum256([]byte(normalize(issue.Title) + "\x00" + normalize(issue.Body)))
+	return hex.EncodeToString(sum[:])
+}
+
+// deduper tracks the tickets imported in a run by issue fingerprint, for
+// --dedup
+type deduper struct {
+	seen map[string]*models.TicketFile
+}
+
+func newDeduper() *deduper {
+	return &deduper{seen: make(map[string]*models.TicketFile)}
+}
+
+// original returns the ticket already imported from an issue with the same
+// title and body, or nil
+func (d *deduper) original(issue Issue) *models.TicketFile {
+	return d.seen[issueFingerprint(issue)]
+}
+
+// add records ticket as imported from issue
+func (d *deduper) add(issue Issue, ticket *models.TicketFile) {
+	fp := issueFingerprint(issue)
+	if _, ok := d.seen[fp]; !ok {
+		d.seen[fp] = ticket
+	}
+}
+
+// linkDuplicate records a duplicate issue as an external reference of the
+// ticket imported from the original
+func linkDuplicate(ticket *models.TicketFile, provider models.RepositoryProvider, repo string, issue Issue) {
+	ticket.ExternalReferences = append(ticket.ExternalReferences, models.TicketFileRef{
+		System: string(provider),
+		ID:     fmt.Sprintf("%s#%d (duplicate)", repo, issue.Number),
+		URL:    issue.URL,
+	})
+}
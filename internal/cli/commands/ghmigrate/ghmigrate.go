@@ -1,6 +1,8 @@
 package ghmigrate
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 )
 
@@ -8,11 +10,12 @@ import (
 var GHMigrateCmd = &cobra.Command{
 	Use:     "gh-migrate",
 	Aliases: []string{"ghm", "github-migrate"},
-	Short:   "Migrate GitHub Issues to Changes ticketing system",
-	Long: `Migrate GitHub Issues to the After Dark Systems Changes ticketing system.
+	Short:   "Migrate GitHub, Bitbucket or Azure DevOps issues to Changes ticketing system",
+	Long: `Migrate issues to the After Dark Systems Changes ticketing system.
 
-This tool fetches issues from GitHub repositories and converts them into
-Changes tickets, preserving metadata, labels, and comments.
+This tool fetches issues from GitHub (default), Bitbucket or Azure DevOps
+and converts them into Changes tickets, preserving metadata, labels, and
+comments. Select the tracker with --source.
 
 Examples:
   # List issues from a repository
@@ -24,6 +27,16 @@ Examples:
   # Import issues from a repository
   gh-migrate --import --repos owner/repo
 
+  # Preview label mappings before importing
+  gh-migrate --preview --repos owner/repo
+
+  # Import with finance defaults (SOX/GLBA, IT, security and risk approvals);
+  # industry:, compliance: and approval: labels override them per issue
+  gh-migrate -i -r owner/repo --profile finance
+
+  # Import mirrored repos, linking repeated issues to one ticket
+  gh-migrate -i -r owner/repo,mirror/repo --dedup
+
   # Import only open issues
   gh-migrate -i -r owner/repo --status open
 
@@ -31,19 +44,28 @@ Examples:
   gh-migrate --status
 
   # Use custom GitHub Enterprise URL
-  gh-migrate -l -r owner/repo -g https://github.mycompany.com/api/v3`,
+  gh-migrate -l -r owner/repo -g https://github.mycompany.com/api/v3
+
+  # Import from Bitbucket (workspace/repo, app password via BITBUCKET_TOKEN)
+  gh-migrate -i --source bitbucket -u me -r myteam/backend
+
+  # Import Azure DevOps work items (organization/project, PAT via AZURE_DEVOPS_TOKEN)
+  gh-migrate -i --source azure_devops -r contoso/Platform`,
 }
 
 func init() {
 	// Global flags for gh-migrate
-	GHMigrateCmd.PersistentFlags().StringP("user", "u", "", "GitHub username (or set GITHUB_USER env var)")
-	GHMigrateCmd.PersistentFlags().StringP("api-key", "a", "", "GitHub personal access token (or set GITHUB_TOKEN env var)")
+	GHMigrateCmd.PersistentFlags().String("source", "github", "Issue source (github, bitbucket, azure_devops)")
+	GHMigrateCmd.PersistentFlags().StringP("user", "u", "", "Username (or set GITHUB_USER / BITBUCKET_USER env var)")
+	GHMigrateCmd.PersistentFlags().StringP("api-key", "a", "", "API token (or set GITHUB_TOKEN / BITBUCKET_TOKEN / AZURE_DEVOPS_TOKEN env var)")
 	GHMigrateCmd.PersistentFlags().StringP("gh-url", "g", "https://api.github.com", "GitHub API URL (for GitHub Enterprise)")
-	GHMigrateCmd.PersistentFlags().StringSliceP("repos", "r", []string{}, "Repository list (owner/repo format, comma-separated)")
+	GHMigrateCmd.PersistentFlags().String("source-url", "", "API base URL for the selected source (e.g. Azure DevOps Server)")
+	GHMigrateCmd.PersistentFlags().StringSliceP("repos", "r", []string{}, "Repository list (owner/repo, workspace/repo or organization/project; comma-separated)")
 
 	// Action flags
-	GHMigrateCmd.Flags().BoolP("list", "l", false, "List GitHub issues from specified repos")
-	GHMigrateCmd.Flags().BoolP("import", "i", false, "Import GitHub issues to Changes system")
+	GHMigrateCmd.Flags().BoolP("list", "l", false, "List issues from specified repos")
+	GHMigrateCmd.Flags().BoolP("import", "i", false, "Import issues to Changes system")
+	GHMigrateCmd.Flags().BoolP("preview", "p", false, "Preview how issue labels map to ticket priority/risk/type/compliance")
 	GHMigrateCmd.Flags().BoolP("status", "s", false, "Show migration status")
 
 	// Filter flags
@@ -54,9 +76,12 @@ func init() {
 	// Import options
 	GHMigrateCmd.Flags().Bool("dry-run", false, "Show what would be imported without importing")
 	GHMigrateCmd.Flags().Bool("include-comments", true, "Include issue comments in migration")
+	GHMigrateCmd.Flags().Int("concurrency", defaultConcurrency, "Maximum concurrent comment fetches during import")
 	GHMigrateCmd.Flags().Bool("include-closed", false, "Include closed issues in migration")
+	GHMigrateCmd.Flags().Bool("dedup", false, "Link issues with the same title and body as one already imported in this run instead of creating duplicate tickets")
 	GHMigrateCmd.Flags().String("default-priority", "normal", "Default priority for imported tickets")
-	GHMigrateCmd.Flags().String("default-industry", "", "Default industry for imported tickets")
+	GHMigrateCmd.Flags().String("default-industry", "", "Default industry for imported tickets (overrides the profile's)")
+	GHMigrateCmd.Flags().String("profile", "", "Defaults bundle for imported tickets: "+strings.Join(profileNames(), ", ")+" (industry, compliance frameworks and approval types)")
 
 	// Set command run function
 	GHMigrateCmd.Run = runGHMigrate
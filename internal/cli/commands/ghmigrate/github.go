@@ -6,8 +6,12 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
 )
 
 // GitHubIssue represents a GitHub issue
@@ -50,12 +54,24 @@ type GitHubComment struct {
 	UpdatedAt time.Time  `json:"updated_at"`
 }
 
-// GitHubClient handles GitHub API interactions
+// maxRateLimitRetries bounds how often a request is retried after hitting
+// the GitHub rate limit
+const maxRateLimitRetries = 3
+
+// maxRateLimitWait caps a single rate-limit pause
+const maxRateLimitWait = 15 * time.Minute
+
+// GitHubClient handles GitHub API interactions. It is safe for concurrent
+// use; when any request hits the rate limit, all requests pause until the
+// limit resets.
 type GitHubClient struct {
 	BaseURL    string
 	Token      string
 	Username   string
 	HTTPClient *http.Client
+
+	mu          sync.Mutex
+	pausedUntil time.Time
 }
 
 // NewGitHubClient creates a new GitHub API client
@@ -74,13 +90,33 @@ func NewGitHubClient(baseURL, token, username string) *GitHubClient {
 	}
 }
 
-// doRequest performs an authenticated HTTP request
+// Provider identifies the source
+func (c *GitHubClient) Provider() models.RepositoryProvider {
+	return models.RepositoryProviderGitHub
+}
+
+// doRequest performs an authenticated HTTP request, waiting out and
+// retrying rate-limit responses
 func (c *GitHubClient) doRequest(method, endpoint string) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		c.waitForRateLimit()
+
+		body, wait, err := c.doRequestOnce(method, endpoint)
+		if wait <= 0 || attempt >= maxRateLimitRetries {
+			return body, err
+		}
+		c.pauseFor(wait)
+	}
+}
+
+// doRequestOnce performs a single request. A positive wait means the
+// request was rate limited and may be retried after that long.
+func (c *GitHubClient) doRequestOnce(method, endpoint string) ([]byte, time.Duration, error) {
 	reqURL := c.BaseURL + endpoint
 
 	req, err := http.NewRequest(method, reqURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Accept", "application/vnd.github+json")
@@ -92,25 +128,80 @@ func (c *GitHubClient) doRequest(method, endpoint string) ([]byte, error) {
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, 0, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, 0, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		err := fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, rateLimitWait(resp), err
 	}
 
-	return body, nil
+	return body, 0, nil
+}
+
+// waitForRateLimit blocks while the client is paused for a rate limit
+func (c *GitHubClient) waitForRateLimit() {
+	c.mu.Lock()
+	wait := time.Until(c.pausedUntil)
+	c.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// pauseFor pauses all requests on the client for the given duration
+func (c *GitHubClient) pauseFor(wait time.Duration) {
+	if wait > maxRateLimitWait {
+		wait = maxRateLimitWait
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if until := time.Now().Add(wait); until.After(c.pausedUntil) {
+		c.pausedUntil = until
+	}
+}
+
+// rateLimitWait returns how long to wait before retrying a rate-limited
+// response, or zero if the response was not rate limited. GitHub signals
+// primary limits with X-RateLimit-Remaining: 0 and secondary limits with
+// Retry-After.
+func rateLimitWait(resp *http.Response) time.Duration {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+				return wait + time.Second
+			}
+			return time.Second
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return time.Minute
+	}
+	return 0
 }
 
 // ListIssues fetches issues from a repository
-func (c *GitHubClient) ListIssues(owner, repo string, state string, labels []string, limit int) ([]GitHubIssue, error) {
-	var allIssues []GitHubIssue
+func (c *GitHubClient) ListIssues(owner, repo string, state string, labels []string, limit int) ([]Issue, error) {
+	var allIssues []Issue
 	page := 1
 	perPage := 100
 	if limit < perPage {
@@ -146,7 +237,7 @@ func (c *GitHubClient) ListIssues(owner, repo string, state string, labels []str
 			// PRs have a pull_request field, but we're using a simplified struct
 			// Check if the URL contains /pull/ which would indicate a PR
 			if !strings.Contains(issue.HTMLURL, "/pull/") {
-				allIssues = append(allIssues, issue)
+				allIssues = append(allIssues, issue.normalize())
 			}
 		}
 
@@ -165,7 +256,7 @@ func (c *GitHubClient) ListIssues(owner, repo string, state string, labels []str
 }
 
 // GetIssueComments fetches comments for an issue
-func (c *GitHubClient) GetIssueComments(owner, repo string, issueNumber int) ([]GitHubComment, error) {
+func (c *GitHubClient) GetIssueComments(owner, repo string, issueNumber int) ([]IssueComment, error) {
 	endpoint := fmt.Sprintf("/repos/%s/%s/issues/%d/comments?per_page=100", owner, repo, issueNumber)
 
 	body, err := c.doRequest(http.MethodGet, endpoint)
@@ -178,7 +269,43 @@ func (c *GitHubClient) GetIssueComments(owner, repo string, issueNumber int) ([]
 		return nil, fmt.Errorf("failed to parse comments: %w", err)
 	}
 
-	return comments, nil
+	normalized := make([]IssueComment, len(comments))
+	for i, gc := range comments {
+		normalized[i] = IssueComment{
+			ID:        gc.ID,
+			Body:      gc.Body,
+			Author:    gc.User.Login,
+			CreatedAt: gc.CreatedAt,
+		}
+	}
+
+	return normalized, nil
+}
+
+// normalize converts a GitHub issue to the common form
+func (gi GitHubIssue) normalize() Issue {
+	labels := make([]string, len(gi.Labels))
+	for i, l := range gi.Labels {
+		labels[i] = l.Name
+	}
+
+	issue := Issue{
+		Number:       gi.Number,
+		Title:        gi.Title,
+		Body:         gi.Body,
+		State:        gi.State,
+		Labels:       labels,
+		Author:       gi.User.Login,
+		CreatedAt:    gi.CreatedAt,
+		UpdatedAt:    gi.UpdatedAt,
+		ClosedAt:     gi.ClosedAt,
+		URL:          gi.HTMLURL,
+		CommentCount: gi.Comments,
+	}
+	if gi.Assignee != nil {
+		issue.Assignee = gi.Assignee.Login
+	}
+	return issue
 }
 
 // ParseRepoString parses "owner/repo" format into owner and repo
@@ -0,0 +1,46 @@
+package ghmigrate
+
+import "sync"
+
+// defaultConcurrency is the default number of concurrent comment fetches
+const defaultConcurrency = 4
+
+// commentResult holds the prefetched comments for one issue
+type commentResult struct {
+	comments []IssueComment
+	err      error
+}
+
+// prefetchComments fetches comments for the selected issues using at most
+// concurrency workers. Results are returned by issue index so callers can
+// process issues in their original order. Issues for which want returns
+// false are skipped and have a zero result.
+func prefetchComments(client IssueSource, owner, repo string, issues []Issue, concurrency int, want func(Issue) bool) []commentResult {
+	results := make([]commentResult, len(issues))
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				comments, err := client.GetIssueComments(owner, repo, issues[i].Number)
+				results[i] = commentResult{comments: comments, err: err}
+			}
+		}()
+	}
+
+	for i, issue := range issues {
+		if want(issue) {
+			jobs <- i
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,109 @@
+package ghmigrate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// MigrationProfile is a bundle of defaults applied to every migrated
+// ticket, selected with --profile. Issue labels override it: an
+// "industry:<name>" label replaces the industry, compliance labels replace
+// the frameworks and "approval:<type>" labels replace the approval types.
+// Approvals the frameworks require are always added.
+type MigrationProfile struct {
+	Industry      models.IndustryType
+	Compliance    []models.ComplianceFramework
+	ApprovalTypes []models.ApprovalType
+}
+
+// migrationProfiles are the profiles --profile accepts
+var migrationProfiles = map[string]MigrationProfile{
+	"finance": {
+		Industry:      models.IndustryFinance,
+		Compliance:    []models.ComplianceFramework{models.ComplianceSOX, models.ComplianceGLBA},
+		ApprovalTypes: []models.ApprovalType{models.ApprovalTypeIT, models.ApprovalTypeSecurity, models.ApprovalTypeRisk},
+	},
+	"healthcare": {
+		Industry:      models.IndustryHealthcare,
+		Compliance:    []models.ComplianceFramework{models.ComplianceHIPAA},
+		ApprovalTypes: []models.ApprovalType{models.ApprovalTypeIT, models.ApprovalTypeSecurity},
+	},
+	"it": {
+		Industry:      models.IndustryIT,
+		ApprovalTypes: []models.ApprovalType{models.ApprovalTypeOperations, models.ApprovalTypeIT},
+	},
+}
+
+// profileNames returns the --profile values in sorted order
+func profileNames() []string {
+	names := make([]string, 0, len(migrationProfiles))
+	for name := range migrationProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupProfile returns the named profile; an empty name is the empty
+// profile
+func lookupProfile(name string) (MigrationProfile, error) {
+	if name == "" {
+		return MigrationProfile{}, nil
+	}
+	profile, ok := migrationProfiles[strings.ToLower(name)]
+	if !ok {
+		return MigrationProfile{}, fmt.Errorf("unknown profile %q (profiles: %s)", name, strings.Join(profileNames(), ", "))
+	}
+	return profile, nil
+}
+
+// ticketDefaults are the values a migrated ticket gets when its labels do
+// not say otherwise
+type ticketDefaults struct {
+	Priority string
+	MigrationProfile
+}
+
+// newTicketDefaults combines --default-priority, --profile and
+// --default-industry, which takes precedence over the profile's industry
+func newTicketDefaults(priority, profileName, industry string) (ticketDefaults, error) {
+	profile, err := lookupProfile(profileName)
+	if err != nil {
+		return ticketDefaults{}, err
+	}
+	if industry != "" {
+		profile.Industry = models.IndustryType(strings.ToLower(industry))
+	}
+	return ticketDefaults{Priority: priority, MigrationProfile: profile}, nil
+}
+
+// industryFromLabel maps an "industry:<name>" label to an industry, or
+// returns ""
+func industryFromLabel(label string) models.IndustryType {
+	name, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(label)), "industry:")
+	if !ok {
+		return ""
+	}
+	industry := models.IndustryType(strings.TrimSpace(name))
+	if !industry.Valid() {
+		return ""
+	}
+	return industry
+}
+
+// approvalFromLabel maps an "approval:<type>" label to an approval type,
+// or returns ""
+func approvalFromLabel(label string) models.ApprovalType {
+	name, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(label)), "approval:")
+	if !ok {
+		return ""
+	}
+	approval := models.ApprovalType(strings.NewReplacer("-", "_", " ", "_").Replace(strings.TrimSpace(name)))
+	if !approval.Valid() {
+		return ""
+	}
+	return approval
+}
@@ -9,6 +9,9 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/fsutil"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/ticketnum"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -19,8 +22,11 @@ type MigrationState struct {
 	UpdatedAt  time.Time         `json:"updated_at"`
 }
 
-// MigrationRecord tracks a single migrated issue
+// MigrationRecord tracks a single migrated issue. The GitHub-prefixed field
+// names predate other sources and are kept for state file compatibility;
+// an empty Source means github.
 type MigrationRecord struct {
+	Source        string    `json:"source,omitempty"`
 	GitHubRepo    string    `json:"github_repo"`
 	GitHubIssue   int       `json:"github_issue"`
 	GitHubURL     string    `json:"github_url"`
@@ -29,56 +35,16 @@ type MigrationRecord struct {
 	MigratedBy    string    `json:"migrated_by"`
 }
 
-// TicketData matches the Changes system ticket format
-type TicketData struct {
-	ID                   string           `json:"id"`
-	Title                string           `json:"title"`
-	Description          string           `json:"description"`
-	Status               string           `json:"status"`
-	Priority             string           `json:"priority"`
-	Risk                 string           `json:"risk"`
-	Type                 string           `json:"type"`
-	Industry             string           `json:"industry"`
-	ComplianceFrameworks []string         `json:"compliance_frameworks"`
-	AffectedSystems      []string         `json:"affected_systems"`
-	AcceptanceCriteria   []string         `json:"acceptance_criteria"`
-	TestingPlan          string           `json:"testing_plan"`
-	RollbackPlan         string           `json:"rollback_plan"`
-	CreatedBy            string           `json:"created_by"`
-	CreatedAt            string           `json:"created_at"`
-	UpdatedAt            string           `json:"updated_at"`
-	Sprint               string           `json:"sprint"`
-	Assignee             *string          `json:"assignee"`
-	ApprovalsRequired    []string         `json:"approvals_required"`
-	Approvals            []string         `json:"approvals"`
-	Dependencies         []string         `json:"dependencies"`
-	Comments             []TicketComment  `json:"comments"`
-	ExternalReferences   []ExternalRef    `json:"external_references"`
-}
-
-// TicketComment represents a comment on a ticket
-type TicketComment struct {
-	Author    string `json:"author"`
-	Timestamp string `json:"timestamp"`
-	Text      string `json:"text"`
-}
-
-// ExternalRef stores reference to the original GitHub issue
-type ExternalRef struct {
-	System string `json:"system"`
-	ID     string `json:"id"`
-	URL    string `json:"url"`
-}
-
 func runGHMigrate(cmd *cobra.Command, args []string) {
 	// Get action flags
 	listFlag, _ := cmd.Flags().GetBool("list")
 	importFlag, _ := cmd.Flags().GetBool("import")
 	statusFlag, _ := cmd.Flags().GetBool("status")
+	previewFlag, _ := cmd.Flags().GetBool("preview")
 
 	// Validate at least one action is specified
-	if !listFlag && !importFlag && !statusFlag {
-		fmt.Println("Error: must specify an action flag (-l/--list, -i/--import, or -s/--status)")
+	if !listFlag && !importFlag && !statusFlag && !previewFlag {
+		fmt.Println("Error: must specify an action flag (-l/--list, -i/--import, -p/--preview, or -s/--status)")
 		fmt.Println()
 		cmd.Help()
 		os.Exit(1)
@@ -93,26 +59,82 @@ func runGHMigrate(cmd *cobra.Command, args []string) {
 	// Get repos
 	repos, _ := cmd.Flags().GetStringSlice("repos")
 	if len(repos) == 0 {
-		fmt.Println("Error: --repos/-r is required for list and import operations")
+		fmt.Println("Error: --repos/-r is required for list, preview and import operations")
 		fmt.Println("Example: gh-migrate -l -r owner/repo")
 		os.Exit(1)
 	}
 
-	// Get GitHub credentials
-	token := getGitHubToken(cmd)
-	username := getGitHubUsername(cmd)
-	ghURL, _ := cmd.Flags().GetString("gh-url")
+	sourceName, _ := cmd.Flags().GetString("source")
+	provider := models.RepositoryProvider(sourceName)
 
-	// Create GitHub client
-	client := NewGitHubClient(ghURL, token, username)
+	// Create the issue source client
+	client, err := newIssueSource(provider, getSourceURL(cmd, provider), getSourceToken(cmd, provider), getSourceUsername(cmd, provider))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	if listFlag {
 		runList(cmd, client, repos)
+	} else if previewFlag {
+		runPreview(cmd, client, repos)
 	} else if importFlag {
 		runImport(cmd, client, repos)
 	}
 }
 
+// getSourceURL returns the API base URL for the source; an empty value
+// selects the client's default
+func getSourceURL(cmd *cobra.Command, provider models.RepositoryProvider) string {
+	if sourceURL, _ := cmd.Flags().GetString("source-url"); sourceURL != "" {
+		return sourceURL
+	}
+	if provider == models.RepositoryProviderGitHub {
+		ghURL, _ := cmd.Flags().GetString("gh-url")
+		return ghURL
+	}
+	return viper.GetString(string(provider) + ".url")
+}
+
+// getSourceToken resolves the API token for the source from flags, config
+// or the environment
+func getSourceToken(cmd *cobra.Command, provider models.RepositoryProvider) string {
+	switch provider {
+	case models.RepositoryProviderBitbucket:
+		return firstNonEmpty(flagString(cmd, "api-key"), viper.GetString("bitbucket.token"),
+			os.Getenv("BITBUCKET_TOKEN"), os.Getenv("BITBUCKET_APP_PASSWORD"))
+	case models.RepositoryProviderAzureDevOps:
+		return firstNonEmpty(flagString(cmd, "api-key"), viper.GetString("azure_devops.token"),
+			os.Getenv("AZURE_DEVOPS_TOKEN"), os.Getenv("AZURE_DEVOPS_EXT_PAT"))
+	}
+	return getGitHubToken(cmd)
+}
+
+// getSourceUsername resolves the username for the source
+func getSourceUsername(cmd *cobra.Command, provider models.RepositoryProvider) string {
+	switch provider {
+	case models.RepositoryProviderBitbucket:
+		return firstNonEmpty(flagString(cmd, "user"), viper.GetString("bitbucket.user"), os.Getenv("BITBUCKET_USER"))
+	case models.RepositoryProviderAzureDevOps:
+		return ""
+	}
+	return getGitHubUsername(cmd)
+}
+
+func flagString(cmd *cobra.Command, name string) string {
+	value, _ := cmd.Flags().GetString(name)
+	return value
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 func getGitHubToken(cmd *cobra.Command) string {
 	token, _ := cmd.Flags().GetString("api-key")
 	if token == "" {
@@ -138,7 +160,7 @@ func getGitHubUsername(cmd *cobra.Command) string {
 	return user
 }
 
-func runList(cmd *cobra.Command, client *GitHubClient, repos []string) {
+func runList(cmd *cobra.Command, client IssueSource, repos []string) {
 	state, _ := cmd.Flags().GetString("issue-status")
 	labels, _ := cmd.Flags().GetStringSlice("labels")
 	limit, _ := cmd.Flags().GetInt("limit")
@@ -167,11 +189,7 @@ func runList(cmd *cobra.Command, client *GitHubClient, repos []string) {
 				title = title[:47] + "..."
 			}
 
-			labelNames := make([]string, len(issue.Labels))
-			for i, l := range issue.Labels {
-				labelNames[i] = l.Name
-			}
-			labelsStr := strings.Join(labelNames, ",")
+			labelsStr := strings.Join(issue.Labels, ",")
 			if len(labelsStr) > 30 {
 				labelsStr = labelsStr[:27] + "..."
 			}
@@ -192,30 +210,139 @@ func runList(cmd *cobra.Command, client *GitHubClient, repos []string) {
 	fmt.Printf("\n%d issue(s) found.\n", totalIssues)
 }
 
-func runImport(cmd *cobra.Command, client *GitHubClient, repos []string) {
+// runPreview shows how each issue's labels would map onto ticket fields,
+// without writing tickets or migration state
+func runPreview(cmd *cobra.Command, client IssueSource, repos []string) {
+	state, _ := cmd.Flags().GetString("issue-status")
+	labels, _ := cmd.Flags().GetStringSlice("labels")
+	limit, _ := cmd.Flags().GetInt("limit")
+	includeClosed, _ := cmd.Flags().GetBool("include-closed")
+	defaults, err := flagTicketDefaults(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Mirror the import's state handling so the preview matches it
+	if !includeClosed && state == "all" {
+		state = "open"
+	}
+
+	migrationState := loadMigrationState()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tISSUE\tLABELS\tSTATUS\tPRIORITY\tRISK\tTYPE\tINDUSTRY\tCOMPLIANCE\tNOTE")
+	fmt.Fprintln(w, "----\t-----\t------\t------\t--------\t----\t----\t--------\t----------\t----")
+
+	byPriority := make(map[string]int)
+	var total, alreadyMigrated int
+	for _, repoStr := range repos {
+		owner, repo, err := ParseRepoString(repoStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
+		}
+
+		issues, err := client.ListIssues(owner, repo, state, labels, limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fetching issues from %s: %v\n", repoStr, err)
+			continue
+		}
+
+		for _, issue := range issues {
+			ticket := mapIssueToTicket("", client.Provider(), issue, nil, repoStr, defaults)
+
+			note := ""
+			if isAlreadyMigrated(migrationState, client.Provider(), repoStr, issue.Number) {
+				note = "already migrated"
+				alreadyMigrated++
+			}
+			if len(ticket.ComplianceWarnings()) > 0 {
+				note = strings.TrimPrefix(note+", compliance mismatch", ", ")
+			}
+
+			compliance := strings.Join(ticket.ComplianceFrameworks, ",")
+			if compliance == "" {
+				compliance = "-"
+			}
+			labelsStr := strings.Join(issue.Labels, ",")
+			if labelsStr == "" {
+				labelsStr = "-"
+			}
+
+			industry := ticket.Industry
+			if industry == "" {
+				industry = "-"
+			}
+
+			fmt.Fprintf(w, "%s\t#%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				repoStr,
+				issue.Number,
+				truncate(labelsStr, 40),
+				ticket.Status,
+				ticket.Priority,
+				ticket.Risk,
+				ticket.Type,
+				industry,
+				compliance,
+				note,
+			)
+			byPriority[ticket.Priority]++
+			total++
+		}
+	}
+	w.Flush()
+
+	fmt.Println()
+	fmt.Printf("%d issue(s) previewed, %d already migrated.\n", total, alreadyMigrated)
+	for _, priority := range []string{"emergency", "urgent", "high", "normal", "low"} {
+		if n := byPriority[priority]; n > 0 {
+			fmt.Printf("  %-9s %d\n", priority, n)
+		}
+	}
+	fmt.Println("No tickets or migration state were written.")
+}
+
+func runImport(cmd *cobra.Command, client IssueSource, repos []string) {
 	state, _ := cmd.Flags().GetString("issue-status")
 	labels, _ := cmd.Flags().GetStringSlice("labels")
 	limit, _ := cmd.Flags().GetInt("limit")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	includeComments, _ := cmd.Flags().GetBool("include-comments")
 	includeClosed, _ := cmd.Flags().GetBool("include-closed")
-	defaultPriority, _ := cmd.Flags().GetString("default-priority")
-	defaultIndustry, _ := cmd.Flags().GetString("default-industry")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	dedup, _ := cmd.Flags().GetBool("dedup")
+
+	defaults, err := flagTicketDefaults(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	// If not including closed, force state to open
 	if !includeClosed && state == "all" {
 		state = "open"
 	}
 
-	// Load migration state
-	migrationState := loadMigrationState()
+	// Load migration state. It is checkpointed after every imported issue,
+	// so an interrupted run resumes by skipping recorded issues.
+	checkpoint := newCheckpointer(loadMigrationState(), dryRun)
+	stopInterrupts := checkpoint.handleInterrupts()
+	defer stopInterrupts()
 
 	if dryRun {
 		fmt.Println("DRY RUN - no changes will be made")
 		fmt.Println()
 	}
 
-	var imported, skipped, failed int
+	var imported, skipped, duplicates, failed int
+
+	// With --dedup, issues whose title and body match one already imported
+	// in this run are linked to its ticket instead of becoming new tickets
+	var dupes *deduper
+	if dedup {
+		dupes = newDeduper()
+	}
 
 	for _, repoStr := range repos {
 		owner, repo, err := ParseRepoString(repoStr)
@@ -233,35 +360,81 @@ func runImport(cmd *cobra.Command, client *GitHubClient, repos []string) {
 			continue
 		}
 
-		for _, issue := range issues {
+		// Fetch comments for the whole batch up front so conversion is not
+		// blocked on one round-trip per issue. A negative count means the
+		// source does not report one.
+		var comments []commentResult
+		if includeComments {
+			comments = prefetchComments(client, owner, repo, issues, concurrency, func(issue Issue) bool {
+				return issue.CommentCount != 0 && !checkpoint.migrated(client.Provider(), repoStr, issue.Number)
+			})
+		}
+
+		for i, issue := range issues {
 			fmt.Printf("  Issue #%d: %s... ", issue.Number, truncate(issue.Title, 40))
 
 			// Check if already migrated
-			if isAlreadyMigrated(migrationState, repoStr, issue.Number) {
+			if checkpoint.migrated(client.Provider(), repoStr, issue.Number) {
 				fmt.Println("SKIPPED (already migrated)")
 				skipped++
 				continue
 			}
 
-			// Get comments if requested
-			var comments []GitHubComment
-			if includeComments && issue.Comments > 0 {
-				comments, err = client.GetIssueComments(owner, repo, issue.Number)
-				if err != nil {
-					fmt.Printf("Warning: failed to fetch comments: %v\n", err)
+			if dupes != nil {
+				if original := dupes.original(issue); original != nil {
+					if dryRun {
+						fmt.Printf("would link as duplicate of %s\n", original.ID)
+						duplicates++
+						continue
+					}
+					linkDuplicate(original, client.Provider(), repoStr, issue)
+					original.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+					if err := saveTicket(original); err != nil {
+						fmt.Printf("FAILED (save error: %v)\n", err)
+						failed++
+						continue
+					}
+					record := MigrationRecord{
+						Source:        string(client.Provider()),
+						GitHubRepo:    repoStr,
+						GitHubIssue:   issue.Number,
+						GitHubURL:     issue.URL,
+						ChangesTicket: original.ID,
+						MigratedAt:    time.Now().UTC(),
+						MigratedBy:    getCurrentUser(),
+					}
+					if err := checkpoint.record(record); err != nil {
+						fmt.Fprintf(os.Stderr, "\nWarning: failed to save migration state: %v\n", err)
+					}
+					fmt.Printf("DUPLICATE of %s\n", original.ID)
+					duplicates++
+					continue
 				}
 			}
 
+			var issueComments []IssueComment
+			if comments != nil {
+				if comments[i].err != nil {
+					fmt.Printf("Warning: failed to fetch comments: %v\n", comments[i].err)
+				}
+				issueComments = comments[i].comments
+			}
+
 			// Convert to ticket
-			ticket, err := convertIssueToTicket(issue, comments, repoStr, defaultPriority, defaultIndustry)
+			ticket, err := convertIssueToTicket(client.Provider(), issue, issueComments, repoStr, defaults)
 			if err != nil {
 				fmt.Printf("FAILED (conversion error: %v)\n", err)
 				failed++
 				continue
 			}
 
+			if dupes != nil {
+				dupes.add(issue, ticket)
+			}
+
 			if dryRun {
 				fmt.Printf("would import as %s\n", ticket.ID)
+				printComplianceWarnings(ticket)
 				imported++
 				continue
 			}
@@ -275,27 +448,29 @@ func runImport(cmd *cobra.Command, client *GitHubClient, repos []string) {
 
 			// Record migration
 			record := MigrationRecord{
+				Source:        string(client.Provider()),
 				GitHubRepo:    repoStr,
 				GitHubIssue:   issue.Number,
-				GitHubURL:     issue.HTMLURL,
+				GitHubURL:     issue.URL,
 				ChangesTicket: ticket.ID,
 				MigratedAt:    time.Now().UTC(),
 				MigratedBy:    getCurrentUser(),
 			}
-			migrationState.Migrations = append(migrationState.Migrations, record)
+			if err := checkpoint.record(record); err != nil {
+				fmt.Fprintf(os.Stderr, "\nWarning: failed to save migration state: %v\n", err)
+			}
 
 			fmt.Printf("IMPORTED as %s\n", ticket.ID)
+			printComplianceWarnings(ticket)
 			imported++
 		}
 	}
 
-	// Save migration state
-	if !dryRun {
-		migrationState.UpdatedAt = time.Now().UTC()
-		saveMigrationState(migrationState)
-	}
-
 	fmt.Println()
+	if dedup {
+		fmt.Printf("Import complete: %d imported, %d duplicates linked, %d skipped, %d failed\n", imported, duplicates, skipped, failed)
+		return
+	}
 	fmt.Printf("Import complete: %d imported, %d skipped, %d failed\n", imported, skipped, failed)
 }
 
@@ -310,20 +485,22 @@ func runStatus(cmd *cobra.Command) {
 	fmt.Printf("Migration Status (last updated: %s)\n", state.UpdatedAt.Format(time.RFC3339))
 	fmt.Println()
 
-	// Group by repo
+	// Group by source and repo
 	byRepo := make(map[string][]MigrationRecord)
 	for _, m := range state.Migrations {
-		byRepo[m.GitHubRepo] = append(byRepo[m.GitHubRepo], m)
+		key := recordSource(m) + ":" + m.GitHubRepo
+		byRepo[key] = append(byRepo[key], m)
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "REPO\tGH ISSUE\tCHANGES TICKET\tMIGRATED AT")
-	fmt.Fprintln(w, "----\t--------\t--------------\t-----------")
+	fmt.Fprintln(w, "SOURCE\tREPO\tISSUE\tCHANGES TICKET\tMIGRATED AT")
+	fmt.Fprintln(w, "------\t----\t-----\t--------------\t-----------")
 
-	for repo, records := range byRepo {
+	for _, records := range byRepo {
 		for _, r := range records {
-			fmt.Fprintf(w, "%s\t#%d\t%s\t%s\n",
-				repo,
+			fmt.Fprintf(w, "%s\t%s\t#%d\t%s\t%s\n",
+				recordSource(r),
+				r.GitHubRepo,
 				r.GitHubIssue,
 				r.ChangesTicket,
 				r.MigratedAt.Format("2006-01-02 15:04"),
@@ -335,18 +512,24 @@ func runStatus(cmd *cobra.Command) {
 	fmt.Printf("\nTotal: %d issues migrated from %d repositories\n", len(state.Migrations), len(byRepo))
 }
 
-func convertIssueToTicket(issue GitHubIssue, comments []GitHubComment, repo, defaultPriority, defaultIndustry string) (*TicketData, error) {
+func convertIssueToTicket(provider models.RepositoryProvider, issue Issue, comments []IssueComment, repo string, defaults ticketDefaults) (*models.TicketFile, error) {
 	ticketID, err := getNextTicketNumber()
 	if err != nil {
 		return nil, err
 	}
 
+	return mapIssueToTicket(ticketID, provider, issue, comments, repo, defaults), nil
+}
+
+// mapIssueToTicket derives a ticket from an issue without touching the
+// tickets directory. Labels take precedence over defaults.
+func mapIssueToTicket(ticketID string, provider models.RepositoryProvider, issue Issue, comments []IssueComment, repo string, defaults ticketDefaults) *models.TicketFile {
 	now := time.Now().UTC()
 
 	// Determine priority from labels
-	priority := defaultPriority
+	priority := defaults.Priority
 	for _, label := range issue.Labels {
-		name := strings.ToLower(label.Name)
+		name := strings.ToLower(label)
 		switch {
 		case strings.Contains(name, "critical") || strings.Contains(name, "emergency"):
 			priority = "emergency"
@@ -362,7 +545,7 @@ func convertIssueToTicket(issue GitHubIssue, comments []GitHubComment, repo, def
 	// Determine risk from labels
 	risk := "medium"
 	for _, label := range issue.Labels {
-		name := strings.ToLower(label.Name)
+		name := strings.ToLower(label)
 		if strings.Contains(name, "risk:high") || strings.Contains(name, "security") {
 			risk = "high"
 		} else if strings.Contains(name, "risk:low") || strings.Contains(name, "minor") {
@@ -373,7 +556,7 @@ func convertIssueToTicket(issue GitHubIssue, comments []GitHubComment, repo, def
 	// Determine ticket type from labels
 	ticketType := "standard"
 	for _, label := range issue.Labels {
-		name := strings.ToLower(label.Name)
+		name := strings.ToLower(label)
 		switch {
 		case strings.Contains(name, "bug") || strings.Contains(name, "fix"):
 			ticketType = "bug_fix"
@@ -386,91 +569,179 @@ func convertIssueToTicket(issue GitHubIssue, comments []GitHubComment, repo, def
 		}
 	}
 
+	// Determine compliance frameworks from labels such as "sox" or
+	// "compliance:hipaa", falling back to the profile's
+	frameworks := []string{}
+	for _, label := range issue.Labels {
+		framework := complianceFromLabel(label)
+		if framework != "" && !containsString(frameworks, framework) {
+			frameworks = append(frameworks, framework)
+		}
+	}
+	if len(frameworks) == 0 {
+		for _, f := range defaults.Compliance {
+			frameworks = append(frameworks, string(f))
+		}
+	}
+
+	// Industry from an "industry:<name>" label, else the default
+	industry := string(defaults.Industry)
+	for _, label := range issue.Labels {
+		if i := industryFromLabel(label); i != "" {
+			industry = string(i)
+		}
+	}
+
+	// Approval types from "approval:<type>" labels, else the profile's,
+	// plus any the compliance frameworks require
+	var approvals []models.ApprovalType
+	for _, label := range issue.Labels {
+		if a := approvalFromLabel(label); a != "" && !containsApproval(approvals, a) {
+			approvals = append(approvals, a)
+		}
+	}
+	if len(approvals) == 0 {
+		approvals = append(approvals, defaults.ApprovalTypes...)
+	}
+	for _, a := range models.RequiredApprovalsFor(frameworkTypes(frameworks)) {
+		if !containsApproval(approvals, a) {
+			approvals = append(approvals, a)
+		}
+	}
+	approvalsRequired := make([]string, len(approvals))
+	for i, a := range approvals {
+		approvalsRequired[i] = string(a)
+	}
+
 	// Map status
 	status := "draft"
 	if issue.State == "closed" {
 		status = "closed"
 	}
 
-	// Extract label names for affected systems
-	labelNames := make([]string, len(issue.Labels))
-	for i, l := range issue.Labels {
-		labelNames[i] = l.Name
-	}
+	// Labels become affected systems
+	labelNames := append([]string{}, issue.Labels...)
+	info := sources[provider]
 
-	// Build description with GitHub reference
+	// Build description with source reference
 	description := issue.Body
 	if description == "" {
 		description = "(No description provided)"
 	}
-	description = fmt.Sprintf("%s\n\n---\n_Migrated from GitHub: %s_", description, issue.HTMLURL)
+	description = fmt.Sprintf("%s\n\n---\n_Migrated from %s: %s_", description, info.DisplayName, issue.URL)
 
 	// Convert comments
-	var ticketComments []TicketComment
+	var ticketComments []models.TicketFileComment
 	// Add original issue as first comment
-	ticketComments = append(ticketComments, TicketComment{
-		Author:    fmt.Sprintf("%s@github.com", issue.User.Login),
-		Timestamp: issue.CreatedAt.Format(time.RFC3339),
-		Text:      fmt.Sprintf("Original issue created by @%s on GitHub", issue.User.Login),
+	ticketComments = append(ticketComments, models.TicketFileComment{
+		Author:    sourceEmail(provider, issue.Author),
+		Timestamp: issue.CreatedAt.Truncate(time.Second),
+		Text:      fmt.Sprintf("Original issue created by @%s on %s", issue.Author, info.DisplayName),
 	})
 
 	for _, c := range comments {
-		ticketComments = append(ticketComments, TicketComment{
-			Author:    fmt.Sprintf("%s@github.com", c.User.Login),
-			Timestamp: c.CreatedAt.Format(time.RFC3339),
+		ticketComments = append(ticketComments, models.TicketFileComment{
+			Author:    sourceEmail(provider, c.Author),
+			Timestamp: c.CreatedAt.Truncate(time.Second),
 			Text:      c.Body,
 		})
 	}
 
-	// Calculate sprint
-	_, week := now.ISOWeek()
-	quarter := (now.Month()-1)/3 + 1
-	sprint := fmt.Sprintf("%d-Q%d-Sprint-%d", now.Year(), quarter, (week-1)%2+1)
-
 	// Set assignee if present
 	var assignee *string
-	if issue.Assignee != nil {
-		assigneeEmail := fmt.Sprintf("%s@github.com", issue.Assignee.Login)
+	if issue.Assignee != "" {
+		assigneeEmail := sourceEmail(provider, issue.Assignee)
 		assignee = &assigneeEmail
 	}
 
-	ticket := &TicketData{
-		ID:                   ticketID,
-		Title:                fmt.Sprintf("[GH#%d] %s", issue.Number, issue.Title),
-		Description:          description,
-		Status:               status,
-		Priority:             priority,
-		Risk:                 risk,
-		Type:                 ticketType,
-		Industry:             defaultIndustry,
-		ComplianceFrameworks: []string{},
-		AffectedSystems:      labelNames,
-		AcceptanceCriteria:   []string{},
-		TestingPlan:          "",
-		RollbackPlan:         "",
-		CreatedBy:            fmt.Sprintf("%s@github.com", issue.User.Login),
-		CreatedAt:            issue.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:            now.Format(time.RFC3339),
-		Sprint:               sprint,
-		Assignee:             assignee,
-		ApprovalsRequired:    []string{},
-		Approvals:            []string{},
-		Dependencies:         []string{},
-		Comments:             ticketComments,
-		ExternalReferences: []ExternalRef{
-			{
-				System: "github",
-				ID:     fmt.Sprintf("%s#%d", repo, issue.Number),
-				URL:    issue.HTMLURL,
-			},
+	ticket := models.NewTicketFile(ticketID, issue.CreatedAt)
+	ticket.Title = fmt.Sprintf("[%s#%d] %s", info.TitlePrefix, issue.Number, issue.Title)
+	ticket.Description = description
+	ticket.Status = status
+	ticket.Priority = priority
+	ticket.Risk = risk
+	ticket.Type = ticketType
+	ticket.Industry = industry
+	ticket.ComplianceFrameworks = frameworks
+	ticket.ApprovalsRequired = approvalsRequired
+	ticket.AffectedSystems = labelNames
+	ticket.CreatedBy = sourceEmail(provider, issue.Author)
+	ticket.UpdatedAt = now.Truncate(time.Second)
+	ticket.Sprint = models.SprintAt(now)
+	ticket.Assignee = assignee
+	ticket.Comments = ticketComments
+	ticket.ExternalReferences = []models.TicketFileRef{
+		{
+			System: string(provider),
+			ID:     fmt.Sprintf("%s#%d", repo, issue.Number),
+			URL:    issue.URL,
 		},
 	}
 
-	return ticket, nil
+	return ticket
+}
+
+// printComplianceWarnings reports frameworks that do not fit the imported
+// ticket's industry, under the issue's progress line
+func printComplianceWarnings(ticket *models.TicketFile) {
+	for _, warning := range ticket.ComplianceWarnings() {
+		fmt.Printf("    Warning: %s\n", warning.Message)
+	}
+}
+
+// complianceFromLabel maps a label to a compliance framework, or returns ""
+func complianceFromLabel(label string) string {
+	name := strings.ToLower(strings.TrimSpace(label))
+	name = strings.TrimPrefix(name, "compliance:")
+	name = strings.NewReplacer("-", "_", " ", "_").Replace(name)
+
+	if name == "bsa" {
+		name = string(models.ComplianceBankingSecrecyAct)
+	}
+	framework := models.ComplianceFramework(name)
+	if framework.Valid() && framework != models.ComplianceCustom {
+		return name
+	}
+	return ""
 }
 
 // Helper functions
 
+// flagTicketDefaults reads --default-priority, --profile and
+// --default-industry
+func flagTicketDefaults(cmd *cobra.Command) (ticketDefaults, error) {
+	priority, _ := cmd.Flags().GetString("default-priority")
+	profile, _ := cmd.Flags().GetString("profile")
+	industry, _ := cmd.Flags().GetString("default-industry")
+	return newTicketDefaults(priority, profile, industry)
+}
+
+func frameworkTypes(names []string) []models.ComplianceFramework {
+	frameworks := make([]models.ComplianceFramework, len(names))
+	for i, name := range names {
+		frameworks[i] = models.ComplianceFramework(name)
+	}
+	return frameworks
+}
+
+func containsApproval(values []models.ApprovalType, value models.ApprovalType) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func getTicketsDir() string {
 	if _, err := os.Stat("tickets"); err == nil {
 		return "tickets"
@@ -503,55 +774,41 @@ func saveMigrationState(state *MigrationState) error {
 		return err
 	}
 
-	return os.WriteFile(getMigrationStateFile(), data, 0600)
+	if err := os.MkdirAll(getTicketsDir(), 0755); err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(getMigrationStateFile(), data, 0600)
 }
 
-func isAlreadyMigrated(state *MigrationState, repo string, issueNumber int) bool {
+func isAlreadyMigrated(state *MigrationState, provider models.RepositoryProvider, repo string, issueNumber int) bool {
 	for _, m := range state.Migrations {
-		if m.GitHubRepo == repo && m.GitHubIssue == issueNumber {
+		if recordSource(m) == string(provider) && m.GitHubRepo == repo && m.GitHubIssue == issueNumber {
 			return true
 		}
 	}
 	return false
 }
 
-func getNextTicketNumber() (string, error) {
-	ticketsDir := getTicketsDir()
-	year := time.Now().Year()
-
-	if err := os.MkdirAll(ticketsDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create tickets directory: %w", err)
+// recordSource returns the source of a migration record, defaulting to
+// github for records written before other sources existed
+func recordSource(m MigrationRecord) string {
+	if m.Source == "" {
+		return string(models.RepositoryProviderGitHub)
 	}
+	return m.Source
+}
 
-	// Scan for existing tickets
-	entries, err := os.ReadDir(ticketsDir)
+// getNextTicketNumber returns the next ticket number in the configured
+// format (see ticketnum.Load)
+func getNextTicketNumber() (string, error) {
+	format, err := ticketnum.Load()
 	if err != nil {
 		return "", err
 	}
-
-	var maxNum int
-	prefix := fmt.Sprintf("CHG-%d-", year)
-	for _, entry := range entries {
-		name := entry.Name()
-		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".json") {
-			continue
-		}
-		numStr := strings.TrimPrefix(name, prefix)
-		numStr = strings.TrimSuffix(numStr, ".json")
-		var num int
-		fmt.Sscanf(numStr, "%d", &num)
-		if num > maxNum {
-			maxNum = num
-		}
-	}
-
-	nextNum := maxNum + 1
-	ticketID := fmt.Sprintf("CHG-%d-%05d", year, nextNum)
-
-	return ticketID, nil
+	return format.NextTicketNumber(getTicketsDir(), format.PrefixAt(time.Now()), 0)
 }
 
-func saveTicket(ticket *TicketData) error {
+func saveTicket(ticket *models.TicketFile) error {
 	ticketsDir := getTicketsDir()
 	if err := os.MkdirAll(ticketsDir, 0755); err != nil {
 		return err
@@ -563,7 +820,7 @@ func saveTicket(ticket *TicketData) error {
 		return err
 	}
 
-	return os.WriteFile(filename, data, 0600)
+	return fsutil.WriteFileAtomic(filename, data, 0600)
 }
 
 func getCurrentUser() string {
@@ -0,0 +1,79 @@
+package ghmigrate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// Issue is the source-neutral form of an issue fetched from any tracker
+type Issue struct {
+	Number       int
+	Title        string
+	Body         string
+	State        string // "open" or "closed"
+	Labels       []string
+	Author       string
+	Assignee     string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	ClosedAt     *time.Time
+	URL          string
+	CommentCount int
+}
+
+// IssueComment is the source-neutral form of an issue comment
+type IssueComment struct {
+	ID        int64
+	Body      string
+	Author    string
+	CreatedAt time.Time
+}
+
+// IssueSource fetches issues and comments from a hosted tracker. The repo
+// is given as owner/repo (GitHub), workspace/repo (Bitbucket) or
+// organization/project (Azure DevOps).
+type IssueSource interface {
+	Provider() models.RepositoryProvider
+	ListIssues(owner, repo string, state string, labels []string, limit int) ([]Issue, error)
+	GetIssueComments(owner, repo string, issueNumber int) ([]IssueComment, error)
+}
+
+// sourceInfo describes how a tracker's issues appear once migrated
+type sourceInfo struct {
+	DisplayName string
+	TitlePrefix string
+	EmailDomain string
+}
+
+var sources = map[models.RepositoryProvider]sourceInfo{
+	models.RepositoryProviderGitHub:      {DisplayName: "GitHub", TitlePrefix: "GH", EmailDomain: "github.com"},
+	models.RepositoryProviderBitbucket:   {DisplayName: "Bitbucket", TitlePrefix: "BB", EmailDomain: "bitbucket.org"},
+	models.RepositoryProviderAzureDevOps: {DisplayName: "Azure DevOps", TitlePrefix: "ADO", EmailDomain: "dev.azure.com"},
+}
+
+// newIssueSource creates the client for the named source
+func newIssueSource(provider models.RepositoryProvider, baseURL, token, username string) (IssueSource, error) {
+	switch provider {
+	case models.RepositoryProviderGitHub:
+		return NewGitHubClient(baseURL, token, username), nil
+	case models.RepositoryProviderBitbucket:
+		return NewBitbucketClient(baseURL, token, username), nil
+	case models.RepositoryProviderAzureDevOps:
+		return NewAzureDevOpsClient(baseURL, token), nil
+	case models.RepositoryProviderGitLab:
+		return nil, fmt.Errorf("source %q is not supported yet", provider)
+	}
+	return nil, fmt.Errorf("unknown source %q (expected github, bitbucket or azure_devops)", provider)
+}
+
+// sourceEmail turns a tracker login into the identity recorded on tickets.
+// Logins that already look like email addresses are kept as-is.
+func sourceEmail(provider models.RepositoryProvider, login string) string {
+	if login == "" || strings.Contains(login, "@") {
+		return login
+	}
+	return fmt.Sprintf("%s@%s", login, sources[provider].EmailDomain)
+}
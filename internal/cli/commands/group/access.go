@@ -0,0 +1,197 @@
+package group
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/afterdarksys/adsops-utils/internal/cli/commands/entitlement"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var entitlementsCmd = &cobra.Command{
+	Use:   "entitlements [group-name]",
+	Short: "List the entitlements conferred by group membership",
+	Long: `List the entitlements and features a user gains by joining an ACL group.
+
+Entitlements are read from the entitlements API, so you must be logged in
+with 'changes entitlement login' (or set ENTITLEMENTS_API_KEY).
+
+Examples:
+  # What does billing-access unlock?
+  changes group entitlements billing-access
+
+  # Only on one domain
+  changes group entitlements billing-access --domain getthis.money`,
+	Args: cobra.ExactArgs(1),
+	Run:  runEntitlements,
+}
+
+var effectiveAccessCmd = &cobra.Command{
+	Use:   "effective-access [email]",
+	Short: "Show a user's combined access across all their groups",
+	Long: `Show the union of the entitlements conferred by every group a user
+belongs to, with the groups each entitlement comes from.
+
+Group membership is read from the changes API and entitlements from the
+entitlements API, so both must be reachable.
+
+Examples:
+  changes group effective-access jane@afterdarksys.com
+  changes group effective-access jane@afterdarksys.com --domain getthis.money`,
+	Args: cobra.ExactArgs(1),
+	Run:  runEffectiveAccess,
+}
+
+func init() {
+	entitlementsCmd.Flags().String("domain", "", "Only show entitlements on this domain")
+
+	effectiveAccessCmd.Flags().String("domain", "", "Only show entitlements on this domain")
+	effectiveAccessCmd.Flags().Bool("acl-only", true, "Only consider ACL groups")
+	effectiveAccessCmd.Flags().String("api-url", "", "API URL (default: from config or https://api.changes.afterdarksys.com)")
+	effectiveAccessCmd.Flags().String("token", "", "API authentication token (or set CHANGES_API_TOKEN env var)")
+}
+
+func runEntitlements(cmd *cobra.Command, args []string) {
+	groupName := args[0]
+	domain, _ := cmd.Flags().GetString("domain")
+
+	list, err := entitlement.GroupEntitlements(groupName, domain)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PRODUCT\tDOMAIN\tTIER\tFEATURES\tLIMITS")
+	fmt.Fprintln(w, "-------\t------\t----\t--------\t------")
+	for _, e := range list {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			e.ProductName, e.Domain, e.Tier, joinOrDash(e.Features), formatLimits(e.Limits))
+	}
+	w.Flush()
+
+	fmt.Printf("\nMembers of %s receive %d entitlements\n", groupName, len(list))
+}
+
+func runEffectiveAccess(cmd *cobra.Command, args []string) {
+	email := args[0]
+	domain, _ := cmd.Flags().GetString("domain")
+	aclOnly, _ := cmd.Flags().GetBool("acl-only")
+	apiURL, _ := cmd.Flags().GetString("api-url")
+	token, _ := cmd.Flags().GetString("token")
+
+	client, err := newMemberClient(apiURL, token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	groups, err := client.groupsOf(email, aclOnly)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(groups) == 0 {
+		fmt.Printf("%s is not a member of any groups\n", email)
+		return
+	}
+
+	byGroup := make(map[string][]entitlement.Entitlement, len(groups))
+	for _, g := range groups {
+		list, err := entitlement.GroupEntitlements(g.Name, domain)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fetching entitlements for %s: %v\n", g.Name, err)
+			os.Exit(1)
+		}
+		byGroup[g.Name] = list
+	}
+	effective := entitlement.EffectiveEntitlements(byGroup)
+
+	names := make([]string, len(groups))
+	for i, g := range groups {
+		names[i] = g.Name
+	}
+	sort.Strings(names)
+	fmt.Printf("Groups: %s\n\n", strings.Join(names, ", "))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PRODUCT\tDOMAIN\tTIER\tFEATURES\tLIMITS\tVIA")
+	fmt.Fprintln(w, "-------\t------\t----\t--------\t------\t---")
+	for _, e := range effective {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.ProductName, e.Domain, joinOrDash(e.Tiers), joinOrDash(e.Features),
+			formatLimits(e.Limits), strings.Join(e.Groups, ", "))
+	}
+	w.Flush()
+
+	fmt.Printf("\nTotal: %d entitlements from %d groups\n", len(effective), len(groups))
+}
+
+// groupsOf returns the groups the user with the given email belongs to
+func (c *memberClient) groupsOf(email string, aclOnly bool) ([]models.GroupSummary, error) {
+	query := url.Values{"member": {email}}
+	if aclOnly {
+		query.Set("acl_only", "true")
+	}
+	body, err := c.Request(http.MethodGet, "/v1/groups?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups for %s: %w", email, err)
+	}
+
+	var result struct {
+		Groups []models.GroupSummary `json:"groups"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse group list: %w", err)
+	}
+	return result.Groups, nil
+}
+
+// MemberGroups returns every group the user with the given email belongs
+// to. apiURL and token may be empty to use the config or environment.
+func MemberGroups(apiURL, token, email string) ([]models.GroupSummary, error) {
+	client, err := newMemberClient(apiURL, token)
+	if err != nil {
+		return nil, err
+	}
+	return client.groupsOf(email, false)
+}
+
+// RemoveMember removes the user with the given email from a group
+func RemoveMember(apiURL, token, group, email string) error {
+	client, err := newMemberClient(apiURL, token)
+	if err != nil {
+		return err
+	}
+	return client.remove(group, email)
+}
+
+func joinOrDash(list []string) string {
+	if len(list) == 0 {
+		return "-"
+	}
+	return strings.Join(list, ", ")
+}
+
+// formatLimits renders limits as name=value pairs sorted by name
+func formatLimits(limits map[string]int) string {
+	if len(limits) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(limits))
+	for name := range limits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%d", name, limits[name])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -26,7 +26,11 @@ Commands:
   create    Create a new group
   update    Update group settings
   members   Manage group membership
-  requests  Manage join requests (for approval groups)`,
+  requests  Manage join requests (for approval groups)
+  sync      Reconcile membership with an external member list
+
+  entitlements      List the entitlements a group confers
+  effective-access  Show a user's combined access across their groups`,
 }
 
 func init() {
@@ -36,6 +40,9 @@ func init() {
 	GroupCmd.AddCommand(updateCmd)
 	GroupCmd.AddCommand(membersCmd)
 	GroupCmd.AddCommand(requestsCmd)
+	GroupCmd.AddCommand(syncCmd)
+	GroupCmd.AddCommand(entitlementsCmd)
+	GroupCmd.AddCommand(effectiveAccessCmd)
 }
 
 var listCmd = &cobra.Command{
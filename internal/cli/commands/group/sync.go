@@ -0,0 +1,298 @@
+package group
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/apiclient"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync [group-name]",
+	Short: "Reconcile group membership with an external member list",
+	Long: `Reconcile a group's membership with a member list exported from an
+upstream source such as LDAP or HR.
+
+The file is a CSV of member email addresses. If the first row has an
+"email" column, that column is used; otherwise the first column is. Blank
+lines and lines starting with # are ignored.
+
+Members in the file but not in the group are added. With --remove-extra,
+members in the group but not in the file are also removed.
+
+Examples:
+  # Add anyone in the HR export who is missing from the group
+  changes group sync billing-access --from-file members.csv
+
+  # Make the group match the file exactly
+  changes group sync billing-access --from-file members.csv --remove-extra
+
+  # Preview the changes
+  changes group sync billing-access --from-file members.csv --remove-extra --dry-run`,
+	Args: cobra.ExactArgs(1),
+	Run:  runSync,
+}
+
+func init() {
+	syncCmd.Flags().StringP("from-file", "f", "", "CSV file of member emails (required)")
+	syncCmd.Flags().Bool("remove-extra", false, "Remove members that are not in the file")
+	syncCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	syncCmd.Flags().StringP("role", "r", "member", "Role for added members (member, lead, admin)")
+	syncCmd.Flags().String("api-url", "", "API URL (default: from config or https://api.changes.afterdarksys.com)")
+	syncCmd.Flags().String("token", "", "API authentication token (or set CHANGES_API_TOKEN env var)")
+	syncCmd.MarkFlagRequired("from-file")
+}
+
+// MembershipDelta is the set of changes needed to make a group match a
+// member list
+type MembershipDelta struct {
+	Add       []string
+	Remove    []string
+	Unchanged int
+}
+
+// ComputeDelta compares the desired member emails with the current ones.
+// Emails are compared case-insensitively. Remove is only filled when
+// removeExtra is set. Add and Remove are sorted.
+func ComputeDelta(desired, current []string, removeExtra bool) MembershipDelta {
+	want := make(map[string]bool, len(desired))
+	for _, e := range desired {
+		want[normalizeEmail(e)] = true
+	}
+	have := make(map[string]bool, len(current))
+	for _, e := range current {
+		have[normalizeEmail(e)] = true
+	}
+
+	var delta MembershipDelta
+	for e := range want {
+		if have[e] {
+			delta.Unchanged++
+		} else {
+			delta.Add = append(delta.Add, e)
+		}
+	}
+	if removeExtra {
+		for e := range have {
+			if !want[e] {
+				delta.Remove = append(delta.Remove, e)
+			}
+		}
+	}
+	sort.Strings(delta.Add)
+	sort.Strings(delta.Remove)
+	return delta
+}
+
+// ReadMemberFile reads member emails from a CSV file. Duplicates are
+// dropped, keeping the first occurrence.
+func ReadMemberFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	col := 0
+	seen := make(map[string]bool)
+	var emails []string
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 {
+			if i := headerColumn(record, "email"); i >= 0 {
+				col = i
+				continue
+			}
+		}
+		if col >= len(record) {
+			continue
+		}
+		email := normalizeEmail(record[col])
+		if email == "" || seen[email] {
+			continue
+		}
+		if !strings.Contains(email, "@") {
+			return nil, fmt.Errorf("line %d: %q is not an email address", line, record[col])
+		}
+		seen[email] = true
+		emails = append(emails, email)
+	}
+	return emails, nil
+}
+
+func headerColumn(record []string, name string) int {
+	for i, field := range record {
+		if strings.EqualFold(strings.TrimSpace(field), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func runSync(cmd *cobra.Command, args []string) {
+	groupName := args[0]
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	removeExtra, _ := cmd.Flags().GetBool("remove-extra")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	role, _ := cmd.Flags().GetString("role")
+	apiURL, _ := cmd.Flags().GetString("api-url")
+	token, _ := cmd.Flags().GetString("token")
+
+	switch role {
+	case "member", "lead", "admin":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: invalid role %q (must be member, lead, or admin)\n", role)
+		os.Exit(1)
+	}
+
+	desired, err := ReadMemberFile(fromFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", fromFile, err)
+		os.Exit(1)
+	}
+
+	client, err := newMemberClient(apiURL, token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	current, err := client.list(groupName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	delta := ComputeDelta(desired, current, removeExtra)
+
+	if dryRun {
+		fmt.Println("DRY RUN - no changes will be made")
+		fmt.Println()
+	}
+	fmt.Printf("Syncing group %s from %s\n\n", groupName, fromFile)
+
+	added, removed, failed := 0, 0, 0
+	for _, email := range delta.Add {
+		if dryRun {
+			fmt.Printf("  + %s\n", email)
+			continue
+		}
+		if err := client.add(groupName, email, role); err != nil {
+			fmt.Printf("  ! %s: %v\n", email, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  + %s\n", email)
+		added++
+	}
+	for _, email := range delta.Remove {
+		if dryRun {
+			fmt.Printf("  - %s\n", email)
+			continue
+		}
+		if err := client.remove(groupName, email); err != nil {
+			fmt.Printf("  ! %s: %v\n", email, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  - %s\n", email)
+		removed++
+	}
+
+	if len(delta.Add) == 0 && len(delta.Remove) == 0 {
+		fmt.Println("  Membership already matches")
+	}
+	fmt.Println()
+
+	if dryRun {
+		fmt.Printf("Would add %d, remove %d (%d unchanged)\n", len(delta.Add), len(delta.Remove), delta.Unchanged)
+		if !removeExtra {
+			fmt.Println("Members not in the file are kept; use --remove-extra to remove them.")
+		}
+		return
+	}
+
+	fmt.Printf("Sync complete: %d added, %d removed, %d unchanged, %d failed\n", added, removed, delta.Unchanged, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// memberClient calls the group member API
+type memberClient struct {
+	*apiclient.Client
+}
+
+// newMemberClient returns a client for the changes API, with apiURL and
+// token overriding the configured values when set
+func newMemberClient(apiURL, token string) (*memberClient, error) {
+	client, err := apiclient.FromConfig(apiclient.ChangesAPI, apiURL, token)
+	if err != nil {
+		return nil, err
+	}
+	return &memberClient{client}, nil
+}
+
+func (c *memberClient) membersURL(group string) string {
+	return fmt.Sprintf("/v1/groups/%s/members", url.PathEscape(group))
+}
+
+// list returns the emails of the group's current members
+func (c *memberClient) list(group string) ([]string, error) {
+	body, err := c.Request(http.MethodGet, c.membersURL(group), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of %s: %w", group, err)
+	}
+
+	var result struct {
+		Members []models.GroupMember `json:"members"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse member list: %w", err)
+	}
+
+	emails := make([]string, 0, len(result.Members))
+	for _, m := range result.Members {
+		if m.User != nil && m.User.Email != "" {
+			emails = append(emails, m.User.Email)
+		}
+	}
+	return emails, nil
+}
+
+func (c *memberClient) add(group, email, role string) error {
+	data, err := json.Marshal(map[string]string{"email": email, "role": role})
+	if err != nil {
+		return err
+	}
+	_, err = c.Request(http.MethodPost, c.membersURL(group), data)
+	return err
+}
+
+func (c *memberClient) remove(group, email string) error {
+	_, err := c.Request(http.MethodDelete, c.membersURL(group)+"/"+url.PathEscape(email), nil)
+	return err
+}
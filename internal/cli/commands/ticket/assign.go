@@ -0,0 +1,116 @@
+package ticket
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var assignCmd = &cobra.Command{
+	Use:   "assign [ticket-number] [email]",
+	Short: "Assign a ticket to someone",
+	Long: `Set or clear the assignee of a local change ticket. The change is
+recorded as a comment on the ticket.
+
+Examples:
+  # Assign a ticket
+  changes ticket assign CHG-2025-00001 jane@example.com
+
+  # Clear the assignee
+  changes ticket assign CHG-2025-00001 --unassign`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runAssign,
+}
+
+func init() {
+	assignCmd.Flags().Bool("unassign", false, "Clear the assignee")
+}
+
+// isAssignable reports whether a ticket in status can still be worked on:
+// any open status, or approved and awaiting implementation
+func isAssignable(status string) bool {
+	s := models.TicketStatus(status)
+	return s.IsOpen() || s == models.TicketStatusApproved
+}
+
+func runAssign(cmd *cobra.Command, args []string) {
+	ticketNumber := strings.ToUpper(args[0])
+	unassign, _ := cmd.Flags().GetBool("unassign")
+
+	var assignee string
+	switch {
+	case unassign && len(args) == 2:
+		fmt.Fprintln(os.Stderr, "Error: give either an email or --unassign, not both")
+		os.Exit(1)
+	case !unassign && len(args) == 1:
+		fmt.Fprintln(os.Stderr, "Error: an assignee email is required (or use --unassign)")
+		os.Exit(1)
+	case !unassign:
+		addr, err := mail.ParseAddress(args[1])
+		if err != nil || addr.Address != args[1] {
+			fmt.Fprintf(os.Stderr, "Error: invalid email %q\n", args[1])
+			os.Exit(1)
+		}
+		assignee = strings.ToLower(addr.Address)
+	}
+
+	ticket, err := loadLocalTicket(ticketNumber)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	previous := ""
+	if ticket.Assignee != nil {
+		previous = *ticket.Assignee
+	}
+	if previous == assignee {
+		if assignee == "" {
+			fmt.Printf("Ticket %s is already unassigned\n", ticket.ID)
+		} else {
+			fmt.Printf("Ticket %s is already assigned to %s\n", ticket.ID, assignee)
+		}
+		return
+	}
+
+	if !isAssignable(ticket.Status) {
+		fmt.Fprintf(os.Stderr, "Warning: ticket %s is %s\n", ticket.ID, ticket.Status)
+	}
+
+	var text string
+	switch {
+	case assignee == "":
+		ticket.Assignee = nil
+		text = "Unassigned (was " + previous + ")."
+	case previous == "":
+		ticket.Assignee = &assignee
+		text = "Assigned to " + assignee + "."
+	default:
+		ticket.Assignee = &assignee
+		text = "Reassigned from " + previous + " to " + assignee + "."
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	ticket.UpdatedAt = now
+	ticket.Comments = append(ticket.Comments, models.TicketFileComment{
+		Author:    currentUserEmail(),
+		Timestamp: now,
+		Text:      text,
+	})
+
+	if err := saveTicket(ticket); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving ticket: %v\n", err)
+		os.Exit(1)
+	}
+
+	if assignee == "" {
+		fmt.Printf("Ticket %s unassigned\n", ticket.ID)
+	} else {
+		fmt.Printf("Ticket %s assigned to %s\n", ticket.ID, assignee)
+	}
+}
@@ -0,0 +1,137 @@
+package ticket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/afterdarksys/adsops-utils/internal/pkg/apiclient"
+)
+
+// attachment is a resolved --attach value: either a URL stored as given or
+// a local file to upload
+type attachment struct {
+	URL  string
+	Path string
+}
+
+// parseAttachment validates an --attach value. http(s) URLs need a host;
+// file:// URLs and plain paths must name an existing regular file. Local
+// files are returned with an absolute Path.
+func parseAttachment(value string) (attachment, error) {
+	if u, err := url.Parse(value); err == nil && u.Scheme != "" && len(u.Scheme) > 1 {
+		switch u.Scheme {
+		case "http", "https":
+			if u.Host == "" {
+				return attachment{}, fmt.Errorf("invalid attachment URL %q: missing host", value)
+			}
+			return attachment{URL: u.String()}, nil
+		case "file":
+			value = u.Path
+		default:
+			return attachment{}, fmt.Errorf("unsupported attachment URL scheme %q (use http, https or a local path)", u.Scheme)
+		}
+	}
+
+	path, err := filepath.Abs(value)
+	if err != nil {
+		return attachment{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return attachment{}, fmt.Errorf("attachment %s: %w", value, err)
+	}
+	if !info.Mode().IsRegular() {
+		return attachment{}, fmt.Errorf("attachment %s is not a regular file", value)
+	}
+	return attachment{Path: path}, nil
+}
+
+// attachmentStore uploads local attachments to the object store configured
+// by attachments.upload_url (or CHANGES_ATTACHMENT_URL). Files are PUT to
+// <upload_url>/<ticket-id>/<file name>.
+type attachmentStore struct {
+	client *apiclient.Client
+}
+
+// newAttachmentStore returns the configured store, or nil when none is
+// configured
+func newAttachmentStore() *attachmentStore {
+	uploadURL := apiclient.Resolve("", "attachments.upload_url", "CHANGES_ATTACHMENT_URL", "")
+	if uploadURL == "" {
+		return nil
+	}
+	token, _ := apiclient.ChangesAPI.Token("")
+	return &attachmentStore{client: apiclient.New(uploadURL, token)}
+}
+
+// upload stores the file and returns its URL: the "url" field of a JSON
+// response, else the Location header, else the URL it was PUT to
+func (s *attachmentStore) upload(ticketID, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	target := s.client.BaseURL + "/" + url.PathEscape(ticketID) + "/" + url.PathEscape(filepath.Base(path))
+	req, err := http.NewRequest(http.MethodPut, target, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", http.DetectContentType(data))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("upload failed (%d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		URL string `json:"url"`
+	}
+	if json.Unmarshal(body, &result) == nil && result.URL != "" {
+		return result.URL, nil
+	}
+	if loc := resp.Header.Get("Location"); loc != "" {
+		return loc, nil
+	}
+	return target, nil
+}
+
+// fileURL returns a file:// reference to a local path
+func fileURL(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// storeAttachments returns the URLs to record for the attachments. Local
+// files are uploaded to store, or recorded as file:// references when store
+// is nil.
+func storeAttachments(ticketID string, attachments []attachment, store *attachmentStore) ([]string, error) {
+	urls := make([]string, 0, len(attachments))
+	for _, a := range attachments {
+		switch {
+		case a.URL != "":
+			urls = append(urls, a.URL)
+		case store == nil:
+			urls = append(urls, fileURL(a.Path))
+		default:
+			u, err := store.upload(ticketID, a.Path)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", filepath.Base(a.Path), err)
+			}
+			urls = append(urls, u)
+		}
+	}
+	return urls, nil
+}
@@ -0,0 +1,247 @@
+package ticket
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/fsutil"
+	"github.com/spf13/cobra"
+)
+
+var bulkTransitionCmd = &cobra.Command{
+	Use:   "bulk-transition",
+	Short: "Move many local tickets to a new status at once",
+	Long: `Move every local ticket matching a filter to a new status.
+
+Each ticket is checked against the same transition rules as the single
+ticket commands; tickets that cannot make the transition are skipped and
+listed with the reason. The rest are updated together: if any file cannot
+be written, the ones already written are restored.
+
+Filters are field=value[,value...] terms on status, priority or risk,
+matched case-insensitively. Repeat --filter (or put several terms in one,
+separated by spaces) to require all of them. --status and --priority work
+as in 'ticket list'. At least one filter is required.
+
+Targets: submitted, cancelled, closed, and update_requested (reopen).
+
+Examples:
+  # Preview closing every completed ticket
+  changes ticket bulk-transition --filter status=completed --to closed --dry-run
+
+  # Close them
+  changes ticket bulk-transition --filter status=completed --to closed
+
+  # Cancel low priority drafts
+  changes ticket bulk-transition --filter "status=draft priority=low" --to cancelled`,
+	Args: cobra.NoArgs,
+	Run:  runBulkTransition,
+}
+
+func init() {
+	bulkTransitionCmd.Flags().StringArray("filter", nil, "Filter expression, e.g. status=completed or priority=low,normal")
+	bulkTransitionCmd.Flags().StringSlice("status", []string{}, "Filter by status")
+	bulkTransitionCmd.Flags().StringSlice("priority", []string{}, "Filter by priority")
+	bulkTransitionCmd.Flags().String("to", "", "Target status (required)")
+	bulkTransitionCmd.Flags().Bool("dry-run", false, "Show what would change without saving")
+	bulkTransitionCmd.MarkFlagRequired("to")
+}
+
+// statusTransition is a status the bulk command can move tickets to, and
+// the model guard a ticket must pass to get there
+type statusTransition struct {
+	Verb  string
+	Allow func(*models.Ticket) bool
+}
+
+var statusTransitions = map[models.TicketStatus]statusTransition{
+	models.TicketStatusSubmitted:       {"submitted", (*models.Ticket).CanSubmit},
+	models.TicketStatusCancelled:       {"cancelled", (*models.Ticket).CanCancel},
+	models.TicketStatusClosed:          {"closed", (*models.Ticket).CanClose},
+	models.TicketStatusUpdateRequested: {"reopened", (*models.Ticket).CanReopen},
+}
+
+// ticketFilter holds the accepted values of each filtered field. A ticket
+// matches when every field with values matches one of them.
+type ticketFilter map[string][]string
+
+// parseTicketFilter parses --filter expressions into a ticketFilter.
+// Values are checked against the field's enum.
+func parseTicketFilter(exprs []string) (ticketFilter, error) {
+	filter := make(ticketFilter)
+	for _, expr := range exprs {
+		for _, term := range strings.Fields(expr) {
+			field, values, ok := strings.Cut(term, "=")
+			field = strings.ToLower(strings.TrimSpace(field))
+			if !ok || values == "" {
+				return nil, fmt.Errorf("invalid filter %q (use field=value[,value...])", term)
+			}
+			switch field {
+			case "status", "priority", "risk":
+			default:
+				return nil, fmt.Errorf("unknown filter field %q (fields: status, priority, risk)", field)
+			}
+			for _, v := range strings.Split(values, ",") {
+				if err := checkEnum(field, v); err != nil {
+					return nil, err
+				}
+				filter[field] = append(filter[field], v)
+			}
+		}
+	}
+	return filter, nil
+}
+
+// matches reports whether a ticket passes every field of the filter
+func (f ticketFilter) matches(t ticketSummary) bool {
+	return matchesAny(t.Status, f["status"]) &&
+		matchesAny(t.Priority, f["priority"]) &&
+		matchesAny(t.Risk, f["risk"])
+}
+
+// pendingWrite is a ticket file to rewrite, with its current contents kept
+// so the batch can be rolled back
+type pendingWrite struct {
+	path     string
+	original []byte
+	data     []byte
+}
+
+// writeAll writes every file, restoring the ones already written when one
+// fails
+func writeAll(writes []pendingWrite) error {
+	for i, w := range writes {
+		if err := fsutil.WriteFileAtomic(w.path, w.data, 0600); err != nil {
+			for _, done := range writes[:i] {
+				if rerr := fsutil.WriteFileAtomic(done.path, done.original, 0600); rerr != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to restore %s: %v\n", done.path, rerr)
+				}
+			}
+			return fmt.Errorf("failed to write %s: %w", filepath.Base(w.path), err)
+		}
+	}
+	return nil
+}
+
+func runBulkTransition(cmd *cobra.Command, args []string) {
+	exprs, _ := cmd.Flags().GetStringArray("filter")
+	statusFilter, _ := cmd.Flags().GetStringSlice("status")
+	priorityFilter, _ := cmd.Flags().GetStringSlice("priority")
+	to, _ := cmd.Flags().GetString("to")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	target := models.TicketStatus(strings.ToLower(to))
+	transition, ok := statusTransitions[target]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: invalid --to %q (allowed: submitted, cancelled, closed, update_requested)\n", to)
+		os.Exit(1)
+	}
+
+	filter, err := parseTicketFilter(exprs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	filter["status"] = append(filter["status"], statusFilter...)
+	filter["priority"] = append(filter["priority"], priorityFilter...)
+	if len(filter["status"])+len(filter["priority"])+len(filter["risk"]) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: at least one filter is required (e.g. --filter status=completed)")
+		os.Exit(1)
+	}
+
+	matched, err := findLocalTickets(filter.matches, ticketOrder("created_at", false), 0)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading tickets: %v\n", err)
+		os.Exit(1)
+	}
+	if len(matched) == 0 {
+		fmt.Println("No tickets match the filter.")
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	var writes []pendingWrite
+	var moved []string
+	skipped := make(map[string]string)
+
+	for _, s := range matched {
+		ticket, err := loadLocalTicket(s.ID)
+		if err != nil {
+			skipped[s.ID] = err.Error()
+			continue
+		}
+		from := ticket.Status
+		if models.TicketStatus(from) == target {
+			skipped[s.ID] = "already " + string(target)
+			continue
+		}
+		if !transition.Allow(models.TicketFromLocalFile(ticket)) {
+			skipped[s.ID] = fmt.Sprintf("cannot be %s from %s", transition.Verb, from)
+			continue
+		}
+
+		path := filepath.Join(getTicketsDir(), ticket.ID+".json")
+		original, err := os.ReadFile(path)
+		if err != nil {
+			skipped[s.ID] = err.Error()
+			continue
+		}
+
+		ticket.Status = string(target)
+		ticket.UpdatedAt = now
+		ticket.Comments = append(ticket.Comments, models.TicketFileComment{
+			Author:    currentUserEmail(),
+			Timestamp: now,
+			Text:      fmt.Sprintf("Status changed from %s to %s (bulk transition).", from, target),
+		})
+		data, err := json.MarshalIndent(ticket, "", "  ")
+		if err != nil {
+			skipped[s.ID] = err.Error()
+			continue
+		}
+
+		writes = append(writes, pendingWrite{path: path, original: original, data: data})
+		moved = append(moved, fmt.Sprintf("%s  %s -> %s", ticket.ID, from, target))
+	}
+
+	if dryRun {
+		fmt.Println("DRY RUN - no changes will be made")
+		fmt.Println()
+	}
+	if len(writes) > 0 && !dryRun {
+		if err := writeAll(writes); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v (no tickets were changed)\n", err)
+			os.Exit(1)
+		}
+	}
+
+	for _, line := range moved {
+		fmt.Println("  " + line)
+	}
+	if len(skipped) > 0 {
+		ids := make([]string, 0, len(skipped))
+		for id := range skipped {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		fmt.Println()
+		fmt.Println("Skipped:")
+		for _, id := range ids {
+			fmt.Printf("  %s  %s\n", id, skipped[id])
+		}
+	}
+
+	fmt.Println()
+	verb := "Moved"
+	if dryRun {
+		verb = "Would move"
+	}
+	fmt.Printf("%s %d ticket(s) to %s, skipped %d.\n", verb, len(writes), target, len(skipped))
+}
@@ -6,46 +6,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/fsutil"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/ticketnum"
 	_ "github.com/lib/pq"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-// CreateTicketData represents the full ticket data for JSON storage
-type CreateTicketData struct {
-	ID                   string   `json:"id"`
-	Title                string   `json:"title"`
-	Description          string   `json:"description"`
-	Status               string   `json:"status"`
-	Priority             string   `json:"priority"`
-	Risk                 string   `json:"risk"`
-	Type                 string   `json:"type"`
-	Industry             string   `json:"industry"`
-	ComplianceFrameworks []string `json:"compliance_frameworks"`
-	AffectedSystems      []string `json:"affected_systems"`
-	AcceptanceCriteria   []string `json:"acceptance_criteria"`
-	TestingPlan          string   `json:"testing_plan"`
-	RollbackPlan         string   `json:"rollback_plan"`
-	CreatedBy            string   `json:"created_by"`
-	CreatedAt            string   `json:"created_at"`
-	UpdatedAt            string   `json:"updated_at"`
-	Sprint               string   `json:"sprint"`
-	Assignee             *string  `json:"assignee"`
-	ApprovalsRequired    []string `json:"approvals_required"`
-	Approvals            []string `json:"approvals"`
-	Dependencies         []string `json:"dependencies"`
-	Comments             []struct {
-		Author    string `json:"author"`
-		Timestamp string `json:"timestamp"`
-		Text      string `json:"text"`
-	} `json:"comments"`
-}
-
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new change ticket",
@@ -69,7 +40,15 @@ Examples:
     --risk medium \
     --industry finance \
     --compliance glba,sox \
-    --approval-types operations,it,security`,
+    --approval-types operations,it,security
+
+  # Attach a runbook and a design doc link
+  changes ticket create --title "Rotate TLS certs" \
+    --attach ./runbook.pdf --attach https://wiki.example.com/tls-rotation
+
+Local attachments are uploaded to the object store set by
+attachments.upload_url in the config (or CHANGES_ATTACHMENT_URL). With no
+store configured, or with --offline, they are recorded as file:// references.`,
 	Run: runCreate,
 }
 
@@ -79,20 +58,22 @@ func init() {
 	createCmd.Flags().StringP("priority", "p", "normal", "Priority (emergency, urgent, high, normal, low)")
 	createCmd.Flags().StringP("risk", "r", "medium", "Risk level (critical, high, medium, low)")
 	createCmd.Flags().StringP("industry", "i", "", "Industry (healthcare, it, government, insurance, finance)")
-	createCmd.Flags().StringSlice("compliance", []string{}, "Compliance frameworks (glba, sox, hipaa, gdpr, banking_secrecy_act)")
-	createCmd.Flags().StringSlice("approval-types", []string{}, "Required approval types")
+	createCmd.Flags().StringSlice("compliance", []string{}, "Compliance frameworks (glba, sox, hipaa, gdpr, banking_secrecy_act, custom)")
+	createCmd.Flags().StringSlice("approval-types", []string{}, "Required approval types (see 'changes ticket enums approval-types')")
 	createCmd.Flags().StringSlice("affected-systems", []string{}, "Affected systems")
 	createCmd.Flags().String("change-type", "", "Type of change")
 	createCmd.Flags().String("impact", "", "Impact description")
 	createCmd.Flags().String("rollback", "", "Rollback plan")
 	createCmd.Flags().String("testing", "", "Testing plan")
+	createCmd.Flags().StringArray("attach", nil, "Attach a local file or http(s) URL (repeatable)")
+	createCmd.Flags().Bool("offline", false, "Record local attachments as file:// references instead of uploading")
 	createCmd.Flags().Bool("submit", false, "Submit immediately instead of saving as draft")
 	createCmd.Flags().Bool("interactive", true, "Use interactive mode")
 }
 
 // getMaxTicketNumFromDB attempts to get the max ticket number from the database
 // Returns 0 if database is unavailable or query fails (graceful degradation)
-func getMaxTicketNumFromDB(year int) int {
+func getMaxTicketNumFromDB(prefix string) int {
 	// Try to load database config from viper
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
@@ -138,16 +119,17 @@ func getMaxTicketNumFromDB(year int) int {
 		return 0
 	}
 
-	// Query for max ticket number this year
+	// Query for max ticket number under this prefix
 	var maxNum sql.NullInt64
 	query := `
 		SELECT MAX(
 			CAST(SUBSTRING(ticket_number FROM '[0-9]+$') AS INTEGER)
 		)
 		FROM change_tickets
-		WHERE EXTRACT(YEAR FROM created_at) = $1
+		WHERE ticket_number LIKE $1 || '%'
+		  AND SUBSTRING(ticket_number FROM char_length($1) + 1) ~ '^[0-9]+$'
 	`
-	if err := db.QueryRow(query, year).Scan(&maxNum); err != nil {
+	if err := db.QueryRow(query, prefix).Scan(&maxNum); err != nil {
 		return 0
 	}
 
@@ -157,77 +139,25 @@ func getMaxTicketNumFromDB(year int) int {
 	return 0
 }
 
-// getMaxTicketNumFromFiles scans local JSON files for the max ticket number
-func getMaxTicketNumFromFiles(ticketsDir string, year int) int {
-	entries, err := os.ReadDir(ticketsDir)
-	if err != nil {
-		return 0
-	}
-
-	var maxNum int
-	prefix := fmt.Sprintf("CHG-%d-", year)
-	for _, entry := range entries {
-		name := entry.Name()
-		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".json") {
-			continue
-		}
-		// Extract number from CHG-2025-00001.json -> 1
-		numStr := strings.TrimPrefix(name, prefix)
-		numStr = strings.TrimSuffix(numStr, ".json")
-		num, err := strconv.Atoi(numStr)
-		if err != nil {
-			continue
-		}
-		if num > maxNum {
-			maxNum = num
-		}
-	}
-	return maxNum
-}
-
-// getNextTicketNumber determines the next available ticket number
+// getNextTicketNumber determines the next available ticket number in the
+// configured format (see ticketnum.Load) for a ticket created at now
 // Checks BOTH local JSON files AND the database (if available) to prevent ID collisions
-func getNextTicketNumber() (string, error) {
-	ticketsDir := getTicketsDir()
-	year := time.Now().Year()
-
-	// Ensure tickets directory exists
-	if err := os.MkdirAll(ticketsDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create tickets directory: %w", err)
-	}
-
-	// Get max from local JSON files
-	maxFromFiles := getMaxTicketNumFromFiles(ticketsDir, year)
-
-	// Get max from database (gracefully handles unavailable DB)
-	maxFromDB := getMaxTicketNumFromDB(year)
-
-	// Use the higher of the two
-	maxNum := maxFromFiles
-	if maxFromDB > maxNum {
-		maxNum = maxFromDB
+func getNextTicketNumber(now time.Time) (string, error) {
+	format, err := ticketnum.Load()
+	if err != nil {
+		return "", err
 	}
+	prefix := format.PrefixAt(now)
 
-	// Generate next ID
-	nextNum := maxNum + 1
-	ticketID := fmt.Sprintf("CHG-%d-%05d", year, nextNum)
-
-	// Final safety check: ensure file doesn't already exist (race condition protection)
-	for {
-		filename := filepath.Join(ticketsDir, ticketID+".json")
-		if _, err := os.Stat(filename); os.IsNotExist(err) {
-			break // File doesn't exist, safe to use this ID
-		}
-		// File exists, increment and try again
-		nextNum++
-		ticketID = fmt.Sprintf("CHG-%d-%05d", year, nextNum)
-	}
+	// Get max from database (gracefully handles unavailable DB); local
+	// files are checked by NextTicketNumber
+	maxFromDB := getMaxTicketNumFromDB(prefix)
 
-	return ticketID, nil
+	return format.NextTicketNumber(getTicketsDir(), prefix, maxFromDB)
 }
 
 // saveTicket saves a ticket to the local tickets directory
-func saveTicket(ticket *CreateTicketData) error {
+func saveTicket(ticket *models.TicketFile) error {
 	ticketsDir := getTicketsDir()
 	if err := os.MkdirAll(ticketsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create tickets directory: %w", err)
@@ -239,7 +169,7 @@ func saveTicket(ticket *CreateTicketData) error {
 		return fmt.Errorf("failed to marshal ticket: %w", err)
 	}
 
-	if err := os.WriteFile(filename, data, 0600); err != nil {
+	if err := fsutil.WriteFileAtomic(filename, data, 0600); err != nil {
 		return fmt.Errorf("failed to write ticket file: %w", err)
 	}
 
@@ -267,8 +197,10 @@ func runCreate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	now := time.Now().UTC()
+
 	// Get next ticket number
-	ticketID, err := getNextTicketNumber()
+	ticketID, err := getNextTicketNumber(now)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating ticket ID: %v\n", err)
 		os.Exit(1)
@@ -286,60 +218,93 @@ func runCreate(cmd *cobra.Command, args []string) {
 	rollback, _ := cmd.Flags().GetString("rollback")
 	testing, _ := cmd.Flags().GetString("testing")
 	submit, _ := cmd.Flags().GetBool("submit")
+	attachValues, _ := cmd.Flags().GetStringArray("attach")
+	offline, _ := cmd.Flags().GetBool("offline")
+
+	priority, risk, industry = strings.ToLower(priority), strings.ToLower(risk), strings.ToLower(industry)
+	for i := range compliance {
+		compliance[i] = strings.ToLower(strings.TrimSpace(compliance[i]))
+	}
+	for i := range approvalTypes {
+		approvalTypes[i] = strings.ToLower(strings.TrimSpace(approvalTypes[i]))
+	}
+	type enumFlag struct{ field, value string }
+	flags := []enumFlag{{"priority", priority}, {"risk", risk}}
+	if industry != "" {
+		flags = append(flags, enumFlag{"industry", industry})
+	}
+	for _, c := range compliance {
+		flags = append(flags, enumFlag{"compliance", c})
+	}
+	for _, a := range approvalTypes {
+		flags = append(flags, enumFlag{"approval-types", a})
+	}
+	for _, f := range flags {
+		if err := checkEnum(f.field, f.value); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	attachments := make([]attachment, 0, len(attachValues))
+	for _, v := range attachValues {
+		a, err := parseAttachment(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		attachments = append(attachments, a)
+	}
 
-	now := time.Now().UTC()
 	status := "draft"
 	if submit {
 		status = "submitted"
 	}
 
-	// Determine current user
-	user := os.Getenv("USER")
-	if user == "" {
-		user = "unknown"
-	}
-	createdBy := user + "@afterdarksys.com"
-
-	// Calculate sprint
-	_, week := now.ISOWeek()
-	quarter := (now.Month()-1)/3 + 1
-	sprint := fmt.Sprintf("%d-Q%d-Sprint-%d", now.Year(), quarter, (week-1)%2+1)
-
-	ticket := &CreateTicketData{
-		ID:                   ticketID,
-		Title:                title,
-		Description:          description,
-		Status:               status,
-		Priority:             priority,
-		Risk:                 risk,
-		Type:                 changeType,
-		Industry:             industry,
-		ComplianceFrameworks: compliance,
-		AffectedSystems:      affectedSystems,
-		AcceptanceCriteria:   []string{},
-		TestingPlan:          testing,
-		RollbackPlan:         rollback,
-		CreatedBy:            createdBy,
-		CreatedAt:            now.Format(time.RFC3339),
-		UpdatedAt:            now.Format(time.RFC3339),
-		Sprint:               sprint,
-		Assignee:             nil,
-		ApprovalsRequired:    approvalTypes,
-		Approvals:            []string{},
-		Dependencies:         []string{},
-		Comments: []struct {
-			Author    string `json:"author"`
-			Timestamp string `json:"timestamp"`
-			Text      string `json:"text"`
-		}{
-			{
-				Author:    createdBy,
-				Timestamp: now.Format(time.RFC3339),
-				Text:      "Ticket created via CLI.",
-			},
+	createdBy := currentUserEmail()
+
+	ticket := models.NewTicketFile(ticketID, now)
+	ticket.Title = title
+	ticket.Description = description
+	ticket.Status = status
+	ticket.Priority = priority
+	ticket.Risk = risk
+	ticket.Type = changeType
+	ticket.Industry = industry
+	ticket.ComplianceFrameworks = compliance
+	ticket.AffectedSystems = affectedSystems
+	ticket.TestingPlan = testing
+	ticket.RollbackPlan = rollback
+	ticket.CreatedBy = createdBy
+	ticket.Sprint = models.SprintAt(now)
+	ticket.ApprovalsRequired = approvalTypes
+	ticket.Comments = []models.TicketFileComment{
+		{
+			Author:    createdBy,
+			Timestamp: ticket.CreatedAt,
+			Text:      "Ticket created via CLI.",
 		},
 	}
 
+	for _, warning := range ticket.ComplianceWarnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning.Message)
+	}
+
+	if len(attachments) > 0 {
+		var store *attachmentStore
+		if !offline {
+			if store = newAttachmentStore(); store == nil {
+				fmt.Fprintln(os.Stderr, "Warning: no attachment store configured (attachments.upload_url); recording local files as file:// references")
+			}
+		}
+		urls, err := storeAttachments(ticketID, attachments, store)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error uploading attachment %v\n", err)
+			os.Exit(1)
+		}
+		ticket.Attachments = urls
+	}
+
 	if err := saveTicket(ticket); err != nil {
 		fmt.Fprintf(os.Stderr, "Error saving ticket: %v\n", err)
 		os.Exit(1)
@@ -367,17 +332,10 @@ func runInteractiveCreate(cmd *cobra.Command) {
 	fmt.Println()
 
 	// List existing tickets
-	tickets, err := loadLocalTickets()
+	tickets, err := findLocalTickets(nil, ticketOrder("created_at", true), 5)
 	if err == nil && len(tickets) > 0 {
 		fmt.Println("Recent tickets:")
-		// Sort by created date descending
-		sort.Slice(tickets, func(i, j int) bool {
-			return tickets[i].CreatedAt.After(tickets[j].CreatedAt)
-		})
-		for i, t := range tickets {
-			if i >= 5 {
-				break
-			}
+		for _, t := range tickets {
 			fmt.Printf("  %s: %s (%s)\n", t.ID, t.Title, t.Status)
 		}
 	}
@@ -0,0 +1,98 @@
+package ticket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var enumsCmd = &cobra.Command{
+	Use:   "enums [field]",
+	Short: "List the valid values of ticket fields",
+	Long: `List the values accepted by the enumerated ticket fields: status,
+priority, risk, industry, compliance and approval-types.
+
+Examples:
+  # Every field
+  changes ticket enums
+
+  # Only compliance frameworks
+  changes ticket enums compliance`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runEnums,
+}
+
+// ticketEnum is an enumerated ticket field and its allowed values
+type ticketEnum struct {
+	Field  string
+	Values []string
+}
+
+// ticketEnums lists the enumerated fields in the order enums prints them.
+// Field names match the create flags.
+var ticketEnums = []ticketEnum{
+	{"status", enumStrings(models.AllTicketStatuses())},
+	{"priority", enumStrings(models.AllPriorities())},
+	{"risk", enumStrings(models.AllRiskLevels())},
+	{"industry", enumStrings(models.AllIndustries())},
+	{"compliance", enumStrings(models.AllFrameworks())},
+	{"approval-types", enumStrings(models.AllApprovalTypes())},
+}
+
+func enumStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// lookupEnum returns the allowed values of a field
+func lookupEnum(field string) (ticketEnum, bool) {
+	for _, e := range ticketEnums {
+		if e.Field == field {
+			return e, true
+		}
+	}
+	return ticketEnum{}, false
+}
+
+// checkEnum returns an error listing the allowed values when value is not
+// one of the field's values. Values are compared case-insensitively.
+func checkEnum(field, value string) error {
+	e, _ := lookupEnum(field)
+	for _, allowed := range e.Values {
+		if strings.EqualFold(value, allowed) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --%s %q (allowed: %s)", field, value, strings.Join(e.Values, ", "))
+}
+
+func runEnums(cmd *cobra.Command, args []string) {
+	enums := ticketEnums
+	if len(args) == 1 {
+		e, ok := lookupEnum(strings.ToLower(args[0]))
+		if !ok {
+			names := make([]string, len(ticketEnums))
+			for i, e := range ticketEnums {
+				names[i] = e.Field
+			}
+			fmt.Fprintf(os.Stderr, "Error: unknown field %q (fields: %s)\n", args[0], strings.Join(names, ", "))
+			os.Exit(1)
+		}
+		enums = []ticketEnum{e}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tVALUES")
+	fmt.Fprintln(w, "-----\t------")
+	for _, e := range enums {
+		fmt.Fprintf(w, "%s\t%s\n", e.Field, strings.Join(e.Values, ", "))
+	}
+	w.Flush()
+}
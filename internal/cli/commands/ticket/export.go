@@ -3,15 +3,14 @@ package ticket
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/afterdarksys/adsops-utils/internal/pkg/apiclient"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var exportCmd = &cobra.Command{
@@ -63,15 +62,10 @@ func runExport(cmd *cobra.Command, args []string) {
 	overwrite, _ := cmd.Flags().GetBool("overwrite")
 	apiURL, _ := cmd.Flags().GetString("api-url")
 
-	// Determine API URL
-	if apiURL == "" {
-		apiURL = viper.GetString("api.url")
-	}
-	if apiURL == "" {
-		apiURL = os.Getenv("CHANGES_API_URL")
-	}
-	if apiURL == "" {
-		apiURL = "https://api.changes.afterdarksys.com"
+	client, err := apiclient.FromConfig(apiclient.ChangesAPI, apiURL, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
 	// Get output directory
@@ -89,7 +83,7 @@ func runExport(cmd *cobra.Command, args []string) {
 
 	if exportAll {
 		// Fetch ticket list from API
-		ids, err := fetchTicketIDsFromAPI(apiURL, statusFilter)
+		ids, err := fetchTicketIDsFromAPI(client, statusFilter)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error fetching ticket list: %v\n", err)
 			os.Exit(1)
@@ -117,7 +111,7 @@ func runExport(cmd *cobra.Command, args []string) {
 		fmt.Printf("Exporting %s... ", ticketID)
 
 		// Fetch ticket from API
-		ticketData, err := fetchTicketFromAPI(apiURL, ticketID)
+		ticketData, err := fetchTicketFromAPI(client, ticketID)
 		if err != nil {
 			fmt.Printf("FAILED (%v)\n", err)
 			failed++
@@ -191,21 +185,10 @@ func runExport(cmd *cobra.Command, args []string) {
 	fmt.Printf("Export complete: %d exported, %d skipped, %d failed\n", exported, skipped, failed)
 }
 
-func fetchTicketIDsFromAPI(apiURL string, statusFilter []string) ([]string, error) {
-	url := fmt.Sprintf("%s/v1/tickets?per_page=1000", apiURL)
+func fetchTicketIDsFromAPI(client *apiclient.Client, statusFilter []string) ([]string, error) {
+	endpoint := "/v1/tickets?per_page=1000"
 	if len(statusFilter) > 0 {
-		url += "&status=" + strings.Join(statusFilter, ",")
-	}
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		endpoint += "&status=" + strings.Join(statusFilter, ",")
 	}
 
 	var result struct {
@@ -214,8 +197,7 @@ func fetchTicketIDsFromAPI(apiURL string, statusFilter []string) ([]string, erro
 			TicketNumber string `json:"ticket_number"`
 		} `json:"tickets"`
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := client.JSON(http.MethodGet, endpoint, nil, &result); err != nil {
 		return nil, err
 	}
 
@@ -230,23 +212,11 @@ func fetchTicketIDsFromAPI(apiURL string, statusFilter []string) ([]string, erro
 	return ids, nil
 }
 
-func fetchTicketFromAPI(apiURL, ticketID string) (map[string]interface{}, error) {
-	resp, err := http.Get(fmt.Sprintf("%s/v1/tickets/%s", apiURL, ticketID))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
-	}
-
+func fetchTicketFromAPI(client *apiclient.Client, ticketID string) (map[string]interface{}, error) {
 	var result struct {
 		Ticket map[string]interface{} `json:"ticket"`
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := client.JSON(http.MethodGet, "/v1/tickets/"+ticketID, nil, &result); err != nil {
 		return nil, err
 	}
 
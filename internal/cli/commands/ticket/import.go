@@ -1,6 +1,7 @@
 package ticket
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,8 +10,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/afterdarksys/adsops-utils/internal/pkg/apiclient"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/ticketnum"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var importCmd = &cobra.Command{
@@ -51,9 +53,6 @@ func init() {
 	importCmd.Flags().String("token", "", "API authentication token (or set CHANGES_API_TOKEN env var)")
 }
 
-// Global token for API requests (set in runImport)
-var apiToken string
-
 func runImport(cmd *cobra.Command, args []string) {
 	importAll, _ := cmd.Flags().GetBool("all")
 	update, _ := cmd.Flags().GetBool("update")
@@ -62,24 +61,10 @@ func runImport(cmd *cobra.Command, args []string) {
 	apiURL, _ := cmd.Flags().GetString("api-url")
 	token, _ := cmd.Flags().GetString("token")
 
-	// Determine API URL
-	if apiURL == "" {
-		apiURL = viper.GetString("api.url")
-	}
-	if apiURL == "" {
-		apiURL = os.Getenv("CHANGES_API_URL")
-	}
-	if apiURL == "" {
-		apiURL = "https://api.changes.afterdarksys.com"
-	}
-
-	// Determine API token
-	apiToken = token
-	if apiToken == "" {
-		apiToken = viper.GetString("api.token")
-	}
-	if apiToken == "" {
-		apiToken = os.Getenv("CHANGES_API_TOKEN")
+	client, err := apiclient.FromConfig(apiclient.ChangesAPI, apiURL, token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
 	// Get tickets directory
@@ -98,7 +83,7 @@ func runImport(cmd *cobra.Command, args []string) {
 			os.Exit(1)
 		}
 		for _, entry := range entries {
-			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") && strings.HasPrefix(entry.Name(), "CHG-") {
+			if ticketnum.IsTicketFile(entry) {
 				files = append(files, filepath.Join(ticketsDir, entry.Name()))
 			}
 		}
@@ -161,7 +146,7 @@ func runImport(cmd *cobra.Command, args []string) {
 		}
 
 		// Check if ticket exists (GET request)
-		exists := checkTicketExists(apiURL, ticketID)
+		exists, etag := checkTicketExists(client, ticketID)
 
 		if exists && !update {
 			fmt.Println("SKIPPED (already exists)")
@@ -172,9 +157,9 @@ func runImport(cmd *cobra.Command, args []string) {
 		// Import or update the ticket
 		var err2 error
 		if exists && update {
-			err2 = updateTicketViaAPI(apiURL, ticketID, data)
+			err2 = updateTicketViaAPI(client, ticketID, etag, data)
 		} else {
-			err2 = createTicketViaAPI(apiURL, data)
+			err2 = createTicketViaAPI(client, data)
 		}
 
 		if err2 != nil {
@@ -195,67 +180,37 @@ func runImport(cmd *cobra.Command, args []string) {
 	fmt.Printf("Import complete: %d imported, %d skipped, %d failed\n", imported, skipped, failed)
 }
 
-func checkTicketExists(apiURL, ticketID string) bool {
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/tickets/%s", apiURL, ticketID), nil)
+// checkTicketExists reports whether the ticket exists, along with its ETag
+// for use as the If-Match precondition on update
+func checkTicketExists(client *apiclient.Client, ticketID string) (bool, string) {
+	req, err := http.NewRequest(http.MethodGet, client.BaseURL+"/v1/tickets/"+ticketID, nil)
 	if err != nil {
-		return false
-	}
-	if apiToken != "" {
-		req.Header.Set("Authorization", "Bearer "+apiToken)
+		return false, ""
 	}
 
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return false
+		return false, ""
 	}
 	defer resp.Body.Close()
-	return resp.StatusCode == http.StatusOK
+	return resp.StatusCode == http.StatusOK, resp.Header.Get("ETag")
 }
 
-func createTicketViaAPI(apiURL string, data []byte) error {
-	req, err := http.NewRequest(
-		http.MethodPost,
-		fmt.Sprintf("%s/v1/tickets", apiURL),
-		strings.NewReader(string(data)),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	if apiToken != "" {
-		req.Header.Set("Authorization", "Bearer "+apiToken)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
-	}
-	return nil
+func createTicketViaAPI(client *apiclient.Client, data []byte) error {
+	_, err := client.Request(http.MethodPost, "/v1/tickets", data)
+	return err
 }
 
-func updateTicketViaAPI(apiURL, ticketID string, data []byte) error {
-	req, err := http.NewRequest(
-		http.MethodPatch,
-		fmt.Sprintf("%s/v1/tickets/%s", apiURL, ticketID),
-		strings.NewReader(string(data)),
-	)
+func updateTicketViaAPI(client *apiclient.Client, ticketID, etag string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPatch, client.BaseURL+"/v1/tickets/"+ticketID, bytes.NewReader(data))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	if apiToken != "" {
-		req.Header.Set("Authorization", "Bearer "+apiToken)
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
 	}
 
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
@@ -0,0 +1,227 @@
+package ticket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link [ticket-number]",
+	Short: "Link a ticket to its dependencies, parent or epic",
+	Long: `Record that a local ticket depends on other tickets, or set its parent
+or epic. Linked tickets must exist in the tickets directory. Links that
+would make a ticket depend on, or be the parent of, itself (directly or
+through other tickets) are refused. Each change is recorded as a comment
+on the ticket.
+
+Examples:
+  # CHG-2025-00002 cannot start until CHG-2025-00001 is done
+  changes ticket link CHG-2025-00002 --depends-on CHG-2025-00001
+
+  # Put a ticket under a parent and an epic
+  changes ticket link CHG-2025-00003 --parent CHG-2025-00002 --epic CHG-2025-00010
+
+  # Clear the parent
+  changes ticket link CHG-2025-00003 --parent ""`,
+	Args: cobra.ExactArgs(1),
+	Run:  runLink,
+}
+
+func init() {
+	linkCmd.Flags().StringSlice("depends-on", []string{}, "Tickets this ticket depends on (repeatable)")
+	linkCmd.Flags().String("parent", "", "Parent ticket (empty to clear)")
+	linkCmd.Flags().String("epic", "", "Epic the ticket belongs to (empty to clear)")
+}
+
+// ticketLinks caches the tickets read while checking links for cycles
+type ticketLinks struct {
+	tickets map[string]*models.TicketFile
+}
+
+func newTicketLinks(t *models.TicketFile) *ticketLinks {
+	return &ticketLinks{tickets: map[string]*models.TicketFile{t.ID: t}}
+}
+
+// get returns a local ticket, reading it on first use
+func (l *ticketLinks) get(id string) (*models.TicketFile, error) {
+	if t, ok := l.tickets[id]; ok {
+		return t, nil
+	}
+	t, err := loadLocalTicket(id)
+	if err != nil {
+		return nil, err
+	}
+	l.tickets[id] = t
+	return t, nil
+}
+
+// dependsOn reports whether from depends on target directly or through
+// other tickets, returning the chain that does. Dependencies that are not
+// in the tickets directory end the walk.
+func (l *ticketLinks) dependsOn(from, target string) []string {
+	seen := make(map[string]bool)
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		if id == target {
+			return []string{id}
+		}
+		if seen[id] {
+			return nil
+		}
+		seen[id] = true
+		t, err := l.get(id)
+		if err != nil {
+			return nil
+		}
+		for _, dep := range t.Dependencies {
+			if chain := walk(strings.ToUpper(dep)); chain != nil {
+				return append([]string{id}, chain...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
+
+// ancestors returns the chain of tickets followed by next from id, stopping
+// at a ticket with no link, one not in the tickets directory, or a loop
+func (l *ticketLinks) ancestors(id string, next func(*models.TicketFile) string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+	for id != "" && !seen[id] {
+		seen[id] = true
+		chain = append(chain, id)
+		t, err := l.get(id)
+		if err != nil {
+			break
+		}
+		id = strings.ToUpper(next(t))
+	}
+	return chain
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if strings.EqualFold(v, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func runLink(cmd *cobra.Command, args []string) {
+	ticketNumber := strings.ToUpper(args[0])
+	dependsOn, _ := cmd.Flags().GetStringSlice("depends-on")
+	parent, _ := cmd.Flags().GetString("parent")
+	epic, _ := cmd.Flags().GetString("epic")
+	setParent := cmd.Flags().Changed("parent")
+	setEpic := cmd.Flags().Changed("epic")
+
+	if len(dependsOn) == 0 && !setParent && !setEpic {
+		fmt.Fprintln(os.Stderr, "Error: give at least one of --depends-on, --parent or --epic")
+		os.Exit(1)
+	}
+
+	ticket, err := loadLocalTicket(ticketNumber)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	links := newTicketLinks(ticket)
+
+	fail := func(format string, a ...interface{}) {
+		fmt.Fprintf(os.Stderr, "Error: "+format+"\n", a...)
+		os.Exit(1)
+	}
+	mustExist := func(id string) {
+		if _, err := links.get(id); err != nil {
+			fail("%v", err)
+		}
+	}
+
+	var changes []string
+
+	for _, dep := range dependsOn {
+		dep = strings.ToUpper(strings.TrimSpace(dep))
+		if dep == ticket.ID {
+			fail("%s cannot depend on itself", ticket.ID)
+		}
+		mustExist(dep)
+		if containsID(ticket.Dependencies, dep) {
+			continue
+		}
+		if chain := links.dependsOn(dep, ticket.ID); chain != nil {
+			fail("%s cannot depend on %s: %s already depends on it (%s)",
+				ticket.ID, dep, dep, strings.Join(chain, " -> "))
+		}
+		ticket.Dependencies = append(ticket.Dependencies, dep)
+		changes = append(changes, "depends on "+dep)
+	}
+
+	if setParent {
+		parent = strings.ToUpper(strings.TrimSpace(parent))
+		switch {
+		case parent == ticket.Parent:
+		case parent == "":
+			changes = append(changes, "parent cleared (was "+ticket.Parent+")")
+		case parent == ticket.ID:
+			fail("%s cannot be its own parent", ticket.ID)
+		default:
+			mustExist(parent)
+			chain := links.ancestors(parent, func(t *models.TicketFile) string { return t.Parent })
+			if containsID(chain, ticket.ID) {
+				fail("%s cannot have parent %s: %s is already an ancestor of it (%s)",
+					ticket.ID, parent, ticket.ID, strings.Join(chain, " -> "))
+			}
+			changes = append(changes, "parent set to "+parent)
+		}
+		ticket.Parent = parent
+	}
+
+	if setEpic {
+		epic = strings.ToUpper(strings.TrimSpace(epic))
+		switch {
+		case epic == ticket.Epic:
+		case epic == "":
+			changes = append(changes, "epic cleared (was "+ticket.Epic+")")
+		case epic == ticket.ID:
+			fail("%s cannot be its own epic", ticket.ID)
+		default:
+			mustExist(epic)
+			chain := links.ancestors(epic, func(t *models.TicketFile) string { return t.Epic })
+			if containsID(chain, ticket.ID) {
+				fail("%s cannot have epic %s: it is already the epic of %s (%s)",
+					ticket.ID, epic, epic, strings.Join(chain, " -> "))
+			}
+			changes = append(changes, "epic set to "+epic)
+		}
+		ticket.Epic = epic
+	}
+
+	if len(changes) == 0 {
+		fmt.Printf("Ticket %s is already linked as requested\n", ticket.ID)
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	ticket.UpdatedAt = now
+	ticket.Comments = append(ticket.Comments, models.TicketFileComment{
+		Author:    currentUserEmail(),
+		Timestamp: now,
+		Text:      "Links: " + strings.Join(changes, "; ") + ".",
+	})
+
+	if err := saveTicket(ticket); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving ticket: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, c := range changes {
+		fmt.Printf("%s: %s\n", ticket.ID, c)
+	}
+}
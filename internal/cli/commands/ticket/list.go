@@ -1,41 +1,29 @@
 package ticket
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 	"text/tabwriter"
-	"time"
 
 	"github.com/spf13/cobra"
 )
 
-// LocalTicket represents a ticket stored in local JSON files
-type LocalTicket struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	Priority    string    `json:"priority"`
-	Risk        string    `json:"risk"`
-	Type        string    `json:"type"`
-	Industry    string    `json:"industry"`
-	CreatedBy   string    `json:"created_by"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	Assignee    string    `json:"assignee"`
-	Sprint      string    `json:"sprint"`
-}
-
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "List change tickets",
 	Long: `List change tickets with optional filters.
 
+--sort orders by created_at (the default), updated_at, priority, status,
+risk, title, assignee or sprint; --desc (on by default) reverses it.
+Ascending, priority runs emergency to low, risk critical to low, and
+status follows the workflow: draft, submitted, in_review,
+update_requested, partially_approved, approved, denied, implementing,
+completed, closed, cancelled. Unrecognized values sort last. Title,
+assignee and sprint sort alphabetically, unset values first.
+
 Examples:
   # List all tickets
   changes ticket list
@@ -50,7 +38,14 @@ Examples:
   changes ticket list --mine
 
   # List tickets with JSON output
-  changes ticket list --output json`,
+  changes ticket list --output json
+
+  # Triage: unassigned work first, then by workflow status
+  changes ticket list --sort assignee --desc=false
+  changes ticket list --sort status --desc=false
+
+  # Any 10 open tickets, without scanning the whole directory
+  changes ticket list --status open --sort none --limit 10`,
 	Run: runList,
 }
 
@@ -61,7 +56,7 @@ func init() {
 	listCmd.Flags().Bool("mine", false, "Show only tickets created by me")
 	listCmd.Flags().Bool("assigned", false, "Show only tickets assigned to me")
 	listCmd.Flags().Int("limit", 50, "Maximum number of tickets to display")
-	listCmd.Flags().String("sort", "created_at", "Sort field (created_at, updated_at, priority)")
+	listCmd.Flags().String("sort", "created_at", "Sort field (created_at, updated_at, priority, status, risk, title, assignee, sprint, or none for directory order)")
 	listCmd.Flags().Bool("desc", true, "Sort descending")
 }
 
@@ -84,37 +79,6 @@ func getTicketsDir() string {
 	return "tickets"
 }
 
-// loadLocalTickets loads tickets from the local tickets directory
-func loadLocalTickets() ([]LocalTicket, error) {
-	ticketsDir := getTicketsDir()
-
-	entries, err := os.ReadDir(ticketsDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read tickets directory: %w", err)
-	}
-
-	var tickets []LocalTicket
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
-			continue
-		}
-
-		data, err := os.ReadFile(filepath.Join(ticketsDir, entry.Name()))
-		if err != nil {
-			continue
-		}
-
-		var ticket LocalTicket
-		if err := json.Unmarshal(data, &ticket); err != nil {
-			continue
-		}
-
-		tickets = append(tickets, ticket)
-	}
-
-	return tickets, nil
-}
-
 func runList(cmd *cobra.Command, args []string) {
 	statusFilter, _ := cmd.Flags().GetStringSlice("status")
 	priorityFilter, _ := cmd.Flags().GetStringSlice("priority")
@@ -122,67 +86,19 @@ func runList(cmd *cobra.Command, args []string) {
 	sortField, _ := cmd.Flags().GetString("sort")
 	descending, _ := cmd.Flags().GetBool("desc")
 
-	tickets, err := loadLocalTickets()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading tickets: %v\n", err)
+	sortField = strings.ToLower(strings.TrimSpace(sortField))
+	if !validTicketSortField(sortField) {
+		fmt.Fprintf(os.Stderr, "Error: unknown --sort field %q (use one of: %s)\n", sortField, strings.Join(ticketSortFields, ", "))
 		os.Exit(1)
 	}
 
-	// Apply filters
-	var filtered []LocalTicket
-	for _, t := range tickets {
-		// Status filter
-		if len(statusFilter) > 0 {
-			match := false
-			for _, s := range statusFilter {
-				if strings.EqualFold(t.Status, s) {
-					match = true
-					break
-				}
-			}
-			if !match {
-				continue
-			}
-		}
-
-		// Priority filter
-		if len(priorityFilter) > 0 {
-			match := false
-			for _, p := range priorityFilter {
-				if strings.EqualFold(t.Priority, p) {
-					match = true
-					break
-				}
-			}
-			if !match {
-				continue
-			}
-		}
-
-		filtered = append(filtered, t)
+	filter := func(t ticketSummary) bool {
+		return matchesAny(t.Status, statusFilter) && matchesAny(t.Priority, priorityFilter)
 	}
-
-	// Sort
-	sort.Slice(filtered, func(i, j int) bool {
-		var less bool
-		switch sortField {
-		case "priority":
-			priorityOrder := map[string]int{"emergency": 0, "urgent": 1, "high": 2, "normal": 3, "low": 4}
-			less = priorityOrder[strings.ToLower(filtered[i].Priority)] < priorityOrder[strings.ToLower(filtered[j].Priority)]
-		case "updated_at":
-			less = filtered[i].UpdatedAt.Before(filtered[j].UpdatedAt)
-		default: // created_at
-			less = filtered[i].CreatedAt.Before(filtered[j].CreatedAt)
-		}
-		if descending {
-			return !less
-		}
-		return less
-	})
-
-	// Apply limit
-	if limit > 0 && len(filtered) > limit {
-		filtered = filtered[:limit]
+	filtered, err := findLocalTickets(filter, ticketOrder(sortField, descending), limit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading tickets: %v\n", err)
+		os.Exit(1)
 	}
 
 	// Output
@@ -206,3 +122,17 @@ func runList(cmd *cobra.Command, args []string) {
 		fmt.Printf("\n%d ticket(s) found.\n", len(filtered))
 	}
 }
+
+// matchesAny reports whether value equals one of filters, ignoring case.
+// An empty filter list matches everything.
+func matchesAny(value string, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		if strings.EqualFold(value, f) {
+			return true
+		}
+	}
+	return false
+}
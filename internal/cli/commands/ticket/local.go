@@ -0,0 +1,264 @@
+package ticket
+
+import (
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/ticketnum"
+)
+
+// readDirBatch is the number of directory entries read at a time
+const readDirBatch = 256
+
+// ticketSummary holds the ticket file fields needed to filter, sort and
+// list tickets. Decoding into it skips the description, plans and comments.
+type ticketSummary struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Priority  string    `json:"priority"`
+	Risk      string    `json:"risk"`
+	CreatedBy string    `json:"created_by"`
+	Assignee  *string   `json:"assignee"`
+	Sprint    string    `json:"sprint"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// assignee returns the assignee's email, or "" when unassigned
+func (t ticketSummary) assignee() string {
+	if t.Assignee == nil {
+		return ""
+	}
+	return *t.Assignee
+}
+
+// walkLocalTickets calls fn with the path and summary of each ticket file
+// in the tickets directory. Entries are read in batches, in directory
+// order, so the directory is never listed in full. Files that cannot be
+// read or parsed are skipped. Iteration stops when fn returns false.
+func walkLocalTickets(fn func(path string, t ticketSummary) bool) error {
+	ticketsDir := getTicketsDir()
+
+	dir, err := os.Open(ticketsDir)
+	if err != nil {
+		return fmt.Errorf("failed to read tickets directory: %w", err)
+	}
+	defer dir.Close()
+
+	for {
+		entries, err := dir.ReadDir(readDirBatch)
+		for _, entry := range entries {
+			if !ticketnum.IsTicketFile(entry) {
+				continue
+			}
+
+			path := filepath.Join(ticketsDir, entry.Name())
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			var t ticketSummary
+			if err := json.Unmarshal(data, &t); err != nil {
+				continue
+			}
+			if !fn(path, t) {
+				return nil
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tickets directory: %w", err)
+		}
+	}
+}
+
+// priorityRank orders priorities from most to least urgent
+var priorityRank = map[string]int{"emergency": 0, "urgent": 1, "high": 2, "normal": 3, "low": 4}
+
+// riskRank orders risk levels from most to least severe
+var riskRank = map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3}
+
+// statusRank orders statuses along the ticket workflow, from draft through
+// review and implementation to the closed states
+var statusRank = map[string]int{
+	"draft":              0,
+	"submitted":          1,
+	"in_review":          2,
+	"update_requested":   3,
+	"partially_approved": 4,
+	"approved":           5,
+	"denied":             6,
+	"implementing":       7,
+	"completed":          8,
+	"closed":             9,
+	"cancelled":          10,
+}
+
+// ticketSortFields are the accepted --sort values, in the order they are
+// listed in errors
+var ticketSortFields = []string{"created_at", "updated_at", "priority", "status", "risk", "title", "assignee", "sprint", "none"}
+
+// validTicketSortField reports whether field is one of ticketSortFields
+func validTicketSortField(field string) bool {
+	for _, f := range ticketSortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// compareRank compares two values by their rank, ignoring case. Values
+// missing from rank sort after every ranked one.
+func compareRank(rank map[string]int, a, b string) int {
+	ra, ok := rank[strings.ToLower(a)]
+	if !ok {
+		ra = len(rank)
+	}
+	rb, ok := rank[strings.ToLower(b)]
+	if !ok {
+		rb = len(rank)
+	}
+	return ra - rb
+}
+
+// ticketOrder returns the comparison for a --sort field (one of
+// ticketSortFields), with ties broken by ticket ID. Priority, risk and
+// status sort by priorityRank, riskRank and statusRank; title, assignee
+// and sprint compare case-insensitively, with unset values first. It
+// returns nil for "none", which keeps directory order.
+func ticketOrder(field string, descending bool) func(a, b ticketSummary) bool {
+	if field == "none" {
+		return nil
+	}
+
+	compare := func(a, b ticketSummary) int {
+		switch field {
+		case "priority":
+			return compareRank(priorityRank, a.Priority, b.Priority)
+		case "risk":
+			return compareRank(riskRank, a.Risk, b.Risk)
+		case "status":
+			return compareRank(statusRank, a.Status, b.Status)
+		case "title":
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		case "assignee":
+			return strings.Compare(strings.ToLower(a.assignee()), strings.ToLower(b.assignee()))
+		case "sprint":
+			return strings.Compare(strings.ToLower(a.Sprint), strings.ToLower(b.Sprint))
+		case "updated_at":
+			return a.UpdatedAt.Compare(b.UpdatedAt)
+		default:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+	}
+
+	return func(a, b ticketSummary) bool {
+		c := compare(a, b)
+		if c == 0 {
+			c = strings.Compare(a.ID, b.ID)
+		}
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	}
+}
+
+// findLocalTickets returns the summaries of the tickets matching filter,
+// ordered by before and cut to limit when it is positive. Without an order
+// the walk stops as soon as limit tickets match; with one, only the best
+// limit summaries seen so far are kept.
+func findLocalTickets(filter func(ticketSummary) bool, before func(a, b ticketSummary) bool, limit int) ([]ticketSummary, error) {
+	var found []ticketSummary
+	top := &summaryHeap{before: before}
+
+	err := walkLocalTickets(func(_ string, t ticketSummary) bool {
+		if filter != nil && !filter(t) {
+			return true
+		}
+		switch {
+		case before == nil:
+			found = append(found, t)
+			return limit <= 0 || len(found) < limit
+		case limit <= 0 || top.Len() < limit:
+			heap.Push(top, t)
+		case before(t, top.items[0]):
+			top.items[0] = t
+			heap.Fix(top, 0)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if before == nil {
+		return found, nil
+	}
+	found = top.items
+	sort.Slice(found, func(i, j int) bool { return before(found[i], found[j]) })
+	return found, nil
+}
+
+// summaryHeap keeps the ticket that sorts last at its root, so it is the
+// one replaced when a better ticket turns up
+type summaryHeap struct {
+	items  []ticketSummary
+	before func(a, b ticketSummary) bool
+}
+
+func (h *summaryHeap) Len() int           { return len(h.items) }
+func (h *summaryHeap) Less(i, j int) bool { return h.before(h.items[j], h.items[i]) }
+func (h *summaryHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *summaryHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(ticketSummary))
+}
+
+func (h *summaryHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// loadLocalTicket reads the ticket file with the given ID from the tickets
+// directory
+func loadLocalTicket(id string) (*models.TicketFile, error) {
+	path := filepath.Join(getTicketsDir(), id+".json")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("ticket %s not found in %s", id, getTicketsDir())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var t models.TicketFile
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &t, nil
+}
+
+// currentUserEmail returns the email recorded as the author of local
+// changes, derived from $USER
+func currentUserEmail() string {
+	user := os.Getenv("USER")
+	if user == "" {
+		user = "unknown"
+	}
+	return user + "@afterdarksys.com"
+}
@@ -0,0 +1,140 @@
+package ticket
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// writeTestTickets writes n ticket files to a tickets directory under a
+// temporary working directory, the way getTicketsDir finds them. Creation
+// times are spread out and out of ID order so sorting has work to do.
+func writeTestTickets(tb testing.TB, n int) {
+	tb.Helper()
+
+	root := tb.TempDir()
+	dir := filepath.Join(root, "tickets")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		tb.Fatal(err)
+	}
+
+	statuses := []string{"draft", "submitted", "approved", "closed"}
+	priorities := []string{"low", "normal", "high", "urgent"}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("CHG-2025-%05d", i+1)
+		t := models.NewTicketFile(id, start.Add(time.Duration(i*7919%n)*time.Hour))
+		t.Title = fmt.Sprintf("Change %d", i+1)
+		t.Description = strings.Repeat("Rollout step. ", 100)
+		t.Status = statuses[i%len(statuses)]
+		t.Priority = priorities[i%len(priorities)]
+		t.Risk = "medium"
+		t.CreatedBy = "ops@example.com"
+
+		data, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, id+".json"), data, 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestFindLocalTicketsSkipsHiddenFiles(t *testing.T) {
+	writeTestTickets(t, 3)
+	state := filepath.Join(getTicketsDir(), ".gh-migration-state.json")
+	if err := os.WriteFile(state, []byte(`{"issues":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tickets, err := findLocalTickets(nil, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 3 {
+		t.Errorf("found %d tickets, want 3 without the migration state", len(tickets))
+	}
+}
+
+func TestFindLocalTicketsTopN(t *testing.T) {
+	writeTestTickets(t, 500)
+
+	before := ticketOrder("created_at", true)
+	all, err := findLocalTickets(nil, before, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 500 {
+		t.Fatalf("found %d tickets, want 500", len(all))
+	}
+	if !sort.SliceIsSorted(all, func(i, j int) bool { return before(all[i], all[j]) }) {
+		t.Fatal("tickets are not sorted newest first")
+	}
+
+	top, err := findLocalTickets(nil, before, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 20 {
+		t.Fatalf("found %d tickets with limit 20", len(top))
+	}
+	for i := range top {
+		if top[i].ID != all[i].ID {
+			t.Fatalf("top[%d] = %s, want %s", i, top[i].ID, all[i].ID)
+		}
+	}
+
+	drafts, err := findLocalTickets(func(s ticketSummary) bool { return s.Status == "draft" }, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drafts) != 10 {
+		t.Fatalf("found %d drafts with limit 10", len(drafts))
+	}
+	for _, d := range drafts {
+		if d.Status != "draft" {
+			t.Errorf("%s has status %s, want draft", d.ID, d.Status)
+		}
+	}
+}
+
+func BenchmarkLoadLocalTickets(b *testing.B) {
+	writeTestTickets(b, 5000)
+
+	benchmarks := []struct {
+		name   string
+		before func(a, b ticketSummary) bool
+		limit  int
+	}{
+		{"all/sorted", ticketOrder("created_at", true), 0},
+		{"top50/sorted", ticketOrder("created_at", true), 50},
+		{"first50/unsorted", nil, 50},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := findLocalTickets(nil, bm.before, bm.limit); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
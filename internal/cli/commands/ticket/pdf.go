@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/afterdarksys/adsops-utils/internal/pkg/ticketnum"
 	"github.com/spf13/cobra"
 )
 
@@ -59,7 +60,7 @@ func runPDF(cmd *cobra.Command, args []string) {
 			os.Exit(1)
 		}
 		for _, entry := range entries {
-			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") && strings.HasPrefix(entry.Name(), "CHG-") {
+			if ticketnum.IsTicketFile(entry) {
 				files = append(files, filepath.Join(ticketsDir, entry.Name()))
 			}
 		}
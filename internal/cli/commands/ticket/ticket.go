@@ -27,17 +27,29 @@ Examples:
   # Edit a ticket
   changes ticket edit CHG-2025-00001
 
+  # Assign a ticket to a teammate
+  changes ticket assign CHG-2025-00001 jane@example.com
+
   # Submit a draft ticket for approval
   changes ticket submit CHG-2025-00001
 
+  # Record that a ticket depends on another
+  changes ticket link CHG-2025-00002 --depends-on CHG-2025-00001
+
   # Close a completed ticket
   changes ticket close CHG-2025-00001
 
+  # Close every completed ticket
+  changes ticket bulk-transition --filter status=completed --to closed
+
   # Import tickets from JSON files
   changes ticket import --all
 
   # Export tickets to JSON or PDF
-  changes ticket export CHG-2025-00001 --format pdf`,
+  changes ticket export CHG-2025-00001 --format pdf
+
+  # List the valid priorities, industries, compliance frameworks, ...
+  changes ticket enums`,
 }
 
 func init() {
@@ -46,11 +58,15 @@ func init() {
 	TicketCmd.AddCommand(listCmd)
 	TicketCmd.AddCommand(viewCmd)
 	TicketCmd.AddCommand(editCmd)
+	TicketCmd.AddCommand(assignCmd)
+	TicketCmd.AddCommand(linkCmd)
 	TicketCmd.AddCommand(submitCmd)
 	TicketCmd.AddCommand(closeCmd)
 	TicketCmd.AddCommand(openCmd)
 	TicketCmd.AddCommand(cancelCmd)
 	TicketCmd.AddCommand(importCmd)
 	TicketCmd.AddCommand(exportCmd)
+	TicketCmd.AddCommand(enumsCmd)
+	TicketCmd.AddCommand(bulkTransitionCmd)
 	// pdfCmd is registered in pdf.go init()
 }
@@ -2,13 +2,16 @@ package ticket
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var viewCmd = &cobra.Command{
-	Use:   "view [ticket-number]",
-	Short: "View a change ticket",
+	Use:     "view [ticket-number]",
+	Aliases: []string{"show"},
+	Short:   "View a change ticket",
 	Long: `View detailed information about a specific change ticket.
 
 Examples:
@@ -35,34 +38,121 @@ func init() {
 }
 
 func runView(cmd *cobra.Command, args []string) {
-	ticketNumber := args[0]
+	ticketNumber := strings.ToUpper(args[0])
+	showApprovals, _ := cmd.Flags().GetBool("approvals")
+	showComments, _ := cmd.Flags().GetBool("comments")
+	showAudit, _ := cmd.Flags().GetBool("audit")
+	showRevisions, _ := cmd.Flags().GetBool("revisions")
 
-	// TODO: Implement API call to get ticket details
+	t, err := loadLocalTicket(ticketNumber)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
-	fmt.Printf("Ticket: %s\n", ticketNumber)
+	assignee := "Unassigned"
+	if t.Assignee != nil && *t.Assignee != "" {
+		assignee = *t.Assignee
+	}
+
+	fmt.Printf("Ticket: %s\n", t.ID)
 	fmt.Println("========================================")
 	fmt.Println()
-	fmt.Println("Title:       Database schema update")
-	fmt.Println("Status:      submitted")
-	fmt.Println("Priority:    high")
-	fmt.Println("Risk Level:  medium")
-	fmt.Println()
-	fmt.Println("Industry:    Finance")
-	fmt.Println("Compliance:  GLBA, SOX")
-	fmt.Println()
-	fmt.Println("Description:")
-	fmt.Println("  Adding new index to improve query performance")
-	fmt.Println("  on the transactions table.")
-	fmt.Println()
-	fmt.Println("Affected Systems:")
-	fmt.Println("  - PostgreSQL primary database")
-	fmt.Println("  - Transaction processing service")
+	fmt.Printf("Title:       %s\n", t.Title)
+	fmt.Printf("Status:      %s\n", t.Status)
+	fmt.Printf("Priority:    %s\n", t.Priority)
+	fmt.Printf("Risk Level:  %s\n", t.Risk)
+	fmt.Printf("Assignee:    %s\n", assignee)
 	fmt.Println()
+	if t.Industry != "" {
+		fmt.Printf("Industry:    %s\n", t.Industry)
+	}
+	if len(t.ComplianceFrameworks) > 0 {
+		fmt.Printf("Compliance:  %s\n", strings.ToUpper(strings.Join(t.ComplianceFrameworks, ", ")))
+	}
+	if t.Industry != "" || len(t.ComplianceFrameworks) > 0 {
+		fmt.Println()
+	}
+
+	if t.Description != "" {
+		fmt.Println("Description:")
+		for _, line := range strings.Split(strings.TrimSpace(t.Description), "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+		fmt.Println()
+	}
+
+	if t.Parent != "" {
+		fmt.Printf("Parent:      %s\n", t.Parent)
+	}
+	if t.Epic != "" {
+		fmt.Printf("Epic:        %s\n", t.Epic)
+	}
+	if t.Parent != "" || t.Epic != "" {
+		fmt.Println()
+	}
+	printList("Depends On:", t.Dependencies)
+	printList("Affected Systems:", t.AffectedSystems)
+	printList("Attachments:", t.Attachments)
+
 	fmt.Println("Approvals Required:")
-	fmt.Println("  [ ] Operations Approval")
-	fmt.Println("  [ ] IT Approval")
-	fmt.Println("  [ ] Security Approval")
+	if len(t.ApprovalsRequired) == 0 {
+		fmt.Println("  (none)")
+	}
+	approved := make(map[string]bool, len(t.Approvals))
+	for _, a := range t.Approvals {
+		approved[a] = true
+	}
+	for _, a := range t.ApprovalsRequired {
+		mark := " "
+		if approved[a] {
+			mark = "x"
+		}
+		fmt.Printf("  [%s] %s\n", mark, a)
+	}
+	fmt.Println()
+
+	fmt.Printf("Created:     %s\n", t.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	fmt.Printf("Created By:  %s\n", t.CreatedBy)
+	fmt.Printf("Updated:     %s\n", t.UpdatedAt.Format("2006-01-02 15:04:05 MST"))
+
+	if showApprovals {
+		fmt.Println()
+		fmt.Println("Approval History:")
+		if len(t.Approvals) == 0 {
+			fmt.Println("  (no approvals yet)")
+		}
+		for _, a := range t.Approvals {
+			fmt.Printf("  - %s approved\n", a)
+		}
+	}
+
+	if showComments {
+		fmt.Println()
+		fmt.Printf("Comments (%d):\n", len(t.Comments))
+		for _, c := range t.Comments {
+			fmt.Printf("  [%s] %s\n", c.Timestamp.Format("2006-01-02 15:04"), c.Author)
+			for _, line := range strings.Split(strings.TrimSpace(c.Text), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+
+	if showAudit || showRevisions {
+		fmt.Println()
+		fmt.Println("Audit trail and revision history are not stored with local tickets.")
+	}
+}
+
+// printList prints a heading and one "- item" line per entry, followed by
+// a blank line; nothing is printed for an empty list
+func printList(heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Println(heading)
+	for _, item := range items {
+		fmt.Printf("  - %s\n", item)
+	}
 	fmt.Println()
-	fmt.Println("Created:     2025-12-20 10:30:00 UTC")
-	fmt.Println("Created By:  John Doe <john@example.com>")
 }
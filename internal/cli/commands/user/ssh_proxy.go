@@ -1,17 +1,15 @@
 package user
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/afterdarksys/adsops-utils/internal/pkg/apiclient"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var sshAccessCmd = &cobra.Command{
@@ -43,58 +41,19 @@ func init() {
 	sshAccessCmd.AddCommand(sshStatusCmd)
 }
 
-// API client helper
-func getAPIClient() (*http.Client, string, error) {
-	baseURL := viper.GetString("login_api_url")
-	if baseURL == "" {
-		baseURL = "https://login.afterdarksys.com"
-	}
-
-	token := viper.GetString("auth_token")
-	if token == "" {
-		// Try to read from file
-		tokenFile := os.ExpandEnv("$HOME/.config/afterdark/token")
-		data, err := os.ReadFile(tokenFile)
-		if err != nil {
-			return nil, "", fmt.Errorf("not authenticated. Run 'changes auth login' first")
-		}
-		token = strings.TrimSpace(string(data))
-	}
-
-	return &http.Client{}, baseURL, nil
+// newAPIClient returns a client for the login API, authenticated with the
+// auth_token config value or the token saved by 'changes auth login'
+func newAPIClient() (*apiclient.Client, error) {
+	return apiclient.FromConfig(apiclient.LoginAPI, "", "")
 }
 
-func makeAPIRequest(method, endpoint string, body interface{}) (*http.Response, error) {
-	client, baseURL, err := getAPIClient()
-	if err != nil {
-		return nil, err
-	}
-
-	token := viper.GetString("auth_token")
-	if token == "" {
-		tokenFile := os.ExpandEnv("$HOME/.config/afterdark/token")
-		data, _ := os.ReadFile(tokenFile)
-		token = strings.TrimSpace(string(data))
-	}
-
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, err
-		}
-		reqBody = bytes.NewBuffer(jsonBody)
-	}
-
-	req, err := http.NewRequest(method, baseURL+endpoint, reqBody)
+// callAPI sends body as JSON and decodes the response into out
+func callAPI(method, endpoint string, body, out interface{}) error {
+	client, err := newAPIClient()
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	return client.Do(req)
+	return client.JSON(method, endpoint, body, out)
 }
 
 // Grant SSH proxy access
@@ -119,33 +78,23 @@ func runSSHGrant(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Granting SSH proxy access to %s...\n", email)
 
-	resp, err := makeAPIRequest("POST", "/api/admin/ssh-proxy-access", map[string]string{
+	err := callAPI("POST", "/api/admin/ssh-proxy-access", map[string]string{
 		"email": email,
-	})
+	}, nil)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-	defer resp.Body.Close()
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
-		os.Exit(1)
-	}
-
-	if resp.StatusCode != 200 {
-		errMsg := result["error"]
-		if errMsg == nil {
-			errMsg = "Unknown error"
-		}
-		fmt.Fprintf(os.Stderr, "Error: %v\n", errMsg)
-		os.Exit(1)
-	}
 
 	fmt.Printf("SSH proxy access granted to %s\n", email)
 }
 
+// RevokeSSHAccess removes a user's SSH proxy access, ending their active
+// sessions
+func RevokeSSHAccess(email string) error {
+	return callAPI("DELETE", "/api/admin/ssh-proxy-access/"+url.PathEscape(email), nil, nil)
+}
+
 // Revoke SSH proxy access
 var sshRevokeCmd = &cobra.Command{
 	Use:   "revoke [email]",
@@ -168,27 +117,10 @@ func runSSHRevoke(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Revoking SSH proxy access from %s...\n", email)
 
-	resp, err := makeAPIRequest("DELETE", "/api/admin/ssh-proxy-access/"+email, nil)
-	if err != nil {
+	if err := RevokeSSHAccess(email); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-	defer resp.Body.Close()
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
-		os.Exit(1)
-	}
-
-	if resp.StatusCode != 200 {
-		errMsg := result["error"]
-		if errMsg == nil {
-			errMsg = "Unknown error"
-		}
-		fmt.Fprintf(os.Stderr, "Error: %v\n", errMsg)
-		os.Exit(1)
-	}
 
 	fmt.Printf("SSH proxy access revoked from %s\n", email)
 }
@@ -211,13 +143,6 @@ Examples:
 }
 
 func runSSHList(cmd *cobra.Command, args []string) {
-	resp, err := makeAPIRequest("GET", "/api/admin/ssh-proxy-access", nil)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		Success bool `json:"success"`
 		Users   []struct {
@@ -229,16 +154,9 @@ func runSSHList(cmd *cobra.Command, args []string) {
 			UpdatedAt      string `json:"updated_at"`
 			UpdatedBy      string `json:"updated_by"`
 		} `json:"users"`
-		Error string `json:"error"`
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
-		os.Exit(1)
-	}
-
-	if resp.StatusCode != 200 {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", result.Error)
+	if err := callAPI("GET", "/api/admin/ssh-proxy-access", nil, &result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -292,32 +210,18 @@ Examples:
 func runSSHStatus(cmd *cobra.Command, args []string) {
 	email := args[0]
 
-	resp, err := makeAPIRequest("GET", "/api/admin/ssh-proxy-access/"+email, nil)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		Success        bool   `json:"success"`
 		Email          string `json:"email"`
 		SSHProxyAccess bool   `json:"ssh_proxy_access"`
-		Error          string `json:"error"`
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
-		os.Exit(1)
-	}
-
-	if resp.StatusCode == 404 {
+	err := callAPI("GET", "/api/admin/ssh-proxy-access/"+url.PathEscape(email), nil, &result)
+	if apiclient.StatusCode(err) == http.StatusNotFound {
 		fmt.Fprintf(os.Stderr, "User not found: %s\n", email)
 		os.Exit(1)
 	}
-
-	if resp.StatusCode != 200 {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", result.Error)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
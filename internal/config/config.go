@@ -31,6 +31,9 @@ type Config struct {
 
 	// Email
 	Email EmailConfig `mapstructure:"email"`
+
+	// Webhooks
+	Webhooks WebhookConfig `mapstructure:"webhooks"`
 }
 
 // DatabaseConfig holds database configuration
@@ -116,6 +119,21 @@ type EmailConfig struct {
 	CompanyName string `mapstructure:"company_name"`
 }
 
+// WebhookConfig holds outbound webhook configuration
+type WebhookConfig struct {
+	Enabled        bool              `mapstructure:"enabled"`
+	Endpoints      []WebhookEndpoint `mapstructure:"endpoints"`
+	MaxRetries     int               `mapstructure:"max_retries"`
+	TimeoutSeconds int               `mapstructure:"timeout_seconds"`
+}
+
+// WebhookEndpoint holds configuration for a single webhook receiver
+type WebhookEndpoint struct {
+	URL    string   `mapstructure:"url"`
+	Secret string   `mapstructure:"secret"`
+	Events []string `mapstructure:"events"` // empty means all events
+}
+
 // Load loads configuration from environment and config files
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
@@ -140,6 +158,9 @@ func Load() (*Config, error) {
 	viper.SetDefault("jwt.refresh_token_duration", 7)
 	viper.SetDefault("jwt.issuer", "changes.afterdarksys.com")
 	viper.SetDefault("aws.region", "us-east-1")
+	viper.SetDefault("webhooks.enabled", false)
+	viper.SetDefault("webhooks.max_retries", 3)
+	viper.SetDefault("webhooks.timeout_seconds", 10)
 
 	// Environment variable bindings
 	viper.SetEnvPrefix("ADSOPS")
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/afterdarksys/adsops-utils/internal/pkg/clock"
 	"github.com/google/uuid"
 )
 
@@ -53,6 +54,38 @@ func (t TicketACLRole) CanEdit() bool {
 	return false
 }
 
+// CanViewConfidential returns true if the role may see the full contents of
+// confidential tickets
+func (t TicketACLRole) CanViewConfidential() bool {
+	switch t {
+	case TicketACLRoleEditor, TicketACLRoleOwner, TicketACLRoleAdmin,
+		TicketACLRoleManagement, TicketACLRoleLegal, TicketACLRoleAuditor:
+		return true
+	}
+	return false
+}
+
+// Rank orders roles by privilege so the strongest of several grants wins
+func (t TicketACLRole) Rank() int {
+	switch t {
+	case TicketACLRoleViewer:
+		return 1
+	case TicketACLRoleCommenter:
+		return 2
+	case TicketACLRoleAuditor, TicketACLRoleLegal:
+		return 3
+	case TicketACLRoleEditor:
+		return 4
+	case TicketACLRoleManagement:
+		return 5
+	case TicketACLRoleOwner:
+		return 6
+	case TicketACLRoleAdmin:
+		return 7
+	}
+	return 0
+}
+
 // CanManageACLs returns true if the role can manage ticket ACLs
 func (t TicketACLRole) CanManageACLs() bool {
 	switch t {
@@ -98,7 +131,7 @@ type TicketACL struct {
 	Reason        *string       `db:"reason" json:"reason,omitempty"`
 	CreatedAt     time.Time     `db:"created_at" json:"created_at"`
 	RevokedAt     *time.Time    `db:"revoked_at" json:"revoked_at,omitempty"`
-	RevokedBy     *uuid.UUID    `db:"revoked_by" json:"revoked_by,omitempty"`
+	RevokedBy     *uuid.UUID    `db:"revoked_by" json:"revoked_by,omitempty"` // Nil on a revoked ACL when it was swept after expiring
 
 	// Relationships
 	GrantedByUser *UserSummary  `db:"-" json:"granted_by_user,omitempty"`
@@ -107,17 +140,45 @@ type TicketACL struct {
 	PrincipalGroup *GroupSummary `db:"-" json:"principal_group,omitempty"` // If principal_type is group
 }
 
-// IsActive returns true if the ACL is currently active
-func (a *TicketACL) IsActive() bool {
+// IsActive returns true if the ACL is active according to clk
+func (a *TicketACL) IsActive(clk clock.Clock) bool {
+	return a.IsActiveAt(clk.Now())
+}
+
+// IsActiveAt returns true if the ACL is active at now
+func (a *TicketACL) IsActiveAt(now time.Time) bool {
 	if a.RevokedAt != nil {
 		return false
 	}
-	if a.ExpiresAt != nil && a.ExpiresAt.Before(time.Now()) {
+	if a.ExpiresAt != nil && a.ExpiresAt.Before(now) {
 		return false
 	}
 	return true
 }
 
+// ExpiresWithin returns true if the ACL is active at now and expires no
+// later than now+d
+func (a *TicketACL) ExpiresWithin(now time.Time, d time.Duration) bool {
+	return a.IsActiveAt(now) && a.ExpiresAt != nil && !a.ExpiresAt.After(now.Add(d))
+}
+
+// ExpiringACL describes a temporary grant that is about to lapse, for access
+// review. The ACL carries the principal and the reason it was granted.
+type ExpiringACL struct {
+	Ticket           TicketSummary `json:"ticket"`
+	ACL              TicketACL     `json:"acl"`
+	ExpiresInSeconds int64         `json:"expires_in_seconds"`
+}
+
+// NewExpiringACL builds the expiring view of a ticket's ACL as of now
+func NewExpiringACL(t *Ticket, acl TicketACL, now time.Time) ExpiringACL {
+	expiring := ExpiringACL{Ticket: t.ToSummary(), ACL: acl}
+	if acl.ExpiresAt != nil {
+		expiring.ExpiresInSeconds = int64(acl.ExpiresAt.Sub(now) / time.Second)
+	}
+	return expiring
+}
+
 // GrantTicketACLInput represents input for granting access to a ticket
 type GrantTicketACLInput struct {
 	TicketID      uuid.UUID     `json:"ticket_id" validate:"required"`
@@ -197,6 +258,7 @@ type AuditLogFilter struct {
 	ActionCategory       *string    `json:"action_category,omitempty"`
 	IsComplianceRelevant *bool      `json:"is_compliance_relevant,omitempty"`
 	RequiresReview       *bool      `json:"requires_review,omitempty"`
+	Framework            *ComplianceFramework `json:"framework,omitempty"`
 	FromDate             *time.Time `json:"from_date,omitempty"`
 	ToDate               *time.Time `json:"to_date,omitempty"`
 	Page                 int        `json:"page" validate:"min=1"`
@@ -265,3 +327,16 @@ type CollectContactInfoInput struct {
 type ResolveFailedSignupInput struct {
 	Resolution string `json:"resolution" validate:"required"`
 }
+
+// AuditReport summarizes ticket audit log entries for a reporting period
+type AuditReport struct {
+	OrganizationID uuid.UUID        `json:"organization_id"`
+	FromDate       *time.Time       `json:"from_date,omitempty"`
+	ToDate         *time.Time       `json:"to_date,omitempty"`
+	GeneratedAt    time.Time        `json:"generated_at"`
+	TotalEntries   int              `json:"total_entries"`
+	ByAction       map[string]int   `json:"by_action"`
+	ByCategory     map[string]int   `json:"by_category"`
+	ByFramework    map[string]int   `json:"by_framework"`
+	Entries        []TicketAuditLog `json:"entries"`
+}
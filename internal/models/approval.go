@@ -125,3 +125,88 @@ type PendingApprovalView struct {
 	ApproverName string         `db:"approver_name" json:"approver_name"`
 	ApproverEmail string        `db:"approver_email" json:"approver_email"`
 }
+
+// ApprovalProgress reports how a ticket's approvals stand against the
+// approval types it requires. A type is satisfied once any approver of that
+// type has approved.
+type ApprovalProgress struct {
+	Required    []ApprovalType `json:"required"`
+	Approved    []ApprovalType `json:"approved"`
+	Denied      []ApprovalType `json:"denied,omitempty"`
+	Outstanding []ApprovalType `json:"outstanding"`
+	Complete    bool           `json:"complete"`
+}
+
+// ApprovalStatus derives the ticket's approval progress from its loaded
+// Approvals. Types with a denial but no approval count as outstanding.
+func (t *Ticket) ApprovalStatus() ApprovalProgress {
+	approved := make(map[ApprovalType]bool)
+	denied := make(map[ApprovalType]bool)
+	for _, a := range t.Approvals {
+		switch a.Status {
+		case ApprovalStatusApproved:
+			approved[a.ApprovalType] = true
+		case ApprovalStatusDenied:
+			denied[a.ApprovalType] = true
+		}
+	}
+
+	progress := ApprovalProgress{
+		Required:    t.RequiresApprovalTypes,
+		Approved:    []ApprovalType{},
+		Outstanding: []ApprovalType{},
+	}
+	for _, at := range t.RequiresApprovalTypes {
+		if approved[at] {
+			progress.Approved = append(progress.Approved, at)
+			continue
+		}
+		if denied[at] {
+			progress.Denied = append(progress.Denied, at)
+		}
+		progress.Outstanding = append(progress.Outstanding, at)
+	}
+	progress.Complete = len(progress.Outstanding) == 0
+
+	return progress
+}
+
+// AwaitingApproval returns true if the ticket is submitted and waiting on
+// approvers
+func (t *Ticket) AwaitingApproval() bool {
+	switch t.Status {
+	case TicketStatusSubmitted, TicketStatusInReview, TicketStatusPartiallyApproved:
+		return true
+	}
+	return false
+}
+
+// IsApprovalOverdue returns true if the ticket is awaiting approval past its
+// approval deadline with approval types still outstanding
+func (t *Ticket) IsApprovalOverdue(now time.Time) bool {
+	if !t.AwaitingApproval() || t.ApprovalDeadline == nil || !t.ApprovalDeadline.Before(now) {
+		return false
+	}
+	return !t.ApprovalStatus().Complete
+}
+
+// OverdueApproval describes a ticket that missed its approval deadline
+type OverdueApproval struct {
+	Ticket         TicketSummary    `json:"ticket"`
+	Deadline       time.Time        `json:"approval_deadline"`
+	OverdueSeconds int64            `json:"overdue_seconds"`
+	Approvals      ApprovalProgress `json:"approvals"`
+}
+
+// NewOverdueApproval builds the overdue view of a ticket as of now
+func NewOverdueApproval(t *Ticket, now time.Time) OverdueApproval {
+	overdue := OverdueApproval{
+		Ticket:    t.ToSummary(),
+		Approvals: t.ApprovalStatus(),
+	}
+	if t.ApprovalDeadline != nil {
+		overdue.Deadline = *t.ApprovalDeadline
+		overdue.OverdueSeconds = int64(now.Sub(*t.ApprovalDeadline) / time.Second)
+	}
+	return overdue
+}
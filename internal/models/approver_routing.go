@@ -0,0 +1,38 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// ResolveApprovers returns, for each approval type the ticket requires, the
+// IDs of the members of the type's approver group who can approve that type.
+// groupMembers holds the members of each group keyed by group ID. The
+// ticket's creator is never returned as an approver of their own change.
+// Types with no mapped group, or no eligible members, are omitted.
+func ResolveApprovers(ticket *Ticket, approverGroups map[ApprovalType]uuid.UUID, groupMembers map[uuid.UUID][]User) map[ApprovalType][]uuid.UUID {
+	approvers := make(map[ApprovalType][]uuid.UUID)
+	for _, approvalType := range ticket.RequiresApprovalTypes {
+		if _, done := approvers[approvalType]; done {
+			continue
+		}
+		groupID, ok := approverGroups[approvalType]
+		if !ok {
+			continue
+		}
+
+		seen := make(map[uuid.UUID]bool)
+		var ids []uuid.UUID
+		for i := range groupMembers[groupID] {
+			member := &groupMembers[groupID][i]
+			if member.ID == ticket.CreatedBy || seen[member.ID] || !member.CanApprove(approvalType) {
+				continue
+			}
+			seen[member.ID] = true
+			ids = append(ids, member.ID)
+		}
+		if len(ids) > 0 {
+			approvers[approvalType] = ids
+		}
+	}
+	return approvers
+}
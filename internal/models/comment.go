@@ -2,6 +2,8 @@ package models
 
 import (
 	"encoding/json"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,24 +11,69 @@ import (
 
 // Comment represents a comment on a ticket
 type Comment struct {
-	ID             uuid.UUID       `db:"id" json:"id"`
-	TicketID       uuid.UUID       `db:"ticket_id" json:"ticket_id"`
-	OrganizationID uuid.UUID       `db:"organization_id" json:"organization_id"`
-	AuthorID       uuid.UUID       `db:"author_id" json:"author_id"`
-	Comment        string          `db:"comment" json:"comment"`
-	IsInternal     bool            `db:"is_internal" json:"is_internal"`
-	MentionedUsers []uuid.UUID     `db:"mentioned_users" json:"mentioned_users,omitempty"`
-	AttachmentURLs []string        `db:"attachment_urls" json:"attachment_urls,omitempty"`
-	CreatedAt      time.Time       `db:"created_at" json:"created_at"`
-	UpdatedAt      time.Time       `db:"updated_at" json:"updated_at"`
-	DeletedAt      *time.Time      `db:"deleted_at" json:"deleted_at,omitempty"`
-	Edited         bool            `db:"edited" json:"edited"`
-	EditHistory    json.RawMessage `db:"edit_history" json:"edit_history,omitempty"`
+	ID              uuid.UUID       `db:"id" json:"id"`
+	TicketID        uuid.UUID       `db:"ticket_id" json:"ticket_id"`
+	OrganizationID  uuid.UUID       `db:"organization_id" json:"organization_id"`
+	AuthorID        uuid.UUID       `db:"author_id" json:"author_id"`
+	ParentCommentID *uuid.UUID      `db:"parent_comment_id" json:"parent_comment_id,omitempty"`
+	Comment         string          `db:"comment" json:"comment"`
+	IsInternal      bool            `db:"is_internal" json:"is_internal"`
+	MentionedUsers  []uuid.UUID     `db:"mentioned_users" json:"mentioned_users,omitempty"`
+	AttachmentURLs  []string        `db:"attachment_urls" json:"attachment_urls,omitempty"`
+	CreatedAt       time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time       `db:"updated_at" json:"updated_at"`
+	DeletedAt       *time.Time      `db:"deleted_at" json:"deleted_at,omitempty"`
+	Edited          bool            `db:"edited" json:"edited"`
+	EditHistory     json.RawMessage `db:"edit_history" json:"edit_history,omitempty"`
+
+	// Threading: Depth is 0 for top-level comments and 1 for replies
+	Depth   int       `db:"-" json:"depth"`
+	Replies []Comment `db:"-" json:"replies,omitempty"`
 
 	// Relationships
 	Author *UserSummary `db:"-" json:"author,omitempty"`
 }
 
+// MaxCommentDepth is the deepest a reply may be nested. Replies can only be
+// made to top-level comments.
+const MaxCommentDepth = 1
+
+// IsReply reports whether the comment is a reply to another comment
+func (c *Comment) IsReply() bool {
+	return c.ParentCommentID != nil
+}
+
+// ThreadComments nests replies under their parent comments, preserving the
+// input order. Replies whose parent is missing (e.g. deleted) are promoted to
+// the top level so they are not lost.
+func ThreadComments(comments []Comment) []Comment {
+	index := make(map[uuid.UUID]int)
+	threads := make([]Comment, 0, len(comments))
+
+	for _, c := range comments {
+		if !c.IsReply() {
+			c.Depth = 0
+			index[c.ID] = len(threads)
+			threads = append(threads, c)
+		}
+	}
+
+	for _, c := range comments {
+		if !c.IsReply() {
+			continue
+		}
+		if i, ok := index[*c.ParentCommentID]; ok {
+			c.Depth = 1
+			threads[i].Replies = append(threads[i].Replies, c)
+			continue
+		}
+		c.Depth = 0
+		threads = append(threads, c)
+	}
+
+	return threads
+}
+
 // CanEdit checks if a user can edit this comment
 func (c *Comment) CanEdit(userID uuid.UUID) bool {
 	// Only author can edit, and only within 15 minutes
@@ -44,10 +91,11 @@ func (c *Comment) CanDelete(userID uuid.UUID, isAdmin bool) bool {
 
 // CreateCommentInput represents input for creating a comment
 type CreateCommentInput struct {
-	Comment        string      `json:"comment" validate:"required,min=1"`
-	IsInternal     bool        `json:"is_internal"`
-	MentionedUsers []uuid.UUID `json:"mentioned_users,omitempty"`
-	AttachmentURLs []string    `json:"attachment_urls,omitempty"`
+	Comment         string      `json:"comment" validate:"required,min=1"`
+	ParentCommentID *uuid.UUID  `json:"parent_comment_id,omitempty"`
+	IsInternal      bool        `json:"is_internal"`
+	MentionedUsers  []uuid.UUID `json:"mentioned_users,omitempty"`
+	AttachmentURLs  []string    `json:"attachment_urls,omitempty"`
 }
 
 // UpdateCommentInput represents input for updating a comment
@@ -60,3 +108,28 @@ type CommentEditEntry struct {
 	PreviousComment string    `json:"previous_comment"`
 	EditedAt        time.Time `json:"edited_at"`
 }
+
+// mentionPattern matches "@" followed by an email address. The "@" must start
+// the text or follow a character that cannot be part of an address, so plain
+// email addresses in prose are not treated as mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9._%+\-@])@([A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,})`)
+
+// ParseMentions extracts the email addresses @-mentioned in text. Addresses
+// are lowercased and returned once each, in order of first appearance.
+func ParseMentions(text string) []string {
+	var mentions []string
+	seen := make(map[string]bool)
+
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		email := strings.ToLower(m[1])
+		if strings.HasPrefix(email, ".") || strings.Contains(email, "..") {
+			continue
+		}
+		if !seen[email] {
+			seen[email] = true
+			mentions = append(mentions, email)
+		}
+	}
+
+	return mentions
+}
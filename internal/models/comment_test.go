@@ -0,0 +1,78 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "dots and plus tag",
+			text: "cc @first.last+tag@example.co.uk for review",
+			want: []string{"first.last+tag@example.co.uk"},
+		},
+		{
+			name: "start of text",
+			text: "@alice@example.com please look",
+			want: []string{"alice@example.com"},
+		},
+		{
+			name: "trailing punctuation",
+			text: "Thanks @alice@example.com. Also (@bob@example.org), and @carol@example.net!",
+			want: []string{"alice@example.com", "bob@example.org", "carol@example.net"},
+		},
+		{
+			name: "adjacent mentions",
+			text: "@alice@example.com @bob@example.com,@carol@example.com",
+			want: []string{"alice@example.com", "bob@example.com", "carol@example.com"},
+		},
+		{
+			name: "plain email is not a mention",
+			text: "send logs to alice@example.com or ops+alerts@example.com",
+			want: nil,
+		},
+		{
+			name: "at sign inside a word",
+			text: "user@alice@example.com",
+			want: nil,
+		},
+		{
+			name: "consecutive dots in local part",
+			text: "@first..last@example.com",
+			want: nil,
+		},
+		{
+			name: "consecutive dots in domain",
+			text: "@alice@example..com",
+			want: nil,
+		},
+		{
+			name: "leading dot",
+			text: "@.alice@example.com",
+			want: nil,
+		},
+		{
+			name: "lowercased and deduplicated",
+			text: "@Alice@Example.com and again @alice@example.COM",
+			want: []string{"alice@example.com"},
+		},
+		{
+			name: "no domain suffix",
+			text: "@alice@localhost",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseMentions(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMentions(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
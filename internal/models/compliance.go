@@ -0,0 +1,341 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceFrameworkInfo describes the requirements a compliance framework
+// places on change tickets
+type ComplianceFrameworkInfo struct {
+	Framework         ComplianceFramework `json:"framework"`
+	DisplayName       string              `json:"display_name"`
+	Description       string              `json:"description"`
+	RequiredApprovals []ApprovalType      `json:"required_approvals"`
+	RetentionYears    int                 `json:"retention_years"`
+}
+
+// ComplianceRegistry maps each supported framework to its metadata
+var ComplianceRegistry = map[ComplianceFramework]ComplianceFrameworkInfo{
+	ComplianceGLBA: {
+		Framework:   ComplianceGLBA,
+		DisplayName: "Gramm-Leach-Bliley Act",
+		Description: "Safeguards for customer financial information",
+		RequiredApprovals: []ApprovalType{
+			ApprovalTypeSecurity,
+			ApprovalTypeRisk,
+		},
+		RetentionYears: 5,
+	},
+	ComplianceSOX: {
+		Framework:   ComplianceSOX,
+		DisplayName: "Sarbanes-Oxley",
+		Description: "Change controls over financial reporting systems",
+		RequiredApprovals: []ApprovalType{
+			ApprovalTypeIT,
+			ApprovalTypeRisk,
+			ApprovalTypeChangeManagementBoard,
+		},
+		RetentionYears: 7,
+	},
+	ComplianceHIPAA: {
+		Framework:   ComplianceHIPAA,
+		DisplayName: "HIPAA",
+		Description: "Protection of electronic protected health information",
+		RequiredApprovals: []ApprovalType{
+			ApprovalTypeIT,
+			ApprovalTypeSecurity,
+		},
+		RetentionYears: 6,
+	},
+	ComplianceBankingSecrecyAct: {
+		Framework:   ComplianceBankingSecrecyAct,
+		DisplayName: "Bank Secrecy Act",
+		Description: "Anti-money laundering recordkeeping and reporting",
+		RequiredApprovals: []ApprovalType{
+			ApprovalTypeOperations,
+			ApprovalTypeRisk,
+		},
+		RetentionYears: 5,
+	},
+	ComplianceGDPR: {
+		Framework:   ComplianceGDPR,
+		DisplayName: "GDPR",
+		Description: "Processing of personal data of EU residents",
+		RequiredApprovals: []ApprovalType{
+			ApprovalTypeSecurity,
+		},
+		RetentionYears: 3,
+	},
+	ComplianceCustom: {
+		Framework:         ComplianceCustom,
+		DisplayName:       "Custom",
+		Description:       "Organization-defined compliance requirements",
+		RequiredApprovals: []ApprovalType{},
+		RetentionYears:    0,
+	},
+}
+
+// complianceFrameworkOrder is the display order for registry listings
+var complianceFrameworkOrder = []ComplianceFramework{
+	ComplianceSOX,
+	ComplianceHIPAA,
+	ComplianceGLBA,
+	ComplianceBankingSecrecyAct,
+	ComplianceGDPR,
+	ComplianceCustom,
+}
+
+// Info returns the registry entry for the framework
+func (c ComplianceFramework) Info() (ComplianceFrameworkInfo, bool) {
+	info, ok := ComplianceRegistry[c]
+	return info, ok
+}
+
+// DisplayName returns a human-readable name for the framework
+func (c ComplianceFramework) DisplayName() string {
+	if info, ok := ComplianceRegistry[c]; ok {
+		return info.DisplayName
+	}
+	return string(c)
+}
+
+// RequiredApprovals returns the approval types the framework mandates
+func (c ComplianceFramework) RequiredApprovals() []ApprovalType {
+	info, ok := ComplianceRegistry[c]
+	if !ok {
+		return nil
+	}
+	approvals := make([]ApprovalType, len(info.RequiredApprovals))
+	copy(approvals, info.RequiredApprovals)
+	return approvals
+}
+
+// RetentionYears returns how long audit records must be kept for the framework
+func (c ComplianceFramework) RetentionYears() int {
+	return ComplianceRegistry[c].RetentionYears
+}
+
+// ListComplianceFrameworks returns all registered frameworks in display order
+func ListComplianceFrameworks() []ComplianceFrameworkInfo {
+	infos := make([]ComplianceFrameworkInfo, 0, len(complianceFrameworkOrder))
+	for _, f := range complianceFrameworkOrder {
+		infos = append(infos, ComplianceRegistry[f])
+	}
+	return infos
+}
+
+// RequiredApprovalsFor returns the union of approval types mandated by the
+// given frameworks, preserving first-seen order
+func RequiredApprovalsFor(frameworks []ComplianceFramework) []ApprovalType {
+	seen := make(map[ApprovalType]bool)
+	var approvals []ApprovalType
+	for _, f := range frameworks {
+		for _, a := range f.RequiredApprovals() {
+			if !seen[a] {
+				seen[a] = true
+				approvals = append(approvals, a)
+			}
+		}
+	}
+	return approvals
+}
+
+// industryCompliance lists, per industry, the frameworks a ticket is
+// expected to carry and the others that are plausible alongside them.
+// Expected frameworks are alternatives: listing any one satisfies them.
+// GDPR and custom frameworks are allowed for every industry.
+var industryCompliance = map[IndustryType]struct {
+	Expected []ComplianceFramework
+	Allowed  []ComplianceFramework
+}{
+	IndustryHealthcare: {
+		Expected: []ComplianceFramework{ComplianceHIPAA},
+		Allowed:  []ComplianceFramework{ComplianceSOX},
+	},
+	IndustryFinance: {
+		Expected: []ComplianceFramework{ComplianceGLBA, ComplianceSOX},
+		Allowed:  []ComplianceFramework{ComplianceBankingSecrecyAct},
+	},
+	IndustryInsurance: {
+		Expected: []ComplianceFramework{ComplianceGLBA},
+		Allowed:  []ComplianceFramework{ComplianceSOX, ComplianceHIPAA, ComplianceBankingSecrecyAct},
+	},
+	IndustryGovernment: {
+		Allowed: []ComplianceFramework{ComplianceHIPAA},
+	},
+	// IT providers take on their customers' obligations, so any framework
+	// is plausible
+	IndustryIT: {
+		Allowed: []ComplianceFramework{
+			ComplianceGLBA, ComplianceSOX, ComplianceHIPAA, ComplianceBankingSecrecyAct,
+		},
+	},
+}
+
+// ValidateIndustryCompliance checks that the compliance frameworks make
+// sense for the industry. It returns warnings, not hard failures, for
+// frameworks unusual for the industry and for industries missing the
+// framework they are normally subject to. An empty industry is not checked.
+func ValidateIndustryCompliance(industry IndustryType, frameworks []ComplianceFramework) []ValidationError {
+	if industry == "" {
+		return nil
+	}
+	rules, ok := industryCompliance[industry]
+	if !ok {
+		return []ValidationError{{
+			Field:   "industry",
+			Message: fmt.Sprintf("unknown industry %q", industry),
+		}}
+	}
+
+	allowed := map[ComplianceFramework]bool{
+		ComplianceGDPR:   true,
+		ComplianceCustom: true,
+	}
+	for _, f := range rules.Expected {
+		allowed[f] = true
+	}
+	for _, f := range rules.Allowed {
+		allowed[f] = true
+	}
+
+	var warnings []ValidationError
+	present := make(map[ComplianceFramework]bool, len(frameworks))
+	for _, f := range frameworks {
+		present[f] = true
+		switch {
+		case !f.Valid():
+			warnings = append(warnings, ValidationError{
+				Field:   "compliance_frameworks",
+				Message: fmt.Sprintf("unknown compliance framework %q", f),
+			})
+		case !allowed[f]:
+			warnings = append(warnings, ValidationError{
+				Field:   "compliance_frameworks",
+				Message: fmt.Sprintf("%s is not expected for the %s industry", f.DisplayName(), industry),
+			})
+		}
+	}
+
+	if len(rules.Expected) > 0 {
+		satisfied := false
+		names := make([]string, len(rules.Expected))
+		for i, f := range rules.Expected {
+			satisfied = satisfied || present[f]
+			names[i] = string(f)
+		}
+		if !satisfied {
+			warnings = append(warnings, ValidationError{
+				Field:   "compliance_frameworks",
+				Message: fmt.Sprintf("%s tickets usually list %s", industry, strings.Join(names, " or ")),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// ComplianceReport lists, per framework, the tickets subject to it within a
+// date range, whether each collected the approvals the framework requires,
+// and the compliance-relevant audit entries recorded for it
+type ComplianceReport struct {
+	OrganizationID uuid.UUID             `json:"organization_id"`
+	FromDate       *time.Time            `json:"from_date,omitempty"`
+	ToDate         *time.Time            `json:"to_date,omitempty"`
+	GeneratedAt    time.Time             `json:"generated_at"`
+	Frameworks     []FrameworkCompliance `json:"frameworks"`
+}
+
+// FrameworkCompliance is one framework's section of a ComplianceReport
+type FrameworkCompliance struct {
+	Framework       ComplianceFrameworkInfo `json:"framework"`
+	TotalTickets    int                     `json:"total_tickets"`
+	CompleteTickets int                     `json:"complete_tickets"`
+	Tickets         []TicketCompliance      `json:"tickets"`
+	AuditEntries    []TicketAuditLog        `json:"audit_entries"`
+}
+
+// TicketCompliance records whether a ticket has every approval a framework
+// requires. Approval types are reported in the framework's order.
+type TicketCompliance struct {
+	TicketID          uuid.UUID      `json:"ticket_id"`
+	TicketNumber      string         `json:"ticket_number"`
+	Title             string         `json:"title"`
+	Status            TicketStatus   `json:"status"`
+	CreatedAt         time.Time      `json:"created_at"`
+	RequiredApprovals []ApprovalType `json:"required_approvals"`
+	MissingApprovals  []ApprovalType `json:"missing_approvals"`
+	Complete          bool           `json:"complete"`
+}
+
+// CheckFrameworkApprovals compares the ticket's approved approvals with
+// those the framework requires
+func CheckFrameworkApprovals(t *Ticket, f ComplianceFramework) TicketCompliance {
+	approved := make(map[ApprovalType]bool, len(t.Approvals))
+	for _, a := range t.Approvals {
+		if a.Status == ApprovalStatusApproved {
+			approved[a.ApprovalType] = true
+		}
+	}
+
+	tc := TicketCompliance{
+		TicketID:          t.ID,
+		TicketNumber:      t.TicketNumber,
+		Title:             t.Title,
+		Status:            t.Status,
+		CreatedAt:         t.CreatedAt,
+		RequiredApprovals: f.RequiredApprovals(),
+		MissingApprovals:  []ApprovalType{},
+	}
+	for _, a := range tc.RequiredApprovals {
+		if !approved[a] {
+			tc.MissingApprovals = append(tc.MissingApprovals, a)
+		}
+	}
+	tc.Complete = len(tc.MissingApprovals) == 0
+	return tc
+}
+
+// NewFrameworkCompliance builds a framework's report section from the
+// tickets and audit entries found for it. Tickets not subject to the
+// framework are skipped.
+func NewFrameworkCompliance(f ComplianceFramework, tickets []Ticket, audit []TicketAuditLog) FrameworkCompliance {
+	info, ok := f.Info()
+	if !ok {
+		info = ComplianceFrameworkInfo{Framework: f, DisplayName: string(f)}
+	}
+
+	section := FrameworkCompliance{
+		Framework:    info,
+		Tickets:      []TicketCompliance{},
+		AuditEntries: audit,
+	}
+	if section.AuditEntries == nil {
+		section.AuditEntries = []TicketAuditLog{}
+	}
+	for i := range tickets {
+		if !containsFramework(tickets[i].ComplianceFrameworks, f) {
+			continue
+		}
+		tc := CheckFrameworkApprovals(&tickets[i], f)
+		section.Tickets = append(section.Tickets, tc)
+		if tc.Complete {
+			section.CompleteTickets++
+		}
+	}
+	section.TotalTickets = len(section.Tickets)
+	return section
+}
+
+func containsFramework(frameworks []ComplianceFramework, f ComplianceFramework) bool {
+	for _, cf := range frameworks {
+		if cf == f {
+			return true
+		}
+	}
+	return false
+}
@@ -184,6 +184,22 @@ type CreateEmployeeProfileInput struct {
 	HireDate          *time.Time        `json:"hire_date,omitempty"`
 }
 
+// Validate checks the values the validate tags cannot: the employee type and
+// security clearance must be known values, and a clearance expiry needs a
+// clearance to expire. An empty clearance means none.
+func (i *CreateEmployeeProfileInput) Validate() error {
+	if !i.EmployeeType.Valid() {
+		return &ValidationError{Field: "employee_type", Message: "invalid employee_type: " + string(i.EmployeeType)}
+	}
+	if i.SecurityClearance != "" && !i.SecurityClearance.Valid() {
+		return &ValidationError{Field: "security_clearance", Message: "invalid security_clearance: " + string(i.SecurityClearance)}
+	}
+	if i.ClearanceExpiry != nil && (i.SecurityClearance == "" || i.SecurityClearance == SecurityClearanceNone) {
+		return &ValidationError{Field: "clearance_expiry", Message: "clearance_expiry requires a security_clearance"}
+	}
+	return nil
+}
+
 // UpdateEmployeeProfileInput represents input for updating an employee profile
 type UpdateEmployeeProfileInput struct {
 	EmployeeNumber    *string            `json:"employee_number,omitempty"`
@@ -223,6 +239,9 @@ type EmployeeSearchFilter struct {
 	ManagerID         *uuid.UUID     `json:"manager_id,omitempty"`
 	ConsultingCompany *string        `json:"consulting_company,omitempty"`
 	Search            string         `json:"search,omitempty"` // Name, email, title search
+	Skills            []string       `json:"skills,omitempty"`
+	Certifications    []string       `json:"certifications,omitempty"`
+	MatchAll          bool           `json:"match_all,omitempty"` // Require every skill/cert instead of any
 	IncludeInactive   bool           `json:"include_inactive,omitempty"`
 	Page              int            `json:"page" validate:"min=1"`
 	PerPage           int            `json:"per_page" validate:"min=1,max=100"`
@@ -250,3 +269,10 @@ func (f *EmployeeSearchFilter) SetDefaults() {
 func (f *EmployeeSearchFilter) Offset() int {
 	return (f.Page - 1) * f.PerPage
 }
+
+// SkillCount is a distinct skill or certification with the number of
+// employees listing it
+type SkillCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
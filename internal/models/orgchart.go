@@ -0,0 +1,161 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// OrgNode is a node in the reporting tree. ManagerID on EmployeeProfile
+// refers to the manager's user ID, so nodes are keyed by UserID.
+type OrgNode struct {
+	Employee    *EmployeeDirectoryEntry `json:"employee,omitempty"`
+	Reports     []*OrgNode              `json:"reports,omitempty"`
+	ReportCount int                     `json:"report_count"` // direct and indirect
+
+	// Cycles is only set on the root returned by BuildOrgChart. Each entry
+	// lists the user IDs of a management loop that was broken to build the
+	// tree.
+	Cycles [][]uuid.UUID `json:"cycles,omitempty"`
+}
+
+// ToDirectoryEntry converts a profile to its public directory entry. Name and
+// email are filled from the User relationship when it is loaded.
+func (e *EmployeeProfile) ToDirectoryEntry() EmployeeDirectoryEntry {
+	entry := EmployeeDirectoryEntry{
+		ID:                e.ID,
+		UserID:            e.UserID,
+		JobTitle:          e.JobTitle,
+		Department:        e.Department,
+		EmployeeType:      e.EmployeeType,
+		ConsultingCompany: e.ConsultingCompany,
+		OfficeLocation:    e.OfficeLocation,
+		OfficePhone:       e.OfficePhone,
+		MobilePhone:       e.MobilePhone,
+		ProfilePictureURL: e.ProfilePictureURL,
+		OutOfOffice:       e.OutOfOffice,
+		Manager:           e.Manager,
+	}
+	if e.User != nil {
+		entry.FullName = e.User.FullName
+		entry.Email = e.User.Email
+	}
+	return entry
+}
+
+// BuildOrgChart builds the reporting tree for profiles. The returned root is
+// synthetic (it has no Employee); its Reports are the employees with no
+// manager, a manager outside profiles, or whose management loop was broken.
+// Management loops are reported in the root's Cycles. Reports keep the order
+// of profiles.
+func BuildOrgChart(profiles []EmployeeProfile) *OrgNode {
+	byUser := make(map[uuid.UUID]int, len(profiles))
+	for i := range profiles {
+		byUser[profiles[i].UserID] = i
+	}
+
+	// parent[i] is the index of i's manager, or -1 for top-level employees
+	parent := make([]int, len(profiles))
+	for i := range profiles {
+		parent[i] = -1
+		if m := profiles[i].ManagerID; m != nil && *m != profiles[i].UserID {
+			if j, ok := byUser[*m]; ok {
+				parent[i] = j
+			}
+		}
+	}
+
+	// Walk each manager chain, breaking any loop at the first member reached
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(profiles))
+	root := &OrgNode{}
+	for i := range profiles {
+		var path []int
+		n := i
+		for n != -1 && state[n] == unvisited {
+			state[n] = visiting
+			path = append(path, n)
+			n = parent[n]
+		}
+		if n != -1 && state[n] == visiting {
+			start := 0
+			for path[start] != n {
+				start++
+			}
+			var cycle []uuid.UUID
+			for _, p := range path[start:] {
+				cycle = append(cycle, profiles[p].UserID)
+			}
+			root.Cycles = append(root.Cycles, cycle)
+			parent[n] = -1
+		}
+		for _, p := range path {
+			state[p] = done
+		}
+	}
+
+	nodes := make([]*OrgNode, len(profiles))
+	for i := range profiles {
+		entry := profiles[i].ToDirectoryEntry()
+		nodes[i] = &OrgNode{Employee: &entry}
+	}
+	for i := range profiles {
+		if parent[i] == -1 {
+			root.Reports = append(root.Reports, nodes[i])
+		} else {
+			nodes[parent[i]].Reports = append(nodes[parent[i]].Reports, nodes[i])
+		}
+	}
+	root.countReports()
+
+	return root
+}
+
+// countReports fills ReportCount for n and its subtree
+func (n *OrgNode) countReports() int {
+	n.ReportCount = 0
+	for _, r := range n.Reports {
+		n.ReportCount += 1 + r.countReports()
+	}
+	return n.ReportCount
+}
+
+// Find returns the node for the employee with the given user or profile ID
+func (n *OrgNode) Find(id uuid.UUID) *OrgNode {
+	if n.Employee != nil && (n.Employee.UserID == id || n.Employee.ID == id) {
+		return n
+	}
+	for _, r := range n.Reports {
+		if found := r.Find(id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// ReportingChain resolves who e reports to, nearest manager first; the last
+// entry is the person e ultimately reports to. profiles is the set to resolve
+// managers from. The chain stops at a manager without a profile and before
+// revisiting anyone, so management loops cannot recurse forever.
+func (e *EmployeeProfile) ReportingChain(profiles []EmployeeProfile) []EmployeeProfile {
+	byUser := make(map[uuid.UUID]*EmployeeProfile, len(profiles))
+	for i := range profiles {
+		byUser[profiles[i].UserID] = &profiles[i]
+	}
+
+	var chain []EmployeeProfile
+	seen := map[uuid.UUID]bool{e.UserID: true}
+	for m := e.ManagerID; m != nil && !seen[*m]; {
+		manager, ok := byUser[*m]
+		if !ok {
+			break
+		}
+		seen[*m] = true
+		chain = append(chain, *manager)
+		m = manager.ManagerID
+	}
+
+	return chain
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// TicketConflict describes another ticket scheduled against the same systems
+// during an overlapping change window
+type TicketConflict struct {
+	Ticket         TicketSummary `json:"ticket"`
+	ScheduledStart time.Time     `json:"scheduled_start"`
+	ScheduledEnd   time.Time     `json:"scheduled_end"`
+	OverlapStart   time.Time     `json:"overlap_start"`
+	OverlapEnd     time.Time     `json:"overlap_end"`
+	SharedSystems  []string      `json:"shared_systems"`
+}
+
+// HasScheduledWindow returns true if the ticket has a valid change window
+func (t *Ticket) HasScheduledWindow() bool {
+	return t.ScheduledStart != nil && t.ScheduledEnd != nil && t.ScheduledEnd.After(*t.ScheduledStart)
+}
+
+// SchedulesConflict returns true if a ticket in this status still holds its
+// change window. Cancelled, denied and closed tickets release it.
+func (s TicketStatus) SchedulesConflict() bool {
+	switch s {
+	case TicketStatusCancelled, TicketStatusDenied, TicketStatusClosed:
+		return false
+	}
+	return true
+}
+
+// WindowOverlap returns the intersection of two half-open time windows
+// [aStart, aEnd) and [bStart, bEnd). ok is false when they do not overlap.
+func WindowOverlap(aStart, aEnd, bStart, bEnd time.Time) (start, end time.Time, ok bool) {
+	start = aStart
+	if bStart.After(start) {
+		start = bStart
+	}
+	end = aEnd
+	if bEnd.Before(end) {
+		end = bEnd
+	}
+	return start, end, end.After(start)
+}
+
+// SharedSystems returns the entries of systems that also appear in other,
+// compared case-insensitively, in the order they appear in systems
+func SharedSystems(systems, other []string) []string {
+	set := make(map[string]bool, len(other))
+	for _, s := range other {
+		set[strings.ToLower(strings.TrimSpace(s))] = true
+	}
+
+	var shared []string
+	seen := make(map[string]bool)
+	for _, s := range systems {
+		key := strings.ToLower(strings.TrimSpace(s))
+		if set[key] && !seen[key] {
+			seen[key] = true
+			shared = append(shared, s)
+		}
+	}
+	return shared
+}
+
+// ConflictWith reports whether t is scheduled during [start, end) against any
+// of systems, returning the overlapping window and shared systems
+func (t *Ticket) ConflictWith(start, end time.Time, systems []string) (TicketConflict, bool) {
+	if !t.HasScheduledWindow() || !t.Status.SchedulesConflict() {
+		return TicketConflict{}, false
+	}
+
+	overlapStart, overlapEnd, ok := WindowOverlap(start, end, *t.ScheduledStart, *t.ScheduledEnd)
+	if !ok {
+		return TicketConflict{}, false
+	}
+	shared := SharedSystems(systems, t.AffectedSystems)
+	if len(shared) == 0 {
+		return TicketConflict{}, false
+	}
+
+	return TicketConflict{
+		Ticket:         t.ToSummary(),
+		ScheduledStart: *t.ScheduledStart,
+		ScheduledEnd:   *t.ScheduledEnd,
+		OverlapStart:   overlapStart,
+		OverlapEnd:     overlapEnd,
+		SharedSystems:  shared,
+	}, true
+}
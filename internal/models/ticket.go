@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"reflect"
 	"time"
 
 	"github.com/google/uuid"
@@ -111,6 +112,52 @@ func (t *Ticket) CanReopen() bool {
 	return t.Status == TicketStatusClosed
 }
 
+// RedactedMarker replaces confidential content for callers without access
+const RedactedMarker = "[redacted — confidential]"
+
+// Redacted returns the ticket as it may be shown to a caller with the given
+// effective role. Confidential tickets viewed by roles that cannot see
+// confidential content get a copy with the description, impact, attachments
+// and comment bodies replaced by RedactedMarker; otherwise t is returned.
+func (t *Ticket) Redacted(forRole TicketACLRole) *Ticket {
+	if !t.IsConfidential || forRole.CanViewConfidential() {
+		return t
+	}
+
+	redacted := *t
+	marker := RedactedMarker
+	redacted.Description = marker
+	if t.ImpactDescription != nil {
+		redacted.ImpactDescription = &marker
+	}
+	redacted.AttachmentURLs = nil
+	redacted.SubmittedSnapshot = nil
+
+	redacted.Comments = t.RedactedComments(forRole, t.Comments)
+
+	return &redacted
+}
+
+// RedactedComments returns comments on t as they may be shown to a caller
+// with the given effective role, by the same rules as Redacted: on
+// confidential tickets, roles that cannot see confidential content get
+// copies with the body replaced by RedactedMarker and no attachments or
+// edit history. Otherwise comments is returned.
+func (t *Ticket) RedactedComments(forRole TicketACLRole, comments []Comment) []Comment {
+	if !t.IsConfidential || forRole.CanViewConfidential() || len(comments) == 0 {
+		return comments
+	}
+
+	redacted := make([]Comment, len(comments))
+	for i, comment := range comments {
+		comment.Comment = RedactedMarker
+		comment.AttachmentURLs = nil
+		comment.EditHistory = nil
+		redacted[i] = comment
+	}
+	return redacted
+}
+
 // TicketSummary represents a minimal ticket for list views
 type TicketSummary struct {
 	ID           uuid.UUID      `json:"id"`
@@ -215,6 +262,10 @@ type UpdateTicketInput struct {
 	Watchers          []uuid.UUID `json:"watchers,omitempty"`
 	ExternalReference *string     `json:"external_reference,omitempty"`
 	IsConfidential    *bool       `json:"is_confidential,omitempty"`
+
+	// Version is the ticket version the edit was based on; the update is
+	// rejected if the ticket has changed since
+	Version *int `json:"version,omitempty"`
 }
 
 // TicketRevision represents a change history entry for a ticket
@@ -283,3 +334,120 @@ func (f *TicketListFilter) SetDefaults() {
 func (f *TicketListFilter) Offset() int {
 	return (f.Page - 1) * f.PerPage
 }
+
+// LabelCount is a label in use and the number of tickets carrying it
+type LabelCount struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// EpicRollup aggregates planning numbers across an epic's child tickets and
+// their direct subtasks
+type EpicRollup struct {
+	EpicID               uuid.UUID      `json:"epic_id"`
+	TicketCount          int            `json:"ticket_count"`
+	StoryPoints          int            `json:"story_points"`
+	CompletedStoryPoints int            `json:"completed_story_points"`
+	TimeEstimateHours    float64        `json:"time_estimate_hours"`
+	TimeSpentHours       float64        `json:"time_spent_hours"`
+	StatusCounts         map[string]int `json:"status_counts"`
+}
+
+// NewEpicRollup creates an empty rollup for the epic
+func NewEpicRollup(epicID uuid.UUID) *EpicRollup {
+	return &EpicRollup{EpicID: epicID, StatusCounts: make(map[string]int)}
+}
+
+// Add folds a ticket's planning fields into the rollup
+func (r *EpicRollup) Add(t *Ticket) {
+	r.TicketCount++
+	r.StatusCounts[string(t.Status)]++
+	if t.StoryPoints != nil {
+		r.StoryPoints += *t.StoryPoints
+		switch t.Status {
+		case TicketStatusCompleted, TicketStatusClosed:
+			r.CompletedStoryPoints += *t.StoryPoints
+		}
+	}
+	if t.TimeEstimateHours != nil {
+		r.TimeEstimateHours += *t.TimeEstimateHours
+	}
+	if t.TimeSpentHours != nil {
+		r.TimeSpentHours += *t.TimeSpentHours
+	}
+}
+
+// RenameLabelInput represents input for renaming a label across tickets
+type RenameLabelInput struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+// FieldChange records the previous and new value of a changed field
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// diffIgnoredFields are bookkeeping or relationship fields that are not
+// meaningful in a revision diff
+var diffIgnoredFields = map[string]bool{
+	"updated_at":         true,
+	"version":            true,
+	"submitted_snapshot": true,
+	"creator":            true,
+	"assignee":           true,
+	"approvals":          true,
+	"comments":           true,
+	"project":            true,
+	"owning_group":       true,
+	"customer":           true,
+	"parent_ticket":      true,
+	"epic":               true,
+	"repositories":       true,
+	"acls":               true,
+	"contacts":           true,
+}
+
+// DiffTickets compares two versions of a ticket and returns the changed
+// fields keyed by their JSON name
+func DiffTickets(before, after *Ticket) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+
+	oldFields := ticketFieldMap(before)
+	newFields := ticketFieldMap(after)
+
+	for key, newVal := range newFields {
+		if diffIgnoredFields[key] {
+			continue
+		}
+		oldVal, ok := oldFields[key]
+		if !ok || !reflect.DeepEqual(oldVal, newVal) {
+			changes[key] = FieldChange{Old: oldVal, New: newVal}
+		}
+	}
+	for key, oldVal := range oldFields {
+		if diffIgnoredFields[key] {
+			continue
+		}
+		if _, ok := newFields[key]; !ok {
+			changes[key] = FieldChange{Old: oldVal, New: nil}
+		}
+	}
+
+	return changes
+}
+
+// ticketFieldMap flattens a ticket into its JSON field representation
+func ticketFieldMap(t *Ticket) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if t == nil {
+		return fields
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fields
+	}
+	json.Unmarshal(data, &fields)
+	return fields
+}
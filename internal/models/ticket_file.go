@@ -0,0 +1,263 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TicketFile is the flat JSON form of a ticket stored by the CLI in its
+// tickets directory, one file per ticket named by its number (CHG-YYYY-NNNNN
+// by default; see ticketnum.Format). Users are
+// identified by email and approvals by type. Ticket.ToLocalFile and
+// TicketFromLocalFile are the one mapping between this form and Ticket.
+type TicketFile struct {
+	ID                   string              `json:"id"`
+	Title                string              `json:"title"`
+	Description          string              `json:"description"`
+	Status               string              `json:"status"`
+	Priority             string              `json:"priority"`
+	Risk                 string              `json:"risk"`
+	Type                 string              `json:"type"`
+	Industry             string              `json:"industry"`
+	ComplianceFrameworks []string            `json:"compliance_frameworks"`
+	AffectedSystems      []string            `json:"affected_systems"`
+	AcceptanceCriteria   []string            `json:"acceptance_criteria"`
+	TestingPlan          string              `json:"testing_plan"`
+	RollbackPlan         string              `json:"rollback_plan"`
+	CreatedBy            string              `json:"created_by"`
+	CreatedAt            time.Time           `json:"created_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+	Sprint               string              `json:"sprint"`
+	Assignee             *string             `json:"assignee"`
+	ApprovalsRequired    []string            `json:"approvals_required"`
+	Approvals            []string            `json:"approvals"`
+	Dependencies         []string            `json:"dependencies"`
+	Labels               []string            `json:"labels,omitempty"`
+	Parent               string              `json:"parent,omitempty"`
+	Epic                 string              `json:"epic,omitempty"`
+	Comments             []TicketFileComment `json:"comments"`
+	ExternalReferences   []TicketFileRef     `json:"external_references,omitempty"`
+	Attachments          []string            `json:"attachments,omitempty"`
+}
+
+// TicketFileComment is a comment in a TicketFile
+type TicketFileComment struct {
+	Author    string    `json:"author"`
+	Timestamp time.Time `json:"timestamp"`
+	Text      string    `json:"text"`
+}
+
+// TicketFileRef points at the issue a ticket was imported from
+type TicketFileRef struct {
+	System string `json:"system"`
+	ID     string `json:"id"`
+	URL    string `json:"url"`
+}
+
+// ticketFileFields holds the TicketFile fields with no Ticket column. They
+// are kept in Ticket.CustomFields so conversions do not lose them.
+type ticketFileFields struct {
+	Sprint             string          `json:"sprint,omitempty"`
+	AcceptanceCriteria []string        `json:"acceptance_criteria,omitempty"`
+	Dependencies       []string        `json:"dependencies,omitempty"`
+	Parent             string          `json:"parent,omitempty"`
+	Epic               string          `json:"epic,omitempty"`
+	ExternalReferences []TicketFileRef `json:"external_references,omitempty"`
+}
+
+// SprintAt returns the sprint a ticket created at t is filed under, e.g.
+// "2025-Q1-Sprint-2". Sprints are two ISO weeks long, numbered 1 and 2.
+func SprintAt(t time.Time) string {
+	_, week := t.ISOWeek()
+	quarter := (t.Month()-1)/3 + 1
+	return fmt.Sprintf("%d-Q%d-Sprint-%d", t.Year(), quarter, (week-1)%2+1)
+}
+
+// NewTicketFile returns a TicketFile with every list initialized, so it
+// is written with [] rather than null like the files the CLI has always
+// produced. Timestamps are truncated to the second.
+func NewTicketFile(id string, createdAt time.Time) *TicketFile {
+	createdAt = createdAt.UTC().Truncate(time.Second)
+	return &TicketFile{
+		ID:                   id,
+		CreatedAt:            createdAt,
+		UpdatedAt:            createdAt,
+		ComplianceFrameworks: []string{},
+		AffectedSystems:      []string{},
+		AcceptanceCriteria:   []string{},
+		ApprovalsRequired:    []string{},
+		Approvals:            []string{},
+		Dependencies:         []string{},
+		Comments:             []TicketFileComment{},
+	}
+}
+
+// ToLocalFile converts the ticket to its on-disk CLI form. Creator,
+// Assignee and comment authors are written by email when loaded;
+// approvals are written as the types that have been approved.
+func (t *Ticket) ToLocalFile() *TicketFile {
+	f := NewTicketFile(t.TicketNumber, t.CreatedAt)
+	f.UpdatedAt = t.UpdatedAt.UTC().Truncate(time.Second)
+	f.Title = t.Title
+	f.Description = t.Description
+	f.Status = string(t.Status)
+	f.Priority = string(t.Priority)
+	f.Risk = string(t.RiskLevel)
+	f.Industry = string(t.Industry)
+	f.Type = derefString(t.ChangeType)
+	f.TestingPlan = derefString(t.TestingPlan)
+	f.RollbackPlan = derefString(t.RollbackPlan)
+	f.AffectedSystems = append(f.AffectedSystems, t.AffectedSystems...)
+	f.Labels = append([]string(nil), t.Labels...)
+	f.Attachments = append([]string(nil), t.AttachmentURLs...)
+
+	for _, cf := range t.ComplianceFrameworks {
+		f.ComplianceFrameworks = append(f.ComplianceFrameworks, string(cf))
+	}
+	for _, at := range t.RequiresApprovalTypes {
+		f.ApprovalsRequired = append(f.ApprovalsRequired, string(at))
+	}
+	for _, a := range t.Approvals {
+		if a.Status == ApprovalStatusApproved {
+			f.Approvals = append(f.Approvals, string(a.ApprovalType))
+		}
+	}
+
+	if t.Creator != nil {
+		f.CreatedBy = t.Creator.Email
+	}
+	if t.Assignee != nil && t.Assignee.Email != "" {
+		email := t.Assignee.Email
+		f.Assignee = &email
+	}
+
+	for _, c := range t.Comments {
+		comment := TicketFileComment{
+			Timestamp: c.CreatedAt.UTC().Truncate(time.Second),
+			Text:      c.Comment,
+		}
+		if c.Author != nil {
+			comment.Author = c.Author.Email
+		}
+		f.Comments = append(f.Comments, comment)
+	}
+
+	var extra ticketFileFields
+	if len(t.CustomFields) > 0 && json.Unmarshal(t.CustomFields, &extra) == nil {
+		f.Sprint = extra.Sprint
+		f.AcceptanceCriteria = append(f.AcceptanceCriteria, extra.AcceptanceCriteria...)
+		f.Dependencies = append(f.Dependencies, extra.Dependencies...)
+		f.Parent = extra.Parent
+		f.Epic = extra.Epic
+		f.ExternalReferences = extra.ExternalReferences
+	}
+	if len(f.ExternalReferences) == 0 && t.ExternalReference != nil {
+		f.ExternalReferences = []TicketFileRef{{URL: *t.ExternalReference}}
+	}
+
+	return f
+}
+
+// TicketFromLocalFile converts an on-disk CLI ticket to a Ticket. Users
+// are set as summaries carrying only their email, and approvals as
+// approved entries carrying only their type, since the file holds no IDs.
+// Sprint, acceptance criteria, dependencies, parent, epic and external
+// references are kept in CustomFields; the first reference's URL also becomes
+// ExternalReference.
+func TicketFromLocalFile(f *TicketFile) *Ticket {
+	t := &Ticket{
+		TicketNumber:    f.ID,
+		Title:           f.Title,
+		Description:     f.Description,
+		Status:          TicketStatus(f.Status),
+		Priority:        TicketPriority(f.Priority),
+		RiskLevel:       RiskLevel(f.Risk),
+		Industry:        IndustryType(f.Industry),
+		ChangeType:      optionalString(f.Type),
+		TestingPlan:     optionalString(f.TestingPlan),
+		RollbackPlan:    optionalString(f.RollbackPlan),
+		AffectedSystems: append([]string(nil), f.AffectedSystems...),
+		Labels:          append([]string(nil), f.Labels...),
+		AttachmentURLs:  append([]string(nil), f.Attachments...),
+		CreatedAt:       f.CreatedAt,
+		UpdatedAt:       f.UpdatedAt,
+	}
+
+	for _, cf := range f.ComplianceFrameworks {
+		t.ComplianceFrameworks = append(t.ComplianceFrameworks, ComplianceFramework(cf))
+	}
+	for _, at := range f.ApprovalsRequired {
+		t.RequiresApprovalTypes = append(t.RequiresApprovalTypes, ApprovalType(at))
+	}
+	for _, at := range f.Approvals {
+		t.Approvals = append(t.Approvals, Approval{
+			ApprovalType: ApprovalType(at),
+			Status:       ApprovalStatusApproved,
+		})
+	}
+
+	if f.CreatedBy != "" {
+		t.Creator = &UserSummary{Email: f.CreatedBy}
+	}
+	if f.Assignee != nil && *f.Assignee != "" {
+		t.Assignee = &UserSummary{Email: *f.Assignee}
+	}
+
+	for _, c := range f.Comments {
+		comment := Comment{
+			Comment:   c.Text,
+			CreatedAt: c.Timestamp,
+			UpdatedAt: c.Timestamp,
+		}
+		if c.Author != "" {
+			comment.Author = &UserSummary{Email: c.Author}
+		}
+		t.Comments = append(t.Comments, comment)
+	}
+
+	extra := ticketFileFields{
+		Sprint:             f.Sprint,
+		AcceptanceCriteria: f.AcceptanceCriteria,
+		Dependencies:       f.Dependencies,
+		Parent:             f.Parent,
+		Epic:               f.Epic,
+		ExternalReferences: f.ExternalReferences,
+	}
+	if data, err := json.Marshal(extra); err == nil && string(data) != "{}" {
+		t.CustomFields = data
+	}
+	if len(f.ExternalReferences) > 0 {
+		ref := f.ExternalReferences[0]
+		t.ExternalReference = optionalString(ref.URL)
+		if t.ExternalReference == nil {
+			t.ExternalReference = optionalString(ref.ID)
+		}
+	}
+
+	return t
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// ComplianceWarnings checks the ticket's compliance frameworks against its
+// industry with ValidateIndustryCompliance
+func (f *TicketFile) ComplianceWarnings() []ValidationError {
+	frameworks := make([]ComplianceFramework, len(f.ComplianceFrameworks))
+	for i, cf := range f.ComplianceFrameworks {
+		frameworks[i] = ComplianceFramework(cf)
+	}
+	return ValidateIndustryCompliance(IndustryType(f.Industry), frameworks)
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSprintAt(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		// 2025-01-01 is in ISO week 1
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "2025-Q1-Sprint-1"},
+		{time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC), "2025-Q1-Sprint-2"},
+		{time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), "2025-Q2-Sprint-2"},
+		{time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), "2025-Q4-Sprint-1"},
+	}
+
+	for _, tt := range tests {
+		if got := SprintAt(tt.date); got != tt.want {
+			t.Errorf("SprintAt(%s) = %q, want %q", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+// roundTrip converts t to its file form and back, and checks that writing
+// the result again gives the same file
+func roundTrip(t *testing.T, ticket *Ticket) (*TicketFile, *Ticket) {
+	t.Helper()
+	file := ticket.ToLocalFile()
+	back := TicketFromLocalFile(file)
+
+	want, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(back.ToLocalFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("file changed over a round trip:\n got %s\nwant %s", got, want)
+	}
+	return file, back
+}
+
+func TestTicketFileRoundTrip(t *testing.T) {
+	created := time.Date(2025, 3, 4, 10, 30, 15, 500, time.UTC)
+	changeType := "standard"
+	rollback := "Restore the previous config"
+	ticket := &Ticket{
+		TicketNumber:          "CHG-2025-00042",
+		Title:                 "Rotate TLS certificates",
+		Description:           "Rotate the edge certificates before expiry",
+		Status:                TicketStatusSubmitted,
+		Priority:              TicketPriorityHigh,
+		RiskLevel:             RiskLevelMedium,
+		Industry:              IndustryFinance,
+		ChangeType:            &changeType,
+		RollbackPlan:          &rollback,
+		AffectedSystems:       []string{"edge", "cdn"},
+		Labels:                []string{"security", "certs"},
+		AttachmentURLs:        []string{"https://files.example.com/plan.pdf"},
+		ComplianceFrameworks:  []ComplianceFramework{ComplianceSOX},
+		RequiresApprovalTypes: []ApprovalType{ApprovalTypeSecurity, ApprovalTypeIT},
+		Approvals: []Approval{
+			{ApprovalType: ApprovalTypeSecurity, Status: ApprovalStatusApproved},
+			{ApprovalType: ApprovalTypeIT, Status: ApprovalStatusPending},
+		},
+		Creator:  &UserSummary{Email: "alice@example.com"},
+		Assignee: &UserSummary{Email: "bob@example.com"},
+		Comments: []Comment{
+			{Comment: "Scheduled for Tuesday", CreatedAt: created, Author: &UserSummary{Email: "bob@example.com"}},
+			{Comment: "Imported note", CreatedAt: created},
+		},
+		CustomFields: json.RawMessage(`{"sprint":"2025-Q1-Sprint-2","dependencies":["CHG-2025-00041"],"epic":"CHG-2025-00001",` +
+			`"external_references":[{"system":"github","id":"acme/edge#12","url":"https://github.com/acme/edge/issues/12"}]}`),
+		CreatedAt: created,
+		UpdatedAt: created.Add(time.Hour),
+	}
+
+	file, back := roundTrip(t, ticket)
+
+	if file.Assignee == nil || *file.Assignee != "bob@example.com" || back.Assignee == nil || back.Assignee.Email != "bob@example.com" {
+		t.Errorf("assignee: file %v, ticket %+v", file.Assignee, back.Assignee)
+	}
+	if !reflect.DeepEqual(back.Labels, ticket.Labels) {
+		t.Errorf("labels = %v, want %v", back.Labels, ticket.Labels)
+	}
+	if !reflect.DeepEqual(file.Approvals, []string{"security"}) {
+		t.Errorf("file approvals = %v, want only the approved security approval", file.Approvals)
+	}
+	if len(back.Approvals) != 1 || back.Approvals[0].ApprovalType != ApprovalTypeSecurity || back.Approvals[0].Status != ApprovalStatusApproved {
+		t.Errorf("approvals = %+v", back.Approvals)
+	}
+	if len(back.Comments) != 2 || back.Comments[0].Author == nil || back.Comments[0].Author.Email != "bob@example.com" ||
+		back.Comments[1].Author != nil || !back.Comments[0].CreatedAt.Equal(created.Truncate(time.Second)) {
+		t.Errorf("comments = %+v", back.Comments)
+	}
+	if file.Sprint != "2025-Q1-Sprint-2" || file.Epic != "CHG-2025-00001" || !reflect.DeepEqual(file.Dependencies, []string{"CHG-2025-00041"}) {
+		t.Errorf("custom fields: sprint %q, epic %q, dependencies %v", file.Sprint, file.Epic, file.Dependencies)
+	}
+	if len(file.ExternalReferences) != 1 || file.ExternalReferences[0].System != "github" {
+		t.Errorf("file external references = %+v", file.ExternalReferences)
+	}
+	if back.ExternalReference == nil || *back.ExternalReference != "https://github.com/acme/edge/issues/12" {
+		t.Errorf("external reference = %v, want the first reference's URL", back.ExternalReference)
+	}
+	if back.TestingPlan != nil || back.ChangeType == nil || *back.ChangeType != changeType {
+		t.Errorf("optional plans: testing %v, change type %v", back.TestingPlan, back.ChangeType)
+	}
+}
+
+func TestTicketFileRoundTripExternalReference(t *testing.T) {
+	ref := "https://jira.example.com/browse/OPS-7"
+	file, back := roundTrip(t, &Ticket{TicketNumber: "CHG-2025-00007", ExternalReference: &ref})
+
+	if len(file.ExternalReferences) != 1 || file.ExternalReferences[0].URL != ref {
+		t.Errorf("file external references = %+v, want the ticket's reference", file.ExternalReferences)
+	}
+	if back.ExternalReference == nil || *back.ExternalReference != ref {
+		t.Errorf("external reference = %v, want %s", back.ExternalReference, ref)
+	}
+}
+
+func TestTicketFileRoundTripEmpty(t *testing.T) {
+	file, back := roundTrip(t, &Ticket{TicketNumber: "CHG-2025-00001"})
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"compliance_frameworks", "affected_systems", "acceptance_criteria", "approvals_required", "approvals", "dependencies", "comments"} {
+		if !strings.Contains(string(data), `"`+field+`":[]`) {
+			t.Errorf("%s is not written as []: %s", field, data)
+		}
+	}
+	for _, field := range []string{"labels", "attachments", "external_references", "parent", "epic"} {
+		if strings.Contains(string(data), `"`+field+`"`) {
+			t.Errorf("empty %s is written: %s", field, data)
+		}
+	}
+	if file.Assignee != nil || file.CreatedBy != "" {
+		t.Errorf("file users: assignee %v, created by %q", file.Assignee, file.CreatedBy)
+	}
+
+	if back.Creator != nil || back.Assignee != nil || back.ChangeType != nil || back.TestingPlan != nil ||
+		back.RollbackPlan != nil || back.ExternalReference != nil || back.CustomFields != nil {
+		t.Errorf("empty optional fields are set after a round trip: %+v", back)
+	}
+	if back.Labels != nil || back.Approvals != nil || back.Comments != nil {
+		t.Errorf("empty lists are set after a round trip: labels %v, approvals %v, comments %v", back.Labels, back.Approvals, back.Comments)
+	}
+}
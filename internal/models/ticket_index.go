@@ -0,0 +1,42 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// TicketIndex is a full-text index over ticket titles, descriptions,
+// labels and ticket numbers. Stores keep it current by indexing tickets as
+// they are created and updated and removing them when deleted.
+type TicketIndex interface {
+	// Index adds a ticket, replacing what was indexed for it before
+	Index(t *Ticket)
+
+	// Remove drops a ticket from the index
+	Remove(ticketID uuid.UUID)
+
+	// Search returns the IDs of the tickets whose indexed text has every
+	// term of query, each contained in one of its tokens, and that carry
+	// every label in filter.Labels. Terms match inside words as List's
+	// search always has, so "failover" finds "dbfailover". An empty query
+	// matches on labels alone. Other filter fields are left to the caller.
+	Search(query string, filter TicketListFilter) []uuid.UUID
+}
+
+// TicketSearchTokens splits text into the lower-case letter and digit runs
+// that TicketIndex matches on, e.g. "CHG-2025-00001: DB failover" becomes
+// chg, 2025, 00001, db and failover
+func TicketSearchTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// TicketIndexText returns the tokens a TicketIndex indexes for a ticket
+func TicketIndexText(t *Ticket) []string {
+	parts := []string{t.TicketNumber, t.Title, t.Description}
+	parts = append(parts, t.Labels...)
+	return TicketSearchTokens(strings.Join(parts, " "))
+}
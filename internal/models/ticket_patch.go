@@ -0,0 +1,153 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergePatchContentType is the media type for RFC 7386 JSON merge patches
+const MergePatchContentType = "application/merge-patch+json"
+
+// immutableTicketFields can never change after a ticket is created
+var immutableTicketFields = map[string]bool{
+	"id":              true,
+	"ticket_number":   true,
+	"created_at":      true,
+	"organization_id": true,
+	"created_by":      true,
+}
+
+// managedTicketFields are maintained by the workflow endpoints or the store
+// and cannot be set through a patch
+var managedTicketFields = map[string]bool{
+	"status":             true,
+	"version":            true,
+	"updated_at":         true,
+	"submitted_at":       true,
+	"submitted_snapshot": true,
+	"closed_at":          true,
+	"deleted_at":         true,
+	"deletion_reason":    true,
+	"watchers":           true,
+}
+
+// MergePatch applies an RFC 7386 JSON merge patch to a JSON document
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target interface{}
+	if len(doc) > 0 {
+		if err := json.Unmarshal(doc, &target); err != nil {
+			return nil, fmt.Errorf("invalid document: %w", err)
+		}
+	}
+
+	var p interface{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+
+	return json.Marshal(mergeValue(target, p))
+}
+
+// mergeValue implements the MergePatch algorithm from RFC 7386 section 2
+func mergeValue(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{})
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergeValue(targetObj[key], value)
+	}
+	return targetObj
+}
+
+// ApplyMergePatch returns a copy of the ticket with the merge patch applied.
+// Patches must be JSON objects and may not touch immutable or
+// workflow-managed fields.
+func (t *Ticket) ApplyMergePatch(patch []byte) (*Ticket, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		return nil, fmt.Errorf("merge patch must be a JSON object: %w", err)
+	}
+
+	var rejected []string
+	for key := range fields {
+		if immutableTicketFields[key] || managedTicketFields[key] || diffIgnoredFields[key] {
+			rejected = append(rejected, key)
+		}
+	}
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		return nil, fmt.Errorf("patch cannot modify fields: %s", strings.Join(rejected, ", "))
+	}
+
+	current, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ticket: %w", err)
+	}
+
+	merged, err := MergePatch(current, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched Ticket
+	if err := json.Unmarshal(merged, &patched); err != nil {
+		return nil, fmt.Errorf("patched ticket is invalid: %w", err)
+	}
+	return &patched, nil
+}
+
+// Validate checks the ticket's fields against the same rules enforced when
+// tickets are created
+func (t *Ticket) Validate() error {
+	if n := len(t.Title); n < 5 || n > 500 {
+		return fmt.Errorf("title must be between 5 and 500 characters")
+	}
+	if len(t.Description) < 10 {
+		return fmt.Errorf("description must be at least 10 characters")
+	}
+	if !t.Priority.Valid() {
+		return fmt.Errorf("invalid priority: %s", t.Priority)
+	}
+	if !t.RiskLevel.Valid() {
+		return fmt.Errorf("invalid risk level: %s", t.RiskLevel)
+	}
+	if !t.Industry.Valid() {
+		return fmt.Errorf("invalid industry: %s", t.Industry)
+	}
+	if len(t.ComplianceFrameworks) == 0 {
+		return fmt.Errorf("at least one compliance framework is required")
+	}
+	for _, f := range t.ComplianceFrameworks {
+		if !f.Valid() {
+			return fmt.Errorf("invalid compliance framework: %s", f)
+		}
+	}
+	for _, a := range t.RequiresApprovalTypes {
+		if !a.Valid() {
+			return fmt.Errorf("invalid approval type: %s", a)
+		}
+	}
+	if t.ScheduledStart != nil && t.ScheduledEnd != nil && t.ScheduledEnd.Before(*t.ScheduledStart) {
+		return fmt.Errorf("scheduled_end must be after scheduled_start")
+	}
+	if t.ParentTicketID != nil && *t.ParentTicketID == t.ID {
+		return fmt.Errorf("ticket cannot be its own parent")
+	}
+	if t.EpicID != nil && *t.EpicID == t.ID {
+		return fmt.Errorf("ticket cannot be its own epic")
+	}
+	return nil
+}
@@ -20,6 +20,11 @@ func (i IndustryType) Valid() bool {
 	return false
 }
 
+// AllIndustries returns every valid industry type
+func AllIndustries() []IndustryType {
+	return []IndustryType{IndustryHealthcare, IndustryIT, IndustryGovernment, IndustryInsurance, IndustryFinance}
+}
+
 // ComplianceFramework represents regulatory compliance frameworks
 type ComplianceFramework string
 
@@ -41,6 +46,11 @@ func (c ComplianceFramework) Valid() bool {
 	return false
 }
 
+// AllFrameworks returns every valid compliance framework
+func AllFrameworks() []ComplianceFramework {
+	return []ComplianceFramework{ComplianceGLBA, ComplianceSOX, ComplianceHIPAA, ComplianceBankingSecrecyAct, ComplianceGDPR, ComplianceCustom}
+}
+
 // TicketStatus represents the status of a change ticket
 type TicketStatus string
 
@@ -69,6 +79,15 @@ func (t TicketStatus) Valid() bool {
 	return false
 }
 
+// AllTicketStatuses returns every valid ticket status in lifecycle order
+func AllTicketStatuses() []TicketStatus {
+	return []TicketStatus{
+		TicketStatusDraft, TicketStatusSubmitted, TicketStatusInReview, TicketStatusApproved,
+		TicketStatusPartiallyApproved, TicketStatusDenied, TicketStatusUpdateRequested,
+		TicketStatusImplementing, TicketStatusCompleted, TicketStatusClosed, TicketStatusCancelled,
+	}
+}
+
 // IsOpen returns true if the ticket is in an open state
 func (t TicketStatus) IsOpen() bool {
 	switch t {
@@ -103,6 +122,14 @@ func (a ApprovalType) Valid() bool {
 	return false
 }
 
+// AllApprovalTypes returns every valid approval type
+func AllApprovalTypes() []ApprovalType {
+	return []ApprovalType{
+		ApprovalTypeOperations, ApprovalTypeIT, ApprovalTypeRisk, ApprovalTypeChangeManagementBoard,
+		ApprovalTypeAIOps, ApprovalTypeSecurity, ApprovalTypeNetworkEngineering, ApprovalTypeCloud,
+	}
+}
+
 // DisplayName returns a human-readable name for the approval type
 func (a ApprovalType) DisplayName() string {
 	switch a {
@@ -166,6 +193,11 @@ func (p TicketPriority) Valid() bool {
 	return false
 }
 
+// AllPriorities returns every valid priority, most urgent first
+func AllPriorities() []TicketPriority {
+	return []TicketPriority{TicketPriorityEmergency, TicketPriorityUrgent, TicketPriorityHigh, TicketPriorityNormal, TicketPriorityLow}
+}
+
 // RiskLevel represents risk assessment levels
 type RiskLevel string
 
@@ -185,6 +217,11 @@ func (r RiskLevel) Valid() bool {
 	return false
 }
 
+// AllRiskLevels returns every valid risk level, highest first
+func AllRiskLevels() []RiskLevel {
+	return []RiskLevel{RiskLevelCritical, RiskLevelHigh, RiskLevelMedium, RiskLevelLow}
+}
+
 // UserRole represents user roles in the system
 type UserRole string
 
@@ -0,0 +1,213 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/config"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// WebhookEvent identifies a ticket lifecycle event
+type WebhookEvent string
+
+const (
+	EventTicketCreated   WebhookEvent = "ticket.created"
+	EventTicketSubmitted WebhookEvent = "ticket.submitted"
+	EventTicketClosed    WebhookEvent = "ticket.closed"
+	EventTicketMentioned WebhookEvent = "ticket.mentioned"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the request body
+const SignatureHeader = "X-Signature"
+
+// WebhookPayload is the JSON body POSTed to webhook endpoints
+type WebhookPayload struct {
+	ID        uuid.UUID            `json:"id"`
+	Event     WebhookEvent         `json:"event"`
+	Timestamp time.Time            `json:"timestamp"`
+	Ticket    models.TicketSummary `json:"ticket"`
+
+	// Mentions lists the users mentioned in a comment for ticket.mentioned
+	Mentions  []models.UserSummary `json:"mentions,omitempty"`
+	CommentID *uuid.UUID           `json:"comment_id,omitempty"`
+}
+
+// WebhookDispatcher delivers signed ticket events to registered endpoints
+type WebhookDispatcher struct {
+	endpoints    []config.WebhookEndpoint
+	client       *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	logger       *zap.Logger
+}
+
+// NewWebhookDispatcher creates a dispatcher from config. It returns nil when
+// webhooks are disabled; a nil dispatcher silently drops events.
+func NewWebhookDispatcher(cfg *config.WebhookConfig, logger *zap.Logger) *WebhookDispatcher {
+	if cfg == nil || !cfg.Enabled || len(cfg.Endpoints) == 0 {
+		return nil
+	}
+
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &WebhookDispatcher{
+		endpoints:    cfg.Endpoints,
+		client:       &http.Client{Timeout: timeout},
+		maxRetries:   cfg.MaxRetries,
+		retryBackoff: time.Second,
+		logger:       logger,
+	}
+}
+
+// Dispatch sends the event to every subscribed endpoint in the background
+func (d *WebhookDispatcher) Dispatch(event WebhookEvent, ticket *models.Ticket) {
+	if d == nil || ticket == nil {
+		return
+	}
+
+	d.dispatch(WebhookPayload{
+		ID:        uuid.New(),
+		Event:     event,
+		Timestamp: time.Now().UTC(),
+		Ticket:    ticket.ToSummary(),
+	})
+}
+
+// DispatchMention notifies endpoints that users were mentioned in a comment
+func (d *WebhookDispatcher) DispatchMention(ticket *models.Ticket, commentID uuid.UUID, mentioned []models.UserSummary) {
+	if d == nil || ticket == nil || len(mentioned) == 0 {
+		return
+	}
+
+	d.dispatch(WebhookPayload{
+		ID:        uuid.New(),
+		Event:     EventTicketMentioned,
+		Timestamp: time.Now().UTC(),
+		Ticket:    ticket.ToSummary(),
+		Mentions:  mentioned,
+		CommentID: &commentID,
+	})
+}
+
+// dispatch marshals a payload and delivers it to subscribed endpoints
+func (d *WebhookDispatcher) dispatch(payload WebhookPayload) {
+	event := payload.Event
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		d.logger.Error("Failed to marshal webhook payload",
+			zap.String("event", string(event)),
+			zap.Error(err),
+		)
+		return
+	}
+
+	for _, endpoint := range d.endpoints {
+		if !subscribed(endpoint, event) {
+			continue
+		}
+		go d.deliver(context.Background(), endpoint, payload, body)
+	}
+}
+
+// deliver POSTs a payload to a single endpoint, retrying 5xx and network
+// failures with exponential backoff
+func (d *WebhookDispatcher) deliver(ctx context.Context, endpoint config.WebhookEndpoint, payload WebhookPayload, body []byte) {
+	signature := Sign(endpoint.Secret, body)
+	backoff := d.retryBackoff
+
+	for attempt := 1; attempt <= d.maxRetries+1; attempt++ {
+		start := time.Now()
+		status, err := d.post(ctx, endpoint.URL, signature, payload, body)
+
+		fields := []zap.Field{
+			zap.String("delivery_id", payload.ID.String()),
+			zap.String("event", string(payload.Event)),
+			zap.String("url", endpoint.URL),
+			zap.Int("attempt", attempt),
+			zap.Int("status", status),
+			zap.Duration("duration", time.Since(start)),
+		}
+
+		if err == nil && status < 300 {
+			d.logger.Info("Webhook delivered", fields...)
+			return
+		}
+
+		retryable := err != nil || status >= 500
+		if err != nil {
+			fields = append(fields, zap.Error(err))
+		}
+		if !retryable || attempt > d.maxRetries {
+			d.logger.Warn("Webhook delivery failed", fields...)
+			return
+		}
+
+		d.logger.Info("Webhook delivery attempt failed, retrying", append(fields, zap.Duration("backoff", backoff))...)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post performs a single delivery attempt
+func (d *WebhookDispatcher) post(ctx context.Context, url, signature string, payload WebhookPayload, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "adsops-webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", string(payload.Event))
+	req.Header.Set("X-Webhook-ID", payload.ID.String())
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of body, prefixed with "sha256="
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// subscribed reports whether the endpoint wants the event
+func subscribed(endpoint config.WebhookEndpoint, event WebhookEvent) bool {
+	if len(endpoint.Events) == 0 {
+		return true
+	}
+	for _, e := range endpoint.Events {
+		if e == string(event) || e == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+// Package apiclient is the authenticated HTTP client shared by the CLI
+// subcommands that call AfterDark APIs. It resolves base URLs and tokens,
+// sends bearer-authenticated JSON requests with retries, and turns error
+// responses into *Error values.
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/pkg/clock"
+	"github.com/spf13/viper"
+)
+
+// Client defaults
+const (
+	DefaultTimeout = 30 * time.Second
+	DefaultRetries = 3
+)
+
+// ErrNotAuthenticated is returned when no token could be found
+var ErrNotAuthenticated = errors.New("not authenticated")
+
+// Client sends requests to one API. Token is sent as a bearer token when
+// set. Timeout and Retries apply to every request; see Do for which
+// requests are retried.
+type Client struct {
+	BaseURL string
+	Token   string
+	Timeout time.Duration
+	Retries int
+
+	// Clock resolves Retry-After dates; Sleep waits between retries
+	Clock clock.Clock
+	Sleep func(time.Duration)
+}
+
+// New creates a client for baseURL with the default timeout and retries
+func New(baseURL, token string) *Client {
+	return &Client{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Token:   token,
+		Timeout: DefaultTimeout,
+		Retries: DefaultRetries,
+		Clock:   clock.System(),
+		Sleep:   time.Sleep,
+	}
+}
+
+// Resolve returns the first non-empty of value, the config key and the
+// environment variable, or def. An empty configKey or envVar is skipped.
+func Resolve(value, configKey, envVar, def string) string {
+	if value == "" && configKey != "" {
+		value = viper.GetString(configKey)
+	}
+	if value == "" && envVar != "" {
+		value = os.Getenv(envVar)
+	}
+	if value == "" {
+		value = def
+	}
+	return value
+}
+
+// ReadTokenFile reads a token stored on its own in path, as written by
+// 'changes auth login'. It returns ErrNotAuthenticated when the file is
+// missing or empty.
+func ReadTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrNotAuthenticated
+		}
+		return "", err
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", ErrNotAuthenticated
+	}
+	return token, nil
+}
+
+// Error is an API response with an error status. Message is the response's
+// "error" or "message" field when it is JSON, otherwise the raw body.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return "unauthorized - please login again"
+	case http.StatusForbidden:
+		return "forbidden - insufficient privileges"
+	}
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// StatusCode returns the HTTP status of an *Error, or 0 for other errors
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+func newError(status int, body []byte) *Error {
+	var fields struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	msg := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &fields) == nil {
+		if fields.Error != "" {
+			msg = fields.Error
+		} else if fields.Message != "" {
+			msg = fields.Message
+		}
+	}
+	return &Error{StatusCode: status, Message: msg}
+}
+
+// Request sends body, if any, as JSON to BaseURL+endpoint and returns the
+// response body. Responses with status 400 and above return an *Error.
+func (c *Client) Request(method, endpoint string, body []byte) ([]byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, c.BaseURL+endpoint, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, newError(resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
+
+// JSON sends in, if not nil, as the request body and decodes the response
+// into out, if not nil
+func (c *Client) JSON(method, endpoint string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	respBody, err := c.Request(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package apiclient
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Profile says where one API's base URL and token are configured. Each is
+// taken from the first that is set of the command's flag, the config key
+// and the environment variable; the URL then falls back to DefaultURL and
+// the token to TokenFile. Empty keys, variables and files are skipped.
+type Profile struct {
+	URLKey     string
+	URLEnv     string
+	DefaultURL string
+
+	TokenKey string
+	TokenEnv string
+
+	// TokenFile holds only the token, as written by Login. Environment
+	// variables such as $HOME are expanded.
+	TokenFile string
+
+	// SessionFile is where Login saves an expiring session, for APIs whose
+	// command package manages refresh itself. Variables are expanded.
+	SessionFile string
+
+	// Login is the command that authenticates against the API. When set, a
+	// missing token is an error pointing at it; otherwise requests are
+	// sent without one.
+	Login string
+}
+
+// Profiles of the AfterDark APIs the CLI calls
+var (
+	ChangesAPI = Profile{
+		URLKey:     "api.url",
+		URLEnv:     "CHANGES_API_URL",
+		DefaultURL: "https://api.changes.afterdarksys.com",
+		TokenKey:   "api.token",
+		TokenEnv:   "CHANGES_API_TOKEN",
+	}
+
+	LoginAPI = Profile{
+		URLKey:     "login_api_url",
+		DefaultURL: "https://login.afterdarksys.com",
+		TokenKey:   "auth_token",
+		TokenFile:  "$HOME/.config/afterdark/token",
+		Login:      "changes auth login",
+	}
+
+	EntitlementsAPI = Profile{
+		URLKey:      "entitlements_api_url",
+		URLEnv:      "ENTITLEMENTS_API_URL",
+		DefaultURL:  "https://billing.afterdarksys.com",
+		TokenEnv:    "ENTITLEMENTS_API_KEY",
+		SessionFile: "$HOME/.adsops-utils/entitlements-auth.json",
+		Login:       "changes entitlement login",
+	}
+)
+
+// URL returns the API's base URL, flag taking precedence when set
+func (p Profile) URL(flag string) string {
+	return Resolve(flag, p.URLKey, p.URLEnv, p.DefaultURL)
+}
+
+// Token returns the API's token, flag taking precedence when set. It
+// returns ErrNotAuthenticated when none is configured.
+func (p Profile) Token(flag string) (string, error) {
+	if token := Resolve(flag, p.TokenKey, p.TokenEnv, ""); token != "" {
+		return token, nil
+	}
+	if p.TokenFile == "" {
+		return "", ErrNotAuthenticated
+	}
+	return ReadTokenFile(os.ExpandEnv(p.TokenFile))
+}
+
+// SessionPath returns the expanded SessionFile
+func (p Profile) SessionPath() string {
+	return os.ExpandEnv(p.SessionFile)
+}
+
+// FromConfig returns a client for the API p describes. urlFlag and
+// tokenFlag are the command's --api-url and --token values, or empty.
+func FromConfig(p Profile, urlFlag, tokenFlag string) (*Client, error) {
+	token, err := p.Token(tokenFlag)
+	switch {
+	case errors.Is(err, ErrNotAuthenticated) && p.Login != "":
+		return nil, fmt.Errorf("%w. Run '%s' first", err, p.Login)
+	case errors.Is(err, ErrNotAuthenticated):
+		token = ""
+	case err != nil:
+		return nil, fmt.Errorf("failed to read token: %w", err)
+	}
+	return New(p.URL(urlFlag), token), nil
+}
@@ -0,0 +1,97 @@
+package apiclient
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func testProfile(t *testing.T) Profile {
+	t.Helper()
+	t.Cleanup(viper.Reset)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("TEST_API_URL", "")
+	t.Setenv("TEST_API_TOKEN", "")
+	return Profile{
+		URLKey:     "test.url",
+		URLEnv:     "TEST_API_URL",
+		DefaultURL: "https://api.example.com",
+		TokenKey:   "test.token",
+		TokenEnv:   "TEST_API_TOKEN",
+		TokenFile:  "$HOME/token",
+		Login:      "changes auth login",
+	}
+}
+
+func TestProfileToken(t *testing.T) {
+	p := testProfile(t)
+
+	if _, err := p.Token(""); !errors.Is(err, ErrNotAuthenticated) {
+		t.Fatalf("Token() with nothing configured = %v, want ErrNotAuthenticated", err)
+	}
+
+	steps := []struct {
+		name string
+		set  func()
+		want string
+	}{
+		{"token file", func() { os.WriteFile(filepath.Join(os.Getenv("HOME"), "token"), []byte("from-file\n"), 0600) }, "from-file"},
+		{"environment", func() { t.Setenv("TEST_API_TOKEN", "from-env") }, "from-env"},
+		{"config", func() { viper.Set("test.token", "from-config") }, "from-config"},
+	}
+	for _, step := range steps {
+		step.set()
+		if got, err := p.Token(""); err != nil || got != step.want {
+			t.Errorf("after setting the %s, Token() = %q, %v; want %q", step.name, got, err, step.want)
+		}
+	}
+
+	if got, _ := p.Token("from-flag"); got != "from-flag" {
+		t.Errorf("Token(flag) = %q, want the flag", got)
+	}
+}
+
+func TestProfileURL(t *testing.T) {
+	p := testProfile(t)
+
+	if got := p.URL(""); got != "https://api.example.com" {
+		t.Errorf("URL() = %q, want the default", got)
+	}
+	t.Setenv("TEST_API_URL", "https://env.example.com")
+	if got := p.URL(""); got != "https://env.example.com" {
+		t.Errorf("URL() = %q, want the environment value", got)
+	}
+	viper.Set("test.url", "https://config.example.com")
+	if got := p.URL(""); got != "https://config.example.com" {
+		t.Errorf("URL() = %q, want the config value", got)
+	}
+	if got := p.URL("https://flag.example.com"); got != "https://flag.example.com" {
+		t.Errorf("URL(flag) = %q, want the flag", got)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	p := testProfile(t)
+
+	if _, err := FromConfig(p, "", ""); !errors.Is(err, ErrNotAuthenticated) {
+		t.Errorf("FromConfig() without a token = %v, want ErrNotAuthenticated", err)
+	}
+
+	p.Login = ""
+	client, err := FromConfig(p, "https://flag.example.com/", "")
+	if err != nil {
+		t.Fatalf("FromConfig() for an API without a login command: %v", err)
+	}
+	if client.BaseURL != "https://flag.example.com" || client.Token != "" {
+		t.Errorf("client = %q with token %q, want the flag URL and no token", client.BaseURL, client.Token)
+	}
+
+	client, err = FromConfig(p, "", "secret")
+	if err != nil || client.Token != "secret" {
+		t.Errorf("FromConfig() with a token flag = %v, %v; want token secret", client, err)
+	}
+}
@@ -0,0 +1,121 @@
+package apiclient
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Backoff starts at retryBaseDelay and doubles per attempt up to
+// retryMaxDelay; Retry-After values are capped at retryAfterMax
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
+	retryAfterMax  = 60 * time.Second
+)
+
+// Do sends req with the client's token and timeout, retrying with
+// exponential backoff. GET and HEAD requests are retried on network errors,
+// 429 and 5xx responses, honoring Retry-After. Other methods are only
+// retried when the connection could not be established, since the server
+// cannot have seen them. Requests with a body must be built with a
+// replayable body (as http.NewRequest does for strings.Reader and
+// bytes.Reader). A request that already carries an Authorization header
+// keeps it.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.Token != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	sleep := c.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	client := &http.Client{Timeout: timeout}
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			if attempt < c.Retries && (idempotent || isDialError(err)) {
+				sleep(backoff(attempt))
+				continue
+			}
+			return nil, err
+		}
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !idempotent || !retryable || attempt >= c.Retries {
+			return resp, nil
+		}
+
+		delay := backoff(attempt)
+		if d, ok := c.retryAfter(resp.Header.Get("Retry-After")); ok {
+			delay = d
+		}
+		resp.Body.Close()
+		sleep(delay)
+	}
+}
+
+// backoff returns the delay before retry number attempt+1
+func backoff(attempt int) time.Duration {
+	d := retryBaseDelay << attempt
+	if d <= 0 || d > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return d
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func (c *Client) retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	if secs, err := strconv.Atoi(value); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		now := time.Now()
+		if c.Clock != nil {
+			now = c.Clock.Now()
+		}
+		d = t.Sub(now)
+	} else {
+		return 0, false
+	}
+
+	if d < 0 {
+		d = 0
+	}
+	if d > retryAfterMax {
+		d = retryAfterMax
+	}
+	return d, true
+}
+
+// isDialError reports whether err happened while connecting, before any of
+// the request was sent
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
@@ -0,0 +1,201 @@
+// Package ticketnum generates local change ticket numbers. Numbers are a
+// prefix, optionally the year, and a zero-padded sequence, e.g.
+// CHG-2025-00001 or OPS-0042. The format is read from .changes.json in the
+// working directory and CHANGES_TICKET_* environment variables.
+package ticketnum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigFile is the project file the format is read from, relative to the
+// working directory
+const ConfigFile = ".changes.json"
+
+// Defaults keep the original CHG-YYYY-NNNNN numbers
+const (
+	DefaultPrefix = "CHG-"
+	DefaultWidth  = 5
+	maxWidth      = 12
+)
+
+// Environment variables that override ConfigFile
+const (
+	EnvPrefix      = "CHANGES_TICKET_PREFIX"
+	EnvWidth       = "CHANGES_TICKET_WIDTH"
+	EnvIncludeYear = "CHANGES_TICKET_INCLUDE_YEAR"
+)
+
+// Format describes how ticket numbers are built: Prefix, then the year and
+// a dash when IncludeYear is set, then the sequence zero-padded to Width
+// digits
+type Format struct {
+	Prefix      string
+	Width       int
+	IncludeYear bool
+}
+
+// Default returns the CHG-YYYY-NNNNN format
+func Default() Format {
+	return Format{Prefix: DefaultPrefix, Width: DefaultWidth, IncludeYear: true}
+}
+
+// fileConfig is the ticket_number section of ConfigFile. Unset fields keep
+// their defaults.
+type fileConfig struct {
+	TicketNumber struct {
+		Prefix      *string `json:"prefix"`
+		Width       *int    `json:"width"`
+		IncludeYear *bool   `json:"include_year"`
+	} `json:"ticket_number"`
+}
+
+// Load returns the default format overridden by ConfigFile, if present,
+// and then by the environment
+func Load() (Format, error) {
+	f := Default()
+
+	data, err := os.ReadFile(ConfigFile)
+	switch {
+	case err == nil:
+		var fc fileConfig
+		if err := json.Unmarshal(data, &fc); err != nil {
+			return f, fmt.Errorf("invalid %s: %w", ConfigFile, err)
+		}
+		if fc.TicketNumber.Prefix != nil {
+			f.Prefix = *fc.TicketNumber.Prefix
+		}
+		if fc.TicketNumber.Width != nil {
+			f.Width = *fc.TicketNumber.Width
+		}
+		if fc.TicketNumber.IncludeYear != nil {
+			f.IncludeYear = *fc.TicketNumber.IncludeYear
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return f, fmt.Errorf("failed to read %s: %w", ConfigFile, err)
+	}
+
+	if v, ok := os.LookupEnv(EnvPrefix); ok {
+		f.Prefix = v
+	}
+	if v := os.Getenv(EnvWidth); v != "" {
+		width, err := strconv.Atoi(v)
+		if err != nil {
+			return f, fmt.Errorf("invalid %s %q: %w", EnvWidth, v, err)
+		}
+		f.Width = width
+	}
+	if v := os.Getenv(EnvIncludeYear); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, fmt.Errorf("invalid %s %q: %w", EnvIncludeYear, v, err)
+		}
+		f.IncludeYear = include
+	}
+
+	return f, f.Validate()
+}
+
+// Validate checks that the format produces usable file names
+func (f Format) Validate() error {
+	if f.Prefix == "" && !f.IncludeYear {
+		return fmt.Errorf("ticket number format needs a prefix or the year")
+	}
+	if strings.ContainsAny(f.Prefix, `/\`) || strings.HasPrefix(f.Prefix, ".") {
+		return fmt.Errorf("invalid ticket number prefix %q", f.Prefix)
+	}
+	if f.Width < 1 || f.Width > maxWidth {
+		return fmt.Errorf("ticket number width must be between 1 and %d, got %d", maxWidth, f.Width)
+	}
+	return nil
+}
+
+// PrefixAt returns the part of the ticket numbers issued at t that comes
+// before the sequence, e.g. "CHG-2025-". Without the year every ticket
+// shares one sequence.
+func (f Format) PrefixAt(t time.Time) string {
+	if f.IncludeYear {
+		return fmt.Sprintf("%s%d-", f.Prefix, t.Year())
+	}
+	return f.Prefix
+}
+
+// Number returns the ticket number for sequence n under prefix
+func (f Format) Number(prefix string, n int) string {
+	return fmt.Sprintf("%s%0*d", prefix, f.Width, n)
+}
+
+// Sequence returns the sequence number of a ticket number or file name
+// under prefix
+func Sequence(name, prefix string) (int, bool) {
+	if !strings.HasPrefix(name, prefix) {
+		return 0, false
+	}
+	digits := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".json")
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// MaxInDir returns the highest sequence number of the ticket files under
+// prefix in dir, or 0 when there are none
+func MaxInDir(dir, prefix string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+
+	var maxNum int
+	for _, entry := range entries {
+		if !IsTicketFile(entry) {
+			continue
+		}
+		if n, ok := Sequence(entry.Name(), prefix); ok && n > maxNum {
+			maxNum = n
+		}
+	}
+	return maxNum
+}
+
+// IsTicketFile reports whether a tickets directory entry is a ticket file.
+// Hidden files such as the migration state are not.
+func IsTicketFile(entry os.DirEntry) bool {
+	name := entry.Name()
+	return !entry.IsDir() && strings.HasSuffix(name, ".json") && !strings.HasPrefix(name, ".")
+}
+
+// NextTicketNumber returns the next ticket number under prefix for the
+// tickets directory dir: one past the highest of the files already there
+// and after, which callers use for numbers issued elsewhere (such as the
+// database). Numbers whose file exists are skipped.
+func (f Format) NextTicketNumber(dir, prefix string, after int) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create tickets directory: %w", err)
+	}
+
+	next := MaxInDir(dir, prefix)
+	if after > next {
+		next = after
+	}
+	next++
+
+	for {
+		id := f.Number(prefix, next)
+		if _, err := os.Stat(filepath.Join(dir, id+".json")); os.IsNotExist(err) {
+			return id, nil
+		}
+		next++
+	}
+}
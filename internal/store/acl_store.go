@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+)
+
+// ACLStore handles ticket ACL database operations
+type ACLStore struct {
+	db *sql.DB
+}
+
+// EffectiveRole resolves the strongest ACL role a user holds on a ticket.
+// Admins, creators and assignees get implicit roles; otherwise the active
+// user, group and role grants are consulted, mirroring check_ticket_access.
+// Users with no grant are treated as viewers.
+func (s *ACLStore) EffectiveRole(ctx context.Context, ticket *models.Ticket, userID uuid.UUID, userRoles []string) (models.TicketACLRole, error) {
+	for _, r := range userRoles {
+		if r == "admin" {
+			return models.TicketACLRoleAdmin, nil
+		}
+	}
+	if ticket.CreatedBy == userID {
+		return models.TicketACLRoleOwner, nil
+	}
+
+	effective := models.TicketACLRoleViewer
+	if ticket.AssignedTo != nil && *ticket.AssignedTo == userID {
+		effective = models.TicketACLRoleEditor
+	}
+
+	query := `
+		SELECT ta.acl_role
+		FROM ticket_acls ta
+		WHERE ta.ticket_id = $1
+		  AND ta.revoked_at IS NULL
+		  AND (ta.expires_at IS NULL OR ta.expires_at > NOW())
+		  AND (
+			(ta.principal_type = 'user' AND ta.principal_id = $2)
+			OR (ta.principal_type = 'group' AND ta.principal_id IN (
+				SELECT group_id FROM group_members WHERE user_id = $2
+			))
+			OR (ta.principal_type = 'role' AND ta.role_name = ANY($3))
+		  )
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, ticket.ID, userID, pq.Array(userRoles))
+	if err != nil {
+		return models.TicketACLRoleViewer, fmt.Errorf("failed to get ticket ACLs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var role models.TicketACLRole
+		if err := rows.Scan(&role); err != nil {
+			return models.TicketACLRoleViewer, fmt.Errorf("failed to scan ticket ACL: %w", err)
+		}
+		if role.Rank() > effective.Rank() {
+			effective = role
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return models.TicketACLRoleViewer, fmt.Errorf("failed to get ticket ACLs: %w", err)
+	}
+
+	return effective, nil
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/pkg/clock"
+	"go.uber.org/zap"
+)
+
+// DefaultACLSweepInterval is how often the ACL sweeper runs when no interval
+// is given
+const DefaultACLSweepInterval = 15 * time.Minute
+
+// ACLSweeper periodically marks ticket ACLs that have passed their expiry as
+// revoked, so expired grants drop out of access checks and ACL listings
+// without waiting for someone to revoke them
+type ACLSweeper struct {
+	tickets  TicketStore
+	interval time.Duration
+	clock    clock.Clock
+	logger   *zap.Logger
+}
+
+// NewACLSweeper creates a sweeper over tickets. A non-positive interval uses
+// DefaultACLSweepInterval and a nil clk uses the system clock.
+func NewACLSweeper(tickets TicketStore, interval time.Duration, clk clock.Clock, logger *zap.Logger) *ACLSweeper {
+	if interval <= 0 {
+		interval = DefaultACLSweepInterval
+	}
+	if clk == nil {
+		clk = clock.System()
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &ACLSweeper{tickets: tickets, interval: interval, clock: clk, logger: logger}
+}
+
+// Sweep marks the ACLs expired as of now and returns how many it marked
+func (s *ACLSweeper) Sweep(ctx context.Context) (int, error) {
+	return s.tickets.ExpireACLs(ctx, s.clock.Now())
+}
+
+// Run sweeps once immediately and then every interval until ctx is done
+func (s *ACLSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.Sweep(ctx)
+		switch {
+		case err != nil:
+			s.logger.Error("ACL expiry sweep failed", zap.Error(err))
+		case n > 0:
+			s.logger.Info("Marked expired ticket ACLs", zap.Int("count", n))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -17,8 +17,14 @@ type AuditStore struct {
 	db *sql.DB
 }
 
-// LogTicketAccess logs an access event for a ticket (SOX compliance)
+// LogTicketAccess logs an access event for a ticket (SOX compliance). An
+// AuditStore without a database, as paired with a MemoryTicketStore, logs
+// nothing.
 func (s *AuditStore) LogTicketAccess(ctx context.Context, ticketID, userID uuid.UUID, action string, ipAddress, userAgent *string, changes map[string]interface{}) error {
+	if s.db == nil {
+		return nil
+	}
+
 	// Get ticket org and compliance info
 	var orgID uuid.UUID
 	var complianceFrameworks []string
@@ -72,46 +78,82 @@ func (s *AuditStore) LogTicketStatusChange(ctx context.Context, ticketID, userID
 
 // GetTicketAuditLog retrieves audit log entries for a ticket
 func (s *AuditStore) GetTicketAuditLog(ctx context.Context, ticketID uuid.UUID, filter *models.AuditLogFilter) ([]models.TicketAuditLog, int, error) {
-	filter.SetDefaults()
+	scoped := *filter
+	scoped.TicketID = &ticketID
+	return s.listAuditLog(ctx, nil, &scoped)
+}
 
-	var conditions []string
-	var args []interface{}
-	argNum := 1
+// GetOrganizationAuditLog retrieves audit log entries across an organization
+func (s *AuditStore) GetOrganizationAuditLog(ctx context.Context, orgID uuid.UUID, filter *models.AuditLogFilter) ([]models.TicketAuditLog, int, error) {
+	return s.listAuditLog(ctx, &orgID, filter)
+}
 
-	conditions = append(conditions, fmt.Sprintf("ticket_id = $%d", argNum))
-	args = append(args, ticketID)
-	argNum++
+// SummarizeAuditLog counts matching audit log entries grouped by action,
+// action category, and compliance framework
+func (s *AuditStore) SummarizeAuditLog(ctx context.Context, orgID uuid.UUID, filter *models.AuditLogFilter) (*models.AuditReport, error) {
+	conditions, args := buildAuditConditions(&orgID, filter)
+	whereClause := strings.Join(conditions, " AND ")
 
-	if filter.UserID != nil {
-		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argNum))
-		args = append(args, *filter.UserID)
-		argNum++
+	report := &models.AuditReport{
+		OrganizationID: orgID,
+		FromDate:       filter.FromDate,
+		ToDate:         filter.ToDate,
+		ByAction:       make(map[string]int),
+		ByCategory:     make(map[string]int),
+		ByFramework:    make(map[string]int),
 	}
 
-	if filter.Action != nil {
-		conditions = append(conditions, fmt.Sprintf("action = $%d", argNum))
-		args = append(args, *filter.Action)
-		argNum++
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM ticket_audit_log WHERE %s", whereClause)
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&report.TotalEntries); err != nil {
+		return nil, fmt.Errorf("failed to count audit logs: %w", err)
 	}
 
-	if filter.ActionCategory != nil {
-		conditions = append(conditions, fmt.Sprintf("action_category = $%d", argNum))
-		args = append(args, *filter.ActionCategory)
-		argNum++
+	groupings := []struct {
+		column string
+		into   map[string]int
+	}{
+		{"action", report.ByAction},
+		{"action_category", report.ByCategory},
+		{"unnest(compliance_frameworks)", report.ByFramework},
 	}
 
-	if filter.IsComplianceRelevant != nil {
-		conditions = append(conditions, fmt.Sprintf("is_compliance_relevant = $%d", argNum))
-		args = append(args, *filter.IsComplianceRelevant)
-		argNum++
+	for _, g := range groupings {
+		query := fmt.Sprintf(
+			"SELECT %s AS key, COUNT(*) FROM ticket_audit_log WHERE %s GROUP BY key",
+			g.column, whereClause,
+		)
+		rows, err := s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize audit logs: %w", err)
+		}
+		for rows.Next() {
+			var key string
+			var count int
+			if err := rows.Scan(&key, &count); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan audit summary: %w", err)
+			}
+			g.into[key] = count
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize audit logs: %w", err)
+		}
 	}
 
-	if filter.RequiresReview != nil {
-		conditions = append(conditions, fmt.Sprintf("requires_review = $%d", argNum))
-		args = append(args, *filter.RequiresReview)
-		argNum++
-	}
+	return report, nil
+}
+
+// listAuditLog runs a filtered, paginated audit log query
+func (s *AuditStore) listAuditLog(ctx context.Context, orgID *uuid.UUID, filter *models.AuditLogFilter) ([]models.TicketAuditLog, int, error) {
+	// Default a copy so the caller's filter is left as given
+	defaulted := *filter
+	defaulted.SetDefaults()
+	filter = &defaulted
 
+	conditions, args := buildAuditConditions(orgID, filter)
+	argNum := len(args) + 1
 	whereClause := strings.Join(conditions, " AND ")
 
 	// Count total
@@ -170,10 +212,86 @@ func (s *AuditStore) GetTicketAuditLog(ctx context.Context, ticketID uuid.UUID,
 
 		logs = append(logs, log)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit logs: %w", err)
+	}
 
 	return logs, total, nil
 }
 
+// buildAuditConditions translates an audit log filter into WHERE conditions
+func buildAuditConditions(orgID *uuid.UUID, filter *models.AuditLogFilter) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if orgID != nil {
+		conditions = append(conditions, fmt.Sprintf("organization_id = $%d", argNum))
+		args = append(args, *orgID)
+		argNum++
+	}
+
+	if filter.TicketID != nil {
+		conditions = append(conditions, fmt.Sprintf("ticket_id = $%d", argNum))
+		args = append(args, *filter.TicketID)
+		argNum++
+	}
+
+	if filter.UserID != nil {
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argNum))
+		args = append(args, *filter.UserID)
+		argNum++
+	}
+
+	if filter.Action != nil {
+		conditions = append(conditions, fmt.Sprintf("action = $%d", argNum))
+		args = append(args, *filter.Action)
+		argNum++
+	}
+
+	if filter.ActionCategory != nil {
+		conditions = append(conditions, fmt.Sprintf("action_category = $%d", argNum))
+		args = append(args, *filter.ActionCategory)
+		argNum++
+	}
+
+	if filter.IsComplianceRelevant != nil {
+		conditions = append(conditions, fmt.Sprintf("is_compliance_relevant = $%d", argNum))
+		args = append(args, *filter.IsComplianceRelevant)
+		argNum++
+	}
+
+	if filter.RequiresReview != nil {
+		conditions = append(conditions, fmt.Sprintf("requires_review = $%d", argNum))
+		args = append(args, *filter.RequiresReview)
+		argNum++
+	}
+
+	if filter.Framework != nil {
+		conditions = append(conditions, fmt.Sprintf("$%d = ANY(compliance_frameworks)", argNum))
+		args = append(args, string(*filter.Framework))
+		argNum++
+	}
+
+	if filter.FromDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argNum))
+		args = append(args, *filter.FromDate)
+		argNum++
+	}
+
+	if filter.ToDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", argNum))
+		args = append(args, *filter.ToDate)
+		argNum++
+	}
+
+	if len(conditions) == 0 {
+		conditions = append(conditions, "TRUE")
+	}
+
+	return conditions, args
+}
+
 // MarkReviewed marks an audit log entry as reviewed
 func (s *AuditStore) MarkReviewed(ctx context.Context, auditID, reviewerID uuid.UUID) error {
 	query := `
@@ -237,10 +355,12 @@ func getActionCategory(action string) string {
 	switch action {
 	case "view", "search", "export":
 		return "access"
-	case "create", "update", "edit", "delete":
+	case "create", "update", "edit", "delete", "label_rename":
 		return "modification"
 	case "approve", "deny", "submit", "status_change":
 		return "approval"
+	case "comment_create", "comment_edit", "comment_delete":
+		return "collaboration"
 	default:
 		return "other"
 	}
@@ -248,7 +368,11 @@ func getActionCategory(action string) string {
 
 func isComplianceRelevantAction(action string) bool {
 	switch action {
-	case "create", "update", "edit", "delete", "approve", "deny", "submit", "status_change":
+	case "create", "update", "edit", "delete", "label_rename", "approve", "deny", "submit", "status_change":
+		return true
+	case "comment_edit", "comment_delete":
+		// Rewriting or removing discussion alters the ticket record; new
+		// comments only add to it
 		return true
 	default:
 		return false
@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ErrCommentNotFound is returned when a comment does not exist or was deleted
+var ErrCommentNotFound = errors.New("comment not found")
+
+// ErrInvalidCommentParent is returned when a reply targets a comment that is
+// itself a reply or belongs to a different ticket
+var ErrInvalidCommentParent = errors.New("replies may only be made to top-level comments on the same ticket")
+
+// CommentStore handles ticket comment database operations
+type CommentStore struct {
+	db *sql.DB
+}
+
+const commentColumns = `
+	id, ticket_id, organization_id, author_id, parent_comment_id, comment,
+	is_internal, mentioned_users, attachment_urls, created_at, updated_at,
+	deleted_at, edited, edit_history
+`
+
+// Create adds a comment to a ticket. When input.ParentCommentID is set the
+// parent must be a live top-level comment on the same ticket.
+func (s *CommentStore) Create(ctx context.Context, orgID, ticketID, authorID uuid.UUID, input *models.CreateCommentInput) (*models.Comment, error) {
+	if input.ParentCommentID != nil {
+		parent, err := s.GetByID(ctx, orgID, *input.ParentCommentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.TicketID != ticketID || parent.IsReply() {
+			return nil, ErrInvalidCommentParent
+		}
+	}
+
+	now := time.Now()
+	comment := &models.Comment{
+		ID:              uuid.New(),
+		TicketID:        ticketID,
+		OrganizationID:  orgID,
+		AuthorID:        authorID,
+		ParentCommentID: input.ParentCommentID,
+		Comment:         input.Comment,
+		IsInternal:      input.IsInternal,
+		MentionedUsers:  input.MentionedUsers,
+		AttachmentURLs:  input.AttachmentURLs,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		EditHistory:     json.RawMessage("[]"),
+	}
+	if comment.IsReply() {
+		comment.Depth = 1
+	}
+
+	query := `
+		INSERT INTO ticket_comments (
+			id, ticket_id, organization_id, author_id, parent_comment_id, comment,
+			is_internal, mentioned_users, attachment_urls, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		comment.ID, comment.TicketID, comment.OrganizationID, comment.AuthorID,
+		comment.ParentCommentID, comment.Comment, comment.IsInternal,
+		pq.Array(uuidStrings(comment.MentionedUsers)), pq.Array(comment.AttachmentURLs),
+		comment.CreatedAt, comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// GetByID retrieves a live comment by ID
+func (s *CommentStore) GetByID(ctx context.Context, orgID, commentID uuid.UUID) (*models.Comment, error) {
+	query := `SELECT ` + commentColumns + `
+		FROM ticket_comments
+		WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL
+	`
+
+	comment, err := scanComment(s.db.QueryRowContext(ctx, query, commentID, orgID))
+	if err == sql.ErrNoRows {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return comment, nil
+}
+
+// ListByTicket returns the live comments on a ticket in creation order.
+// Internal comments are omitted unless includeInternal is set.
+func (s *CommentStore) ListByTicket(ctx context.Context, orgID, ticketID uuid.UUID, includeInternal bool) ([]models.Comment, error) {
+	query := `SELECT ` + commentColumns + `
+		FROM ticket_comments
+		WHERE ticket_id = $1 AND organization_id = $2 AND deleted_at IS NULL
+		  AND ($3 OR is_internal = false)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, ticketID, orgID, includeInternal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []models.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, *comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// Update replaces a comment's text, appending the previous text to its edit
+// history
+func (s *CommentStore) Update(ctx context.Context, orgID, commentID uuid.UUID, input *models.UpdateCommentInput) (*models.Comment, error) {
+	comment, err := s.GetByID(ctx, orgID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []models.CommentEditEntry
+	if len(comment.EditHistory) > 0 {
+		if err := json.Unmarshal(comment.EditHistory, &history); err != nil {
+			return nil, fmt.Errorf("failed to parse edit history: %w", err)
+		}
+	}
+	now := time.Now()
+	history = append(history, models.CommentEditEntry{
+		PreviousComment: comment.Comment,
+		EditedAt:        now,
+	})
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode edit history: %w", err)
+	}
+
+	query := `
+		UPDATE ticket_comments
+		SET comment = $1, edited = true, edit_history = $2, updated_at = $3
+		WHERE id = $4 AND organization_id = $5 AND deleted_at IS NULL
+	`
+	if _, err := s.db.ExecContext(ctx, query, input.Comment, historyJSON, now, commentID, orgID); err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	comment.Comment = input.Comment
+	comment.Edited = true
+	comment.EditHistory = historyJSON
+	comment.UpdatedAt = now
+	return comment, nil
+}
+
+// Delete soft-deletes a comment
+func (s *CommentStore) Delete(ctx context.Context, orgID, commentID uuid.UUID) error {
+	query := `
+		UPDATE ticket_comments
+		SET deleted_at = NOW()
+		WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL
+	`
+	result, err := s.db.ExecContext(ctx, query, commentID, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+// scanComment scans a single comment row selected with commentColumns
+func scanComment(row interface{ Scan(...interface{}) error }) (*models.Comment, error) {
+	var comment models.Comment
+	var mentioned []string
+	var editHistory []byte
+
+	err := row.Scan(
+		&comment.ID, &comment.TicketID, &comment.OrganizationID, &comment.AuthorID,
+		&comment.ParentCommentID, &comment.Comment, &comment.IsInternal,
+		pq.Array(&mentioned), pq.Array(&comment.AttachmentURLs),
+		&comment.CreatedAt, &comment.UpdatedAt, &comment.DeletedAt,
+		&comment.Edited, &editHistory,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range mentioned {
+		if id, err := uuid.Parse(m); err == nil {
+			comment.MentionedUsers = append(comment.MentionedUsers, id)
+		}
+	}
+	comment.EditHistory = editHistory
+	if comment.IsReply() {
+		comment.Depth = 1
+	}
+
+	return &comment, nil
+}
+
+// uuidStrings converts UUIDs for use with pq.Array
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out
+}
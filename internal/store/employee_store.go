@@ -0,0 +1,261 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// EmployeeStore handles employee profile database operations
+type EmployeeStore struct {
+	db *sql.DB
+}
+
+// ListDirectory returns the directory fields of every current employee in an
+// organization, with User populated, ordered by name. Employees whose
+// termination date has passed are excluded.
+func (s *EmployeeStore) ListDirectory(ctx context.Context, orgID uuid.UUID) ([]models.EmployeeProfile, error) {
+	query := `
+		SELECT ep.id, ep.user_id, ep.organization_id, ep.employee_number,
+		       ep.job_title, ep.department, ep.manager_id, ep.employee_type,
+		       ep.consulting_company, ep.office_location, ep.office_phone,
+		       ep.mobile_phone, ep.profile_picture_url, ep.out_of_office,
+		       ep.created_at, ep.updated_at,
+		       u.email, u.full_name
+		FROM employee_profiles ep
+		JOIN users u ON u.id = ep.user_id
+		WHERE ep.organization_id = $1
+		  AND u.deleted_at IS NULL
+		  AND (ep.termination_date IS NULL OR ep.termination_date > CURRENT_DATE)
+		ORDER BY u.full_name
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list employees: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []models.EmployeeProfile
+	for rows.Next() {
+		var p models.EmployeeProfile
+		user := &models.UserSummary{}
+		err := rows.Scan(
+			&p.ID, &p.UserID, &p.OrganizationID, &p.EmployeeNumber,
+			&p.JobTitle, &p.Department, &p.ManagerID, &p.EmployeeType,
+			&p.ConsultingCompany, &p.OfficeLocation, &p.OfficePhone,
+			&p.MobilePhone, &p.ProfilePictureURL, &p.OutOfOffice,
+			&p.CreatedAt, &p.UpdatedAt,
+			&user.Email, &user.FullName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee: %w", err)
+		}
+		user.ID = p.UserID
+		p.User = user
+		profiles = append(profiles, p)
+	}
+
+	return profiles, rows.Err()
+}
+
+// Search finds employees matching filter and returns their directory entries
+// with the total match count. Skills and certifications are compared
+// case-insensitively; an employee matches when they hold any of the listed
+// values, or all of them when filter.MatchAll is set. When both skills and
+// certifications are given, both conditions must hold.
+func (s *EmployeeStore) Search(ctx context.Context, orgID uuid.UUID, filter *models.EmployeeSearchFilter) ([]models.EmployeeDirectoryEntry, int, error) {
+	filter.SetDefaults()
+
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	conditions = append(conditions, fmt.Sprintf("ep.organization_id = $%d", argNum))
+	args = append(args, orgID)
+	argNum++
+
+	conditions = append(conditions, "u.deleted_at IS NULL")
+
+	if !filter.IncludeInactive {
+		conditions = append(conditions, "u.is_active = true")
+		conditions = append(conditions, "(ep.termination_date IS NULL OR ep.termination_date > CURRENT_DATE)")
+	}
+
+	if filter.Department != nil {
+		conditions = append(conditions, fmt.Sprintf("ep.department = $%d", argNum))
+		args = append(args, *filter.Department)
+		argNum++
+	}
+
+	if filter.EmployeeType != nil {
+		conditions = append(conditions, fmt.Sprintf("ep.employee_type = $%d", argNum))
+		args = append(args, *filter.EmployeeType)
+		argNum++
+	}
+
+	if filter.OfficeLocation != nil {
+		conditions = append(conditions, fmt.Sprintf("ep.office_location = $%d", argNum))
+		args = append(args, *filter.OfficeLocation)
+		argNum++
+	}
+
+	if filter.ManagerID != nil {
+		conditions = append(conditions, fmt.Sprintf("ep.manager_id = $%d", argNum))
+		args = append(args, *filter.ManagerID)
+		argNum++
+	}
+
+	if filter.ConsultingCompany != nil {
+		conditions = append(conditions, fmt.Sprintf("ep.consulting_company = $%d", argNum))
+		args = append(args, *filter.ConsultingCompany)
+		argNum++
+	}
+
+	if values := normalizeSkills(filter.Skills); len(values) > 0 {
+		conditions = append(conditions, skillCondition("ep.skills", argNum, filter.MatchAll))
+		args = append(args, pq.Array(values))
+		argNum++
+	}
+
+	if values := normalizeSkills(filter.Certifications); len(values) > 0 {
+		conditions = append(conditions, skillCondition("ep.certifications", argNum, filter.MatchAll))
+		args = append(args, pq.Array(values))
+		argNum++
+	}
+
+	if filter.Search != "" {
+		conditions = append(conditions, fmt.Sprintf("(u.full_name ILIKE $%d OR u.email ILIKE $%d OR ep.job_title ILIKE $%d)", argNum, argNum, argNum))
+		args = append(args, "%"+filter.Search+"%")
+		argNum++
+	}
+
+	whereClause := strings.Join(conditions, " AND ")
+	from := "FROM employee_profiles ep JOIN users u ON u.id = ep.user_id"
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) %s WHERE %s", from, whereClause)
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count employees: %w", err)
+	}
+
+	validSortFields := map[string]string{
+		"full_name":  "u.full_name",
+		"email":      "u.email",
+		"department": "ep.department",
+		"job_title":  "ep.job_title",
+		"hire_date":  "ep.hire_date",
+	}
+	sortBy := "u.full_name"
+	if col, ok := validSortFields[filter.SortBy]; ok {
+		sortBy = col
+	}
+
+	sortOrder := "ASC"
+	if filter.SortOrder == "desc" {
+		sortOrder = "DESC"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT ep.id, ep.user_id, u.full_name, u.email, ep.job_title, ep.department,
+		       ep.employee_type, ep.consulting_company, ep.office_location,
+		       ep.office_phone, ep.mobile_phone, ep.profile_picture_url, ep.out_of_office
+		%s
+		WHERE %s
+		ORDER BY %s %s
+		LIMIT $%d OFFSET $%d
+	`, from, whereClause, sortBy, sortOrder, argNum, argNum+1)
+
+	args = append(args, filter.PerPage, filter.Offset())
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search employees: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.EmployeeDirectoryEntry{}
+	for rows.Next() {
+		var e models.EmployeeDirectoryEntry
+		err := rows.Scan(
+			&e.ID, &e.UserID, &e.FullName, &e.Email, &e.JobTitle, &e.Department,
+			&e.EmployeeType, &e.ConsultingCompany, &e.OfficeLocation,
+			&e.OfficePhone, &e.MobilePhone, &e.ProfilePictureURL, &e.OutOfOffice,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan employee: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, total, rows.Err()
+}
+
+// ListSkills returns each distinct skill held by current employees with the
+// number of employees listing it, most common first. Set certifications to
+// list certifications instead. Values differing only in case are merged.
+func (s *EmployeeStore) ListSkills(ctx context.Context, orgID uuid.UUID, certifications bool) ([]models.SkillCount, error) {
+	column := "ep.skills"
+	if certifications {
+		column = "ep.certifications"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT MIN(trim(skill)), COUNT(DISTINCT ep.id)
+		FROM employee_profiles ep
+		JOIN users u ON u.id = ep.user_id,
+		     unnest(%s) AS skill
+		WHERE ep.organization_id = $1
+		  AND u.deleted_at IS NULL
+		  AND (ep.termination_date IS NULL OR ep.termination_date > CURRENT_DATE)
+		  AND trim(skill) <> ''
+		GROUP BY lower(trim(skill))
+		ORDER BY COUNT(DISTINCT ep.id) DESC, MIN(trim(skill))
+	`, column)
+
+	rows, err := s.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skills: %w", err)
+	}
+	defer rows.Close()
+
+	skills := []models.SkillCount{}
+	for rows.Next() {
+		var sc models.SkillCount
+		if err := rows.Scan(&sc.Name, &sc.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan skill: %w", err)
+		}
+		skills = append(skills, sc)
+	}
+
+	return skills, rows.Err()
+}
+
+// skillCondition matches a text[] column against the lowercased values bound
+// at argNum, requiring any or all of them to be present
+func skillCondition(column string, argNum int, matchAll bool) string {
+	op := "&&"
+	if matchAll {
+		op = "@>"
+	}
+	return fmt.Sprintf("(SELECT array_agg(lower(trim(v))) FROM unnest(%s) AS v) %s $%d::text[]", column, op, argNum)
+}
+
+// normalizeSkills lowercases, trims and de-duplicates skill filter values
+func normalizeSkills(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// GroupStore handles group database operations
+type GroupStore struct {
+	db *sql.DB
+}
+
+// ApproverGroups returns the approver group of each approval type in an
+// organization. A group approves the types listed in the "approval_types"
+// array of its metadata; when several active groups list a type, the first
+// by name is used.
+func (s *GroupStore) ApproverGroups(ctx context.Context, orgID uuid.UUID) (map[models.ApprovalType]uuid.UUID, error) {
+	query := `
+		SELECT t.approval_type, g.id
+		FROM groups g
+		CROSS JOIN LATERAL jsonb_array_elements_text(
+			CASE WHEN jsonb_typeof(g.metadata->'approval_types') = 'array'
+			     THEN g.metadata->'approval_types' ELSE '[]'::jsonb END
+		) AS t(approval_type)
+		WHERE g.organization_id = $1
+		  AND g.is_active = true
+		ORDER BY g.name
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up approver groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := make(map[models.ApprovalType]uuid.UUID)
+	for rows.Next() {
+		var approvalType string
+		var groupID uuid.UUID
+		if err := rows.Scan(&approvalType, &groupID); err != nil {
+			return nil, fmt.Errorf("failed to scan approver group: %w", err)
+		}
+		if _, ok := groups[models.ApprovalType(approvalType)]; !ok {
+			groups[models.ApprovalType(approvalType)] = groupID
+		}
+	}
+
+	return groups, rows.Err()
+}
+
+// MembersByGroup returns the users in each of the groups keyed by group ID.
+// Only identity and approval fields are populated. Deleted users are
+// skipped.
+func (s *GroupStore) MembersByGroup(ctx context.Context, groupIDs []uuid.UUID) (map[uuid.UUID][]models.User, error) {
+	if len(groupIDs) == 0 {
+		return map[uuid.UUID][]models.User{}, nil
+	}
+
+	ids := make([]string, len(groupIDs))
+	for i, id := range groupIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT gm.group_id, u.id, u.organization_id, u.email, u.full_name,
+		       u.is_approver, u.approval_types, u.is_active
+		FROM group_members gm
+		JOIN users u ON u.id = gm.user_id
+		WHERE gm.group_id = ANY($1::uuid[])
+		  AND u.deleted_at IS NULL
+		ORDER BY u.full_name
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up group members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make(map[uuid.UUID][]models.User)
+	for rows.Next() {
+		var groupID uuid.UUID
+		var u models.User
+		var approvalTypes []string
+		if err := rows.Scan(&groupID, &u.ID, &u.OrganizationID, &u.Email, &u.FullName,
+			&u.IsApprover, pq.Array(&approvalTypes), &u.IsActive); err != nil {
+			return nil, fmt.Errorf("failed to scan group member: %w", err)
+		}
+		for _, at := range approvalTypes {
+			u.ApprovalTypes = append(u.ApprovalTypes, models.ApprovalType(at))
+		}
+		members[groupID] = append(members[groupID], u)
+	}
+
+	return members, rows.Err()
+}
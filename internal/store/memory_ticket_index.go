@@ -0,0 +1,163 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+)
+
+// MemoryTicketIndex is an in-memory models.TicketIndex: posting lists from
+// each token and label to the tickets that have it. It is safe for
+// concurrent use.
+type MemoryTicketIndex struct {
+	mu       sync.RWMutex
+	postings map[string]map[uuid.UUID]struct{}
+	labels   map[string]map[uuid.UUID]struct{}
+
+	// docs holds what was indexed for each ticket, so it can be removed
+	docs map[uuid.UUID]indexedTicket
+}
+
+// indexedTicket is the tokens and labels indexed for one ticket
+type indexedTicket struct {
+	tokens []string
+	labels []string
+}
+
+// NewMemoryTicketIndex creates an empty index
+func NewMemoryTicketIndex() *MemoryTicketIndex {
+	return &MemoryTicketIndex{
+		postings: make(map[string]map[uuid.UUID]struct{}),
+		labels:   make(map[string]map[uuid.UUID]struct{}),
+		docs:     make(map[uuid.UUID]indexedTicket),
+	}
+}
+
+// Index adds a ticket, replacing what was indexed for it before
+func (x *MemoryTicketIndex) Index(t *models.Ticket) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(t.ID)
+
+	doc := indexedTicket{tokens: uniqueStrings(models.TicketIndexText(t)), labels: uniqueStrings(t.Labels)}
+	for _, token := range doc.tokens {
+		if x.postings[token] == nil {
+			x.postings[token] = make(map[uuid.UUID]struct{})
+		}
+		x.postings[token][t.ID] = struct{}{}
+	}
+	for _, label := range doc.labels {
+		if x.labels[label] == nil {
+			x.labels[label] = make(map[uuid.UUID]struct{})
+		}
+		x.labels[label][t.ID] = struct{}{}
+	}
+	x.docs[t.ID] = doc
+}
+
+// Remove drops a ticket from the index
+func (x *MemoryTicketIndex) Remove(ticketID uuid.UUID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(ticketID)
+}
+
+// remove drops a ticket's postings; callers must hold the write lock
+func (x *MemoryTicketIndex) remove(ticketID uuid.UUID) {
+	doc, ok := x.docs[ticketID]
+	if !ok {
+		return
+	}
+	for _, token := range doc.tokens {
+		delete(x.postings[token], ticketID)
+		if len(x.postings[token]) == 0 {
+			delete(x.postings, token)
+		}
+	}
+	for _, label := range doc.labels {
+		delete(x.labels[label], ticketID)
+		if len(x.labels[label]) == 0 {
+			delete(x.labels, label)
+		}
+	}
+	delete(x.docs, ticketID)
+}
+
+// Search returns the IDs of the tickets matching every term of query and
+// carrying every label in filter.Labels, sorted
+func (x *MemoryTicketIndex) Search(query string, filter models.TicketListFilter) []uuid.UUID {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	// Each term and label narrows the candidates; nil means unrestricted
+	var matched map[uuid.UUID]struct{}
+	narrow := func(ids map[uuid.UUID]struct{}) {
+		if matched == nil {
+			matched = make(map[uuid.UUID]struct{}, len(ids))
+			for id := range ids {
+				matched[id] = struct{}{}
+			}
+			return
+		}
+		for id := range matched {
+			if _, ok := ids[id]; !ok {
+				delete(matched, id)
+			}
+		}
+	}
+
+	for _, label := range filter.Labels {
+		narrow(x.labels[label])
+	}
+	for _, term := range uniqueStrings(models.TicketSearchTokens(query)) {
+		narrow(x.termMatches(term))
+	}
+
+	if matched == nil {
+		matched = make(map[uuid.UUID]struct{}, len(x.docs))
+		for id := range x.docs {
+			matched[id] = struct{}{}
+		}
+	}
+
+	ids := make([]uuid.UUID, 0, len(matched))
+	for id := range matched {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
+
+// termMatches returns the tickets with a token containing term. Scanning
+// the distinct tokens rather than the tickets keeps the substring matching
+// List has always done while staying cheap as tickets repeat words.
+// Callers must hold the read lock.
+func (x *MemoryTicketIndex) termMatches(term string) map[uuid.UUID]struct{} {
+	ids := make(map[uuid.UUID]struct{})
+	for token, postings := range x.postings {
+		if !strings.Contains(token, term) {
+			continue
+		}
+		for id := range postings {
+			ids[id] = struct{}{}
+		}
+	}
+	return ids
+}
+
+// uniqueStrings returns values without duplicates, keeping first occurrences
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,148 @@
+package store
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestMemoryTicketIndexSearch(t *testing.T) {
+	ids := make([]uuid.UUID, 4)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	tickets := []*models.Ticket{
+		{ID: ids[0], TicketNumber: "CHG-2025-00001", Title: "Database failover drill", Description: "Fail the primary over to the replica", Labels: []string{"db", "ops"}},
+		{ID: ids[1], TicketNumber: "CHG-2025-00002", Title: "Rotate dbfailover credentials", Description: "Quarterly rotation", Labels: []string{"security"}},
+		{ID: ids[2], TicketNumber: "CHG-2025-00003", Title: "Upgrade database driver", Description: "Patch release", Labels: []string{"db"}},
+		{ID: ids[3], TicketNumber: "CHG-2025-00004", Title: "Network change", Description: "Replace core switch", Labels: []string{"ops", "network"}},
+	}
+
+	x := NewMemoryTicketIndex()
+	for _, tk := range tickets {
+		x.Index(tk)
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		labels []string
+		want   []uuid.UUID
+	}{
+		{name: "single term", query: "database", want: []uuid.UUID{ids[0], ids[2]}},
+		{name: "every term must match", query: "database failover", want: []uuid.UUID{ids[0]}},
+		{name: "terms in any field and order", query: "replica drill", want: []uuid.UUID{ids[0]}},
+		{name: "case and punctuation ignored", query: "DATABASE, Driver!", want: []uuid.UUID{ids[2]}},
+		{name: "term inside a word", query: "failover", want: []uuid.UUID{ids[0], ids[1]}},
+		{name: "ticket number", query: "chg-2025-00003", want: []uuid.UUID{ids[2]}},
+		{name: "label text is searchable", query: "network", want: []uuid.UUID{ids[3]}},
+		{name: "unknown term", query: "database kubernetes"},
+		{name: "labels alone", labels: []string{"ops"}, want: []uuid.UUID{ids[0], ids[3]}},
+		{name: "every label must match", labels: []string{"db", "ops"}, want: []uuid.UUID{ids[0]}},
+		{name: "label and text", query: "database", labels: []string{"ops"}, want: []uuid.UUID{ids[0]}},
+		{name: "label and several terms", query: "upgrade driver", labels: []string{"db"}, want: []uuid.UUID{ids[2]}},
+		{name: "label excludes text match", query: "failover", labels: []string{"security"}, want: []uuid.UUID{ids[1]}},
+		{name: "unknown label", query: "database", labels: []string{"frontend"}},
+		{name: "empty query and filter", want: ids},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := x.Search(tt.query, models.TicketListFilter{Labels: tt.labels})
+			if !reflect.DeepEqual(sortedIDs(got), sortedIDs(tt.want)) {
+				t.Errorf("Search(%q, %v) = %v, want %v", tt.query, tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryTicketIndexUpdates(t *testing.T) {
+	id := uuid.New()
+	x := NewMemoryTicketIndex()
+	x.Index(&models.Ticket{ID: id, Title: "Database failover", Labels: []string{"db"}})
+
+	x.Index(&models.Ticket{ID: id, Title: "Cache warmup", Labels: []string{"cache"}})
+	if got := x.Search("failover", models.TicketListFilter{}); len(got) != 0 {
+		t.Errorf("old title still matches after reindexing: %v", got)
+	}
+	if got := x.Search("", models.TicketListFilter{Labels: []string{"db"}}); len(got) != 0 {
+		t.Errorf("old label still matches after reindexing: %v", got)
+	}
+	if got := x.Search("warm", models.TicketListFilter{Labels: []string{"cache"}}); !reflect.DeepEqual(got, []uuid.UUID{id}) {
+		t.Errorf("new title and label = %v, want %v", got, id)
+	}
+
+	x.Remove(id)
+	if got := x.Search("", models.TicketListFilter{}); len(got) != 0 {
+		t.Errorf("removed ticket still indexed: %v", got)
+	}
+	if len(x.postings) != 0 || len(x.labels) != 0 {
+		t.Errorf("postings left after removing the only ticket: %d tokens, %d labels", len(x.postings), len(x.labels))
+	}
+}
+
+func sortedIDs(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestMemoryTicketStoreListSearch(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryTicketStore()
+	org, other := uuid.New(), uuid.New()
+
+	create := func(orgID uuid.UUID, title string, labels ...string) *models.Ticket {
+		t.Helper()
+		tk, err := s.Create(ctx, orgID, uuid.New(), &models.CreateTicketInput{
+			Title:       title,
+			Description: "Planned maintenance window",
+			Labels:      labels,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tk
+	}
+	drill := create(org, "Database failover drill", "db", "ops")
+	upgrade := create(org, "Database driver upgrade", "db")
+	create(other, "Database failover drill", "db", "ops")
+
+	list := func(filter models.TicketListFilter) []string {
+		t.Helper()
+		tickets, _, err := s.List(ctx, org, &filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		titles := make([]string, len(tickets))
+		for i, tk := range tickets {
+			titles[i] = tk.Title
+		}
+		sort.Strings(titles)
+		return titles
+	}
+
+	if got := list(models.TicketListFilter{Search: "database maintenance"}); !reflect.DeepEqual(got, []string{upgrade.Title, drill.Title}) {
+		t.Errorf("multi-term search = %v", got)
+	}
+	if got := list(models.TicketListFilter{Search: "database", Labels: []string{"ops"}}); !reflect.DeepEqual(got, []string{drill.Title}) {
+		t.Errorf("label and text search = %v, want only the drill", got)
+	}
+
+	title := "Cache warmup after failover"
+	if _, err := s.Update(ctx, org, upgrade.ID, &models.UpdateTicketInput{Title: &title}); err != nil {
+		t.Fatal(err)
+	}
+	if got := list(models.TicketListFilter{Search: "driver"}); len(got) != 0 {
+		t.Errorf("search matches the title replaced by an update: %v", got)
+	}
+	if got := list(models.TicketListFilter{Search: "failover", Labels: []string{"db"}}); !reflect.DeepEqual(got, []string{title, drill.Title}) {
+		t.Errorf("search after update = %v", got)
+	}
+}
@@ -0,0 +1,973 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/afterdarksys/adsops-utils/internal/pkg/ticketnum"
+)
+
+// MemoryTicketStore is a thread-safe, in-memory TicketStore for tests and
+// local development. It has no external dependencies.
+type MemoryTicketStore struct {
+	mu        sync.RWMutex
+	tickets   map[uuid.UUID]*models.Ticket
+	revisions map[uuid.UUID][]models.TicketRevision
+	links     map[uuid.UUID]map[uuid.UUID]models.LinkRepositoryInput
+	sequences map[string]int // org:prefix -> last ticket number
+	format    ticketnum.Format
+	index     *MemoryTicketIndex
+}
+
+// NewMemoryTicketStore creates an empty in-memory ticket store. Ticket
+// numbers use the configured format (see ticketnum.Load), or the default
+// format when the configuration is invalid.
+func NewMemoryTicketStore() *MemoryTicketStore {
+	format, err := ticketnum.Load()
+	if err != nil {
+		format = ticketnum.Default()
+	}
+	return &MemoryTicketStore{
+		format:    format,
+		tickets:   make(map[uuid.UUID]*models.Ticket),
+		revisions: make(map[uuid.UUID][]models.TicketRevision),
+		links:     make(map[uuid.UUID]map[uuid.UUID]models.LinkRepositoryInput),
+		sequences: make(map[string]int),
+		index:     NewMemoryTicketIndex(),
+	}
+}
+
+// Create creates a new ticket
+func (s *MemoryTicketStore) Create(ctx context.Context, orgID, userID uuid.UUID, input *models.CreateTicketInput) (*models.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	ticket := &models.Ticket{
+		ID:                          uuid.New(),
+		OrganizationID:              orgID,
+		TicketNumber:                s.nextTicketNumber(orgID, now),
+		CreatedBy:                   userID,
+		Title:                       input.Title,
+		Description:                 input.Description,
+		Status:                      models.TicketStatusDraft,
+		Priority:                    input.Priority,
+		RiskLevel:                   input.RiskLevel,
+		Industry:                    input.Industry,
+		ComplianceFrameworks:        input.ComplianceFrameworks,
+		ComplianceNotes:             input.ComplianceNotes,
+		ChangeType:                  input.ChangeType,
+		AffectedSystems:             input.AffectedSystems,
+		AffectedDataTypes:           input.AffectedDataTypes,
+		ImpactDescription:           input.ImpactDescription,
+		RollbackPlan:                input.RollbackPlan,
+		TestingPlan:                 input.TestingPlan,
+		RequestedImplementationDate: input.RequestedImplementationDate,
+		RequiresApprovalTypes:       input.RequiresApprovalTypes,
+		ApprovalDeadline:            input.ApprovalDeadline,
+		CustomFields:                input.CustomFields,
+		Version:                     1,
+		CreatedAt:                   now,
+		UpdatedAt:                   now,
+		ProjectID:                   input.ProjectID,
+		OwningGroupID:               input.OwningGroupID,
+		CustomerID:                  input.CustomerID,
+		ParentTicketID:              input.ParentTicketID,
+		EpicID:                      input.EpicID,
+		StoryPoints:                 input.StoryPoints,
+		TimeEstimateHours:           input.TimeEstimateHours,
+		Labels:                      input.Labels,
+		Watchers:                    input.Watchers,
+		ExternalReference:           input.ExternalReference,
+		ACLInheritance:              true,
+		IsConfidential:              input.IsConfidential,
+	}
+
+	s.tickets[ticket.ID] = ticket
+	s.reindex(ticket)
+	return cloneTicket(ticket), nil
+}
+
+// GetByID retrieves a ticket by ID
+func (s *MemoryTicketStore) GetByID(ctx context.Context, orgID, ticketID uuid.UUID) (*models.Ticket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ticket, err := s.get(orgID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return cloneTicket(ticket), nil
+}
+
+// GetByNumber retrieves a ticket by ticket number
+func (s *MemoryTicketStore) GetByNumber(ctx context.Context, orgID uuid.UUID, ticketNumber string) (*models.Ticket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.tickets {
+		if t.OrganizationID == orgID && t.TicketNumber == ticketNumber && t.DeletedAt == nil {
+			return cloneTicket(t), nil
+		}
+	}
+	return nil, fmt.Errorf("ticket not found")
+}
+
+// List retrieves tickets with filtering, sorting and pagination
+func (s *MemoryTicketStore) List(ctx context.Context, orgID uuid.UUID, filter *models.TicketListFilter) ([]models.Ticket, int, error) {
+	// Defaults are set on a copy so the caller's filter is left as it was
+	var f models.TicketListFilter
+	if filter != nil {
+		f = *filter
+	}
+	filter = &f
+	filter.SetDefaults()
+
+	s.mu.RLock()
+	var matched []*models.Ticket
+	if filter.Search != "" {
+		// Text search goes through the index; the rest of the filter is
+		// checked on its candidates
+		rest := *filter
+		rest.Search = ""
+		for _, id := range s.index.Search(filter.Search, *filter) {
+			t, ok := s.tickets[id]
+			if ok && t.OrganizationID == orgID && t.DeletedAt == nil && matchesTicketFilter(t, &rest) {
+				matched = append(matched, t)
+			}
+		}
+	} else {
+		for _, t := range s.tickets {
+			if t.OrganizationID == orgID && t.DeletedAt == nil && matchesTicketFilter(t, filter) {
+				matched = append(matched, t)
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	sortTickets(matched, filter.SortBy, filter.SortOrder == "asc")
+
+	total := len(matched)
+	start := filter.Offset()
+	if start > total {
+		start = total
+	}
+	end := start + filter.PerPage
+	if end > total {
+		end = total
+	}
+
+	tickets := make([]models.Ticket, 0, end-start)
+	for _, t := range matched[start:end] {
+		tickets = append(tickets, *cloneTicket(t))
+	}
+
+	return tickets, total, nil
+}
+
+// Update updates a ticket and records a revision of the changed fields
+func (s *MemoryTicketStore) Update(ctx context.Context, orgID, ticketID uuid.UUID, input *models.UpdateTicketInput) (*models.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, err := s.get(orgID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ticket.CanEdit() {
+		return nil, fmt.Errorf("ticket cannot be edited in current status")
+	}
+	if input.Version != nil && *input.Version != ticket.Version {
+		return nil, ErrVersionConflict
+	}
+
+	before := cloneTicket(ticket)
+	applyTicketUpdate(ticket, input)
+	s.commit(before, ticket, uuid.Nil, nil)
+
+	return cloneTicket(ticket), nil
+}
+
+// Patch replaces the stored ticket's editable fields with those of after,
+// provided the stored version still matches before.Version
+func (s *MemoryTicketStore) Patch(ctx context.Context, orgID, userID uuid.UUID, before, after *models.Ticket) (*models.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, err := s.get(orgID, before.ID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Version != before.Version {
+		return nil, ErrVersionConflict
+	}
+
+	stored := cloneTicket(after)
+	stored.Status = ticket.Status
+	stored.Watchers = ticket.Watchers
+	s.commit(ticket, stored, userID, nil)
+	s.tickets[stored.ID] = stored
+
+	return cloneTicket(stored), nil
+}
+
+// UpdateStatus updates the status of a ticket
+func (s *MemoryTicketStore) UpdateStatus(ctx context.Context, orgID, ticketID uuid.UUID, status models.TicketStatus) error {
+	return s.mutate(orgID, ticketID, nil, func(t *models.Ticket) error {
+		t.Status = status
+		return nil
+	})
+}
+
+// Submit submits a ticket for approval
+func (s *MemoryTicketStore) Submit(ctx context.Context, orgID, ticketID uuid.UUID) error {
+	return s.mutate(orgID, ticketID, nil, func(t *models.Ticket) error {
+		if !t.CanSubmit() {
+			return fmt.Errorf("ticket cannot be submitted in current status")
+		}
+		snapshot, _ := json.Marshal(t)
+		now := time.Now()
+		t.Status = models.TicketStatusSubmitted
+		t.SubmittedAt = &now
+		t.SubmittedSnapshot = snapshot
+		return nil
+	})
+}
+
+// Close closes a ticket
+func (s *MemoryTicketStore) Close(ctx context.Context, orgID, ticketID uuid.UUID) error {
+	return s.mutate(orgID, ticketID, nil, func(t *models.Ticket) error {
+		if !t.CanClose() {
+			return fmt.Errorf("ticket cannot be closed in current status")
+		}
+		now := time.Now()
+		t.Status = models.TicketStatusClosed
+		t.ClosedAt = &now
+		return nil
+	})
+}
+
+// Cancel cancels a ticket
+func (s *MemoryTicketStore) Cancel(ctx context.Context, orgID, ticketID uuid.UUID, reason string) error {
+	return s.mutate(orgID, ticketID, &reason, func(t *models.Ticket) error {
+		if !t.CanCancel() {
+			return fmt.Errorf("ticket cannot be cancelled in current status")
+		}
+		t.Status = models.TicketStatusCancelled
+		t.DeletionReason = &reason
+		return nil
+	})
+}
+
+// GetQueue retrieves tickets that need assignment (for ticket queue bot)
+func (s *MemoryTicketStore) GetQueue(ctx context.Context, orgID uuid.UUID) ([]models.Ticket, error) {
+	filter := &models.TicketListFilter{
+		NeedsAssignment: true,
+		SortBy:          "created_at",
+		SortOrder:       "asc",
+		PerPage:         100,
+	}
+	tickets, _, err := s.List(ctx, orgID, filter)
+	return tickets, err
+}
+
+// Assign assigns a ticket to a user
+func (s *MemoryTicketStore) Assign(ctx context.Context, orgID, ticketID, userID uuid.UUID) error {
+	return s.mutate(orgID, ticketID, nil, func(t *models.Ticket) error {
+		t.AssignedTo = &userID
+		return nil
+	})
+}
+
+// AddWatcher adds a watcher to a ticket
+func (s *MemoryTicketStore) AddWatcher(ctx context.Context, orgID, ticketID, userID uuid.UUID) error {
+	return s.mutate(orgID, ticketID, nil, func(t *models.Ticket) error {
+		for _, w := range t.Watchers {
+			if w == userID {
+				return nil
+			}
+		}
+		t.Watchers = append(t.Watchers, userID)
+		return nil
+	})
+}
+
+// RemoveWatcher removes a watcher from a ticket
+func (s *MemoryTicketStore) RemoveWatcher(ctx context.Context, orgID, ticketID, userID uuid.UUID) error {
+	return s.mutate(orgID, ticketID, nil, func(t *models.Ticket) error {
+		watchers := t.Watchers[:0:0]
+		for _, w := range t.Watchers {
+			if w != userID {
+				watchers = append(watchers, w)
+			}
+		}
+		t.Watchers = watchers
+		return nil
+	})
+}
+
+// LinkRepository links a repository to a ticket
+func (s *MemoryTicketStore) LinkRepository(ctx context.Context, ticketID, repoID, linkedBy uuid.UUID, input *models.LinkRepositoryInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tickets[ticketID]; !ok {
+		return fmt.Errorf("ticket not found")
+	}
+	if s.links[ticketID] == nil {
+		s.links[ticketID] = make(map[uuid.UUID]models.LinkRepositoryInput)
+	}
+	link := *input
+	if link.LinkType == "" {
+		link.LinkType = "related"
+	}
+	s.links[ticketID][input.RepositoryID] = link
+	return nil
+}
+
+// UnlinkRepository unlinks a repository from a ticket
+func (s *MemoryTicketStore) UnlinkRepository(ctx context.Context, ticketID, repoID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.links[ticketID], repoID)
+	return nil
+}
+
+// GetRevisions retrieves the change history for a ticket
+func (s *MemoryTicketStore) GetRevisions(ctx context.Context, orgID, ticketID uuid.UUID) ([]models.TicketRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := s.get(orgID, ticketID); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]models.TicketRevision, len(s.revisions[ticketID]))
+	copy(revisions, s.revisions[ticketID])
+	return revisions, nil
+}
+
+// ListLabels returns every label in use with the number of tickets carrying it
+func (s *MemoryTicketStore) ListLabels(ctx context.Context, orgID uuid.UUID) ([]models.LabelCount, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, t := range s.tickets {
+		if t.OrganizationID != orgID || t.DeletedAt != nil {
+			continue
+		}
+		for _, label := range t.Labels {
+			counts[label]++
+		}
+	}
+
+	labels := make([]models.LabelCount, 0, len(counts))
+	for label, count := range counts {
+		labels = append(labels, models.LabelCount{Label: label, Count: count})
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Count != labels[j].Count {
+			return labels[i].Count > labels[j].Count
+		}
+		return labels[i].Label < labels[j].Label
+	})
+
+	return labels, nil
+}
+
+// RenameLabel replaces a label on every ticket that carries it, recording a
+// revision per ticket. Returns the number of tickets updated.
+func (s *MemoryTicketStore) RenameLabel(ctx context.Context, orgID, userID uuid.UUID, from, to string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updated := 0
+	for _, t := range s.tickets {
+		if t.OrganizationID != orgID || t.DeletedAt != nil || !containsValue(t.Labels, from) {
+			continue
+		}
+
+		before := cloneTicket(t)
+		labels := make([]string, 0, len(t.Labels))
+		for _, label := range t.Labels {
+			if label == from {
+				label = to
+			}
+			if !containsValue(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+		t.Labels = labels
+
+		reason := fmt.Sprintf("label %q renamed to %q", from, to)
+		s.commit(before, t, userID, &reason)
+		updated++
+	}
+
+	return updated, nil
+}
+
+// EpicRollup sums story points, time tracking and status counts across the
+// tickets in an epic. Direct subtasks of those tickets are included once.
+func (s *MemoryTicketStore) EpicRollup(ctx context.Context, orgID, epicID uuid.UUID) (*models.EpicRollup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scope := make(map[uuid.UUID]*models.Ticket)
+	for _, t := range s.tickets {
+		if t.OrganizationID == orgID && t.DeletedAt == nil && uuidPtrMatches(&epicID, t.EpicID) {
+			scope[t.ID] = t
+		}
+	}
+	for _, t := range s.tickets {
+		if t.OrganizationID != orgID || t.DeletedAt != nil || t.ParentTicketID == nil {
+			continue
+		}
+		if _, ok := scope[*t.ParentTicketID]; ok && scope[t.ID] == nil {
+			scope[t.ID] = t
+		}
+	}
+
+	rollup := models.NewEpicRollup(epicID)
+	for _, t := range scope {
+		rollup.Add(t)
+	}
+	return rollup, nil
+}
+
+// OverdueApprovals returns tickets awaiting approval whose approval deadline
+// passed before now without every required approval type approved
+func (s *MemoryTicketStore) OverdueApprovals(ctx context.Context, orgID uuid.UUID, now time.Time) ([]models.Ticket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var overdue []models.Ticket
+	for _, t := range s.tickets {
+		if t.OrganizationID == orgID && t.DeletedAt == nil && t.IsApprovalOverdue(now) {
+			overdue = append(overdue, *cloneTicket(t))
+		}
+	}
+	sort.Slice(overdue, func(i, j int) bool {
+		return overdue[i].ApprovalDeadline.Before(*overdue[j].ApprovalDeadline)
+	})
+	return overdue, nil
+}
+
+// ConflictingTickets returns tickets other than excludeID whose scheduled
+// window overlaps [start, end) and which share any of systems
+func (s *MemoryTicketStore) ConflictingTickets(ctx context.Context, orgID, excludeID uuid.UUID, start, end time.Time, systems []string) ([]models.Ticket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var conflicts []models.Ticket
+	for _, t := range s.tickets {
+		if t.OrganizationID != orgID || t.ID == excludeID || t.DeletedAt != nil {
+			continue
+		}
+		if _, ok := t.ConflictWith(start, end, systems); ok {
+			conflicts = append(conflicts, *cloneTicket(t))
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].ScheduledStart.Before(*conflicts[j].ScheduledStart)
+	})
+	return conflicts, nil
+}
+
+// ComplianceTickets returns tickets carrying any of frameworks that were
+// created between from and to (either may be nil), oldest first
+func (s *MemoryTicketStore) ComplianceTickets(ctx context.Context, orgID uuid.UUID, frameworks []models.ComplianceFramework, from, to *time.Time) ([]models.Ticket, error) {
+	filter := &models.TicketListFilter{
+		ComplianceFramework: frameworks,
+		FromDate:            from,
+		ToDate:              to,
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tickets []models.Ticket
+	for _, t := range s.tickets {
+		if t.OrganizationID == orgID && t.DeletedAt == nil && matchesTicketFilter(t, filter) {
+			tickets = append(tickets, *cloneTicket(t))
+		}
+	}
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].CreatedAt.Before(tickets[j].CreatedAt)
+	})
+	return tickets, nil
+}
+
+// ExpiringACLs returns the active ACLs on an organization's tickets that
+// expire after now and no later than now+within, soonest first
+func (s *MemoryTicketStore) ExpiringACLs(ctx context.Context, orgID uuid.UUID, now time.Time, within time.Duration) ([]models.ExpiringACL, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var expiring []models.ExpiringACL
+	for _, t := range s.tickets {
+		if t.OrganizationID != orgID || t.DeletedAt != nil {
+			continue
+		}
+		var copied *models.Ticket
+		for i, acl := range t.ACLs {
+			if !acl.ExpiresWithin(now, within) || !acl.ExpiresAt.After(now) {
+				continue
+			}
+			if copied == nil {
+				copied = cloneTicket(t)
+			}
+			expiring = append(expiring, models.NewExpiringACL(copied, copied.ACLs[i], now))
+		}
+	}
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].ACL.ExpiresAt.Before(*expiring[j].ACL.ExpiresAt)
+	})
+	return expiring, nil
+}
+
+// ExpireACLs marks every ACL whose expiry is at or before now as revoked at
+// its expiry time and returns how many it marked
+func (s *MemoryTicketStore) ExpireACLs(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := 0
+	for _, t := range s.tickets {
+		for i := range t.ACLs {
+			acl := &t.ACLs[i]
+			if acl.RevokedAt == nil && acl.ExpiresAt != nil && !acl.ExpiresAt.After(now) {
+				revokedAt := *acl.ExpiresAt
+				acl.RevokedAt = &revokedAt
+				expired++
+			}
+		}
+	}
+	return expired, nil
+}
+
+// SeedFromDir loads local CLI ticket files (tickets/*.json) into the store
+// under the given organization. It returns the number of tickets loaded.
+func (s *MemoryTicketStore) SeedFromDir(orgID uuid.UUID, dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list ticket files: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loaded := 0
+	for _, entry := range entries {
+		if !ticketnum.IsTicketFile(entry) {
+			continue
+		}
+
+		file := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return loaded, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		var local models.TicketFile
+		if err := json.Unmarshal(data, &local); err != nil {
+			return loaded, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		ticket := seedTicket(orgID, &local)
+		s.tickets[ticket.ID] = ticket
+		s.reindex(ticket)
+		s.trackSequence(orgID, ticket)
+		loaded++
+	}
+
+	return loaded, nil
+}
+
+// get returns the stored ticket; callers must hold the lock
+func (s *MemoryTicketStore) get(orgID, ticketID uuid.UUID) (*models.Ticket, error) {
+	ticket, ok := s.tickets[ticketID]
+	if !ok || ticket.OrganizationID != orgID || ticket.DeletedAt != nil {
+		return nil, fmt.Errorf("ticket not found")
+	}
+	return ticket, nil
+}
+
+// mutate applies fn to a ticket under lock and records a revision
+func (s *MemoryTicketStore) mutate(orgID, ticketID uuid.UUID, reason *string, fn func(t *models.Ticket) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, err := s.get(orgID, ticketID)
+	if err != nil {
+		return err
+	}
+
+	before := cloneTicket(ticket)
+	if err := fn(ticket); err != nil {
+		return err
+	}
+	s.commit(before, ticket, uuid.Nil, reason)
+	return nil
+}
+
+// commit bumps the version and records a revision when fields changed;
+// callers must hold the lock
+func (s *MemoryTicketStore) commit(before, after *models.Ticket, changedBy uuid.UUID, reason *string) {
+	changes := models.DiffTickets(before, after)
+	if len(changes) == 0 {
+		return
+	}
+
+	after.Version++
+	after.UpdatedAt = time.Now()
+
+	changesJSON, _ := json.Marshal(changes)
+	snapshot, _ := json.Marshal(after)
+
+	s.revisions[after.ID] = append(s.revisions[after.ID], models.TicketRevision{
+		ID:             uuid.New(),
+		TicketID:       after.ID,
+		OrganizationID: after.OrganizationID,
+		RevisionNumber: len(s.revisions[after.ID]) + 1,
+		ChangedBy:      changedBy,
+		ChangeReason:   reason,
+		Changes:        changesJSON,
+		TicketSnapshot: snapshot,
+		CreatedAt:      after.UpdatedAt,
+	})
+	s.reindex(after)
+}
+
+// reindex brings a ticket's search index entry up to date, dropping it once
+// the ticket is deleted; callers must hold the lock
+func (s *MemoryTicketStore) reindex(t *models.Ticket) {
+	if t.DeletedAt != nil {
+		s.index.Remove(t.ID)
+		return
+	}
+	s.index.Index(t)
+}
+
+// nextTicketNumber allocates the next number in the store's format for a
+// ticket created at now; callers must hold the lock
+func (s *MemoryTicketStore) nextTicketNumber(orgID uuid.UUID, now time.Time) string {
+	prefix := s.format.PrefixAt(now)
+	key := orgID.String() + ":" + prefix
+	s.sequences[key]++
+	return s.format.Number(prefix, s.sequences[key])
+}
+
+// trackSequence keeps the number allocator ahead of seeded tickets. Numbers
+// in another format are ignored.
+func (s *MemoryTicketStore) trackSequence(orgID uuid.UUID, t *models.Ticket) {
+	prefix := s.format.PrefixAt(t.CreatedAt)
+	num, ok := ticketnum.Sequence(t.TicketNumber, prefix)
+	if !ok {
+		return
+	}
+	key := orgID.String() + ":" + prefix
+	if num > s.sequences[key] {
+		s.sequences[key] = num
+	}
+}
+
+// applyTicketUpdate copies the set fields of an update onto a ticket
+func applyTicketUpdate(t *models.Ticket, input *models.UpdateTicketInput) {
+	if input.Title != nil {
+		t.Title = *input.Title
+	}
+	if input.Description != nil {
+		t.Description = *input.Description
+	}
+	if input.Priority != nil {
+		t.Priority = *input.Priority
+	}
+	if input.RiskLevel != nil {
+		t.RiskLevel = *input.RiskLevel
+	}
+	if input.ComplianceFrameworks != nil {
+		t.ComplianceFrameworks = input.ComplianceFrameworks
+	}
+	if input.ComplianceNotes != nil {
+		t.ComplianceNotes = input.ComplianceNotes
+	}
+	if input.ChangeType != nil {
+		t.ChangeType = input.ChangeType
+	}
+	if input.AffectedSystems != nil {
+		t.AffectedSystems = input.AffectedSystems
+	}
+	if input.AffectedDataTypes != nil {
+		t.AffectedDataTypes = input.AffectedDataTypes
+	}
+	if input.ImpactDescription != nil {
+		t.ImpactDescription = input.ImpactDescription
+	}
+	if input.RollbackPlan != nil {
+		t.RollbackPlan = input.RollbackPlan
+	}
+	if input.TestingPlan != nil {
+		t.TestingPlan = input.TestingPlan
+	}
+	if input.RequestedImplementationDate != nil {
+		t.RequestedImplementationDate = input.RequestedImplementationDate
+	}
+	if input.ScheduledStart != nil {
+		t.ScheduledStart = input.ScheduledStart
+	}
+	if input.ScheduledEnd != nil {
+		t.ScheduledEnd = input.ScheduledEnd
+	}
+	if input.RequiresApprovalTypes != nil {
+		t.RequiresApprovalTypes = input.RequiresApprovalTypes
+	}
+	if input.ApprovalDeadline != nil {
+		t.ApprovalDeadline = input.ApprovalDeadline
+	}
+	if input.CustomFields != nil {
+		t.CustomFields = input.CustomFields
+	}
+	if input.AssignedTo != nil {
+		t.AssignedTo = input.AssignedTo
+	}
+	if input.ProjectID != nil {
+		t.ProjectID = input.ProjectID
+	}
+	if input.OwningGroupID != nil {
+		t.OwningGroupID = input.OwningGroupID
+	}
+	if input.CustomerID != nil {
+		t.CustomerID = input.CustomerID
+	}
+	if input.ParentTicketID != nil {
+		t.ParentTicketID = input.ParentTicketID
+	}
+	if input.EpicID != nil {
+		t.EpicID = input.EpicID
+	}
+	if input.StoryPoints != nil {
+		t.StoryPoints = input.StoryPoints
+	}
+	if input.TimeEstimateHours != nil {
+		t.TimeEstimateHours = input.TimeEstimateHours
+	}
+	if input.TimeSpentHours != nil {
+		t.TimeSpentHours = input.TimeSpentHours
+	}
+	if input.Labels != nil {
+		t.Labels = input.Labels
+	}
+}
+
+// matchesTicketFilter reports whether a ticket satisfies every set filter field
+func matchesTicketFilter(t *models.Ticket, f *models.TicketListFilter) bool {
+	if len(f.Status) > 0 && !containsValue(f.Status, t.Status) {
+		return false
+	}
+	if len(f.Priority) > 0 && !containsValue(f.Priority, t.Priority) {
+		return false
+	}
+	if len(f.RiskLevel) > 0 && !containsValue(f.RiskLevel, t.RiskLevel) {
+		return false
+	}
+	if f.CreatedBy != nil && t.CreatedBy != *f.CreatedBy {
+		return false
+	}
+	if f.AssignedTo != nil && (t.AssignedTo == nil || *t.AssignedTo != *f.AssignedTo) {
+		return false
+	}
+	if len(f.ComplianceFramework) > 0 {
+		found := false
+		for _, cf := range t.ComplianceFrameworks {
+			if containsValue(f.ComplianceFramework, cf) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.FromDate != nil && t.CreatedAt.Before(*f.FromDate) {
+		return false
+	}
+	if f.ToDate != nil && t.CreatedAt.After(*f.ToDate) {
+		return false
+	}
+	if !uuidPtrMatches(f.ProjectID, t.ProjectID) ||
+		!uuidPtrMatches(f.OwningGroupID, t.OwningGroupID) ||
+		!uuidPtrMatches(f.CustomerID, t.CustomerID) ||
+		!uuidPtrMatches(f.EpicID, t.EpicID) ||
+		!uuidPtrMatches(f.ParentTicketID, t.ParentTicketID) {
+		return false
+	}
+	for _, label := range f.Labels {
+		if !containsValue(t.Labels, label) {
+			return false
+		}
+	}
+	if f.WatchedBy != nil && !containsValue(t.Watchers, *f.WatchedBy) {
+		return false
+	}
+	if f.IsConfidential != nil && t.IsConfidential != *f.IsConfidential {
+		return false
+	}
+	if f.NeedsAssignment {
+		if t.AssignedTo != nil {
+			return false
+		}
+		switch t.Status {
+		case models.TicketStatusSubmitted, models.TicketStatusInReview, models.TicketStatusUpdateRequested:
+		default:
+			return false
+		}
+	}
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		if !strings.Contains(strings.ToLower(t.Title), search) &&
+			!strings.Contains(strings.ToLower(t.Description), search) &&
+			!strings.Contains(strings.ToLower(t.TicketNumber), search) {
+			return false
+		}
+	}
+	return true
+}
+
+// priorityRank orders priorities from most to least urgent
+var priorityRank = map[models.TicketPriority]int{
+	models.TicketPriorityEmergency: 0,
+	models.TicketPriorityUrgent:    1,
+	models.TicketPriorityHigh:      2,
+	models.TicketPriorityNormal:    3,
+	models.TicketPriorityLow:       4,
+}
+
+// sortTickets sorts using the same field whitelist as the Postgres store
+func sortTickets(tickets []*models.Ticket, sortBy string, asc bool) {
+	less := func(a, b *models.Ticket) bool {
+		switch sortBy {
+		case "updated_at":
+			return a.UpdatedAt.Before(b.UpdatedAt)
+		case "priority":
+			return priorityRank[a.Priority] < priorityRank[b.Priority]
+		case "status":
+			return a.Status < b.Status
+		case "ticket_number":
+			return a.TicketNumber < b.TicketNumber
+		case "title":
+			return a.Title < b.Title
+		default:
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	}
+
+	sort.SliceStable(tickets, func(i, j int) bool {
+		if asc {
+			return less(tickets[i], tickets[j])
+		}
+		return less(tickets[j], tickets[i])
+	})
+}
+
+// cloneTicket returns a deep copy so callers cannot mutate stored state
+func cloneTicket(t *models.Ticket) *models.Ticket {
+	data, err := json.Marshal(t)
+	if err != nil {
+		copied := *t
+		return &copied
+	}
+	var copied models.Ticket
+	json.Unmarshal(data, &copied)
+	return &copied
+}
+
+func containsValue[T comparable](slice []T, item T) bool {
+	for _, v := range slice {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+func uuidPtrMatches(want, have *uuid.UUID) bool {
+	if want == nil {
+		return true
+	}
+	return have != nil && *have == *want
+}
+
+// seedTicket converts a local CLI ticket with models.TicketFromLocalFile
+// and fills in what the file does not hold. Users are emails in the file,
+// so they become deterministic name-based UUIDs; CLI statuses and
+// priorities are mapped onto the API's, and the sprint becomes a label.
+func seedTicket(orgID uuid.UUID, f *models.TicketFile) *models.Ticket {
+	t := models.TicketFromLocalFile(f)
+	t.ID = uuid.NewSHA1(orgID, []byte(f.ID))
+	t.OrganizationID = orgID
+	t.CreatedBy = emailUserID(f.CreatedBy)
+	if t.Assignee != nil {
+		assignee := emailUserID(t.Assignee.Email)
+		t.AssignedTo = &assignee
+	}
+	t.Status = localStatus(f.Status)
+	t.Priority = localPriority(f.Priority)
+	t.Version = 1
+	t.ACLInheritance = true
+
+	if !t.RiskLevel.Valid() {
+		t.RiskLevel = models.RiskLevelMedium
+	}
+	if !t.Industry.Valid() {
+		t.Industry = models.IndustryIT
+	}
+	if f.Sprint != "" && !containsValue(t.Labels, f.Sprint) {
+		t.Labels = append(t.Labels, f.Sprint)
+	}
+
+	return t
+}
+
+// emailUserID returns the name-based UUID standing in for a user known
+// only by email
+func emailUserID(email string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("mailto:"+email))
+}
+
+// localStatus maps CLI status names onto API ticket statuses
+func localStatus(status string) models.TicketStatus {
+	switch status {
+	case "pending":
+		return models.TicketStatusSubmitted
+	case "in_progress":
+		return models.TicketStatusImplementing
+	}
+	if s := models.TicketStatus(status); s.Valid() {
+		return s
+	}
+	return models.TicketStatusDraft
+}
+
+// localPriority maps CLI priority names onto API ticket priorities
+func localPriority(priority string) models.TicketPriority {
+	if priority == "medium" {
+		return models.TicketPriorityNormal
+	}
+	if p := models.TicketPriority(priority); p.Valid() {
+		return p
+	}
+	return models.TicketPriorityNormal
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+)
+
+// writeTicketFile saves f to dir as the CLI names ticket files
+func writeTicketFile(t *testing.T, dir string, f *models.TicketFile) {
+	t.Helper()
+	data, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, f.ID+".json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSeedFromDir(t *testing.T) {
+	dir := t.TempDir()
+	assignee := "bob@example.com"
+	f := models.NewTicketFile("CHG-2025-00003", time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC))
+	f.Title = "Patch load balancers"
+	f.Status = "pending"
+	f.Priority = "medium"
+	f.CreatedBy = "alice@example.com"
+	f.Assignee = &assignee
+	f.Sprint = "2025-Q1-Sprint-1"
+	f.Labels = []string{"network"}
+	f.Comments = append(f.Comments, models.TicketFileComment{Author: assignee, Text: "Done in staging"})
+	writeTicketFile(t, dir, f)
+
+	ctx := context.Background()
+	orgID := uuid.New()
+	s := NewMemoryTicketStore()
+	n, err := s.SeedFromDir(orgID, dir)
+	if err != nil || n != 1 {
+		t.Fatalf("SeedFromDir = %d, %v; want 1 ticket", n, err)
+	}
+
+	got, err := s.GetByNumber(ctx, orgID, "CHG-2025-00003")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.TicketStatusSubmitted || got.Priority != models.TicketPriorityNormal {
+		t.Errorf("status %s priority %s, want the CLI's pending and medium mapped to submitted and normal", got.Status, got.Priority)
+	}
+	if got.CreatedBy != emailUserID("alice@example.com") || got.AssignedTo == nil || *got.AssignedTo != emailUserID(assignee) {
+		t.Errorf("created by %s assigned to %v, want the users' email-based IDs", got.CreatedBy, got.AssignedTo)
+	}
+	if !reflect.DeepEqual(got.Labels, []string{"network", "2025-Q1-Sprint-1"}) {
+		t.Errorf("labels = %v, want the file's labels and its sprint", got.Labels)
+	}
+	if len(got.Comments) != 1 || got.Comments[0].Comment != "Done in staging" {
+		t.Errorf("comments = %+v", got.Comments)
+	}
+}
+
+func TestSeedFromDirTicketFormat(t *testing.T) {
+	t.Setenv("CHANGES_TICKET_PREFIX", "OPS-")
+	t.Setenv("CHANGES_TICKET_WIDTH", "4")
+	t.Setenv("CHANGES_TICKET_INCLUDE_YEAR", "false")
+
+	dir := t.TempDir()
+	f := models.NewTicketFile("OPS-0007", time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC))
+	f.Title = "Rotate VPN certificates"
+	writeTicketFile(t, dir, f)
+	// The migration state is not a ticket and must not be seeded
+	if err := os.WriteFile(filepath.Join(dir, ".gh-migration-state.json"), []byte(`{"issues":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	orgID := uuid.New()
+	s := NewMemoryTicketStore()
+	n, err := s.SeedFromDir(orgID, dir)
+	if err != nil || n != 1 {
+		t.Fatalf("SeedFromDir = %d, %v; want 1 ticket", n, err)
+	}
+
+	created, err := s.Create(ctx, orgID, uuid.New(), &models.CreateTicketInput{Title: "Renew TLS certificates"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.TicketNumber != "OPS-0008" {
+		t.Errorf("created ticket number = %s, want OPS-0008 after the seeded OPS-0007", created.TicketNumber)
+	}
+}
+
+func TestMemoryTicketStoreReadsReturnCopies(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	s := NewMemoryTicketStore()
+	created, err := s.Create(ctx, orgID, uuid.New(), &models.CreateTicketInput{
+		Title:                 "Upgrade core switches",
+		AffectedSystems:       []string{"core-net"},
+		RequiresApprovalTypes: []models.ApprovalType{models.ApprovalTypeNetworkEngineering},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	start, end, deadline := now.Add(time.Hour), now.Add(2*time.Hour), now.Add(-time.Hour)
+	stored := s.tickets[created.ID]
+	stored.Status = models.TicketStatusSubmitted
+	stored.ScheduledStart, stored.ScheduledEnd = &start, &end
+	stored.ApprovalDeadline = &deadline
+	stored.Labels = []string{"network"}
+
+	overdue, err := s.OverdueApprovals(ctx, orgID, now)
+	if err != nil || len(overdue) != 1 {
+		t.Fatalf("OverdueApprovals = %d tickets, %v; want the test ticket", len(overdue), err)
+	}
+	conflicts, err := s.ConflictingTickets(ctx, orgID, uuid.Nil, start, end, []string{"core-net"})
+	if err != nil || len(conflicts) != 1 {
+		t.Fatalf("ConflictingTickets = %d tickets, %v; want the test ticket", len(conflicts), err)
+	}
+
+	for _, got := range []models.Ticket{overdue[0], conflicts[0]} {
+		got.Labels[0] = "changed"
+		got.AffectedSystems[0] = "changed"
+		*got.ScheduledStart = time.Time{}
+	}
+	if stored.Labels[0] != "network" || stored.AffectedSystems[0] != "core-net" || !stored.ScheduledStart.Equal(start) {
+		t.Errorf("changing returned tickets changed the store: labels %v, systems %v, start %v", stored.Labels, stored.AffectedSystems, stored.ScheduledStart)
+	}
+}
+
+func TestMemoryTicketStoreListFilter(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	s := NewMemoryTicketStore()
+	if _, err := s.Create(ctx, orgID, uuid.New(), &models.CreateTicketInput{Title: "Rotate keys"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tickets, total, err := s.List(ctx, orgID, nil)
+	if err != nil || total != 1 || len(tickets) != 1 {
+		t.Errorf("List(nil) = %d of %d, %v; want the one ticket", len(tickets), total, err)
+	}
+
+	filter := &models.TicketListFilter{Search: "rotate"}
+	if _, _, err := s.List(ctx, orgID, filter); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*filter, models.TicketListFilter{Search: "rotate"}) {
+		t.Errorf("List changed the caller's filter to %+v", *filter)
+	}
+}
@@ -12,7 +12,9 @@ import (
 // Store provides access to all data stores
 type Store struct {
 	db      *sql.DB
-	Tickets *TicketStore
+	Tickets TicketStore
+	Comments *CommentStore
+	Users   *UserStore
 	Projects *ProjectStore
 	Groups  *GroupStore
 	Repositories *RepositoryStore
@@ -37,8 +39,16 @@ func New(cfg *config.DatabaseConfig) (*Store, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	return NewFromDB(db), nil
+}
+
+// NewFromDB creates a store over an open database. New opens and checks
+// the connection first.
+func NewFromDB(db *sql.DB) *Store {
 	s := &Store{db: db}
-	s.Tickets = &TicketStore{db: db}
+	s.Tickets = &PostgresTicketStore{db: db}
+	s.Comments = &CommentStore{db: db}
+	s.Users = &UserStore{db: db}
 	s.Projects = &ProjectStore{db: db}
 	s.Groups = &GroupStore{db: db}
 	s.Repositories = &RepositoryStore{db: db}
@@ -47,7 +57,7 @@ func New(cfg *config.DatabaseConfig) (*Store, error) {
 	s.ACLs = &ACLStore{db: db}
 	s.Audit = &AuditStore{db: db}
 
-	return s, nil
+	return s
 }
 
 // Close closes the database connection
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -13,13 +14,46 @@ import (
 	"github.com/afterdarksys/adsops-utils/internal/models"
 )
 
-// TicketStore handles ticket database operations
-type TicketStore struct {
+// ErrVersionConflict is returned when a ticket changed between being read
+// and being written
+var ErrVersionConflict = errors.New("ticket was modified concurrently")
+
+// TicketStore is the set of ticket operations used by the API handlers
+type TicketStore interface {
+	Create(ctx context.Context, orgID, userID uuid.UUID, input *models.CreateTicketInput) (*models.Ticket, error)
+	GetByID(ctx context.Context, orgID, ticketID uuid.UUID) (*models.Ticket, error)
+	GetByNumber(ctx context.Context, orgID uuid.UUID, ticketNumber string) (*models.Ticket, error)
+	List(ctx context.Context, orgID uuid.UUID, filter *models.TicketListFilter) ([]models.Ticket, int, error)
+	Update(ctx context.Context, orgID, ticketID uuid.UUID, input *models.UpdateTicketInput) (*models.Ticket, error)
+	Patch(ctx context.Context, orgID, userID uuid.UUID, before, after *models.Ticket) (*models.Ticket, error)
+	UpdateStatus(ctx context.Context, orgID, ticketID uuid.UUID, status models.TicketStatus) error
+	Submit(ctx context.Context, orgID, ticketID uuid.UUID) error
+	Close(ctx context.Context, orgID, ticketID uuid.UUID) error
+	Cancel(ctx context.Context, orgID, ticketID uuid.UUID, reason string) error
+	GetQueue(ctx context.Context, orgID uuid.UUID) ([]models.Ticket, error)
+	Assign(ctx context.Context, orgID, ticketID, userID uuid.UUID) error
+	AddWatcher(ctx context.Context, orgID, ticketID, userID uuid.UUID) error
+	RemoveWatcher(ctx context.Context, orgID, ticketID, userID uuid.UUID) error
+	LinkRepository(ctx context.Context, ticketID, repoID, linkedBy uuid.UUID, input *models.LinkRepositoryInput) error
+	UnlinkRepository(ctx context.Context, ticketID, repoID uuid.UUID) error
+	GetRevisions(ctx context.Context, orgID, ticketID uuid.UUID) ([]models.TicketRevision, error)
+	ListLabels(ctx context.Context, orgID uuid.UUID) ([]models.LabelCount, error)
+	RenameLabel(ctx context.Context, orgID, userID uuid.UUID, from, to string) (int, error)
+	EpicRollup(ctx context.Context, orgID, epicID uuid.UUID) (*models.EpicRollup, error)
+	OverdueApprovals(ctx context.Context, orgID uuid.UUID, now time.Time) ([]models.Ticket, error)
+	ConflictingTickets(ctx context.Context, orgID, excludeID uuid.UUID, start, end time.Time, systems []string) ([]models.Ticket, error)
+	ComplianceTickets(ctx context.Context, orgID uuid.UUID, frameworks []models.ComplianceFramework, from, to *time.Time) ([]models.Ticket, error)
+	ExpiringACLs(ctx context.Context, orgID uuid.UUID, now time.Time, within time.Duration) ([]models.ExpiringACL, error)
+	ExpireACLs(ctx context.Context, now time.Time) (int, error)
+}
+
+// PostgresTicketStore handles ticket database operations
+type PostgresTicketStore struct {
 	db *sql.DB
 }
 
 // Create creates a new ticket
-func (s *TicketStore) Create(ctx context.Context, orgID, userID uuid.UUID, input *models.CreateTicketInput) (*models.Ticket, error) {
+func (s *PostgresTicketStore) Create(ctx context.Context, orgID, userID uuid.UUID, input *models.CreateTicketInput) (*models.Ticket, error) {
 	// Generate ticket number
 	var ticketNumber string
 	err := s.db.QueryRowContext(ctx,
@@ -111,7 +145,7 @@ func (s *TicketStore) Create(ctx context.Context, orgID, userID uuid.UUID, input
 }
 
 // GetByID retrieves a ticket by ID
-func (s *TicketStore) GetByID(ctx context.Context, orgID, ticketID uuid.UUID) (*models.Ticket, error) {
+func (s *PostgresTicketStore) GetByID(ctx context.Context, orgID, ticketID uuid.UUID) (*models.Ticket, error) {
 	query := `
 		SELECT
 			id, organization_id, ticket_number, created_by, assigned_to, title,
@@ -184,7 +218,7 @@ func (s *TicketStore) GetByID(ctx context.Context, orgID, ticketID uuid.UUID) (*
 }
 
 // GetByNumber retrieves a ticket by ticket number
-func (s *TicketStore) GetByNumber(ctx context.Context, orgID uuid.UUID, ticketNumber string) (*models.Ticket, error) {
+func (s *PostgresTicketStore) GetByNumber(ctx context.Context, orgID uuid.UUID, ticketNumber string) (*models.Ticket, error) {
 	var ticketID uuid.UUID
 	err := s.db.QueryRowContext(ctx,
 		"SELECT id FROM change_tickets WHERE organization_id = $1 AND ticket_number = $2 AND deleted_at IS NULL",
@@ -200,7 +234,7 @@ func (s *TicketStore) GetByNumber(ctx context.Context, orgID uuid.UUID, ticketNu
 }
 
 // List retrieves tickets with filtering
-func (s *TicketStore) List(ctx context.Context, orgID uuid.UUID, filter *models.TicketListFilter) ([]models.Ticket, int, error) {
+func (s *PostgresTicketStore) List(ctx context.Context, orgID uuid.UUID, filter *models.TicketListFilter) ([]models.Ticket, int, error) {
 	filter.SetDefaults()
 
 	var conditions []string
@@ -334,7 +368,7 @@ func (s *TicketStore) List(ctx context.Context, orgID uuid.UUID, filter *models.
 }
 
 // Update updates a ticket
-func (s *TicketStore) Update(ctx context.Context, orgID, ticketID uuid.UUID, input *models.UpdateTicketInput) (*models.Ticket, error) {
+func (s *PostgresTicketStore) Update(ctx context.Context, orgID, ticketID uuid.UUID, input *models.UpdateTicketInput) (*models.Ticket, error) {
 	// Get current ticket
 	ticket, err := s.GetByID(ctx, orgID, ticketID)
 	if err != nil {
@@ -344,6 +378,9 @@ func (s *TicketStore) Update(ctx context.Context, orgID, ticketID uuid.UUID, inp
 	if !ticket.CanEdit() {
 		return nil, fmt.Errorf("ticket cannot be edited in current status")
 	}
+	if input.Version != nil && *input.Version != ticket.Version {
+		return nil, ErrVersionConflict
+	}
 
 	// Build update query dynamically
 	var updates []string
@@ -412,22 +449,101 @@ func (s *TicketStore) Update(ctx context.Context, orgID, ticketID uuid.UUID, inp
 	updates = append(updates, fmt.Sprintf("version = version + 1"))
 	updates = append(updates, "updated_at = NOW()")
 
+	// Only write if nobody else has updated the ticket since it was read
 	query := fmt.Sprintf(
-		"UPDATE change_tickets SET %s WHERE id = $%d AND organization_id = $%d",
-		strings.Join(updates, ", "), argNum, argNum+1,
+		"UPDATE change_tickets SET %s WHERE id = $%d AND organization_id = $%d AND version = $%d",
+		strings.Join(updates, ", "), argNum, argNum+1, argNum+2,
 	)
-	args = append(args, ticketID, orgID)
+	args = append(args, ticketID, orgID, ticket.Version)
 
-	_, err = s.db.ExecContext(ctx, query, args...)
+	result, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update ticket: %w", err)
 	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, ErrVersionConflict
+	}
 
 	return s.GetByID(ctx, orgID, ticketID)
 }
 
+// Patch persists every editable field of after, which must be a modified
+// copy of before. The write only succeeds if the stored version still
+// matches before.Version; it bumps the version and records a revision with
+// the field-level diff.
+func (s *PostgresTicketStore) Patch(ctx context.Context, orgID, userID uuid.UUID, before, after *models.Ticket) (*models.Ticket, error) {
+	changes := models.DiffTickets(before, after)
+	if len(changes) == 0 {
+		return before, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	customFieldsJSON, _ := json.Marshal(after.CustomFields)
+
+	query := `
+		UPDATE change_tickets SET
+			title = $1, description = $2, priority = $3, risk_level = $4, industry = $5,
+			compliance_frameworks = $6, compliance_notes = $7, change_type = $8,
+			affected_systems = $9, affected_data_types = $10, impact_description = $11,
+			rollback_plan = $12, testing_plan = $13, requested_implementation_date = $14,
+			scheduled_start = $15, scheduled_end = $16, actual_start = $17, actual_end = $18,
+			requires_approval_types = $19, approval_deadline = $20, attachment_urls = $21,
+			custom_fields = $22, assigned_to = $23, project_id = $24, owning_group_id = $25,
+			customer_id = $26, parent_ticket_id = $27, epic_id = $28, story_points = $29,
+			time_estimate_hours = $30, time_spent_hours = $31, labels = $32,
+			external_reference = $33, acl_inheritance = $34, is_confidential = $35,
+			version = version + 1, updated_at = NOW()
+		WHERE id = $36 AND organization_id = $37 AND version = $38
+	`
+	result, err := tx.ExecContext(ctx, query,
+		after.Title, after.Description, after.Priority, after.RiskLevel, after.Industry,
+		pq.Array(after.ComplianceFrameworks), after.ComplianceNotes, after.ChangeType,
+		pq.Array(after.AffectedSystems), pq.Array(after.AffectedDataTypes), after.ImpactDescription,
+		after.RollbackPlan, after.TestingPlan, after.RequestedImplementationDate,
+		after.ScheduledStart, after.ScheduledEnd, after.ActualStart, after.ActualEnd,
+		pq.Array(after.RequiresApprovalTypes), after.ApprovalDeadline, pq.Array(after.AttachmentURLs),
+		customFieldsJSON, after.AssignedTo, after.ProjectID, after.OwningGroupID,
+		after.CustomerID, after.ParentTicketID, after.EpicID, after.StoryPoints,
+		after.TimeEstimateHours, after.TimeSpentHours, pq.Array(after.Labels),
+		after.ExternalReference, after.ACLInheritance, after.IsConfidential,
+		before.ID, orgID, before.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch ticket: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, ErrVersionConflict
+	}
+
+	after.Version = before.Version + 1
+	changesJSON, _ := json.Marshal(changes)
+	snapshot, _ := json.Marshal(after)
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO ticket_revisions (
+			ticket_id, organization_id, revision_number, changed_by, changes, ticket_snapshot
+		)
+		SELECT $1, $2, COALESCE(MAX(revision_number), 0) + 1, $3, $4, $5
+		FROM ticket_revisions WHERE ticket_id = $1
+	`, before.ID, orgID, userID, changesJSON, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record revision: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit patch: %w", err)
+	}
+
+	return s.GetByID(ctx, orgID, before.ID)
+}
+
 // UpdateStatus updates the status of a ticket
-func (s *TicketStore) UpdateStatus(ctx context.Context, orgID, ticketID uuid.UUID, status models.TicketStatus) error {
+func (s *PostgresTicketStore) UpdateStatus(ctx context.Context, orgID, ticketID uuid.UUID, status models.TicketStatus) error {
 	query := `
 		UPDATE change_tickets
 		SET status = $1, updated_at = NOW()
@@ -438,7 +554,7 @@ func (s *TicketStore) UpdateStatus(ctx context.Context, orgID, ticketID uuid.UUI
 }
 
 // Submit submits a ticket for approval
-func (s *TicketStore) Submit(ctx context.Context, orgID, ticketID uuid.UUID) error {
+func (s *PostgresTicketStore) Submit(ctx context.Context, orgID, ticketID uuid.UUID) error {
 	ticket, err := s.GetByID(ctx, orgID, ticketID)
 	if err != nil {
 		return err
@@ -464,7 +580,7 @@ func (s *TicketStore) Submit(ctx context.Context, orgID, ticketID uuid.UUID) err
 }
 
 // Close closes a ticket
-func (s *TicketStore) Close(ctx context.Context, orgID, ticketID uuid.UUID) error {
+func (s *PostgresTicketStore) Close(ctx context.Context, orgID, ticketID uuid.UUID) error {
 	ticket, err := s.GetByID(ctx, orgID, ticketID)
 	if err != nil {
 		return err
@@ -486,7 +602,7 @@ func (s *TicketStore) Close(ctx context.Context, orgID, ticketID uuid.UUID) erro
 }
 
 // Cancel cancels a ticket
-func (s *TicketStore) Cancel(ctx context.Context, orgID, ticketID uuid.UUID, reason string) error {
+func (s *PostgresTicketStore) Cancel(ctx context.Context, orgID, ticketID uuid.UUID, reason string) error {
 	ticket, err := s.GetByID(ctx, orgID, ticketID)
 	if err != nil {
 		return err
@@ -508,7 +624,7 @@ func (s *TicketStore) Cancel(ctx context.Context, orgID, ticketID uuid.UUID, rea
 }
 
 // GetQueue retrieves tickets that need assignment (for ticket queue bot)
-func (s *TicketStore) GetQueue(ctx context.Context, orgID uuid.UUID) ([]models.Ticket, error) {
+func (s *PostgresTicketStore) GetQueue(ctx context.Context, orgID uuid.UUID) ([]models.Ticket, error) {
 	filter := &models.TicketListFilter{
 		NeedsAssignment: true,
 		SortBy:          "created_at",
@@ -520,7 +636,7 @@ func (s *TicketStore) GetQueue(ctx context.Context, orgID uuid.UUID) ([]models.T
 }
 
 // Assign assigns a ticket to a user
-func (s *TicketStore) Assign(ctx context.Context, orgID, ticketID, userID uuid.UUID) error {
+func (s *PostgresTicketStore) Assign(ctx context.Context, orgID, ticketID, userID uuid.UUID) error {
 	query := `
 		UPDATE change_tickets
 		SET assigned_to = $1, updated_at = NOW()
@@ -531,7 +647,7 @@ func (s *TicketStore) Assign(ctx context.Context, orgID, ticketID, userID uuid.U
 }
 
 // AddWatcher adds a watcher to a ticket
-func (s *TicketStore) AddWatcher(ctx context.Context, orgID, ticketID, userID uuid.UUID) error {
+func (s *PostgresTicketStore) AddWatcher(ctx context.Context, orgID, ticketID, userID uuid.UUID) error {
 	query := `
 		UPDATE change_tickets
 		SET watchers = array_append(watchers, $1), updated_at = NOW()
@@ -542,7 +658,7 @@ func (s *TicketStore) AddWatcher(ctx context.Context, orgID, ticketID, userID uu
 }
 
 // RemoveWatcher removes a watcher from a ticket
-func (s *TicketStore) RemoveWatcher(ctx context.Context, orgID, ticketID, userID uuid.UUID) error {
+func (s *PostgresTicketStore) RemoveWatcher(ctx context.Context, orgID, ticketID, userID uuid.UUID) error {
 	query := `
 		UPDATE change_tickets
 		SET watchers = array_remove(watchers, $1), updated_at = NOW()
@@ -553,7 +669,7 @@ func (s *TicketStore) RemoveWatcher(ctx context.Context, orgID, ticketID, userID
 }
 
 // LinkRepository links a repository to a ticket
-func (s *TicketStore) LinkRepository(ctx context.Context, ticketID, repoID, linkedBy uuid.UUID, input *models.LinkRepositoryInput) error {
+func (s *PostgresTicketStore) LinkRepository(ctx context.Context, ticketID, repoID, linkedBy uuid.UUID, input *models.LinkRepositoryInput) error {
 	query := `
 		INSERT INTO ticket_repositories (ticket_id, repository_id, linked_by, link_type, branch_name, commit_sha, pr_number, notes)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
@@ -569,8 +685,466 @@ func (s *TicketStore) LinkRepository(ctx context.Context, ticketID, repoID, link
 }
 
 // UnlinkRepository unlinks a repository from a ticket
-func (s *TicketStore) UnlinkRepository(ctx context.Context, ticketID, repoID uuid.UUID) error {
+func (s *PostgresTicketStore) UnlinkRepository(ctx context.Context, ticketID, repoID uuid.UUID) error {
 	query := "DELETE FROM ticket_repositories WHERE ticket_id = $1 AND repository_id = $2"
 	_, err := s.db.ExecContext(ctx, query, ticketID, repoID)
 	return err
 }
+
+// GetRevisions retrieves the change history for a ticket
+func (s *PostgresTicketStore) GetRevisions(ctx context.Context, orgID, ticketID uuid.UUID) ([]models.TicketRevision, error) {
+	query := `
+		SELECT id, ticket_id, organization_id, revision_number, changed_by,
+		       change_reason, changes, ticket_snapshot, created_at, ip_address, user_agent
+		FROM ticket_revisions
+		WHERE ticket_id = $1 AND organization_id = $2
+		ORDER BY revision_number ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, ticketID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []models.TicketRevision
+	for rows.Next() {
+		var r models.TicketRevision
+		err := rows.Scan(
+			&r.ID, &r.TicketID, &r.OrganizationID, &r.RevisionNumber, &r.ChangedBy,
+			&r.ChangeReason, &r.Changes, &r.TicketSnapshot, &r.CreatedAt,
+			&r.IPAddress, &r.UserAgent,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan revision: %w", err)
+		}
+		revisions = append(revisions, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// ListLabels returns every label in use with the number of tickets carrying it
+func (s *PostgresTicketStore) ListLabels(ctx context.Context, orgID uuid.UUID) ([]models.LabelCount, error) {
+	query := `
+		SELECT label, COUNT(*)
+		FROM change_tickets, unnest(labels) AS label
+		WHERE organization_id = $1 AND deleted_at IS NULL
+		GROUP BY label
+		ORDER BY COUNT(*) DESC, label
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []models.LabelCount
+	for rows.Next() {
+		var l models.LabelCount
+		if err := rows.Scan(&l.Label, &l.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan label: %w", err)
+		}
+		labels = append(labels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// RenameLabel replaces a label on every ticket that carries it and records an
+// audit entry per ticket, all in one transaction. Tickets that already carry
+// the new label simply drop the old one. Returns the number of tickets updated.
+func (s *PostgresTicketStore) RenameLabel(ctx context.Context, orgID, userID uuid.UUID, from, to string) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE change_tickets
+		SET labels = CASE WHEN $3 = ANY(labels) THEN array_remove(labels, $2)
+		                  ELSE array_replace(labels, $2, $3) END,
+		    version = version + 1,
+		    updated_at = NOW()
+		WHERE organization_id = $1 AND $2 = ANY(labels) AND deleted_at IS NULL
+		RETURNING id, compliance_frameworks
+	`
+
+	rows, err := tx.QueryContext(ctx, query, orgID, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename label: %w", err)
+	}
+
+	type renamed struct {
+		id         uuid.UUID
+		frameworks []string
+	}
+	var updated []renamed
+	for rows.Next() {
+		var r renamed
+		if err := rows.Scan(&r.id, pq.Array(&r.frameworks)); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan renamed ticket: %w", err)
+		}
+		updated = append(updated, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to rename label: %w", err)
+	}
+
+	changesJSON, _ := json.Marshal(map[string]interface{}{
+		"labels": map[string]string{"old": from, "new": to},
+	})
+
+	for _, r := range updated {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO ticket_audit_log (
+				ticket_id, organization_id, user_id, action, action_category,
+				field_name, old_value, new_value, changes,
+				is_compliance_relevant, compliance_frameworks, requires_review
+			) VALUES ($1, $2, $3, $4, $5, 'labels', $6, $7, $8, $9, $10, $9)
+		`,
+			r.id, orgID, userID, "label_rename", getActionCategory("label_rename"),
+			from, to, changesJSON,
+			isComplianceRelevantAction("label_rename"), pq.Array(r.frameworks),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record label audit entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit label rename: %w", err)
+	}
+
+	return len(updated), nil
+}
+
+// EpicRollup sums story points, time tracking and status counts across the
+// tickets in an epic. Direct subtasks of those tickets are included once.
+func (s *PostgresTicketStore) EpicRollup(ctx context.Context, orgID, epicID uuid.UUID) (*models.EpicRollup, error) {
+	query := `
+		WITH children AS (
+			SELECT id FROM change_tickets
+			WHERE organization_id = $1 AND epic_id = $2 AND deleted_at IS NULL
+		), scope AS (
+			SELECT id FROM children
+			UNION
+			SELECT t.id FROM change_tickets t
+			JOIN children c ON t.parent_ticket_id = c.id
+			WHERE t.organization_id = $1 AND t.deleted_at IS NULL
+		)
+		SELECT t.status, t.story_points, t.time_estimate_hours, t.time_spent_hours
+		FROM change_tickets t
+		JOIN scope USING (id)
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orgID, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute epic rollup: %w", err)
+	}
+	defer rows.Close()
+
+	rollup := models.NewEpicRollup(epicID)
+	for rows.Next() {
+		var t models.Ticket
+		if err := rows.Scan(&t.Status, &t.StoryPoints, &t.TimeEstimateHours, &t.TimeSpentHours); err != nil {
+			return nil, fmt.Errorf("failed to scan rollup row: %w", err)
+		}
+		rollup.Add(&t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to compute epic rollup: %w", err)
+	}
+
+	return rollup, nil
+}
+
+// OverdueApprovals returns tickets awaiting approval whose approval deadline
+// passed before now without every required approval type approved. The
+// returned tickets have their Approvals loaded, oldest deadline first.
+func (s *PostgresTicketStore) OverdueApprovals(ctx context.Context, orgID uuid.UUID, now time.Time) ([]models.Ticket, error) {
+	query := `
+		SELECT id
+		FROM change_tickets
+		WHERE organization_id = $1
+		  AND deleted_at IS NULL
+		  AND status IN ('submitted', 'in_review', 'partially_approved')
+		  AND approval_deadline < $2
+		ORDER BY approval_deadline ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orgID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find overdue approvals: %w", err)
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan ticket id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find overdue approvals: %w", err)
+	}
+
+	var overdue []models.Ticket
+	for _, id := range ids {
+		ticket, err := s.GetByID(ctx, orgID, id)
+		if err != nil {
+			return nil, err
+		}
+		if ticket.Approvals, err = s.getApprovals(ctx, id); err != nil {
+			return nil, err
+		}
+		if ticket.IsApprovalOverdue(now) {
+			overdue = append(overdue, *ticket)
+		}
+	}
+
+	return overdue, nil
+}
+
+// ComplianceTickets returns tickets carrying any of frameworks that were
+// created between from and to (either may be nil), oldest first, with their
+// Approvals loaded
+func (s *PostgresTicketStore) ComplianceTickets(ctx context.Context, orgID uuid.UUID, frameworks []models.ComplianceFramework, from, to *time.Time) ([]models.Ticket, error) {
+	query := `
+		SELECT id
+		FROM change_tickets
+		WHERE organization_id = $1
+		  AND deleted_at IS NULL
+		  AND compliance_frameworks && $2
+		  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		  AND ($4::timestamptz IS NULL OR created_at <= $4)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orgID, pq.Array(frameworks), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find compliance tickets: %w", err)
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan ticket id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find compliance tickets: %w", err)
+	}
+
+	tickets := make([]models.Ticket, 0, len(ids))
+	for _, id := range ids {
+		ticket, err := s.GetByID(ctx, orgID, id)
+		if err != nil {
+			return nil, err
+		}
+		if ticket.Approvals, err = s.getApprovals(ctx, id); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, *ticket)
+	}
+
+	return tickets, nil
+}
+
+// getApprovals loads the approval decisions recorded for a ticket
+func (s *PostgresTicketStore) getApprovals(ctx context.Context, ticketID uuid.UUID) ([]models.Approval, error) {
+	query := `
+		SELECT id, ticket_id, organization_id, approval_type, sequence_order,
+		       approver_id, status, approved_at, denied_at, created_at, updated_at
+		FROM approvals
+		WHERE ticket_id = $1
+		ORDER BY sequence_order, created_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approvals: %w", err)
+	}
+	defer rows.Close()
+
+	var approvals []models.Approval
+	for rows.Next() {
+		var a models.Approval
+		if err := rows.Scan(
+			&a.ID, &a.TicketID, &a.OrganizationID, &a.ApprovalType, &a.SequenceOrder,
+			&a.ApproverID, &a.Status, &a.ApprovedAt, &a.DeniedAt, &a.CreatedAt, &a.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan approval: %w", err)
+		}
+		approvals = append(approvals, a)
+	}
+
+	return approvals, rows.Err()
+}
+
+// ConflictingTickets returns tickets other than excludeID whose scheduled
+// window overlaps [start, end) and which share any of systems, compared
+// case-insensitively. Cancelled, denied and closed tickets are ignored.
+func (s *PostgresTicketStore) ConflictingTickets(ctx context.Context, orgID, excludeID uuid.UUID, start, end time.Time, systems []string) ([]models.Ticket, error) {
+	if len(systems) == 0 || !end.After(start) {
+		return nil, nil
+	}
+
+	lowered := make([]string, len(systems))
+	for i, sys := range systems {
+		lowered[i] = strings.ToLower(strings.TrimSpace(sys))
+	}
+
+	query := `
+		SELECT id
+		FROM change_tickets
+		WHERE organization_id = $1
+		  AND id <> $2
+		  AND deleted_at IS NULL
+		  AND status NOT IN ('cancelled', 'denied', 'closed')
+		  AND scheduled_start < $4
+		  AND scheduled_end > $3
+		  AND EXISTS (
+			SELECT 1 FROM unnest(affected_systems) AS sys
+			WHERE lower(trim(sys)) = ANY($5)
+		  )
+		ORDER BY scheduled_start ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orgID, excludeID, start, end, pq.Array(lowered))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find conflicting tickets: %w", err)
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan ticket id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find conflicting tickets: %w", err)
+	}
+
+	tickets := make([]models.Ticket, 0, len(ids))
+	for _, id := range ids {
+		ticket, err := s.GetByID(ctx, orgID, id)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, *ticket)
+	}
+
+	return tickets, nil
+}
+
+// ExpiringACLs returns the active ACLs on an organization's tickets that
+// expire after now and no later than now+within, soonest first, with their
+// user or group principal loaded
+func (s *PostgresTicketStore) ExpiringACLs(ctx context.Context, orgID uuid.UUID, now time.Time, within time.Duration) ([]models.ExpiringACL, error) {
+	query := `
+		SELECT a.id, a.ticket_id, a.principal_type, a.principal_id, a.role_name,
+		       a.acl_role, a.granted_by, a.expires_at, a.reason, a.created_at,
+		       u.id, COALESCE(u.email, ''), COALESCE(u.full_name, ''),
+		       g.id, COALESCE(g.name, ''), COALESCE(g.group_type, ''), COALESCE(g.is_active, false)
+		FROM ticket_acls a
+		JOIN change_tickets t ON t.id = a.ticket_id
+		LEFT JOIN users u ON a.principal_type = 'user' AND u.id = a.principal_id
+		LEFT JOIN groups g ON a.principal_type = 'group' AND g.id = a.principal_id
+		WHERE t.organization_id = $1
+		  AND t.deleted_at IS NULL
+		  AND a.revoked_at IS NULL
+		  AND a.expires_at > $2
+		  AND a.expires_at <= $3
+		ORDER BY a.expires_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orgID, now, now.Add(within))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expiring ACLs: %w", err)
+	}
+	var acls []models.TicketACL
+	for rows.Next() {
+		var acl models.TicketACL
+		var userID, groupID *uuid.UUID
+		var email, fullName, groupName, groupType string
+		var groupActive bool
+		if err := rows.Scan(
+			&acl.ID, &acl.TicketID, &acl.PrincipalType, &acl.PrincipalID, &acl.RoleName,
+			&acl.ACLRole, &acl.GrantedBy, &acl.ExpiresAt, &acl.Reason, &acl.CreatedAt,
+			&userID, &email, &fullName,
+			&groupID, &groupName, &groupType, &groupActive,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan ticket ACL: %w", err)
+		}
+		if userID != nil {
+			acl.Principal = &models.UserSummary{ID: *userID, Email: email, FullName: fullName}
+		}
+		if groupID != nil {
+			acl.PrincipalGroup = &models.GroupSummary{
+				ID:        *groupID,
+				Name:      groupName,
+				GroupType: models.GroupType(groupType),
+				IsActive:  groupActive,
+			}
+		}
+		acls = append(acls, acl)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find expiring ACLs: %w", err)
+	}
+
+	tickets := make(map[uuid.UUID]*models.Ticket)
+	expiring := make([]models.ExpiringACL, 0, len(acls))
+	for _, acl := range acls {
+		ticket, ok := tickets[acl.TicketID]
+		if !ok {
+			if ticket, err = s.GetByID(ctx, orgID, acl.TicketID); err != nil {
+				return nil, err
+			}
+			tickets[acl.TicketID] = ticket
+		}
+		expiring = append(expiring, models.NewExpiringACL(ticket, acl, now))
+	}
+
+	return expiring, nil
+}
+
+// ExpireACLs marks every ACL whose expiry is at or before now as revoked at
+// its expiry time, leaving revoked_by empty, and returns how many it marked
+func (s *PostgresTicketStore) ExpireACLs(ctx context.Context, now time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE ticket_acls
+		SET revoked_at = expires_at
+		WHERE revoked_at IS NULL
+		  AND expires_at <= $1
+	`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire ACLs: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire ACLs: %w", err)
+	}
+	return int(n), nil
+}
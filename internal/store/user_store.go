@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/afterdarksys/adsops-utils/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// UserStore handles user lookups
+type UserStore struct {
+	db *sql.DB
+}
+
+// GetByEmails returns the active users in an organization whose email
+// matches one of emails, case-insensitively. Only identity and role fields
+// are populated. Unknown addresses are skipped.
+func (s *UserStore) GetByEmails(ctx context.Context, orgID uuid.UUID, emails []string) ([]models.User, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, organization_id, email, full_name, roles
+		FROM users
+		WHERE organization_id = $1
+		  AND lower(email) = ANY($2)
+		  AND is_active = true
+		  AND deleted_at IS NULL
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orgID, pq.Array(emails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var u models.User
+		var roles []string
+		if err := rows.Scan(&u.ID, &u.OrganizationID, &u.Email, &u.FullName, pq.Array(&roles)); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		for _, r := range roles {
+			u.Roles = append(u.Roles, models.UserRole(r))
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
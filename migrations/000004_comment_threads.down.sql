@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_comments_parent_id;
+ALTER TABLE ticket_comments DROP COLUMN IF EXISTS parent_comment_id;
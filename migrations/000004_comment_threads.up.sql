@@ -0,0 +1,7 @@
+-- Threaded comment replies. Replies may only target top-level comments;
+-- the one-level nesting limit is enforced by the application.
+ALTER TABLE ticket_comments
+    ADD COLUMN IF NOT EXISTS parent_comment_id UUID REFERENCES ticket_comments(id);
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON ticket_comments(parent_comment_id)
+    WHERE parent_comment_id IS NOT NULL;